@@ -16,7 +16,10 @@ package v1alpha2
 
 import (
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 const (
@@ -37,8 +40,71 @@ const (
 	// ensure exclusive job placement per topology, instead of injecting pod affinity/anti-affinites for this.
 	// The user must add the JobSet name node label to the desired topologies separately.
 	NodeSelectorStrategyKey string = "alpha.jobset.sigs.k8s.io/node-selector"
+	// NodeAffinityStrategyKey is an annotation that acts as a flag, the value does not matter.
+	// If set, the JobSet controller will assign a distinct topology domain to each child job and inject
+	// a required node affinity to it, instead of injecting pod affinity/anti-affinities for exclusive
+	// job placement per topology. Topology domains are only assigned exclusively among the child jobs
+	// of the JobSet, so the topology domains should be dedicated to the JobSet.
+	NodeAffinityStrategyKey string = "alpha.jobset.sigs.k8s.io/node-affinity"
 	NamespacedJobKey        string = "alpha.jobset.sigs.k8s.io/namespaced-job"
 	NoScheduleTaintKey      string = "alpha.jobset.sigs.k8s.io/no-schedule"
+	// CoordinatorKey is a label set on the pods of the Job containing the coordinator pod
+	// defined in spec.coordinator. Along with the Job completion index label, it is used to
+	// select the coordinator pod in the coordinator Service.
+	CoordinatorKey string = "jobset.sigs.k8s.io/coordinator"
+	// RestartTriggerKey is an annotation which can be set on the JobSet to restart it on demand.
+	// Each time the annotation value changes, the JobSet controller restarts the JobSet once by
+	// recreating all of its child Jobs.
+	RestartTriggerKey string = "jobset.sigs.k8s.io/restart"
+	// CleanupFinalizer is the finalizer added by the JobSet controller to every JobSet it manages.
+	// When the JobSet is deleted, the finalizer is only removed once all child Jobs and their pods
+	// are gone and the Services of the JobSet have been deleted, so pods keep resolving each other
+	// while they shut down.
+	CleanupFinalizer string = "jobset.sigs.k8s.io/cleanup"
+	// JobResultFinalizer is the finalizer added by the JobSet controller to the child Jobs of a
+	// JobSet with spec.jobTTLSecondsAfterFinished set. It keeps finished child Jobs deleted by
+	// their TTL until the JobSet no longer needs their result to evaluate its policies.
+	JobResultFinalizer string = "jobset.sigs.k8s.io/job-result"
+	// InstanceIndexKey is a label and annotation set on the child Jobs and pods of a JobSet
+	// with spec.instances set, containing the index of the instance they belong to.
+	InstanceIndexKey string = "jobset.sigs.k8s.io/instance-index"
+	// MaxActiveJobSetsKey is an annotation which can be set on a namespace to limit the number of
+	// active JobSets in the namespace. The JobSet validating webhook rejects the creation of
+	// JobSets exceeding the limit. It takes precedence over the limit configured for the webhook.
+	MaxActiveJobSetsKey string = "alpha.jobset.sigs.k8s.io/max-active-jobsets"
+	// ReplicasKey is an annotation which can be set on the JobSet to override the replicas of its
+	// replicated jobs, e.g. by an external autoscaler tracking the depth of a work queue. The value
+	// is a comma separated list of <replicatedJob>=<replicas> pairs. The JobSet controller creates
+	// and deletes child Jobs until each replicated job runs the given number of replicas.
+	ReplicasKey string = "alpha.jobset.sigs.k8s.io/replicas"
+	// OnePodPerNodeKey is an annotation that acts as a flag, the value does not matter. It can be
+	// set on the JobSet or on a ReplicatedJob template. If set, the JobSet controller injects a
+	// required pod anti-affinity on the JobKey label with the kubernetes.io/hostname topology key
+	// into the pods of the child jobs, so at most one pod of each child job runs on a node.
+	OnePodPerNodeKey string = "alpha.jobset.sigs.k8s.io/one-pod-per-node"
+	// JobSetUIDKey is a label and annotation set on the child Jobs and pods of a JobSet, containing
+	// the UID of the JobSet. Unlike the JobSet name, which can be reused by a recreated JobSet, it
+	// identifies the JobSet the Jobs and pods were created for.
+	JobSetUIDKey string = "jobset.sigs.k8s.io/jobset-uid"
+	// JobKeyHashKey is an annotation which can be set on the JobSet to select the hash function
+	// computing the JobKey label of its child Jobs and pods from their namespaced name. Supported
+	// values are sha1, the default, and sha256, whose digest is truncated to the maximum length of
+	// a label value. It can't be changed while the JobSet is active, so the keys of existing child
+	// Jobs remain stable.
+	JobKeyHashKey string = "alpha.jobset.sigs.k8s.io/job-key-hash"
+	// JobKeyHashSHA1 is the default value of the JobKeyHashKey annotation.
+	JobKeyHashSHA1 string = "sha1"
+	// JobKeyHashSHA256 is the value of the JobKeyHashKey annotation selecting SHA256.
+	JobKeyHashSHA256 string = "sha256"
+	// StatusConfigMapKey is an annotation that acts as a flag, the value does not matter. If set,
+	// the JobSet controller maintains a ConfigMap named <jobset-name>-status mirroring the phase,
+	// restarts and replicated job statuses of the JobSet, for tools which can't watch JobSets.
+	// The ConfigMap is owned by the JobSet, so it is garbage collected along with it.
+	StatusConfigMapKey string = "alpha.jobset.sigs.k8s.io/status-configmap"
+	// JobSetPhaseKey is a label set by the JobSet controller on the JobSet itself, containing the
+	// phase of the JobSet, i.e. Running, Suspended, Completed or Failed, so JobSets can be
+	// filtered by phase with a label selector.
+	JobSetPhaseKey string = "jobset.sigs.k8s.io/phase"
 
 	// JobSetControllerName is the reserved value for the managedBy field for the built-in
 	// JobSet controller.
@@ -59,6 +125,47 @@ const (
 	JobSetStartupPolicyInProgress JobSetConditionType = "StartupPolicyInProgress"
 	// JobSetStartupPolicyCompleted means the StartupPolicy has completed.
 	JobSetStartupPolicyCompleted JobSetConditionType = "StartupPolicyCompleted"
+	// JobSetReady means all child Jobs of every ReplicatedJob are ready.
+	JobSetReady JobSetConditionType = "Ready"
+	// JobSetInsufficientCapacity means the creation of child Jobs using exclusive placement is
+	// deferred, since the cluster has too few topology domains to place them.
+	JobSetInsufficientCapacity JobSetConditionType = "InsufficientCapacity"
+	// JobSetNetworkServiceConflict means an existing service with the name of the headless
+	// service of the JobSet does not select the pods of the JobSet, so pod DNS hostnames
+	// do not resolve.
+	JobSetNetworkServiceConflict JobSetConditionType = "NetworkServiceConflict"
+	// JobSetPaused means the JobSet controller does not act on the JobSet, while its child
+	// Jobs keep running.
+	JobSetPaused JobSetConditionType = "Paused"
+	// JobSetAdmissionPending means the JobSet is suspended while it is managed by an external
+	// controller, e.g. while it waits to be admitted by Kueue.
+	JobSetAdmissionPending JobSetConditionType = "AdmissionPending"
+	// JobSetInsufficientSuccessfulJobs means the JobSet failed before enough child Jobs
+	// succeeded to satisfy its success policy. The message reports how many Jobs succeeded
+	// out of the number required.
+	JobSetInsufficientSuccessfulJobs JobSetConditionType = "InsufficientSuccessfulJobs"
+	// JobSetNetworkManagementDisabled means pod DNS hostnames are enabled for the JobSet, but
+	// the JobSet controller runs with network management disabled, so its headless service
+	// must be created out-of-band.
+	JobSetNetworkManagementDisabled JobSetConditionType = "NetworkManagementDisabled"
+	// JobSetPotentialDeadlock means the in-order startup of the JobSet made no progress for
+	// longer than its stall timeout, e.g. because the pods of the replicated job being started
+	// wait on pods of a replicated job which is only started after it.
+	JobSetPotentialDeadlock JobSetConditionType = "PotentialDeadlock"
+	// JobSetRestartLimitApproaching means the restarts of the JobSet reached the configured
+	// fraction of the maxRestarts of its failure policy, so the JobSet is about to fail
+	// permanently on further child Job failures.
+	JobSetRestartLimitApproaching JobSetConditionType = "RestartLimitApproaching"
+	// JobSetReconcileBackingOff means the reconciliation of the JobSet failed repeatedly, so the
+	// controller retries it with a longer backoff, e.g. while the API server rejects an invalid
+	// child Job template.
+	JobSetReconcileBackingOff JobSetConditionType = "ReconcileBackingOff"
+	// JobSetJobKeyCollision means several child Jobs of the JobSet share the same JobKey label,
+	// so pods of one Job may be mistaken for pods of another, e.g. by exclusive placement.
+	JobSetJobKeyCollision JobSetConditionType = "JobKeyCollision"
+	// JobSetNetworkReady means the pod DNS hostnames of the JobSet can be resolved, i.e. its
+	// headless service exists and has endpoints, or pod DNS hostnames are disabled.
+	JobSetNetworkReady JobSetConditionType = "NetworkReady"
 )
 
 // JobSetSpec defines the desired state of JobSet
@@ -68,6 +175,12 @@ type JobSetSpec struct {
 	// +listMapKey=name
 	ReplicatedJobs []ReplicatedJob `json:"replicatedJobs,omitempty"`
 
+	// PodTemplates is a map of named pod templates which can be referenced by
+	// ReplicatedJobs through podTemplateName, to avoid repeating the same pod
+	// template across replicated jobs.
+	// +optional
+	PodTemplates map[string]corev1.PodTemplateSpec `json:"podTemplates,omitempty"`
+
 	// Network defines the networking options for the jobset.
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
 	// +optional
@@ -107,6 +220,253 @@ type JobSetSpec struct {
 	// +kubebuilder:validation:Minimum=0
 	// +optional
 	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+
+	// OnSuspend determines what happens to the child Jobs when the JobSet is suspended.
+	// DeletePods suspends the child Jobs, which deletes their pods. This is the default.
+	// RetainPods leaves the child Jobs and their pods running and only marks the JobSet
+	// as suspended in its status. Note that RetainPods does not free any resources held
+	// by the pods of the child Jobs.
+	// +kubebuilder:validation:Enum=DeletePods;RetainPods
+	// +optional
+	OnSuspend OnSuspendPolicy `json:"onSuspend,omitempty"`
+
+	// ActiveDeadlineSeconds is the duration in seconds, measured from the time the
+	// JobSet was started (or last resumed), during which the JobSet may be active
+	// before it is failed with reason DeadlineExceeded and its active child Jobs are
+	// deleted. It covers the entire JobSet, including restarts.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+
+	// Labels are added to every child Job and pod created by the JobSet. Labels set
+	// in the ReplicatedJob templates take precedence over these, and labels managed
+	// by the JobSet controller take precedence over both.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations are added to every child Job and pod created by the JobSet.
+	// Annotations set in the ReplicatedJob templates take precedence over these, and
+	// annotations managed by the JobSet controller take precedence over both.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// ImagePullSecrets are added to the imagePullSecrets of every pod created by the JobSet,
+	// after the ones set in the pod templates, skipping secrets already set there. They can
+	// be updated while the JobSet is active, e.g. to rotate registry credentials, and apply
+	// to Jobs created afterwards.
+	// +optional
+	// +listType=atomic
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// Coordinator defines the pod acting as the coordinator of the JobSet, e.g. to be
+	// exposed by the coordinator Service configured in spec.network.coordinatorService.
+	// +optional
+	Coordinator *Coordinator `json:"coordinator,omitempty"`
+
+	// JobNameTemplate is a Go text/template used to generate the names of the child Jobs.
+	// The template can reference the JobSet name as .JobSet, the ReplicatedJob name as
+	// .ReplicatedJob, and the Job index as .Index. The rendered names must be valid DNS-1035
+	// labels, and unique across the Jobs of the JobSet. If unset, child Jobs are named
+	// <jobSetName>-<replicatedJobName>-<jobIndex>.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	JobNameTemplate string `json:"jobNameTemplate,omitempty"`
+
+	// Instances is the number of copies of the replicated jobs the JobSet creates, e.g. to run
+	// the same workload for several hyperparameter sweeps. Every Job of every ReplicatedJob is
+	// created once per instance, and its containers receive the instance index in the
+	// INSTANCE_INDEX environment variable. The instances are not independent: they share one
+	// failure and success domain, so a failed Job of any instance triggers the failure policy,
+	// which restarts or fails all the instances together, and the success policy counts the Jobs
+	// of all the instances. If set, child Jobs are named
+	// <jobSetName>-<instanceIndex>-<replicatedJobName>-<jobIndex>, and the template in
+	// spec.jobNameTemplate can reference the instance index as .Instance. The coordinator is
+	// always part of the first instance. Defaults to a single instance.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	// +optional
+	Instances *int32 `json:"instances,omitempty"`
+
+	// JobTTLSecondsAfterFinished, if set, is set as the ttlSecondsAfterFinished of every child
+	// Job, so finished child Jobs are deleted by the Job controller, e.g. while a finished JobSet
+	// is kept for auditing. As the success and failure policies are evaluated on the finished
+	// child Jobs, the JobSet controller keeps them with the jobset.sigs.k8s.io/job-result
+	// finalizer until the JobSet finishes or restarts.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	JobTTLSecondsAfterFinished *int32 `json:"jobTTLSecondsAfterFinished,omitempty"`
+
+	// SidecarContainers are added to every pod created by the JobSet, e.g. to run a logging
+	// agent next to the workload. Containers with restartPolicy Always are added as native
+	// sidecars, i.e. as init containers which keep running along the containers of the pod.
+	// Other containers are added after the containers of the pod. The names of the sidecar
+	// containers must not be used by the containers of the pod templates.
+	// +optional
+	// +listType=atomic
+	SidecarContainers []corev1.Container `json:"sidecarContainers,omitempty"`
+
+	// EnvFrom is a list of sources to populate environment variables in every container of every
+	// pod created by the JobSet, e.g. to distribute shared configuration from a ConfigMap or Secret.
+	// The sources are added before the envFrom sources of the containers, so the sources and env
+	// variables defined in the pod templates take precedence on key conflicts.
+	// +optional
+	// +listType=atomic
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
+
+	// Paused stops the JobSet controller from acting on the JobSet when set to true, e.g. to
+	// inspect a JobSet without it being restarted. Unlike suspend, the child Jobs and their pods
+	// keep running, and the JobSet status is not updated, apart from the Paused condition.
+	// +optional
+	Paused *bool `json:"paused,omitempty"`
+
+	// StatusSyncPeriodSeconds, if set, makes the JobSet controller reconcile the JobSet periodically
+	// with the given period while it is active, in addition to reconciling it on changes of its child
+	// Jobs, so the status stays fresh, e.g. for dashboards. This trades API server load for fresher
+	// status. If unset, the JobSet is only reconciled on changes.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	StatusSyncPeriodSeconds *int32 `json:"statusSyncPeriodSeconds,omitempty"`
+
+	// Volumes are added to the volumes of every pod created by the JobSet, after the ones set in
+	// the pod templates, e.g. to share hostPath or emptyDir volumes across the replicated jobs of
+	// RDMA or IPC workloads. Their names must not be used by the volumes of the pod templates.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+
+	// VolumeMounts are added to the volumeMounts of every container and init container of every
+	// pod created by the JobSet, after the ones set in the containers. Their mount paths must not be
+	// used by the volume mounts of the containers of the pod templates.
+	// +optional
+	// +listType=atomic
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+	// Lifecycle determines how the JobSet handles the end of its child Jobs. Normal runs the
+	// JobSet according to its success and failure policies. This is the default.
+	// Drain lets the active child Jobs finish without restarting the JobSet on failures or
+	// creating any Jobs, e.g. during cluster maintenance. Once no child Jobs are active, the
+	// JobSet is marked failed if any of them failed, and completed otherwise. Unlike suspending
+	// or pausing the JobSet, draining does not stop the active child Jobs.
+	// +kubebuilder:validation:Enum=Normal;Drain
+	// +optional
+	Lifecycle JobSetLifecycle `json:"lifecycle,omitempty"`
+
+	// ReportJobStatuses, if true, makes the JobSet controller report the status of each child Job
+	// of the current run in status.replicatedJobsStatus[*].jobStatuses, e.g. to visualize the
+	// timeline of the JobSet without querying its child Jobs. This grows the JobSet status with
+	// the number of child Jobs.
+	// +optional
+	ReportJobStatuses *bool `json:"reportJobStatuses,omitempty"`
+
+	// CleanupPolicy determines which finished child Jobs are deleted once the JobSet completed or
+	// failed, e.g. to delete successful Jobs to save etcd space while retaining failed Jobs for a
+	// post-mortem. If unset, all finished child Jobs are retained.
+	// +optional
+	CleanupPolicy *CleanupPolicy `json:"cleanupPolicy,omitempty"`
+
+	// DefaultJobActiveDeadlineSeconds, if set, is set as the activeDeadlineSeconds of every child
+	// Job whose template does not set its own, to bound the runtime of runaway Jobs. Unlike
+	// spec.activeDeadlineSeconds, it applies to each child Job on its own.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	DefaultJobActiveDeadlineSeconds *int64 `json:"defaultJobActiveDeadlineSeconds,omitempty"`
+
+	// InjectRestartCountEnvVar, if true, injects the JOBSET_RESTART_COUNT environment variable into
+	// every container of every pod created by the JobSet. It contains the number of restarts of the
+	// JobSet when the child Job was created, which matches the jobset.sigs.k8s.io/restart-attempt
+	// label, so pods know which run of the JobSet they belong to, e.g. to resume from a checkpoint.
+	// +optional
+	InjectRestartCountEnvVar *bool `json:"injectRestartCountEnvVar,omitempty"`
+
+	// ServiceAccountName is set as the serviceAccountName of every pod created by the JobSet whose
+	// pod template does not set its own, e.g. to run all the pods of a team with the service
+	// account mandated for it. Service accounts set in the pod templates take precedence.
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// SchedulingGates are added to the schedulingGates of every pod created by the JobSet, after
+	// the ones set in the pod templates, skipping gates already set there, e.g. to hold the pods
+	// until an external controller, such as an admission system, removes the gates.
+	// +optional
+	// +listType=atomic
+	SchedulingGates []corev1.PodSchedulingGate `json:"schedulingGates,omitempty"`
+
+	// CompletionsPolicy restricts the completions of the Indexed Job templates of the replicated
+	// jobs relative to their parallelism, so templates whose semantics would change silently are
+	// rejected at admission. Any allows all combinations. This is the default. EqualParallelism
+	// requires completions to equal parallelism, so all indexes run at once. AtMostParallelism
+	// requires completions not to exceed parallelism, so no index waits for another one to finish.
+	// An unset parallelism is checked as the default parallelism of 1. NonIndexed Job templates
+	// are not restricted.
+	// +kubebuilder:validation:Enum=Any;EqualParallelism;AtMostParallelism
+	// +optional
+	CompletionsPolicy CompletionsPolicy `json:"completionsPolicy,omitempty"`
+
+	// PodRestartPolicy, if set, is the restartPolicy enforced on all the pods of the JobSet, so
+	// the failure handling of the JobSet is consistent across its pods. Pod templates which do not
+	// set a restartPolicy are defaulted to it, and pod templates setting a different one are
+	// rejected. With Never, failed containers are not restarted in place and every pod failure
+	// counts towards the backoffLimit of its Job. If unset, pod templates which do not set a
+	// restartPolicy are defaulted to OnFailure.
+	// +kubebuilder:validation:Enum=OnFailure;Never
+	// +optional
+	PodRestartPolicy corev1.RestartPolicy `json:"podRestartPolicy,omitempty"`
+
+	// CompletionPolicy, if set, configures in what order the child Jobs are torn down once the
+	// success policy of the JobSet is met, e.g. so workers exit before their driver is declared done.
+	// +optional
+	CompletionPolicy *CompletionPolicy `json:"completionPolicy,omitempty"`
+
+	// CreateJobsSuspended, if true, creates all child Jobs suspended, and the JobSet controller
+	// only resumes them once all the Jobs of the JobSet were created, so they are admitted
+	// together, e.g. by a quota system. With an InOrder startup policy, the Jobs of each replicated
+	// job are resumed in turn once they were created. If false, child Jobs are only created
+	// suspended while the JobSet is suspended.
+	// +optional
+	CreateJobsSuspended *bool `json:"createJobsSuspended,omitempty"`
+
+	// HostAliases are added to the hostAliases of every pod created by the JobSet, e.g. to
+	// resolve hostnames in air-gapped clusters without editing each pod template. The hostnames
+	// of an alias whose IP is also set in the pod template are merged into the alias of the
+	// template, other aliases are added after the ones of the template.
+	// +optional
+	// +listType=atomic
+	HostAliases []corev1.HostAlias `json:"hostAliases,omitempty"`
+
+	// MinReadySeconds, if set, is the minimum number of seconds for which the pods of a child Job
+	// must be ready, without any of their containers crashing, before the Job is counted as ready,
+	// like the minReadySeconds of a Deployment. This keeps pods which report ready and crash right
+	// after from marking the JobSet ready or starting the next replicated job of an InOrder startup
+	// policy. Defaults to 0, counting pods as ready as soon as they are.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinReadySeconds *int32 `json:"minReadySeconds,omitempty"`
+
+	// PodReadinessGates are added to the readinessGates of every pod created by the JobSet, after
+	// the ones set in the pod templates, skipping gates already set there, e.g. so the pods are only
+	// ready once an external controller, such as a service mesh, sets the condition of the gate.
+	// +optional
+	// +listType=atomic
+	PodReadinessGates []corev1.PodReadinessGate `json:"podReadinessGates,omitempty"`
+
+	// JobAnnotations are added to every child Job created by the JobSet, but not to its pods, e.g.
+	// for cost tags used for billing which must not reach the pods. They take precedence over
+	// spec.annotations, and annotations set in the ReplicatedJob templates and managed by the
+	// JobSet controller take precedence over them.
+	// +optional
+	JobAnnotations map[string]string `json:"jobAnnotations,omitempty"`
+
+	// PodAnnotations are added to every pod created by the JobSet, but not to its child Jobs.
+	// They take precedence over spec.annotations, and annotations set in the pod templates and
+	// managed by the JobSet controller take precedence over them.
+	// +optional
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+
+	// RuntimeClassName is set as the runtimeClassName of every pod created by the JobSet whose pod
+	// template does not set its own, e.g. to run all the pods of a GPU workload with the runtime
+	// class of the GPU container runtime. Runtime classes set in the pod templates take precedence.
+	// +optional
+	RuntimeClassName string `json:"runtimeClassName,omitempty"`
 }
 
 // JobSetStatus defines the observed state of JobSet
@@ -124,6 +484,91 @@ type JobSetStatus struct {
 	// +listType=map
 	// +listMapKey=name
 	ReplicatedJobsStatus []ReplicatedJobStatus `json:"replicatedJobsStatus,omitempty"`
+
+	// JobPlacements records the exclusive placement of each active child Job using
+	// exclusive job placement per topology domain.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	JobPlacements []JobPlacement `json:"jobPlacements,omitempty"`
+
+	// StartTime is the time the JobSet was started, i.e. first reconciled while not
+	// suspended. It is reset when the JobSet is suspended and is used to enforce
+	// spec.activeDeadlineSeconds.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// ObservedRestartTrigger is the last value of the jobset.sigs.k8s.io/restart annotation
+	// handled by the JobSet controller. The JobSet is restarted when the annotation value
+	// differs from this value.
+	// +optional
+	ObservedRestartTrigger string `json:"observedRestartTrigger,omitempty"`
+
+	// ObservedGeneration is the most recent generation of the JobSet spec reconciled by the
+	// JobSet controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// StartupPolicyStatus describes the progress of the in-order startup policy, if any. It is
+	// updated while the StartupPolicyCompleted condition is not true, and cleared once it is.
+	// +optional
+	StartupPolicyStatus *StartupPolicyStatus `json:"startupPolicyStatus,omitempty"`
+
+	// RestartTimes records the times of the restarts of the JobSet executed by its failure
+	// policy within the last hour. It is used to enforce failurePolicy.maxRestartsPerHour.
+	// +optional
+	// +listType=atomic
+	RestartTimes []metav1.Time `json:"restartTimes,omitempty"`
+
+	// AggregatedResourceRequests is the total of the resource requests of the containers of all
+	// the pods the JobSet runs at once, i.e. the container requests of each replicated job
+	// multiplied by its parallelism and its replicas. It is recomputed when the replicas change.
+	// +optional
+	AggregatedResourceRequests corev1.ResourceList `json:"aggregatedResourceRequests,omitempty"`
+
+	// ReconcileErrors is the number of consecutive reconciliations of the JobSet which failed
+	// with an error. It is only tracked if the controller backs off reconciliation errors, up to
+	// the number of errors at which it backs off, and is reset by a successful reconciliation.
+	// +optional
+	ReconcileErrors int32 `json:"reconcileErrors,omitempty"`
+
+	// JobsPendingDeletion lists the names of the child Jobs the JobSet controller is deleting, e.g.
+	// the Jobs of the previous run while the JobSet restarts, so an in-progress teardown can be told
+	// apart from a stuck JobSet. It is empty once the Jobs are gone.
+	// +optional
+	// +listType=atomic
+	JobsPendingDeletion []string `json:"jobsPendingDeletion,omitempty"`
+
+	// SucceededJobs lists the names of the child Jobs of the target replicated jobs of the
+	// success policy which succeeded in any run of the JobSet. It is only tracked if the success
+	// policy counts succeeded Jobs across restarts.
+	// +optional
+	// +listType=atomic
+	SucceededJobs []string `json:"succeededJobs,omitempty"`
+
+	// CompletionTime is the time the JobSet completed successfully, i.e. got the Completed
+	// condition. It is not changed once set.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Duration is the time the JobSet took to complete, from its first start time to its
+	// completion time, including the time it was suspended after it first started. It is set
+	// along with the completion time and not changed once set.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// UnschedulableJobs lists the active child Jobs with pending pods the scheduler could not
+	// schedule, e.g. when exclusive placement finds no free topology domain for them.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	UnschedulableJobs []UnschedulableJob `json:"unschedulableJobs,omitempty"`
+
+	// FirstStartTime is the time the JobSet was first started, i.e. first reconciled while not
+	// suspended. Unlike the start time, it is not reset when the JobSet is suspended, and not
+	// changed once set. It is the start of the duration of the JobSet.
+	// +optional
+	FirstStartTime *metav1.Time `json:"firstStartTime,omitempty"`
 }
 
 // ReplicatedJobStatus defines the observed ReplicatedJobs Readiness.
@@ -148,6 +593,79 @@ type ReplicatedJobStatus struct {
 
 	// Suspended is the number of child Jobs which are in a suspended state.
 	Suspended int32 `json:"suspended"`
+
+	// JobStatuses is the status of each child Job of the ReplicatedJob in the current run of
+	// the JobSet. It is only reported if spec.reportJobStatuses is true.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	JobStatuses []JobStatus `json:"jobStatuses,omitempty"`
+}
+
+// JobStatus defines the observed timestamps of a child Job.
+type JobStatus struct {
+	// Name of the child Job.
+	Name string `json:"name"`
+
+	// StartTime is the time the child Job was started, copied from its status.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time the child Job completed successfully, copied from its status.
+	// It is unset while the Job is running, and if the Job failed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// JobPlacement records where a child Job using exclusive placement was placed.
+type JobPlacement struct {
+	// Name of the child Job.
+	Name string `json:"name"`
+
+	// TopologyKey is the exclusive placement topology key of the Job, defined by
+	// the alpha.jobset.sigs.k8s.io/exclusive-topology annotation.
+	TopologyKey string `json:"topologyKey"`
+
+	// TopologyDomain is the value of the topology key label on the node the leader
+	// pod of the Job was scheduled on. It is empty until the leader pod is scheduled.
+	// +optional
+	TopologyDomain string `json:"topologyDomain,omitempty"`
+
+	// NodeName is the name of the node the leader pod of the Job was scheduled on.
+	// It is empty until the leader pod is scheduled.
+	// +optional
+	NodeName string `json:"nodeName,omitempty"`
+}
+
+// UnschedulableJob records a child Job with pending pods the scheduler could not schedule.
+type UnschedulableJob struct {
+	// Name of the child Job.
+	Name string `json:"name"`
+
+	// Message is the message of the scheduler explaining why a pod of the Job could not be
+	// scheduled, from the PodScheduled condition of the pod.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// StartupPolicyStatus describes the progress of an in-order startup policy.
+type StartupPolicyStatus struct {
+	// CurrentReplicatedJob is the name of the replicated job whose Jobs are currently being
+	// started. The following replicated jobs are only started once all of its Jobs are ready.
+	CurrentReplicatedJob string `json:"currentReplicatedJob"`
+
+	// RemainingReplicatedJobs is the number of replicated jobs still to be started after the
+	// current one.
+	RemainingReplicatedJobs int32 `json:"remainingReplicatedJobs"`
+
+	// StartedJobs is the number of Jobs of the current replicated job which are ready or finished.
+	// +optional
+	StartedJobs int32 `json:"startedJobs,omitempty"`
+
+	// LastProgressTime is the last time the current replicated job or its number of started
+	// Jobs changed. It is used to enforce startupPolicy.stallTimeoutSeconds.
+	// +optional
+	LastProgressTime *metav1.Time `json:"lastProgressTime,omitempty"`
 }
 
 // +genclient
@@ -157,6 +675,7 @@ type ReplicatedJobStatus struct {
 // +kubebuilder:printcolumn:name="Restarts",JSONPath=".status.restarts",type=string,description="Number of restarts"
 // +kubebuilder:printcolumn:name="Completed",type="string",priority=0,JSONPath=".status.conditions[?(@.type==\"Completed\")].status"
 // +kubebuilder:printcolumn:name="Suspended",type="string",JSONPath=".spec.suspend",description="JobSet suspended"
+// +kubebuilder:printcolumn:name="Duration",type="string",JSONPath=".status.duration",description="Time the JobSet took to complete"
 // +kubebuilder:printcolumn:name="Age",JSONPath=".metadata.creationTimestamp",type=date,description="Time this JobSet was created"
 
 // JobSet is the Schema for the jobsets API
@@ -182,10 +701,113 @@ type ReplicatedJob struct {
 	// Template defines the template of the Job that will be created.
 	Template batchv1.JobTemplateSpec `json:"template"`
 
+	// PodTemplateName is the name of an entry in spec.podTemplates used as the
+	// pod template of the Jobs created from this ReplicatedJob. When set, the pod
+	// template in template.spec.template must be left empty.
+	// +optional
+	PodTemplateName string `json:"podTemplateName,omitempty"`
+
 	// Replicas is the number of jobs that will be created from this ReplicatedJob's template.
 	// Jobs names will be in the format: <jobSet.name>-<spec.replicatedJob.name>-<job-index>
+	// A ReplicatedJob with zero replicas creates no jobs, and is ignored for the completion
+	// of the JobSet.
 	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=0
 	Replicas int32 `json:"replicas,omitempty"`
+
+	// IndexedOverrides are patches applied to the Job template of some of the Jobs created
+	// from this ReplicatedJob, e.g. to give the Job at index 0 more resources than the others.
+	// The overrides targeting a Job index are applied in order, after the pod template
+	// referenced by podTemplateName (if any) is resolved.
+	// +listType=atomic
+	// +optional
+	IndexedOverrides []IndexedOverride `json:"indexedOverrides,omitempty"`
+
+	// FailurePolicy, if set, overrides the JobSet failure policy for failures of the child
+	// Jobs of this replicated job. MaxRestarts is compared against the restarts of the whole
+	// JobSet. The failure aggregation and deletion settings are always taken from the JobSet
+	// failure policy.
+	// +optional
+	FailurePolicy *FailurePolicy `json:"failurePolicy,omitempty"`
+
+	// PodDisruptionBudget, if set, makes the JobSet controller create a PodDisruptionBudget
+	// selecting the pods of this replicated job, which limits how many of them can be evicted
+	// at once by voluntary disruptions, e.g. node drains.
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudget `json:"podDisruptionBudget,omitempty"`
+
+	// RestartPriority determines the order in which the Jobs of the replicated jobs are
+	// recreated when the JobSet is restarted. The Jobs of replicated jobs with a higher
+	// priority are created first, and the Jobs of a replicated job are only created once all
+	// the Jobs of the replicated jobs with a higher priority are ready or succeeded, e.g. to
+	// bring a coordinator back before the workers. Replicated jobs with the same priority are
+	// created together, in spec order. It is ignored with the InOrder startup policy, which
+	// always creates replicated jobs in spec order, and while the JobSet is suspended.
+	// +optional
+	RestartPriority int32 `json:"restartPriority,omitempty"`
+	// CompletionTimeoutSeconds, if set, is the number of seconds the Jobs of this replicated job
+	// may run, counted from the earliest start time of its Jobs in the current JobSet run. Once
+	// exceeded, the active Jobs of the replicated job are treated as failed, which triggers the
+	// failure policy. Unlike the activeDeadlineSeconds of the Job template, it bounds the
+	// replicated job as a whole.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	CompletionTimeoutSeconds *int32 `json:"completionTimeoutSeconds,omitempty"`
+
+	// NonCritical marks the replicated job as non-critical, e.g. for a monitoring service which
+	// runs forever. The Jobs of a non-critical replicated job neither count towards the success
+	// policy nor trigger the failure policy when they fail, and are deleted once the JobSet
+	// finishes. At least one replicated job must be critical.
+	// +optional
+	NonCritical *bool `json:"nonCritical,omitempty"`
+
+	// SchedulingGates are added to the schedulingGates of every pod of the replicated job, after
+	// the ones set in the pod template and the JobSet level scheduling gates, skipping gates
+	// already set there.
+	// +optional
+	// +listType=atomic
+	SchedulingGates []corev1.PodSchedulingGate `json:"schedulingGates,omitempty"`
+
+	// PodPriorityClassName, if set, is the priorityClassName of the pods of the replicated job,
+	// overriding the one set in the pod template, e.g. to give the coordinator a higher priority
+	// than the workers so its pods preempt worker pods under contention. The priority and
+	// preemptionPolicy of the pod template are cleared, so they are resolved from the class.
+	// +optional
+	PodPriorityClassName string `json:"podPriorityClassName,omitempty"`
+
+	// SchedulerName, if set, is the schedulerName of the pods of the replicated job, overriding
+	// the one set in the pod template, e.g. to schedule the workers with a batch scheduler and the
+	// coordinator with the default scheduler within the same JobSet.
+	// +optional
+	SchedulerName string `json:"schedulerName,omitempty"`
+
+	// RestartIsolation, if true, keeps the Jobs of this replicated job instead of recreating them
+	// when the JobSet is restarted due to failed Jobs of other replicated jobs, e.g. to keep the
+	// workers of a fault-tolerant framework running across a restart of the coordinator. Its Jobs
+	// are still recreated when Jobs of this replicated job failed, or when the restart was
+	// triggered by the restart annotation.
+	// +optional
+	RestartIsolation *bool `json:"restartIsolation,omitempty"`
+
+	// MaxFailedJobs, if set, is the number of Jobs of this replicated job which may fail, across
+	// all instances, before the failure policy is triggered, similar to the maxFailedIndexes of
+	// an Indexed Job. Failed Jobs within the limit are not recreated, and the success policy with
+	// the All operator is met without them. It must be less than the number
+	// of Jobs of the replicated job.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxFailedJobs *int32 `json:"maxFailedJobs,omitempty"`
+
+	// DependsOn are the names of the other replicated jobs this replicated job depends on, e.g. a
+	// trainer depending on the data loader feeding it. Once any replicated job of the JobSet
+	// depends on another, a restart of the JobSet due to failed Jobs only recreates the Jobs of the
+	// replicated jobs with failed Jobs and of the replicated jobs depending on them, directly or
+	// transitively, and keeps the Jobs of the other replicated jobs running. Replicated jobs with
+	// restart isolation are kept regardless, and don't propagate the restart to their dependents.
+	// The dependencies must not form a cycle.
+	// +optional
+	// +listType=atomic
+	DependsOn []string `json:"dependsOn,omitempty"`
 }
 
 type Network struct {
@@ -205,6 +827,17 @@ type Network struct {
 	// Defaults to True.
 	// +optional
 	PublishNotReadyAddresses *bool `json:"publishNotReadyAddresses,omitempty"`
+
+	// CoordinatorService configures an additional Service selecting only the coordinator
+	// pod defined in spec.coordinator, e.g. to make it reachable from outside the cluster.
+	// +optional
+	CoordinatorService *CoordinatorService `json:"coordinatorService,omitempty"`
+
+	// ServiceSelector holds additional labels merged into the selector of the headless service,
+	// e.g. to only publish DNS records for a subset of the pods of the JobSet. The selector
+	// always includes the jobset.sigs.k8s.io/jobset-name label, which can't be overridden.
+	// +optional
+	ServiceSelector map[string]string `json:"serviceSelector,omitempty"`
 }
 
 // Operator defines the target of a SuccessPolicy or FailurePolicy.
@@ -222,8 +855,75 @@ type FailurePolicy struct {
 	// MaxRestarts defines the limit on the number of JobSet restarts.
 	// A restart is achieved by recreating all active child jobs.
 	MaxRestarts int32 `json:"maxRestarts,omitempty"`
+
+	// DeletePropagationPolicy is the propagation policy used when deleting the child Jobs
+	// of the previous run during a JobSet restart.
+	// Defaults to Foreground.
+	// +kubebuilder:validation:Enum=Foreground;Background;Orphan
+	// +optional
+	DeletePropagationPolicy *metav1.DeletionPropagation `json:"deletePropagationPolicy,omitempty"`
+
+	// TerminationGracePeriodOverride, if set, is the grace period in seconds used when
+	// deleting the child Jobs of the previous run during a JobSet restart. If unset,
+	// the default grace period for the object is used.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TerminationGracePeriodOverride *int64 `json:"terminationGracePeriodOverride,omitempty"`
+
+	// FailureAggregationSeconds, if set, is the time window in seconds after the first child
+	// Job failure during which the JobSet controller waits for further failures, before
+	// deciding whether to restart or fail the JobSet based on all failed child Jobs.
+	// This avoids restarting on the first of many near-simultaneous failures.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	FailureAggregationSeconds *int32 `json:"failureAggregationSeconds,omitempty"`
+
+	// Action is the action taken when a child Job fails. RestartJobSet restarts the JobSet
+	// until MaxRestarts is reached, after which the JobSet is failed. Ignore leaves the failed
+	// Job in place without restarting or failing the JobSet, which is useful for auxiliary
+	// replicated jobs whose failures must not interrupt the JobSet.
+	// Defaults to RestartJobSet.
+	// +kubebuilder:validation:Enum=RestartJobSet;Ignore
+	// +optional
+	Action FailurePolicyAction `json:"action,omitempty"`
+
+	// MaxRestartsPerHour, if set, limits the rate of JobSet restarts. A restart which would exceed
+	// the given number of restarts within the last hour is deferred until it no longer exceeds
+	// the rate, rather than failing the JobSet. MaxRestarts still applies.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxRestartsPerHour *int32 `json:"maxRestartsPerHour,omitempty"`
+
+	// OnRestartOverrides, if set, is a strategic merge patch applied to the Job template (a
+	// batch/v1 JobTemplateSpec) of the Jobs recreated by a JobSet restart. It is not applied to
+	// the Jobs of the first run. This allows the JobSet to adapt on restart, e.g. by lowering the
+	// parallelism or raising the backoffLimit of the recreated Jobs. The patch of a replicatedJob
+	// failure policy takes precedence over the patch of the JobSet failure policy.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +optional
+	OnRestartOverrides *runtime.RawExtension `json:"onRestartOverrides,omitempty"`
+
+	// StabilizationWindowSeconds, if set, is the time in seconds for which the terminal condition
+	// of a child Job must persist before the JobSet controller acts on it. Until then, the finished
+	// Job is considered active, so flapping Job statuses neither complete, restart nor fail the JobSet.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	StabilizationWindowSeconds *int32 `json:"stabilizationWindowSeconds,omitempty"`
 }
 
+// FailurePolicyAction is the action taken by the JobSet controller when a child Job fails.
+type FailurePolicyAction string
+
+const (
+	// FailurePolicyActionRestartJobSet restarts the JobSet, until the max restarts are reached.
+	FailurePolicyActionRestartJobSet FailurePolicyAction = "RestartJobSet"
+
+	// FailurePolicyActionIgnore ignores the failure of the child Job.
+	FailurePolicyActionIgnore FailurePolicyAction = "Ignore"
+)
+
 type SuccessPolicy struct {
 	// Operator determines either All or Any of the selected jobs should succeed to consider the JobSet successful
 	// +kubebuilder:validation:Enum=All;Any
@@ -234,6 +934,68 @@ type SuccessPolicy struct {
 	// +optional
 	// +listType=atomic
 	TargetReplicatedJobs []string `json:"targetReplicatedJobs,omitempty"`
+
+	// PodAnnotation, if set, additionally declares a child Job of the target replicated jobs
+	// successful once all of its pods carry the given annotation, regardless of the exit codes
+	// of its containers. This is useful when success is reported by a sidecar which writes
+	// an annotation on the pod.
+	// +optional
+	PodAnnotation *PodAnnotationSuccessCondition `json:"podAnnotation,omitempty"`
+
+	// TotalSucceeded, if set, declares the JobSet successful once the total number of succeeded
+	// child Jobs across the target replicated jobs reaches the given target, regardless of
+	// the operator. The remaining active Jobs are then deleted. This allows work-queue style
+	// JobSets which need a number of successful Jobs rather than particular ones.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	TotalSucceeded *int32 `json:"totalSucceeded,omitempty"`
+
+	// IndexedJobCompletion determines when a completed Indexed child Job is counted as succeeded.
+	// With Condition, a Job is succeeded once it has the Complete condition, which an Indexed Job
+	// with a success policy of its own may reach with fewer succeeded indexes than completions.
+	// With AllIndexes, a Job is only succeeded once all of its completions succeeded, and a
+	// complete Indexed Job with fewer succeeded indexes is counted as failed.
+	// Defaults to Condition.
+	// +kubebuilder:validation:Enum=Condition;AllIndexes
+	// +optional
+	IndexedJobCompletion IndexedJobCompletion `json:"indexedJobCompletion,omitempty"`
+
+	// CompletionGracePeriodSeconds, if set, delays declaring the JobSet completed once the success
+	// policy is met, until the pods of the succeeded child Jobs terminated or the given number of
+	// seconds passed since the last of these Jobs completed, e.g. so sidecars can flush metrics
+	// after the main container exited. The TTL of the JobSet starts once it is completed.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	CompletionGracePeriodSeconds *int32 `json:"completionGracePeriodSeconds,omitempty"`
+
+	// CountAcrossRestarts, if true, counts the child Jobs of the target replicated jobs which
+	// succeeded in any run of the JobSet towards the success policy, rather than only those of
+	// the current run. The JobSet controller records them in status.succeededJobs, so a Job
+	// which succeeded before a restart still counts once it is recreated. Each Job is counted
+	// once, however often it succeeded.
+	// +optional
+	CountAcrossRestarts *bool `json:"countAcrossRestarts,omitempty"`
+}
+
+// IndexedJobCompletion determines when a completed Indexed child Job is counted as succeeded.
+type IndexedJobCompletion string
+
+const (
+	// IndexedJobCompletionCondition counts an Indexed Job as succeeded once it has the Complete condition.
+	IndexedJobCompletionCondition IndexedJobCompletion = "Condition"
+
+	// IndexedJobCompletionAllIndexes counts an Indexed Job as succeeded only once all of its
+	// completions succeeded.
+	IndexedJobCompletionAllIndexes IndexedJobCompletion = "AllIndexes"
+)
+
+// PodAnnotationSuccessCondition defines a pod annotation which marks a child Job as successful.
+type PodAnnotationSuccessCondition struct {
+	// Key is the annotation key to look for on the pods.
+	Key string `json:"key"`
+
+	// Value is the annotation value the pods must carry.
+	Value string `json:"value"`
 }
 
 type StartupPolicyOptions string
@@ -255,6 +1017,192 @@ type StartupPolicy struct {
 	// when all the jobs of the previous one are ready.
 	// +kubebuilder:validation:Enum=AnyOrder;InOrder
 	StartupPolicyOrder StartupPolicyOptions `json:"startupPolicyOrder"`
+
+	// StallTimeoutSeconds, if set, is the number of seconds an in-order startup may make no
+	// progress, i.e. no more Jobs of the current replicated job become ready, before the JobSet
+	// gets the PotentialDeadlock condition, e.g. when the pods of the current replicated job wait
+	// on pods of a later replicated job which are never started.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	StallTimeoutSeconds *int32 `json:"stallTimeoutSeconds,omitempty"`
+
+	// Gates configure the replicated jobs which must reach a condition stronger than being ready,
+	// e.g. a warmup replicated job downloading a dataset which must complete before training
+	// starts, before the replicated jobs listed after them are started. Gates are only
+	// supported with the InOrder startup order.
+	// +optional
+	// +listType=map
+	// +listMapKey=replicatedJob
+	Gates []StartupGate `json:"gates,omitempty"`
+}
+
+// StartupGate holds back the replicated jobs listed after a replicated job until it reaches a condition.
+type StartupGate struct {
+	// ReplicatedJob is the name of the gated replicated job.
+	ReplicatedJob string `json:"replicatedJob"`
+
+	// Condition is the condition the replicated job must reach before the replicated jobs listed
+	// after it are started. CompletedSuccessfully means all of its Jobs must have succeeded.
+	// +kubebuilder:validation:Enum=CompletedSuccessfully
+	Condition StartupGateCondition `json:"condition"`
+}
+
+type StartupGateCondition string
+
+const (
+	// StartupGateCompletedSuccessfully waits for all Jobs of the gated replicated job to succeed.
+	StartupGateCompletedSuccessfully StartupGateCondition = "CompletedSuccessfully"
+)
+
+type JobSetLifecycle string
+
+const (
+	// JobSetLifecycleNormal runs the JobSet according to its success and failure policies.
+	JobSetLifecycleNormal JobSetLifecycle = "Normal"
+
+	// JobSetLifecycleDrain lets the active child Jobs finish without restarting the JobSet
+	// or creating any Jobs, then marks the JobSet completed or failed.
+	JobSetLifecycleDrain JobSetLifecycle = "Drain"
+)
+
+type OnSuspendPolicy string
+
+const (
+	// OnSuspendDeletePods suspends the child Jobs when the JobSet is suspended,
+	// which deletes their pods.
+	OnSuspendDeletePods OnSuspendPolicy = "DeletePods"
+
+	// OnSuspendRetainPods leaves the child Jobs running when the JobSet is suspended,
+	// only marking the JobSet as suspended in its status.
+	OnSuspendRetainPods OnSuspendPolicy = "RetainPods"
+)
+
+// CompletionPolicyOrder determines the teardown order of the replicated jobs of a completing JobSet.
+type CompletionPolicyOrder string
+
+const (
+	// CompletionPolicyAnyOrder declares the JobSet completed without waiting for any Jobs to be torn down.
+	CompletionPolicyAnyOrder CompletionPolicyOrder = "AnyOrder"
+
+	// CompletionPolicyTargetsLast tears down the Jobs not targeted by the success policy before
+	// declaring the JobSet completed.
+	CompletionPolicyTargetsLast CompletionPolicyOrder = "TargetsLast"
+)
+
+// CompletionPolicy configures in what order the child Jobs of a JobSet are torn down once the
+// success policy is met.
+type CompletionPolicy struct {
+	// Order determines the teardown order of the replicated jobs once the success policy is met.
+	// AnyOrder means to declare the JobSet completed right away, and to delete its remaining active
+	// Jobs afterwards. TargetsLast means to first delete the active Jobs of the replicated jobs not
+	// targeted by the success policy, e.g. the workers of a driver, and to only declare the JobSet
+	// completed once they are gone. This is the inverse of an InOrder startup policy.
+	// Defaults to AnyOrder.
+	// +kubebuilder:validation:Enum=AnyOrder;TargetsLast
+	// +optional
+	Order CompletionPolicyOrder `json:"order,omitempty"`
+}
+
+// CompletionsPolicy restricts the completions of the Indexed Job templates of a JobSet relative
+// to their parallelism.
+type CompletionsPolicy string
+
+const (
+	// CompletionsPolicyAny allows any completions.
+	CompletionsPolicyAny CompletionsPolicy = "Any"
+
+	// CompletionsPolicyEqualParallelism requires the completions to equal the parallelism.
+	CompletionsPolicyEqualParallelism CompletionsPolicy = "EqualParallelism"
+
+	// CompletionsPolicyAtMostParallelism requires the completions not to exceed the parallelism.
+	CompletionsPolicyAtMostParallelism CompletionsPolicy = "AtMostParallelism"
+)
+
+// CleanupPolicy defines which finished child Jobs are deleted once the JobSet completed or failed.
+type CleanupPolicy struct {
+	// OnSuccess determines whether the successful child Jobs are deleted or retained.
+	// Defaults to Retain.
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +optional
+	OnSuccess CleanupPolicyAction `json:"onSuccess,omitempty"`
+
+	// OnFailure determines whether the failed child Jobs are deleted or retained.
+	// Defaults to Retain.
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +optional
+	OnFailure CleanupPolicyAction `json:"onFailure,omitempty"`
+}
+
+// CleanupPolicyAction is the action taken on the finished child Jobs of a finished JobSet.
+type CleanupPolicyAction string
+
+const (
+	// CleanupPolicyDelete deletes the child Jobs.
+	CleanupPolicyDelete CleanupPolicyAction = "Delete"
+
+	// CleanupPolicyRetain retains the child Jobs.
+	CleanupPolicyRetain CleanupPolicyAction = "Retain"
+)
+
+// Coordinator defines which pod of the JobSet acts as its coordinator.
+type Coordinator struct {
+	// ReplicatedJob is the name of the ReplicatedJob which contains the coordinator pod.
+	ReplicatedJob string `json:"replicatedJob"`
+
+	// JobIndex is the index of the Job which contains the coordinator pod
+	// (i.e., for a ReplicatedJob with N replicas, there are Job indexes 0 to N-1).
+	// +optional
+	JobIndex int32 `json:"jobIndex,omitempty"`
+
+	// PodIndex is the completion index of the coordinator pod within its Job.
+	// +optional
+	PodIndex int32 `json:"podIndex,omitempty"`
+}
+
+// CoordinatorService defines an additional Service selecting only the coordinator pod.
+type CoordinatorService struct {
+	// Type of the Service, either ClusterIP or LoadBalancer. Defaults to ClusterIP.
+	// +kubebuilder:validation:Enum=ClusterIP;LoadBalancer
+	// +optional
+	Type corev1.ServiceType `json:"type,omitempty"`
+
+	// Ports exposed by the Service.
+	// +listType=atomic
+	Ports []corev1.ServicePort `json:"ports"`
+}
+
+// IndexedOverride is a patch applied to the Job template of a range of Job indexes of a
+// ReplicatedJob.
+type IndexedOverride struct {
+	// StartIndex is the first Job index the patch is applied to.
+	// +kubebuilder:validation:Minimum=0
+	StartIndex int32 `json:"startIndex"`
+
+	// EndIndex is the last Job index the patch is applied to, inclusive.
+	// Defaults to startIndex, i.e. the patch is only applied to a single Job.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	EndIndex *int32 `json:"endIndex,omitempty"`
+
+	// Patch is a strategic merge patch applied to the Job template (a batch/v1 JobTemplateSpec)
+	// of the targeted Jobs.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	Patch runtime.RawExtension `json:"patch"`
+}
+
+// PodDisruptionBudget defines the PodDisruptionBudget created for the pods of a ReplicatedJob.
+type PodDisruptionBudget struct {
+	// MinAvailable is the number or percentage of the pods of the replicated job that must
+	// remain available after an eviction. Mutually exclusive with maxUnavailable.
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// MaxUnavailable is the number or percentage of the pods of the replicated job that can be
+	// unavailable after an eviction. Mutually exclusive with minAvailable.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
 }
 
 func init() {
@@ -24,16 +24,153 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
-		"sigs.k8s.io/jobset/api/jobset/v1alpha2.FailurePolicy":       schema_jobset_api_jobset_v1alpha2_FailurePolicy(ref),
-		"sigs.k8s.io/jobset/api/jobset/v1alpha2.JobSet":              schema_jobset_api_jobset_v1alpha2_JobSet(ref),
-		"sigs.k8s.io/jobset/api/jobset/v1alpha2.JobSetList":          schema_jobset_api_jobset_v1alpha2_JobSetList(ref),
-		"sigs.k8s.io/jobset/api/jobset/v1alpha2.JobSetSpec":          schema_jobset_api_jobset_v1alpha2_JobSetSpec(ref),
-		"sigs.k8s.io/jobset/api/jobset/v1alpha2.JobSetStatus":        schema_jobset_api_jobset_v1alpha2_JobSetStatus(ref),
-		"sigs.k8s.io/jobset/api/jobset/v1alpha2.Network":             schema_jobset_api_jobset_v1alpha2_Network(ref),
-		"sigs.k8s.io/jobset/api/jobset/v1alpha2.ReplicatedJob":       schema_jobset_api_jobset_v1alpha2_ReplicatedJob(ref),
-		"sigs.k8s.io/jobset/api/jobset/v1alpha2.ReplicatedJobStatus": schema_jobset_api_jobset_v1alpha2_ReplicatedJobStatus(ref),
-		"sigs.k8s.io/jobset/api/jobset/v1alpha2.StartupPolicy":       schema_jobset_api_jobset_v1alpha2_StartupPolicy(ref),
-		"sigs.k8s.io/jobset/api/jobset/v1alpha2.SuccessPolicy":       schema_jobset_api_jobset_v1alpha2_SuccessPolicy(ref),
+		"sigs.k8s.io/jobset/api/jobset/v1alpha2.CleanupPolicy":                 schema_jobset_api_jobset_v1alpha2_CleanupPolicy(ref),
+		"sigs.k8s.io/jobset/api/jobset/v1alpha2.CompletionPolicy":              schema_jobset_api_jobset_v1alpha2_CompletionPolicy(ref),
+		"sigs.k8s.io/jobset/api/jobset/v1alpha2.Coordinator":                   schema_jobset_api_jobset_v1alpha2_Coordinator(ref),
+		"sigs.k8s.io/jobset/api/jobset/v1alpha2.CoordinatorService":            schema_jobset_api_jobset_v1alpha2_CoordinatorService(ref),
+		"sigs.k8s.io/jobset/api/jobset/v1alpha2.FailurePolicy":                 schema_jobset_api_jobset_v1alpha2_FailurePolicy(ref),
+		"sigs.k8s.io/jobset/api/jobset/v1alpha2.IndexedOverride":               schema_jobset_api_jobset_v1alpha2_IndexedOverride(ref),
+		"sigs.k8s.io/jobset/api/jobset/v1alpha2.JobPlacement":                  schema_jobset_api_jobset_v1alpha2_JobPlacement(ref),
+		"sigs.k8s.io/jobset/api/jobset/v1alpha2.JobSet":                        schema_jobset_api_jobset_v1alpha2_JobSet(ref),
+		"sigs.k8s.io/jobset/api/jobset/v1alpha2.JobSetList":                    schema_jobset_api_jobset_v1alpha2_JobSetList(ref),
+		"sigs.k8s.io/jobset/api/jobset/v1alpha2.JobSetSpec":                    schema_jobset_api_jobset_v1alpha2_JobSetSpec(ref),
+		"sigs.k8s.io/jobset/api/jobset/v1alpha2.JobSetStatus":                  schema_jobset_api_jobset_v1alpha2_JobSetStatus(ref),
+		"sigs.k8s.io/jobset/api/jobset/v1alpha2.JobStatus":                     schema_jobset_api_jobset_v1alpha2_JobStatus(ref),
+		"sigs.k8s.io/jobset/api/jobset/v1alpha2.Network":                       schema_jobset_api_jobset_v1alpha2_Network(ref),
+		"sigs.k8s.io/jobset/api/jobset/v1alpha2.PodAnnotationSuccessCondition": schema_jobset_api_jobset_v1alpha2_PodAnnotationSuccessCondition(ref),
+		"sigs.k8s.io/jobset/api/jobset/v1alpha2.PodDisruptionBudget":           schema_jobset_api_jobset_v1alpha2_PodDisruptionBudget(ref),
+		"sigs.k8s.io/jobset/api/jobset/v1alpha2.ReplicatedJob":                 schema_jobset_api_jobset_v1alpha2_ReplicatedJob(ref),
+		"sigs.k8s.io/jobset/api/jobset/v1alpha2.ReplicatedJobStatus":           schema_jobset_api_jobset_v1alpha2_ReplicatedJobStatus(ref),
+		"sigs.k8s.io/jobset/api/jobset/v1alpha2.StartupGate":                   schema_jobset_api_jobset_v1alpha2_StartupGate(ref),
+		"sigs.k8s.io/jobset/api/jobset/v1alpha2.StartupPolicy":                 schema_jobset_api_jobset_v1alpha2_StartupPolicy(ref),
+		"sigs.k8s.io/jobset/api/jobset/v1alpha2.StartupPolicyStatus":           schema_jobset_api_jobset_v1alpha2_StartupPolicyStatus(ref),
+		"sigs.k8s.io/jobset/api/jobset/v1alpha2.SuccessPolicy":                 schema_jobset_api_jobset_v1alpha2_SuccessPolicy(ref),
+		"sigs.k8s.io/jobset/api/jobset/v1alpha2.UnschedulableJob":              schema_jobset_api_jobset_v1alpha2_UnschedulableJob(ref),
+	}
+}
+
+func schema_jobset_api_jobset_v1alpha2_CleanupPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CleanupPolicy defines which finished child Jobs are deleted once the JobSet completed or failed.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"onSuccess": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OnSuccess determines whether the successful child Jobs are deleted or retained. Defaults to Retain.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"onFailure": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OnFailure determines whether the failed child Jobs are deleted or retained. Defaults to Retain.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_jobset_api_jobset_v1alpha2_CompletionPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CompletionPolicy configures in what order the child Jobs of a JobSet are torn down once the success policy is met.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"order": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Order determines the teardown order of the replicated jobs once the success policy is met. AnyOrder means to declare the JobSet completed right away, and to delete its remaining active Jobs afterwards. TargetsLast means to first delete the active Jobs of the replicated jobs not targeted by the success policy, e.g. the workers of a driver, and to only declare the JobSet completed once they are gone. This is the inverse of an InOrder startup policy. Defaults to AnyOrder.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_jobset_api_jobset_v1alpha2_Coordinator(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Coordinator defines which pod of the JobSet acts as its coordinator.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"replicatedJob": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReplicatedJob is the name of the ReplicatedJob which contains the coordinator pod.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"jobIndex": {
+						SchemaProps: spec.SchemaProps{
+							Description: "JobIndex is the index of the Job which contains the coordinator pod (i.e., for a ReplicatedJob with N replicas, there are Job indexes 0 to N-1).",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"podIndex": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodIndex is the completion index of the coordinator pod within its Job.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"replicatedJob"},
+			},
+		},
+	}
+}
+
+func schema_jobset_api_jobset_v1alpha2_CoordinatorService(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CoordinatorService defines an additional Service selecting only the coordinator pod.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type of the Service, either ClusterIP or LoadBalancer. Defaults to ClusterIP.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"ports": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Ports exposed by the Service.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.ServicePort"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"ports"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.ServicePort"},
 	}
 }
 
@@ -50,7 +187,139 @@ func schema_jobset_api_jobset_v1alpha2_FailurePolicy(ref common.ReferenceCallbac
 							Format:      "int32",
 						},
 					},
+					"deletePropagationPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DeletePropagationPolicy is the propagation policy used when deleting the child Jobs of the previous run during a JobSet restart. Defaults to Foreground.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"terminationGracePeriodOverride": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TerminationGracePeriodOverride, if set, is the grace period in seconds used when deleting the child Jobs of the previous run during a JobSet restart. If unset, the default grace period for the object is used.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"failureAggregationSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FailureAggregationSeconds, if set, is the time window in seconds after the first child Job failure during which the JobSet controller waits for further failures, before deciding whether to restart or fail the JobSet based on all failed child Jobs. This avoids restarting on the first of many near-simultaneous failures.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"action": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Action is the action taken when a child Job fails. RestartJobSet restarts the JobSet until MaxRestarts is reached, after which the JobSet is failed. Ignore leaves the failed Job in place without restarting or failing the JobSet, which is useful for auxiliary replicated jobs whose failures must not interrupt the JobSet. Defaults to RestartJobSet.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"maxRestartsPerHour": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxRestartsPerHour, if set, limits the rate of JobSet restarts. A restart which would exceed the given number of restarts within the last hour is deferred until it no longer exceeds the rate, rather than failing the JobSet. MaxRestarts still applies.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"onRestartOverrides": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OnRestartOverrides, if set, is a strategic merge patch applied to the Job template (a batch/v1 JobTemplateSpec) of the Jobs recreated by a JobSet restart. It is not applied to the Jobs of the first run. This allows the JobSet to adapt on restart, e.g. by lowering the parallelism or raising the backoffLimit of the recreated Jobs. The patch of a replicatedJob failure policy takes precedence over the patch of the JobSet failure policy.",
+							Ref:         ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
+						},
+					},
+					"stabilizationWindowSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StabilizationWindowSeconds, if set, is the time in seconds for which the terminal condition of a child Job must persist before the JobSet controller acts on it. Until then, the finished Job is considered active, so flapping Job statuses neither complete, restart nor fail the JobSet.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/runtime.RawExtension"},
+	}
+}
+
+func schema_jobset_api_jobset_v1alpha2_IndexedOverride(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "IndexedOverride is a patch applied to the Job template of a range of Job indexes of a ReplicatedJob.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"startIndex": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartIndex is the first Job index the patch is applied to.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"endIndex": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EndIndex is the last Job index the patch is applied to, inclusive. Defaults to startIndex, i.e. the patch is only applied to a single Job.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"patch": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Patch is a strategic merge patch applied to the Job template (a batch/v1 JobTemplateSpec) of the targeted Jobs.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
+						},
+					},
+				},
+				Required: []string{"startIndex", "patch"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/runtime.RawExtension"},
+	}
+}
+
+func schema_jobset_api_jobset_v1alpha2_JobPlacement(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "JobPlacement records where a child Job using exclusive placement was placed.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the child Job.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"topologyKey": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TopologyKey is the exclusive placement topology key of the Job, defined by the alpha.jobset.sigs.k8s.io/exclusive-topology annotation.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"topologyDomain": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TopologyDomain is the value of the topology key label on the node the leader pod of the Job was scheduled on. It is empty until the leader pod is scheduled.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"nodeName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeName is the name of the node the leader pod of the Job was scheduled on. It is empty until the leader pod is scheduled.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
+				Required: []string{"name", "topologyKey"},
 			},
 		},
 	}
@@ -181,6 +450,21 @@ func schema_jobset_api_jobset_v1alpha2_JobSetSpec(ref common.ReferenceCallback)
 							},
 						},
 					},
+					"podTemplates": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodTemplates is a map of named pod templates which can be referenced by ReplicatedJobs through podTemplateName, to avoid repeating the same pod template across replicated jobs.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.PodTemplateSpec"),
+									},
+								},
+							},
+						},
+					},
 					"network": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Network defines the networking options for the jobset.",
@@ -226,60 +510,417 @@ func schema_jobset_api_jobset_v1alpha2_JobSetSpec(ref common.ReferenceCallback)
 							Format:      "int32",
 						},
 					},
-				},
-			},
-		},
-		Dependencies: []string{
-			"sigs.k8s.io/jobset/api/jobset/v1alpha2.FailurePolicy", "sigs.k8s.io/jobset/api/jobset/v1alpha2.Network", "sigs.k8s.io/jobset/api/jobset/v1alpha2.ReplicatedJob", "sigs.k8s.io/jobset/api/jobset/v1alpha2.StartupPolicy", "sigs.k8s.io/jobset/api/jobset/v1alpha2.SuccessPolicy"},
-	}
-}
-
-func schema_jobset_api_jobset_v1alpha2_JobSetStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "JobSetStatus defines the observed state of JobSet",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"conditions": {
-						VendorExtensible: spec.VendorExtensible{
-							Extensions: spec.Extensions{
-								"x-kubernetes-list-map-keys": []interface{}{
-									"type",
-								},
-								"x-kubernetes-list-type": "map",
-							},
+					"onSuspend": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OnSuspend determines what happens to the child Jobs when the JobSet is suspended. DeletePods suspends the child Jobs, which deletes their pods. This is the default. RetainPods leaves the child Jobs and their pods running and only marks the JobSet as suspended in its status. Note that RetainPods does not free any resources held by the pods of the child Jobs.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
+					},
+					"activeDeadlineSeconds": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
-							Items: &spec.SchemaOrArray{
+							Description: "ActiveDeadlineSeconds is the duration in seconds, measured from the time the JobSet was started (or last resumed), during which the JobSet may be active before it is failed with reason DeadlineExceeded and its active child Jobs are deleted. It covers the entire JobSet, including restarts.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"labels": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Labels are added to every child Job and pod created by the JobSet. Labels set in the ReplicatedJob templates take precedence over these, and labels managed by the JobSet controller take precedence over both.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.Condition"),
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
 									},
 								},
 							},
 						},
 					},
-					"restarts": {
+					"annotations": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Restarts tracks the number of times the JobSet has restarted (i.e. recreated in case of RecreateAll policy).",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "Annotations are added to every child Job and pod created by the JobSet. Annotations set in the ReplicatedJob templates take precedence over these, and annotations managed by the JobSet controller take precedence over both.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
-					"replicatedJobsStatus": {
+					"imagePullSecrets": {
 						VendorExtensible: spec.VendorExtensible{
 							Extensions: spec.Extensions{
-								"x-kubernetes-list-map-keys": []interface{}{
-									"name",
-								},
-								"x-kubernetes-list-type": "map",
+								"x-kubernetes-list-type": "atomic",
 							},
 						},
 						SchemaProps: spec.SchemaProps{
-							Description: "ReplicatedJobsStatus track the number of JobsReady for each replicatedJob.",
+							Description: "ImagePullSecrets are added to the imagePullSecrets of every pod created by the JobSet, after the ones set in the pod templates, skipping secrets already set there. They can be updated while the JobSet is active, e.g. to rotate registry credentials, and apply to Jobs created afterwards.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.LocalObjectReference"),
+									},
+								},
+							},
+						},
+					},
+					"coordinator": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Coordinator defines the pod acting as the coordinator of the JobSet, e.g. to be exposed by the coordinator Service configured in spec.network.coordinatorService.",
+							Ref:         ref("sigs.k8s.io/jobset/api/jobset/v1alpha2.Coordinator"),
+						},
+					},
+					"jobNameTemplate": {
+						SchemaProps: spec.SchemaProps{
+							Description: "JobNameTemplate is a Go text/template used to generate the names of the child Jobs. The template can reference the JobSet name as .JobSet, the ReplicatedJob name as .ReplicatedJob, and the Job index as .Index. The rendered names must be valid DNS-1035 labels, and unique across the Jobs of the JobSet. If unset, child Jobs are named <jobSetName>-<replicatedJobName>-<jobIndex>.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"instances": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Instances is the number of copies of the replicated jobs the JobSet creates, e.g. to run the same workload for several hyperparameter sweeps. Every Job of every ReplicatedJob is created once per instance, and its containers receive the instance index in the INSTANCE_INDEX environment variable. The instances are not independent: they share one failure and success domain, so a failed Job of any instance triggers the failure policy, which restarts or fails all the instances together, and the success policy counts the Jobs of all the instances. If set, child Jobs are named <jobSetName>-<instanceIndex>-<replicatedJobName>-<jobIndex>, and the template in spec.jobNameTemplate can reference the instance index as .Instance. The coordinator is always part of the first instance. Defaults to a single instance.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"jobTTLSecondsAfterFinished": {
+						SchemaProps: spec.SchemaProps{
+							Description: "JobTTLSecondsAfterFinished, if set, is set as the ttlSecondsAfterFinished of every child Job, so finished child Jobs are deleted by the Job controller, e.g. while a finished JobSet is kept for auditing. As the success and failure policies are evaluated on the finished child Jobs, the JobSet controller keeps them with the jobset.sigs.k8s.io/job-result finalizer until the JobSet finishes or restarts.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"sidecarContainers": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "SidecarContainers are added to every pod created by the JobSet, e.g. to run a logging agent next to the workload. Containers with restartPolicy Always are added as native sidecars, i.e. as init containers which keep running along the containers of the pod. Other containers are added after the containers of the pod. The names of the sidecar containers must not be used by the containers of the pod templates.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Container"),
+									},
+								},
+							},
+						},
+					},
+					"envFrom": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "EnvFrom is a list of sources to populate environment variables in every container of every pod created by the JobSet, e.g. to distribute shared configuration from a ConfigMap or Secret. The sources are added before the envFrom sources of the containers, so the sources and env variables defined in the pod templates take precedence on key conflicts.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvFromSource"),
+									},
+								},
+							},
+						},
+					},
+					"paused": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Paused stops the JobSet controller from acting on the JobSet when set to true, e.g. to inspect a JobSet without it being restarted. Unlike suspend, the child Jobs and their pods keep running, and the JobSet status is not updated, apart from the Paused condition.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"statusSyncPeriodSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StatusSyncPeriodSeconds, if set, makes the JobSet controller reconcile the JobSet periodically with the given period while it is active, in addition to reconciling it on changes of its child Jobs, so the status stays fresh, e.g. for dashboards. This trades API server load for fresher status. If unset, the JobSet is only reconciled on changes.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"volumes": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Volumes are added to the volumes of every pod created by the JobSet, after the ones set in the pod templates, e.g. to share hostPath or emptyDir volumes across the replicated jobs of RDMA or IPC workloads. Their names must not be used by the volumes of the pod templates.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Volume"),
+									},
+								},
+							},
+						},
+					},
+					"volumeMounts": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "VolumeMounts are added to the volumeMounts of every container and init container of every pod created by the JobSet, after the ones set in the containers. Their mount paths must not be used by the volume mounts of the containers of the pod templates.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.VolumeMount"),
+									},
+								},
+							},
+						},
+					},
+					"lifecycle": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Lifecycle determines how the JobSet handles the end of its child Jobs. Normal runs the JobSet according to its success and failure policies. This is the default. Drain lets the active child Jobs finish without restarting the JobSet on failures or creating any Jobs, e.g. during cluster maintenance. Once no child Jobs are active, the JobSet is marked failed if any of them failed, and completed otherwise. Unlike suspending or pausing the JobSet, draining does not stop the active child Jobs.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"reportJobStatuses": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReportJobStatuses, if true, makes the JobSet controller report the status of each child Job of the current run in status.replicatedJobsStatus[*].jobStatuses, e.g. to visualize the timeline of the JobSet without querying its child Jobs. This grows the JobSet status with the number of child Jobs.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"cleanupPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CleanupPolicy determines which finished child Jobs are deleted once the JobSet completed or failed, e.g. to delete successful Jobs to save etcd space while retaining failed Jobs for a post-mortem. If unset, all finished child Jobs are retained.",
+							Ref:         ref("sigs.k8s.io/jobset/api/jobset/v1alpha2.CleanupPolicy"),
+						},
+					},
+					"defaultJobActiveDeadlineSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DefaultJobActiveDeadlineSeconds, if set, is set as the activeDeadlineSeconds of every child Job whose template does not set its own, to bound the runtime of runaway Jobs. Unlike spec.activeDeadlineSeconds, it applies to each child Job on its own.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"injectRestartCountEnvVar": {
+						SchemaProps: spec.SchemaProps{
+							Description: "InjectRestartCountEnvVar, if true, injects the JOBSET_RESTART_COUNT environment variable into every container of every pod created by the JobSet. It contains the number of restarts of the JobSet when the child Job was created, which matches the jobset.sigs.k8s.io/restart-attempt label, so pods know which run of the JobSet they belong to, e.g. to resume from a checkpoint.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"serviceAccountName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServiceAccountName is set as the serviceAccountName of every pod created by the JobSet whose pod template does not set its own, e.g. to run all the pods of a team with the service account mandated for it. Service accounts set in the pod templates take precedence.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"schedulingGates": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "SchedulingGates are added to the schedulingGates of every pod created by the JobSet, after the ones set in the pod templates, skipping gates already set there, e.g. to hold the pods until an external controller, such as an admission system, removes the gates.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.PodSchedulingGate"),
+									},
+								},
+							},
+						},
+					},
+					"completionsPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CompletionsPolicy restricts the completions of the Indexed Job templates of the replicated jobs relative to their parallelism, so templates whose semantics would change silently are rejected at admission. Any allows all combinations. This is the default. EqualParallelism requires completions to equal parallelism, so all indexes run at once. AtMostParallelism requires completions not to exceed parallelism, so no index waits for another one to finish. An unset parallelism is checked as the default parallelism of 1. NonIndexed Job templates are not restricted.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"podRestartPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodRestartPolicy, if set, is the restartPolicy enforced on all the pods of the JobSet, so the failure handling of the JobSet is consistent across its pods. Pod templates which do not set a restartPolicy are defaulted to it, and pod templates setting a different one are rejected. With Never, failed containers are not restarted in place and every pod failure counts towards the backoffLimit of its Job. If unset, pod templates which do not set a restartPolicy are defaulted to OnFailure.\n\nPossible enum values:\n - `\"Always\"`\n - `\"Never\"`\n - `\"OnFailure\"`",
+							Type:        []string{"string"},
+							Format:      "",
+							Enum:        []interface{}{"Always", "Never", "OnFailure"},
+						},
+					},
+					"completionPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CompletionPolicy, if set, configures in what order the child Jobs are torn down once the success policy of the JobSet is met, e.g. so workers exit before their driver is declared done.",
+							Ref:         ref("sigs.k8s.io/jobset/api/jobset/v1alpha2.CompletionPolicy"),
+						},
+					},
+					"createJobsSuspended": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CreateJobsSuspended, if true, creates all child Jobs suspended, and the JobSet controller only resumes them once all the Jobs of the JobSet were created, so they are admitted together, e.g. by a quota system. With an InOrder startup policy, the Jobs of each replicated job are resumed in turn once they were created. If false, child Jobs are only created suspended while the JobSet is suspended.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"hostAliases": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "HostAliases are added to the hostAliases of every pod created by the JobSet, e.g. to resolve hostnames in air-gapped clusters without editing each pod template. The hostnames of an alias whose IP is also set in the pod template are merged into the alias of the template, other aliases are added after the ones of the template.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.HostAlias"),
+									},
+								},
+							},
+						},
+					},
+					"minReadySeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MinReadySeconds, if set, is the minimum number of seconds for which the pods of a child Job must be ready, without any of their containers crashing, before the Job is counted as ready, like the minReadySeconds of a Deployment. This keeps pods which report ready and crash right after from marking the JobSet ready or starting the next replicated job of an InOrder startup policy. Defaults to 0, counting pods as ready as soon as they are.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"podReadinessGates": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "PodReadinessGates are added to the readinessGates of every pod created by the JobSet, after the ones set in the pod templates, skipping gates already set there, e.g. so the pods are only ready once an external controller, such as a service mesh, sets the condition of the gate.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.PodReadinessGate"),
+									},
+								},
+							},
+						},
+					},
+					"jobAnnotations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "JobAnnotations are added to every child Job created by the JobSet, but not to its pods, e.g. for cost tags used for billing which must not reach the pods. They take precedence over spec.annotations, and annotations set in the ReplicatedJob templates and managed by the JobSet controller take precedence over them.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"podAnnotations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodAnnotations are added to every pod created by the JobSet, but not to its child Jobs. They take precedence over spec.annotations, and annotations set in the pod templates and managed by the JobSet controller take precedence over them.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"runtimeClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RuntimeClassName is set as the runtimeClassName of every pod created by the JobSet whose pod template does not set its own, e.g. to run all the pods of a GPU workload with the runtime class of the GPU container runtime. Runtime classes set in the pod templates take precedence.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.HostAlias", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodReadinessGate", "k8s.io/api/core/v1.PodSchedulingGate", "k8s.io/api/core/v1.PodTemplateSpec", "k8s.io/api/core/v1.Volume", "k8s.io/api/core/v1.VolumeMount", "sigs.k8s.io/jobset/api/jobset/v1alpha2.CleanupPolicy", "sigs.k8s.io/jobset/api/jobset/v1alpha2.CompletionPolicy", "sigs.k8s.io/jobset/api/jobset/v1alpha2.Coordinator", "sigs.k8s.io/jobset/api/jobset/v1alpha2.FailurePolicy", "sigs.k8s.io/jobset/api/jobset/v1alpha2.Network", "sigs.k8s.io/jobset/api/jobset/v1alpha2.ReplicatedJob", "sigs.k8s.io/jobset/api/jobset/v1alpha2.StartupPolicy", "sigs.k8s.io/jobset/api/jobset/v1alpha2.SuccessPolicy"},
+	}
+}
+
+func schema_jobset_api_jobset_v1alpha2_JobSetStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "JobSetStatus defines the observed state of JobSet",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"conditions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"type",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.Condition"),
+									},
+								},
+							},
+						},
+					},
+					"restarts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Restarts tracks the number of times the JobSet has restarted (i.e. recreated in case of RecreateAll policy).",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"replicatedJobsStatus": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "ReplicatedJobsStatus track the number of JobsReady for each replicatedJob.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
@@ -291,11 +932,215 @@ func schema_jobset_api_jobset_v1alpha2_JobSetStatus(ref common.ReferenceCallback
 							},
 						},
 					},
+					"jobPlacements": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "JobPlacements records the exclusive placement of each active child Job using exclusive job placement per topology domain.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("sigs.k8s.io/jobset/api/jobset/v1alpha2.JobPlacement"),
+									},
+								},
+							},
+						},
+					},
+					"startTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartTime is the time the JobSet was started, i.e. first reconciled while not suspended. It is reset when the JobSet is suspended and is used to enforce spec.activeDeadlineSeconds.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"observedRestartTrigger": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ObservedRestartTrigger is the last value of the jobset.sigs.k8s.io/restart annotation handled by the JobSet controller. The JobSet is restarted when the annotation value differs from this value.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"observedGeneration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ObservedGeneration is the most recent generation of the JobSet spec reconciled by the JobSet controller.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"startupPolicyStatus": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartupPolicyStatus describes the progress of the in-order startup policy, if any. It is updated while the StartupPolicyCompleted condition is not true, and cleared once it is.",
+							Ref:         ref("sigs.k8s.io/jobset/api/jobset/v1alpha2.StartupPolicyStatus"),
+						},
+					},
+					"restartTimes": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "RestartTimes records the times of the restarts of the JobSet executed by its failure policy within the last hour. It is used to enforce failurePolicy.maxRestartsPerHour.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+									},
+								},
+							},
+						},
+					},
+					"aggregatedResourceRequests": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AggregatedResourceRequests is the total of the resource requests of the containers of all the pods the JobSet runs at once, i.e. the container requests of each replicated job multiplied by its parallelism and its replicas. It is recomputed when the replicas change.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
+						},
+					},
+					"reconcileErrors": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReconcileErrors is the number of consecutive reconciliations of the JobSet which failed with an error. It is only tracked if the controller backs off reconciliation errors, up to the number of errors at which it backs off, and is reset by a successful reconciliation.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"jobsPendingDeletion": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "JobsPendingDeletion lists the names of the child Jobs the JobSet controller is deleting, e.g. the Jobs of the previous run while the JobSet restarts, so an in-progress teardown can be told apart from a stuck JobSet. It is empty once the Jobs are gone.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"succeededJobs": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "SucceededJobs lists the names of the child Jobs of the target replicated jobs of the success policy which succeeded in any run of the JobSet. It is only tracked if the success policy counts succeeded Jobs across restarts.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"completionTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CompletionTime is the time the JobSet completed successfully, i.e. got the Completed condition. It is not changed once set.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"duration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Duration is the time the JobSet took to complete, from its first start time to its completion time, including the time it was suspended after it first started. It is set along with the completion time and not changed once set.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"unschedulableJobs": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "UnschedulableJobs lists the active child Jobs with pending pods the scheduler could not schedule, e.g. when exclusive placement finds no free topology domain for them.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("sigs.k8s.io/jobset/api/jobset/v1alpha2.UnschedulableJob"),
+									},
+								},
+							},
+						},
+					},
+					"firstStartTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FirstStartTime is the time the JobSet was first started, i.e. first reconciled while not suspended. Unlike the start time, it is not reset when the JobSet is suspended, and not changed once set. It is the start of the duration of the JobSet.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.Condition", "sigs.k8s.io/jobset/api/jobset/v1alpha2.ReplicatedJobStatus"},
+			"k8s.io/apimachinery/pkg/api/resource.Quantity", "k8s.io/apimachinery/pkg/apis/meta/v1.Condition", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "k8s.io/apimachinery/pkg/apis/meta/v1.Time", "sigs.k8s.io/jobset/api/jobset/v1alpha2.JobPlacement", "sigs.k8s.io/jobset/api/jobset/v1alpha2.ReplicatedJobStatus", "sigs.k8s.io/jobset/api/jobset/v1alpha2.StartupPolicyStatus", "sigs.k8s.io/jobset/api/jobset/v1alpha2.UnschedulableJob"},
+	}
+}
+
+func schema_jobset_api_jobset_v1alpha2_JobStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "JobStatus defines the observed timestamps of a child Job.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the child Job.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"startTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartTime is the time the child Job was started, copied from its status.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"completionTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CompletionTime is the time the child Job completed successfully, copied from its status. It is unset while the Job is running, and if the Job failed.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -314,21 +1159,102 @@ func schema_jobset_api_jobset_v1alpha2_Network(ref common.ReferenceCallback) com
 					},
 					"subdomain": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Subdomain is an explicit choice for a network subdomain name When set, any replicated job in the set is added to this network. Defaults to <jobSet.name> if not set.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Subdomain is an explicit choice for a network subdomain name When set, any replicated job in the set is added to this network. Defaults to <jobSet.name> if not set.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"publishNotReadyAddresses": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Indicates if DNS records of pods should be published before the pods are ready. Defaults to True.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"coordinatorService": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CoordinatorService configures an additional Service selecting only the coordinator pod defined in spec.coordinator, e.g. to make it reachable from outside the cluster.",
+							Ref:         ref("sigs.k8s.io/jobset/api/jobset/v1alpha2.CoordinatorService"),
+						},
+					},
+					"serviceSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServiceSelector holds additional labels merged into the selector of the headless service, e.g. to only publish DNS records for a subset of the pods of the JobSet. The selector always includes the jobset.sigs.k8s.io/jobset-name label, which can't be overridden.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"sigs.k8s.io/jobset/api/jobset/v1alpha2.CoordinatorService"},
+	}
+}
+
+func schema_jobset_api_jobset_v1alpha2_PodAnnotationSuccessCondition(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PodAnnotationSuccessCondition defines a pod annotation which marks a child Job as successful.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"key": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Key is the annotation key to look for on the pods.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"value": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Value is the annotation value the pods must carry.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"key", "value"},
+			},
+		},
+	}
+}
+
+func schema_jobset_api_jobset_v1alpha2_PodDisruptionBudget(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PodDisruptionBudget defines the PodDisruptionBudget created for the pods of a ReplicatedJob.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"minAvailable": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MinAvailable is the number or percentage of the pods of the replicated job that must remain available after an eviction. Mutually exclusive with maxUnavailable.",
+							Ref:         ref("k8s.io/apimachinery/pkg/util/intstr.IntOrString"),
 						},
 					},
-					"publishNotReadyAddresses": {
+					"maxUnavailable": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Indicates if DNS records of pods should be published before the pods are ready. Defaults to True.",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "MaxUnavailable is the number or percentage of the pods of the replicated job that can be unavailable after an eviction. Mutually exclusive with minAvailable.",
+							Ref:         ref("k8s.io/apimachinery/pkg/util/intstr.IntOrString"),
 						},
 					},
 				},
 			},
 		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/util/intstr.IntOrString"},
 	}
 }
 
@@ -353,19 +1279,145 @@ func schema_jobset_api_jobset_v1alpha2_ReplicatedJob(ref common.ReferenceCallbac
 							Ref:         ref("k8s.io/api/batch/v1.JobTemplateSpec"),
 						},
 					},
+					"podTemplateName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodTemplateName is the name of an entry in spec.podTemplates used as the pod template of the Jobs created from this ReplicatedJob. When set, the pod template in template.spec.template must be left empty.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"replicas": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Replicas is the number of jobs that will be created from this ReplicatedJob's template. Jobs names will be in the format: <jobSet.name>-<spec.replicatedJob.name>-<job-index>",
+							Description: "Replicas is the number of jobs that will be created from this ReplicatedJob's template. Jobs names will be in the format: <jobSet.name>-<spec.replicatedJob.name>-<job-index> A ReplicatedJob with zero replicas creates no jobs, and is ignored for the completion of the JobSet.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"indexedOverrides": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "IndexedOverrides are patches applied to the Job template of some of the Jobs created from this ReplicatedJob, e.g. to give the Job at index 0 more resources than the others. The overrides targeting a Job index are applied in order, after the pod template referenced by podTemplateName (if any) is resolved.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("sigs.k8s.io/jobset/api/jobset/v1alpha2.IndexedOverride"),
+									},
+								},
+							},
+						},
+					},
+					"failurePolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FailurePolicy, if set, overrides the JobSet failure policy for failures of the child Jobs of this replicated job. MaxRestarts is compared against the restarts of the whole JobSet. The failure aggregation and deletion settings are always taken from the JobSet failure policy.",
+							Ref:         ref("sigs.k8s.io/jobset/api/jobset/v1alpha2.FailurePolicy"),
+						},
+					},
+					"podDisruptionBudget": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodDisruptionBudget, if set, makes the JobSet controller create a PodDisruptionBudget selecting the pods of this replicated job, which limits how many of them can be evicted at once by voluntary disruptions, e.g. node drains.",
+							Ref:         ref("sigs.k8s.io/jobset/api/jobset/v1alpha2.PodDisruptionBudget"),
+						},
+					},
+					"restartPriority": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RestartPriority determines the order in which the Jobs of the replicated jobs are recreated when the JobSet is restarted. The Jobs of replicated jobs with a higher priority are created first, and the Jobs of a replicated job are only created once all the Jobs of the replicated jobs with a higher priority are ready or succeeded, e.g. to bring a coordinator back before the workers. Replicated jobs with the same priority are created together, in spec order. It is ignored with the InOrder startup policy, which always creates replicated jobs in spec order, and while the JobSet is suspended.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"completionTimeoutSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CompletionTimeoutSeconds, if set, is the number of seconds the Jobs of this replicated job may run, counted from the earliest start time of its Jobs in the current JobSet run. Once exceeded, the active Jobs of the replicated job are treated as failed, which triggers the failure policy. Unlike the activeDeadlineSeconds of the Job template, it bounds the replicated job as a whole.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"nonCritical": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NonCritical marks the replicated job as non-critical, e.g. for a monitoring service which runs forever. The Jobs of a non-critical replicated job neither count towards the success policy nor trigger the failure policy when they fail, and are deleted once the JobSet finishes. At least one replicated job must be critical.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"schedulingGates": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "SchedulingGates are added to the schedulingGates of every pod of the replicated job, after the ones set in the pod template and the JobSet level scheduling gates, skipping gates already set there.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.PodSchedulingGate"),
+									},
+								},
+							},
+						},
+					},
+					"podPriorityClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodPriorityClassName, if set, is the priorityClassName of the pods of the replicated job, overriding the one set in the pod template, e.g. to give the coordinator a higher priority than the workers so its pods preempt worker pods under contention. The priority and preemptionPolicy of the pod template are cleared, so they are resolved from the class.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"schedulerName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SchedulerName, if set, is the schedulerName of the pods of the replicated job, overriding the one set in the pod template, e.g. to schedule the workers with a batch scheduler and the coordinator with the default scheduler within the same JobSet.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"restartIsolation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RestartIsolation, if true, keeps the Jobs of this replicated job instead of recreating them when the JobSet is restarted due to failed Jobs of other replicated jobs, e.g. to keep the workers of a fault-tolerant framework running across a restart of the coordinator. Its Jobs are still recreated when Jobs of this replicated job failed, or when the restart was triggered by the restart annotation.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"maxFailedJobs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxFailedJobs, if set, is the number of Jobs of this replicated job which may fail, across all instances, before the failure policy is triggered, similar to the maxFailedIndexes of an Indexed Job. Failed Jobs within the limit are not recreated, and the success policy with the All operator is met without them. It must be less than the number of Jobs of the replicated job.",
 							Type:        []string{"integer"},
 							Format:      "int32",
 						},
 					},
+					"dependsOn": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "DependsOn are the names of the other replicated jobs this replicated job depends on, e.g. a trainer depending on the data loader feeding it. Once any replicated job of the JobSet depends on another, a restart of the JobSet due to failed Jobs only recreates the Jobs of the replicated jobs with failed Jobs and of the replicated jobs depending on them, directly or transitively, and keeps the Jobs of the other replicated jobs running. Replicated jobs with restart isolation are kept regardless, and don't propagate the restart to their dependents. The dependencies must not form a cycle.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
 				},
 				Required: []string{"name", "template"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/api/batch/v1.JobTemplateSpec"},
+			"k8s.io/api/batch/v1.JobTemplateSpec", "k8s.io/api/core/v1.PodSchedulingGate", "sigs.k8s.io/jobset/api/jobset/v1alpha2.FailurePolicy", "sigs.k8s.io/jobset/api/jobset/v1alpha2.IndexedOverride", "sigs.k8s.io/jobset/api/jobset/v1alpha2.PodDisruptionBudget"},
 	}
 }
 
@@ -424,10 +1476,64 @@ func schema_jobset_api_jobset_v1alpha2_ReplicatedJobStatus(ref common.ReferenceC
 							Format:      "int32",
 						},
 					},
+					"jobStatuses": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "JobStatuses is the status of each child Job of the ReplicatedJob in the current run of the JobSet. It is only reported if spec.reportJobStatuses is true.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("sigs.k8s.io/jobset/api/jobset/v1alpha2.JobStatus"),
+									},
+								},
+							},
+						},
+					},
 				},
 				Required: []string{"name", "ready", "succeeded", "failed", "active", "suspended"},
 			},
 		},
+		Dependencies: []string{
+			"sigs.k8s.io/jobset/api/jobset/v1alpha2.JobStatus"},
+	}
+}
+
+func schema_jobset_api_jobset_v1alpha2_StartupGate(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "StartupGate holds back the replicated jobs listed after a replicated job until it reaches a condition.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"replicatedJob": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReplicatedJob is the name of the gated replicated job.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"condition": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Condition is the condition the replicated job must reach before the replicated jobs listed after it are started. CompletedSuccessfully means all of its Jobs must have succeeded.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"replicatedJob", "condition"},
+			},
+		},
 	}
 }
 
@@ -445,10 +1551,86 @@ func schema_jobset_api_jobset_v1alpha2_StartupPolicy(ref common.ReferenceCallbac
 							Format:      "",
 						},
 					},
+					"stallTimeoutSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StallTimeoutSeconds, if set, is the number of seconds an in-order startup may make no progress, i.e. no more Jobs of the current replicated job become ready, before the JobSet gets the PotentialDeadlock condition, e.g. when the pods of the current replicated job wait on pods of a later replicated job which are never started.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"gates": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"replicatedJob",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Gates configure the replicated jobs which must reach a condition stronger than being ready, e.g. a warmup replicated job downloading a dataset which must complete before training starts, before the replicated jobs listed after them are started. Gates are only supported with the InOrder startup order.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("sigs.k8s.io/jobset/api/jobset/v1alpha2.StartupGate"),
+									},
+								},
+							},
+						},
+					},
 				},
 				Required: []string{"startupPolicyOrder"},
 			},
 		},
+		Dependencies: []string{
+			"sigs.k8s.io/jobset/api/jobset/v1alpha2.StartupGate"},
+	}
+}
+
+func schema_jobset_api_jobset_v1alpha2_StartupPolicyStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "StartupPolicyStatus describes the progress of an in-order startup policy.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"currentReplicatedJob": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CurrentReplicatedJob is the name of the replicated job whose Jobs are currently being started. The following replicated jobs are only started once all of its Jobs are ready.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"remainingReplicatedJobs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RemainingReplicatedJobs is the number of replicated jobs still to be started after the current one.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"startedJobs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartedJobs is the number of Jobs of the current replicated job which are ready or finished.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"lastProgressTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastProgressTime is the last time the current replicated job or its number of started Jobs changed. It is used to enforce startupPolicy.stallTimeoutSeconds.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+				Required: []string{"currentReplicatedJob", "remainingReplicatedJobs"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -486,9 +1668,74 @@ func schema_jobset_api_jobset_v1alpha2_SuccessPolicy(ref common.ReferenceCallbac
 							},
 						},
 					},
+					"podAnnotation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodAnnotation, if set, additionally declares a child Job of the target replicated jobs successful once all of its pods carry the given annotation, regardless of the exit codes of its containers. This is useful when success is reported by a sidecar which writes an annotation on the pod.",
+							Ref:         ref("sigs.k8s.io/jobset/api/jobset/v1alpha2.PodAnnotationSuccessCondition"),
+						},
+					},
+					"totalSucceeded": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TotalSucceeded, if set, declares the JobSet successful once the total number of succeeded child Jobs across the target replicated jobs reaches the given target, regardless of the operator. The remaining active Jobs are then deleted. This allows work-queue style JobSets which need a number of successful Jobs rather than particular ones.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"indexedJobCompletion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IndexedJobCompletion determines when a completed Indexed child Job is counted as succeeded. With Condition, a Job is succeeded once it has the Complete condition, which an Indexed Job with a success policy of its own may reach with fewer succeeded indexes than completions. With AllIndexes, a Job is only succeeded once all of its completions succeeded, and a complete Indexed Job with fewer succeeded indexes is counted as failed. Defaults to Condition.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"completionGracePeriodSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CompletionGracePeriodSeconds, if set, delays declaring the JobSet completed once the success policy is met, until the pods of the succeeded child Jobs terminated or the given number of seconds passed since the last of these Jobs completed, e.g. so sidecars can flush metrics after the main container exited. The TTL of the JobSet starts once it is completed.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"countAcrossRestarts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CountAcrossRestarts, if true, counts the child Jobs of the target replicated jobs which succeeded in any run of the JobSet towards the success policy, rather than only those of the current run. The JobSet controller records them in status.succeededJobs, so a Job which succeeded before a restart still counts once it is recreated. Each Job is counted once, however often it succeeded.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"operator"},
 			},
 		},
+		Dependencies: []string{
+			"sigs.k8s.io/jobset/api/jobset/v1alpha2.PodAnnotationSuccessCondition"},
+	}
+}
+
+func schema_jobset_api_jobset_v1alpha2_UnschedulableJob(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "UnschedulableJob records a child Job with pending pods the scheduler could not schedule.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the child Job.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message is the message of the scheduler explaining why a pod of the Job could not be scheduled, from the PodScheduled condition of the pod.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
 	}
 }
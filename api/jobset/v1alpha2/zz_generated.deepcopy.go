@@ -18,13 +18,112 @@ limitations under the License.
 package v1alpha2
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CleanupPolicy) DeepCopyInto(out *CleanupPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CleanupPolicy.
+func (in *CleanupPolicy) DeepCopy() *CleanupPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CompletionPolicy) DeepCopyInto(out *CompletionPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompletionPolicy.
+func (in *CompletionPolicy) DeepCopy() *CompletionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(CompletionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Coordinator) DeepCopyInto(out *Coordinator) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Coordinator.
+func (in *Coordinator) DeepCopy() *Coordinator {
+	if in == nil {
+		return nil
+	}
+	out := new(Coordinator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoordinatorService) DeepCopyInto(out *CoordinatorService) {
+	*out = *in
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]corev1.ServicePort, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoordinatorService.
+func (in *CoordinatorService) DeepCopy() *CoordinatorService {
+	if in == nil {
+		return nil
+	}
+	out := new(CoordinatorService)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FailurePolicy) DeepCopyInto(out *FailurePolicy) {
 	*out = *in
+	if in.DeletePropagationPolicy != nil {
+		in, out := &in.DeletePropagationPolicy, &out.DeletePropagationPolicy
+		*out = new(v1.DeletionPropagation)
+		**out = **in
+	}
+	if in.TerminationGracePeriodOverride != nil {
+		in, out := &in.TerminationGracePeriodOverride, &out.TerminationGracePeriodOverride
+		*out = new(int64)
+		**out = **in
+	}
+	if in.FailureAggregationSeconds != nil {
+		in, out := &in.FailureAggregationSeconds, &out.FailureAggregationSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxRestartsPerHour != nil {
+		in, out := &in.MaxRestartsPerHour, &out.MaxRestartsPerHour
+		*out = new(int32)
+		**out = **in
+	}
+	if in.OnRestartOverrides != nil {
+		in, out := &in.OnRestartOverrides, &out.OnRestartOverrides
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StabilizationWindowSeconds != nil {
+		in, out := &in.StabilizationWindowSeconds, &out.StabilizationWindowSeconds
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailurePolicy.
@@ -37,6 +136,42 @@ func (in *FailurePolicy) DeepCopy() *FailurePolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexedOverride) DeepCopyInto(out *IndexedOverride) {
+	*out = *in
+	if in.EndIndex != nil {
+		in, out := &in.EndIndex, &out.EndIndex
+		*out = new(int32)
+		**out = **in
+	}
+	in.Patch.DeepCopyInto(&out.Patch)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexedOverride.
+func (in *IndexedOverride) DeepCopy() *IndexedOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexedOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobPlacement) DeepCopyInto(out *JobPlacement) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobPlacement.
+func (in *JobPlacement) DeepCopy() *JobPlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(JobPlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JobSet) DeepCopyInto(out *JobSet) {
 	*out = *in
@@ -106,6 +241,13 @@ func (in *JobSetSpec) DeepCopyInto(out *JobSetSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PodTemplates != nil {
+		in, out := &in.PodTemplates, &out.PodTemplates
+		*out = make(map[string]corev1.PodTemplateSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 	if in.Network != nil {
 		in, out := &in.Network, &out.Network
 		*out = new(Network)
@@ -119,12 +261,12 @@ func (in *JobSetSpec) DeepCopyInto(out *JobSetSpec) {
 	if in.FailurePolicy != nil {
 		in, out := &in.FailurePolicy, &out.FailurePolicy
 		*out = new(FailurePolicy)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.StartupPolicy != nil {
 		in, out := &in.StartupPolicy, &out.StartupPolicy
 		*out = new(StartupPolicy)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Suspend != nil {
 		in, out := &in.Suspend, &out.Suspend
@@ -141,6 +283,149 @@ func (in *JobSetSpec) DeepCopyInto(out *JobSetSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Coordinator != nil {
+		in, out := &in.Coordinator, &out.Coordinator
+		*out = new(Coordinator)
+		**out = **in
+	}
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = new(int32)
+		**out = **in
+	}
+	if in.JobTTLSecondsAfterFinished != nil {
+		in, out := &in.JobTTLSecondsAfterFinished, &out.JobTTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SidecarContainers != nil {
+		in, out := &in.SidecarContainers, &out.SidecarContainers
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EnvFrom != nil {
+		in, out := &in.EnvFrom, &out.EnvFrom
+		*out = make([]corev1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Paused != nil {
+		in, out := &in.Paused, &out.Paused
+		*out = new(bool)
+		**out = **in
+	}
+	if in.StatusSyncPeriodSeconds != nil {
+		in, out := &in.StatusSyncPeriodSeconds, &out.StatusSyncPeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]corev1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]corev1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ReportJobStatuses != nil {
+		in, out := &in.ReportJobStatuses, &out.ReportJobStatuses
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CleanupPolicy != nil {
+		in, out := &in.CleanupPolicy, &out.CleanupPolicy
+		*out = new(CleanupPolicy)
+		**out = **in
+	}
+	if in.DefaultJobActiveDeadlineSeconds != nil {
+		in, out := &in.DefaultJobActiveDeadlineSeconds, &out.DefaultJobActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.InjectRestartCountEnvVar != nil {
+		in, out := &in.InjectRestartCountEnvVar, &out.InjectRestartCountEnvVar
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SchedulingGates != nil {
+		in, out := &in.SchedulingGates, &out.SchedulingGates
+		*out = make([]corev1.PodSchedulingGate, len(*in))
+		copy(*out, *in)
+	}
+	if in.CompletionPolicy != nil {
+		in, out := &in.CompletionPolicy, &out.CompletionPolicy
+		*out = new(CompletionPolicy)
+		**out = **in
+	}
+	if in.CreateJobsSuspended != nil {
+		in, out := &in.CreateJobsSuspended, &out.CreateJobsSuspended
+		*out = new(bool)
+		**out = **in
+	}
+	if in.HostAliases != nil {
+		in, out := &in.HostAliases, &out.HostAliases
+		*out = make([]corev1.HostAlias, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MinReadySeconds != nil {
+		in, out := &in.MinReadySeconds, &out.MinReadySeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PodReadinessGates != nil {
+		in, out := &in.PodReadinessGates, &out.PodReadinessGates
+		*out = make([]corev1.PodReadinessGate, len(*in))
+		copy(*out, *in)
+	}
+	if in.JobAnnotations != nil {
+		in, out := &in.JobAnnotations, &out.JobAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodAnnotations != nil {
+		in, out := &in.PodAnnotations, &out.PodAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobSetSpec.
@@ -166,8 +451,66 @@ func (in *JobSetStatus) DeepCopyInto(out *JobSetStatus) {
 	if in.ReplicatedJobsStatus != nil {
 		in, out := &in.ReplicatedJobsStatus, &out.ReplicatedJobsStatus
 		*out = make([]ReplicatedJobStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.JobPlacements != nil {
+		in, out := &in.JobPlacements, &out.JobPlacements
+		*out = make([]JobPlacement, len(*in))
 		copy(*out, *in)
 	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.StartupPolicyStatus != nil {
+		in, out := &in.StartupPolicyStatus, &out.StartupPolicyStatus
+		*out = new(StartupPolicyStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RestartTimes != nil {
+		in, out := &in.RestartTimes, &out.RestartTimes
+		*out = make([]v1.Time, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AggregatedResourceRequests != nil {
+		in, out := &in.AggregatedResourceRequests, &out.AggregatedResourceRequests
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.JobsPendingDeletion != nil {
+		in, out := &in.JobsPendingDeletion, &out.JobsPendingDeletion
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SucceededJobs != nil {
+		in, out := &in.SucceededJobs, &out.SucceededJobs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.UnschedulableJobs != nil {
+		in, out := &in.UnschedulableJobs, &out.UnschedulableJobs
+		*out = make([]UnschedulableJob, len(*in))
+		copy(*out, *in)
+	}
+	if in.FirstStartTime != nil {
+		in, out := &in.FirstStartTime, &out.FirstStartTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobSetStatus.
@@ -180,6 +523,29 @@ func (in *JobSetStatus) DeepCopy() *JobSetStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobStatus) DeepCopyInto(out *JobStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobStatus.
+func (in *JobStatus) DeepCopy() *JobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(JobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Network) DeepCopyInto(out *Network) {
 	*out = *in
@@ -193,6 +559,18 @@ func (in *Network) DeepCopyInto(out *Network) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.CoordinatorService != nil {
+		in, out := &in.CoordinatorService, &out.CoordinatorService
+		*out = new(CoordinatorService)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceSelector != nil {
+		in, out := &in.ServiceSelector, &out.ServiceSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Network.
@@ -205,10 +583,97 @@ func (in *Network) DeepCopy() *Network {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodAnnotationSuccessCondition) DeepCopyInto(out *PodAnnotationSuccessCondition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodAnnotationSuccessCondition.
+func (in *PodAnnotationSuccessCondition) DeepCopy() *PodAnnotationSuccessCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(PodAnnotationSuccessCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodDisruptionBudget) DeepCopyInto(out *PodDisruptionBudget) {
+	*out = *in
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudget.
+func (in *PodDisruptionBudget) DeepCopy() *PodDisruptionBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDisruptionBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReplicatedJob) DeepCopyInto(out *ReplicatedJob) {
 	*out = *in
 	in.Template.DeepCopyInto(&out.Template)
+	if in.IndexedOverrides != nil {
+		in, out := &in.IndexedOverrides, &out.IndexedOverrides
+		*out = make([]IndexedOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FailurePolicy != nil {
+		in, out := &in.FailurePolicy, &out.FailurePolicy
+		*out = new(FailurePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudget)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CompletionTimeoutSeconds != nil {
+		in, out := &in.CompletionTimeoutSeconds, &out.CompletionTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NonCritical != nil {
+		in, out := &in.NonCritical, &out.NonCritical
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SchedulingGates != nil {
+		in, out := &in.SchedulingGates, &out.SchedulingGates
+		*out = make([]corev1.PodSchedulingGate, len(*in))
+		copy(*out, *in)
+	}
+	if in.RestartIsolation != nil {
+		in, out := &in.RestartIsolation, &out.RestartIsolation
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxFailedJobs != nil {
+		in, out := &in.MaxFailedJobs, &out.MaxFailedJobs
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicatedJob.
@@ -224,6 +689,13 @@ func (in *ReplicatedJob) DeepCopy() *ReplicatedJob {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReplicatedJobStatus) DeepCopyInto(out *ReplicatedJobStatus) {
 	*out = *in
+	if in.JobStatuses != nil {
+		in, out := &in.JobStatuses, &out.JobStatuses
+		*out = make([]JobStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicatedJobStatus.
@@ -236,9 +708,34 @@ func (in *ReplicatedJobStatus) DeepCopy() *ReplicatedJobStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StartupGate) DeepCopyInto(out *StartupGate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StartupGate.
+func (in *StartupGate) DeepCopy() *StartupGate {
+	if in == nil {
+		return nil
+	}
+	out := new(StartupGate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StartupPolicy) DeepCopyInto(out *StartupPolicy) {
 	*out = *in
+	if in.StallTimeoutSeconds != nil {
+		in, out := &in.StallTimeoutSeconds, &out.StallTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Gates != nil {
+		in, out := &in.Gates, &out.Gates
+		*out = make([]StartupGate, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StartupPolicy.
@@ -251,6 +748,25 @@ func (in *StartupPolicy) DeepCopy() *StartupPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StartupPolicyStatus) DeepCopyInto(out *StartupPolicyStatus) {
+	*out = *in
+	if in.LastProgressTime != nil {
+		in, out := &in.LastProgressTime, &out.LastProgressTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StartupPolicyStatus.
+func (in *StartupPolicyStatus) DeepCopy() *StartupPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StartupPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SuccessPolicy) DeepCopyInto(out *SuccessPolicy) {
 	*out = *in
@@ -259,6 +775,26 @@ func (in *SuccessPolicy) DeepCopyInto(out *SuccessPolicy) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PodAnnotation != nil {
+		in, out := &in.PodAnnotation, &out.PodAnnotation
+		*out = new(PodAnnotationSuccessCondition)
+		**out = **in
+	}
+	if in.TotalSucceeded != nil {
+		in, out := &in.TotalSucceeded, &out.TotalSucceeded
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CompletionGracePeriodSeconds != nil {
+		in, out := &in.CompletionGracePeriodSeconds, &out.CompletionGracePeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CountAcrossRestarts != nil {
+		in, out := &in.CountAcrossRestarts, &out.CountAcrossRestarts
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SuccessPolicy.
@@ -270,3 +806,18 @@ func (in *SuccessPolicy) DeepCopy() *SuccessPolicy {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnschedulableJob) DeepCopyInto(out *UnschedulableJob) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnschedulableJob.
+func (in *UnschedulableJob) DeepCopy() *UnschedulableJob {
+	if in == nil {
+		return nil
+	}
+	out := new(UnschedulableJob)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -14,10 +14,23 @@ limitations under the License.
 
 package v1alpha2
 
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	v1alpha2 "sigs.k8s.io/jobset/api/jobset/v1alpha2"
+)
+
 // FailurePolicyApplyConfiguration represents an declarative configuration of the FailurePolicy type for use
 // with apply.
 type FailurePolicyApplyConfiguration struct {
-	MaxRestarts *int32 `json:"maxRestarts,omitempty"`
+	MaxRestarts                    *int32                        `json:"maxRestarts,omitempty"`
+	DeletePropagationPolicy        *v1.DeletionPropagation       `json:"deletePropagationPolicy,omitempty"`
+	TerminationGracePeriodOverride *int64                        `json:"terminationGracePeriodOverride,omitempty"`
+	FailureAggregationSeconds      *int32                        `json:"failureAggregationSeconds,omitempty"`
+	Action                         *v1alpha2.FailurePolicyAction `json:"action,omitempty"`
+	MaxRestartsPerHour             *int32                        `json:"maxRestartsPerHour,omitempty"`
+	OnRestartOverrides             *runtime.RawExtension         `json:"onRestartOverrides,omitempty"`
+	StabilizationWindowSeconds     *int32                        `json:"stabilizationWindowSeconds,omitempty"`
 }
 
 // FailurePolicyApplyConfiguration constructs an declarative configuration of the FailurePolicy type for use with
@@ -33,3 +46,59 @@ func (b *FailurePolicyApplyConfiguration) WithMaxRestarts(value int32) *FailureP
 	b.MaxRestarts = &value
 	return b
 }
+
+// WithDeletePropagationPolicy sets the DeletePropagationPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DeletePropagationPolicy field is set to the value of the last call.
+func (b *FailurePolicyApplyConfiguration) WithDeletePropagationPolicy(value v1.DeletionPropagation) *FailurePolicyApplyConfiguration {
+	b.DeletePropagationPolicy = &value
+	return b
+}
+
+// WithTerminationGracePeriodOverride sets the TerminationGracePeriodOverride field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TerminationGracePeriodOverride field is set to the value of the last call.
+func (b *FailurePolicyApplyConfiguration) WithTerminationGracePeriodOverride(value int64) *FailurePolicyApplyConfiguration {
+	b.TerminationGracePeriodOverride = &value
+	return b
+}
+
+// WithFailureAggregationSeconds sets the FailureAggregationSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FailureAggregationSeconds field is set to the value of the last call.
+func (b *FailurePolicyApplyConfiguration) WithFailureAggregationSeconds(value int32) *FailurePolicyApplyConfiguration {
+	b.FailureAggregationSeconds = &value
+	return b
+}
+
+// WithAction sets the Action field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Action field is set to the value of the last call.
+func (b *FailurePolicyApplyConfiguration) WithAction(value v1alpha2.FailurePolicyAction) *FailurePolicyApplyConfiguration {
+	b.Action = &value
+	return b
+}
+
+// WithMaxRestartsPerHour sets the MaxRestartsPerHour field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxRestartsPerHour field is set to the value of the last call.
+func (b *FailurePolicyApplyConfiguration) WithMaxRestartsPerHour(value int32) *FailurePolicyApplyConfiguration {
+	b.MaxRestartsPerHour = &value
+	return b
+}
+
+// WithOnRestartOverrides sets the OnRestartOverrides field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OnRestartOverrides field is set to the value of the last call.
+func (b *FailurePolicyApplyConfiguration) WithOnRestartOverrides(value runtime.RawExtension) *FailurePolicyApplyConfiguration {
+	b.OnRestartOverrides = &value
+	return b
+}
+
+// WithStabilizationWindowSeconds sets the StabilizationWindowSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StabilizationWindowSeconds field is set to the value of the last call.
+func (b *FailurePolicyApplyConfiguration) WithStabilizationWindowSeconds(value int32) *FailurePolicyApplyConfiguration {
+	b.StabilizationWindowSeconds = &value
+	return b
+}
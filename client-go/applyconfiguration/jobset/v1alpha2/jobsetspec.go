@@ -14,17 +14,55 @@ limitations under the License.
 
 package v1alpha2
 
+import (
+	corev1 "k8s.io/api/core/v1"
+	v1alpha2 "sigs.k8s.io/jobset/api/jobset/v1alpha2"
+)
+
 // JobSetSpecApplyConfiguration represents an declarative configuration of the JobSetSpec type for use
 // with apply.
 type JobSetSpecApplyConfiguration struct {
-	ReplicatedJobs          []ReplicatedJobApplyConfiguration `json:"replicatedJobs,omitempty"`
-	Network                 *NetworkApplyConfiguration        `json:"network,omitempty"`
-	SuccessPolicy           *SuccessPolicyApplyConfiguration  `json:"successPolicy,omitempty"`
-	FailurePolicy           *FailurePolicyApplyConfiguration  `json:"failurePolicy,omitempty"`
-	StartupPolicy           *StartupPolicyApplyConfiguration  `json:"startupPolicy,omitempty"`
-	Suspend                 *bool                             `json:"suspend,omitempty"`
-	ManagedBy               *string                           `json:"managedBy,omitempty"`
-	TTLSecondsAfterFinished *int32                            `json:"ttlSecondsAfterFinished,omitempty"`
+	ReplicatedJobs                  []ReplicatedJobApplyConfiguration   `json:"replicatedJobs,omitempty"`
+	PodTemplates                    map[string]corev1.PodTemplateSpec   `json:"podTemplates,omitempty"`
+	Network                         *NetworkApplyConfiguration          `json:"network,omitempty"`
+	SuccessPolicy                   *SuccessPolicyApplyConfiguration    `json:"successPolicy,omitempty"`
+	FailurePolicy                   *FailurePolicyApplyConfiguration    `json:"failurePolicy,omitempty"`
+	StartupPolicy                   *StartupPolicyApplyConfiguration    `json:"startupPolicy,omitempty"`
+	Suspend                         *bool                               `json:"suspend,omitempty"`
+	ManagedBy                       *string                             `json:"managedBy,omitempty"`
+	TTLSecondsAfterFinished         *int32                              `json:"ttlSecondsAfterFinished,omitempty"`
+	OnSuspend                       *v1alpha2.OnSuspendPolicy           `json:"onSuspend,omitempty"`
+	ActiveDeadlineSeconds           *int64                              `json:"activeDeadlineSeconds,omitempty"`
+	Labels                          map[string]string                   `json:"labels,omitempty"`
+	Annotations                     map[string]string                   `json:"annotations,omitempty"`
+	ImagePullSecrets                []corev1.LocalObjectReference       `json:"imagePullSecrets,omitempty"`
+	Coordinator                     *CoordinatorApplyConfiguration      `json:"coordinator,omitempty"`
+	JobNameTemplate                 *string                             `json:"jobNameTemplate,omitempty"`
+	Instances                       *int32                              `json:"instances,omitempty"`
+	JobTTLSecondsAfterFinished      *int32                              `json:"jobTTLSecondsAfterFinished,omitempty"`
+	SidecarContainers               []corev1.Container                  `json:"sidecarContainers,omitempty"`
+	EnvFrom                         []corev1.EnvFromSource              `json:"envFrom,omitempty"`
+	Paused                          *bool                               `json:"paused,omitempty"`
+	StatusSyncPeriodSeconds         *int32                              `json:"statusSyncPeriodSeconds,omitempty"`
+	Volumes                         []corev1.Volume                     `json:"volumes,omitempty"`
+	VolumeMounts                    []corev1.VolumeMount                `json:"volumeMounts,omitempty"`
+	Lifecycle                       *v1alpha2.JobSetLifecycle           `json:"lifecycle,omitempty"`
+	ReportJobStatuses               *bool                               `json:"reportJobStatuses,omitempty"`
+	CleanupPolicy                   *CleanupPolicyApplyConfiguration    `json:"cleanupPolicy,omitempty"`
+	DefaultJobActiveDeadlineSeconds *int64                              `json:"defaultJobActiveDeadlineSeconds,omitempty"`
+	InjectRestartCountEnvVar        *bool                               `json:"injectRestartCountEnvVar,omitempty"`
+	ServiceAccountName              *string                             `json:"serviceAccountName,omitempty"`
+	SchedulingGates                 []corev1.PodSchedulingGate          `json:"schedulingGates,omitempty"`
+	CompletionsPolicy               *v1alpha2.CompletionsPolicy         `json:"completionsPolicy,omitempty"`
+	PodRestartPolicy                *corev1.RestartPolicy               `json:"podRestartPolicy,omitempty"`
+	CompletionPolicy                *CompletionPolicyApplyConfiguration `json:"completionPolicy,omitempty"`
+	CreateJobsSuspended             *bool                               `json:"createJobsSuspended,omitempty"`
+	HostAliases                     []corev1.HostAlias                  `json:"hostAliases,omitempty"`
+	MinReadySeconds                 *int32                              `json:"minReadySeconds,omitempty"`
+	PodReadinessGates               []corev1.PodReadinessGate           `json:"podReadinessGates,omitempty"`
+	JobAnnotations                  map[string]string                   `json:"jobAnnotations,omitempty"`
+	PodAnnotations                  map[string]string                   `json:"podAnnotations,omitempty"`
+	RuntimeClassName                *string                             `json:"runtimeClassName,omitempty"`
 }
 
 // JobSetSpecApplyConfiguration constructs an declarative configuration of the JobSetSpec type for use with
@@ -46,6 +84,20 @@ func (b *JobSetSpecApplyConfiguration) WithReplicatedJobs(values ...*ReplicatedJ
 	return b
 }
 
+// WithPodTemplates puts the entries into the PodTemplates field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the PodTemplates field,
+// overwriting an existing map entries in PodTemplates field with the same key.
+func (b *JobSetSpecApplyConfiguration) WithPodTemplates(entries map[string]corev1.PodTemplateSpec) *JobSetSpecApplyConfiguration {
+	if b.PodTemplates == nil && len(entries) > 0 {
+		b.PodTemplates = make(map[string]corev1.PodTemplateSpec, len(entries))
+	}
+	for k, v := range entries {
+		b.PodTemplates[k] = v
+	}
+	return b
+}
+
 // WithNetwork sets the Network field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Network field is set to the value of the last call.
@@ -101,3 +153,299 @@ func (b *JobSetSpecApplyConfiguration) WithTTLSecondsAfterFinished(value int32)
 	b.TTLSecondsAfterFinished = &value
 	return b
 }
+
+// WithOnSuspend sets the OnSuspend field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OnSuspend field is set to the value of the last call.
+func (b *JobSetSpecApplyConfiguration) WithOnSuspend(value v1alpha2.OnSuspendPolicy) *JobSetSpecApplyConfiguration {
+	b.OnSuspend = &value
+	return b
+}
+
+// WithActiveDeadlineSeconds sets the ActiveDeadlineSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ActiveDeadlineSeconds field is set to the value of the last call.
+func (b *JobSetSpecApplyConfiguration) WithActiveDeadlineSeconds(value int64) *JobSetSpecApplyConfiguration {
+	b.ActiveDeadlineSeconds = &value
+	return b
+}
+
+// WithLabels puts the entries into the Labels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Labels field,
+// overwriting an existing map entries in Labels field with the same key.
+func (b *JobSetSpecApplyConfiguration) WithLabels(entries map[string]string) *JobSetSpecApplyConfiguration {
+	if b.Labels == nil && len(entries) > 0 {
+		b.Labels = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.Labels[k] = v
+	}
+	return b
+}
+
+// WithAnnotations puts the entries into the Annotations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Annotations field,
+// overwriting an existing map entries in Annotations field with the same key.
+func (b *JobSetSpecApplyConfiguration) WithAnnotations(entries map[string]string) *JobSetSpecApplyConfiguration {
+	if b.Annotations == nil && len(entries) > 0 {
+		b.Annotations = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.Annotations[k] = v
+	}
+	return b
+}
+
+// WithImagePullSecrets adds the given value to the ImagePullSecrets field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ImagePullSecrets field.
+func (b *JobSetSpecApplyConfiguration) WithImagePullSecrets(values ...corev1.LocalObjectReference) *JobSetSpecApplyConfiguration {
+	for i := range values {
+		b.ImagePullSecrets = append(b.ImagePullSecrets, values[i])
+	}
+	return b
+}
+
+// WithCoordinator sets the Coordinator field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Coordinator field is set to the value of the last call.
+func (b *JobSetSpecApplyConfiguration) WithCoordinator(value *CoordinatorApplyConfiguration) *JobSetSpecApplyConfiguration {
+	b.Coordinator = value
+	return b
+}
+
+// WithJobNameTemplate sets the JobNameTemplate field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the JobNameTemplate field is set to the value of the last call.
+func (b *JobSetSpecApplyConfiguration) WithJobNameTemplate(value string) *JobSetSpecApplyConfiguration {
+	b.JobNameTemplate = &value
+	return b
+}
+
+// WithInstances sets the Instances field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Instances field is set to the value of the last call.
+func (b *JobSetSpecApplyConfiguration) WithInstances(value int32) *JobSetSpecApplyConfiguration {
+	b.Instances = &value
+	return b
+}
+
+// WithJobTTLSecondsAfterFinished sets the JobTTLSecondsAfterFinished field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the JobTTLSecondsAfterFinished field is set to the value of the last call.
+func (b *JobSetSpecApplyConfiguration) WithJobTTLSecondsAfterFinished(value int32) *JobSetSpecApplyConfiguration {
+	b.JobTTLSecondsAfterFinished = &value
+	return b
+}
+
+// WithSidecarContainers adds the given value to the SidecarContainers field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the SidecarContainers field.
+func (b *JobSetSpecApplyConfiguration) WithSidecarContainers(values ...corev1.Container) *JobSetSpecApplyConfiguration {
+	for i := range values {
+		b.SidecarContainers = append(b.SidecarContainers, values[i])
+	}
+	return b
+}
+
+// WithEnvFrom adds the given value to the EnvFrom field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the EnvFrom field.
+func (b *JobSetSpecApplyConfiguration) WithEnvFrom(values ...corev1.EnvFromSource) *JobSetSpecApplyConfiguration {
+	for i := range values {
+		b.EnvFrom = append(b.EnvFrom, values[i])
+	}
+	return b
+}
+
+// WithPaused sets the Paused field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Paused field is set to the value of the last call.
+func (b *JobSetSpecApplyConfiguration) WithPaused(value bool) *JobSetSpecApplyConfiguration {
+	b.Paused = &value
+	return b
+}
+
+// WithStatusSyncPeriodSeconds sets the StatusSyncPeriodSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StatusSyncPeriodSeconds field is set to the value of the last call.
+func (b *JobSetSpecApplyConfiguration) WithStatusSyncPeriodSeconds(value int32) *JobSetSpecApplyConfiguration {
+	b.StatusSyncPeriodSeconds = &value
+	return b
+}
+
+// WithVolumes adds the given value to the Volumes field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Volumes field.
+func (b *JobSetSpecApplyConfiguration) WithVolumes(values ...corev1.Volume) *JobSetSpecApplyConfiguration {
+	for i := range values {
+		b.Volumes = append(b.Volumes, values[i])
+	}
+	return b
+}
+
+// WithVolumeMounts adds the given value to the VolumeMounts field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the VolumeMounts field.
+func (b *JobSetSpecApplyConfiguration) WithVolumeMounts(values ...corev1.VolumeMount) *JobSetSpecApplyConfiguration {
+	for i := range values {
+		b.VolumeMounts = append(b.VolumeMounts, values[i])
+	}
+	return b
+}
+
+// WithLifecycle sets the Lifecycle field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Lifecycle field is set to the value of the last call.
+func (b *JobSetSpecApplyConfiguration) WithLifecycle(value v1alpha2.JobSetLifecycle) *JobSetSpecApplyConfiguration {
+	b.Lifecycle = &value
+	return b
+}
+
+// WithReportJobStatuses sets the ReportJobStatuses field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReportJobStatuses field is set to the value of the last call.
+func (b *JobSetSpecApplyConfiguration) WithReportJobStatuses(value bool) *JobSetSpecApplyConfiguration {
+	b.ReportJobStatuses = &value
+	return b
+}
+
+// WithCleanupPolicy sets the CleanupPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CleanupPolicy field is set to the value of the last call.
+func (b *JobSetSpecApplyConfiguration) WithCleanupPolicy(value *CleanupPolicyApplyConfiguration) *JobSetSpecApplyConfiguration {
+	b.CleanupPolicy = value
+	return b
+}
+
+// WithDefaultJobActiveDeadlineSeconds sets the DefaultJobActiveDeadlineSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DefaultJobActiveDeadlineSeconds field is set to the value of the last call.
+func (b *JobSetSpecApplyConfiguration) WithDefaultJobActiveDeadlineSeconds(value int64) *JobSetSpecApplyConfiguration {
+	b.DefaultJobActiveDeadlineSeconds = &value
+	return b
+}
+
+// WithInjectRestartCountEnvVar sets the InjectRestartCountEnvVar field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the InjectRestartCountEnvVar field is set to the value of the last call.
+func (b *JobSetSpecApplyConfiguration) WithInjectRestartCountEnvVar(value bool) *JobSetSpecApplyConfiguration {
+	b.InjectRestartCountEnvVar = &value
+	return b
+}
+
+// WithServiceAccountName sets the ServiceAccountName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServiceAccountName field is set to the value of the last call.
+func (b *JobSetSpecApplyConfiguration) WithServiceAccountName(value string) *JobSetSpecApplyConfiguration {
+	b.ServiceAccountName = &value
+	return b
+}
+
+// WithSchedulingGates adds the given value to the SchedulingGates field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the SchedulingGates field.
+func (b *JobSetSpecApplyConfiguration) WithSchedulingGates(values ...corev1.PodSchedulingGate) *JobSetSpecApplyConfiguration {
+	for i := range values {
+		b.SchedulingGates = append(b.SchedulingGates, values[i])
+	}
+	return b
+}
+
+// WithCompletionsPolicy sets the CompletionsPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CompletionsPolicy field is set to the value of the last call.
+func (b *JobSetSpecApplyConfiguration) WithCompletionsPolicy(value v1alpha2.CompletionsPolicy) *JobSetSpecApplyConfiguration {
+	b.CompletionsPolicy = &value
+	return b
+}
+
+// WithPodRestartPolicy sets the PodRestartPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PodRestartPolicy field is set to the value of the last call.
+func (b *JobSetSpecApplyConfiguration) WithPodRestartPolicy(value corev1.RestartPolicy) *JobSetSpecApplyConfiguration {
+	b.PodRestartPolicy = &value
+	return b
+}
+
+// WithCompletionPolicy sets the CompletionPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CompletionPolicy field is set to the value of the last call.
+func (b *JobSetSpecApplyConfiguration) WithCompletionPolicy(value *CompletionPolicyApplyConfiguration) *JobSetSpecApplyConfiguration {
+	b.CompletionPolicy = value
+	return b
+}
+
+// WithCreateJobsSuspended sets the CreateJobsSuspended field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CreateJobsSuspended field is set to the value of the last call.
+func (b *JobSetSpecApplyConfiguration) WithCreateJobsSuspended(value bool) *JobSetSpecApplyConfiguration {
+	b.CreateJobsSuspended = &value
+	return b
+}
+
+// WithHostAliases adds the given value to the HostAliases field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the HostAliases field.
+func (b *JobSetSpecApplyConfiguration) WithHostAliases(values ...corev1.HostAlias) *JobSetSpecApplyConfiguration {
+	for i := range values {
+		b.HostAliases = append(b.HostAliases, values[i])
+	}
+	return b
+}
+
+// WithMinReadySeconds sets the MinReadySeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MinReadySeconds field is set to the value of the last call.
+func (b *JobSetSpecApplyConfiguration) WithMinReadySeconds(value int32) *JobSetSpecApplyConfiguration {
+	b.MinReadySeconds = &value
+	return b
+}
+
+// WithPodReadinessGates adds the given value to the PodReadinessGates field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PodReadinessGates field.
+func (b *JobSetSpecApplyConfiguration) WithPodReadinessGates(values ...corev1.PodReadinessGate) *JobSetSpecApplyConfiguration {
+	for i := range values {
+		b.PodReadinessGates = append(b.PodReadinessGates, values[i])
+	}
+	return b
+}
+
+// WithJobAnnotations puts the entries into the JobAnnotations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the JobAnnotations field,
+// overwriting an existing map entries in JobAnnotations field with the same key.
+func (b *JobSetSpecApplyConfiguration) WithJobAnnotations(entries map[string]string) *JobSetSpecApplyConfiguration {
+	if b.JobAnnotations == nil && len(entries) > 0 {
+		b.JobAnnotations = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.JobAnnotations[k] = v
+	}
+	return b
+}
+
+// WithPodAnnotations puts the entries into the PodAnnotations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the PodAnnotations field,
+// overwriting an existing map entries in PodAnnotations field with the same key.
+func (b *JobSetSpecApplyConfiguration) WithPodAnnotations(entries map[string]string) *JobSetSpecApplyConfiguration {
+	if b.PodAnnotations == nil && len(entries) > 0 {
+		b.PodAnnotations = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.PodAnnotations[k] = v
+	}
+	return b
+}
+
+// WithRuntimeClassName sets the RuntimeClassName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RuntimeClassName field is set to the value of the last call.
+func (b *JobSetSpecApplyConfiguration) WithRuntimeClassName(value string) *JobSetSpecApplyConfiguration {
+	b.RuntimeClassName = &value
+	return b
+}
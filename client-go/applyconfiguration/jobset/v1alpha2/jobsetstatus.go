@@ -15,15 +15,30 @@ limitations under the License.
 package v1alpha2
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // JobSetStatusApplyConfiguration represents an declarative configuration of the JobSetStatus type for use
 // with apply.
 type JobSetStatusApplyConfiguration struct {
-	Conditions           []v1.Condition                          `json:"conditions,omitempty"`
-	Restarts             *int32                                  `json:"restarts,omitempty"`
-	ReplicatedJobsStatus []ReplicatedJobStatusApplyConfiguration `json:"replicatedJobsStatus,omitempty"`
+	Conditions                 []v1.Condition                          `json:"conditions,omitempty"`
+	Restarts                   *int32                                  `json:"restarts,omitempty"`
+	ReplicatedJobsStatus       []ReplicatedJobStatusApplyConfiguration `json:"replicatedJobsStatus,omitempty"`
+	JobPlacements              []JobPlacementApplyConfiguration        `json:"jobPlacements,omitempty"`
+	StartTime                  *v1.Time                                `json:"startTime,omitempty"`
+	ObservedRestartTrigger     *string                                 `json:"observedRestartTrigger,omitempty"`
+	ObservedGeneration         *int64                                  `json:"observedGeneration,omitempty"`
+	StartupPolicyStatus        *StartupPolicyStatusApplyConfiguration  `json:"startupPolicyStatus,omitempty"`
+	RestartTimes               []v1.Time                               `json:"restartTimes,omitempty"`
+	AggregatedResourceRequests *corev1.ResourceList                    `json:"aggregatedResourceRequests,omitempty"`
+	ReconcileErrors            *int32                                  `json:"reconcileErrors,omitempty"`
+	JobsPendingDeletion        []string                                `json:"jobsPendingDeletion,omitempty"`
+	SucceededJobs              []string                                `json:"succeededJobs,omitempty"`
+	CompletionTime             *v1.Time                                `json:"completionTime,omitempty"`
+	Duration                   *v1.Duration                            `json:"duration,omitempty"`
+	UnschedulableJobs          []UnschedulableJobApplyConfiguration    `json:"unschedulableJobs,omitempty"`
+	FirstStartTime             *v1.Time                                `json:"firstStartTime,omitempty"`
 }
 
 // JobSetStatusApplyConfiguration constructs an declarative configuration of the JobSetStatus type for use with
@@ -62,3 +77,131 @@ func (b *JobSetStatusApplyConfiguration) WithReplicatedJobsStatus(values ...*Rep
 	}
 	return b
 }
+
+// WithJobPlacements adds the given value to the JobPlacements field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the JobPlacements field.
+func (b *JobSetStatusApplyConfiguration) WithJobPlacements(values ...*JobPlacementApplyConfiguration) *JobSetStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithJobPlacements")
+		}
+		b.JobPlacements = append(b.JobPlacements, *values[i])
+	}
+	return b
+}
+
+// WithStartTime sets the StartTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StartTime field is set to the value of the last call.
+func (b *JobSetStatusApplyConfiguration) WithStartTime(value *v1.Time) *JobSetStatusApplyConfiguration {
+	b.StartTime = value
+	return b
+}
+
+// WithObservedRestartTrigger sets the ObservedRestartTrigger field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ObservedRestartTrigger field is set to the value of the last call.
+func (b *JobSetStatusApplyConfiguration) WithObservedRestartTrigger(value string) *JobSetStatusApplyConfiguration {
+	b.ObservedRestartTrigger = &value
+	return b
+}
+
+// WithObservedGeneration sets the ObservedGeneration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ObservedGeneration field is set to the value of the last call.
+func (b *JobSetStatusApplyConfiguration) WithObservedGeneration(value int64) *JobSetStatusApplyConfiguration {
+	b.ObservedGeneration = &value
+	return b
+}
+
+// WithStartupPolicyStatus sets the StartupPolicyStatus field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StartupPolicyStatus field is set to the value of the last call.
+func (b *JobSetStatusApplyConfiguration) WithStartupPolicyStatus(value *StartupPolicyStatusApplyConfiguration) *JobSetStatusApplyConfiguration {
+	b.StartupPolicyStatus = value
+	return b
+}
+
+// WithRestartTimes adds the given value to the RestartTimes field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the RestartTimes field.
+func (b *JobSetStatusApplyConfiguration) WithRestartTimes(values ...v1.Time) *JobSetStatusApplyConfiguration {
+	for i := range values {
+		b.RestartTimes = append(b.RestartTimes, values[i])
+	}
+	return b
+}
+
+// WithAggregatedResourceRequests sets the AggregatedResourceRequests field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AggregatedResourceRequests field is set to the value of the last call.
+func (b *JobSetStatusApplyConfiguration) WithAggregatedResourceRequests(value corev1.ResourceList) *JobSetStatusApplyConfiguration {
+	b.AggregatedResourceRequests = &value
+	return b
+}
+
+// WithReconcileErrors sets the ReconcileErrors field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReconcileErrors field is set to the value of the last call.
+func (b *JobSetStatusApplyConfiguration) WithReconcileErrors(value int32) *JobSetStatusApplyConfiguration {
+	b.ReconcileErrors = &value
+	return b
+}
+
+// WithJobsPendingDeletion adds the given value to the JobsPendingDeletion field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the JobsPendingDeletion field.
+func (b *JobSetStatusApplyConfiguration) WithJobsPendingDeletion(values ...string) *JobSetStatusApplyConfiguration {
+	for i := range values {
+		b.JobsPendingDeletion = append(b.JobsPendingDeletion, values[i])
+	}
+	return b
+}
+
+// WithSucceededJobs adds the given value to the SucceededJobs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the SucceededJobs field.
+func (b *JobSetStatusApplyConfiguration) WithSucceededJobs(values ...string) *JobSetStatusApplyConfiguration {
+	for i := range values {
+		b.SucceededJobs = append(b.SucceededJobs, values[i])
+	}
+	return b
+}
+
+// WithCompletionTime sets the CompletionTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CompletionTime field is set to the value of the last call.
+func (b *JobSetStatusApplyConfiguration) WithCompletionTime(value *v1.Time) *JobSetStatusApplyConfiguration {
+	b.CompletionTime = value
+	return b
+}
+
+// WithDuration sets the Duration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Duration field is set to the value of the last call.
+func (b *JobSetStatusApplyConfiguration) WithDuration(value *v1.Duration) *JobSetStatusApplyConfiguration {
+	b.Duration = value
+	return b
+}
+
+// WithUnschedulableJobs adds the given value to the UnschedulableJobs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the UnschedulableJobs field.
+func (b *JobSetStatusApplyConfiguration) WithUnschedulableJobs(values ...*UnschedulableJobApplyConfiguration) *JobSetStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithUnschedulableJobs")
+		}
+		b.UnschedulableJobs = append(b.UnschedulableJobs, *values[i])
+	}
+	return b
+}
+
+// WithFirstStartTime sets the FirstStartTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FirstStartTime field is set to the value of the last call.
+func (b *JobSetStatusApplyConfiguration) WithFirstStartTime(value *v1.Time) *JobSetStatusApplyConfiguration {
+	b.FirstStartTime = value
+	return b
+}
@@ -17,9 +17,11 @@ package v1alpha2
 // NetworkApplyConfiguration represents an declarative configuration of the Network type for use
 // with apply.
 type NetworkApplyConfiguration struct {
-	EnableDNSHostnames       *bool   `json:"enableDNSHostnames,omitempty"`
-	Subdomain                *string `json:"subdomain,omitempty"`
-	PublishNotReadyAddresses *bool   `json:"publishNotReadyAddresses,omitempty"`
+	EnableDNSHostnames       *bool                                 `json:"enableDNSHostnames,omitempty"`
+	Subdomain                *string                               `json:"subdomain,omitempty"`
+	PublishNotReadyAddresses *bool                                 `json:"publishNotReadyAddresses,omitempty"`
+	CoordinatorService       *CoordinatorServiceApplyConfiguration `json:"coordinatorService,omitempty"`
+	ServiceSelector          map[string]string                     `json:"serviceSelector,omitempty"`
 }
 
 // NetworkApplyConfiguration constructs an declarative configuration of the Network type for use with
@@ -51,3 +53,25 @@ func (b *NetworkApplyConfiguration) WithPublishNotReadyAddresses(value bool) *Ne
 	b.PublishNotReadyAddresses = &value
 	return b
 }
+
+// WithCoordinatorService sets the CoordinatorService field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CoordinatorService field is set to the value of the last call.
+func (b *NetworkApplyConfiguration) WithCoordinatorService(value *CoordinatorServiceApplyConfiguration) *NetworkApplyConfiguration {
+	b.CoordinatorService = value
+	return b
+}
+
+// WithServiceSelector puts the entries into the ServiceSelector field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the ServiceSelector field,
+// overwriting an existing map entries in ServiceSelector field with the same key.
+func (b *NetworkApplyConfiguration) WithServiceSelector(entries map[string]string) *NetworkApplyConfiguration {
+	if b.ServiceSelector == nil && len(entries) > 0 {
+		b.ServiceSelector = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.ServiceSelector[k] = v
+	}
+	return b
+}
@@ -16,14 +16,28 @@ package v1alpha2
 
 import (
 	v1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 )
 
 // ReplicatedJobApplyConfiguration represents an declarative configuration of the ReplicatedJob type for use
 // with apply.
 type ReplicatedJobApplyConfiguration struct {
-	Name     *string             `json:"name,omitempty"`
-	Template *v1.JobTemplateSpec `json:"template,omitempty"`
-	Replicas *int32              `json:"replicas,omitempty"`
+	Name                     *string                                `json:"name,omitempty"`
+	Template                 *v1.JobTemplateSpec                    `json:"template,omitempty"`
+	PodTemplateName          *string                                `json:"podTemplateName,omitempty"`
+	Replicas                 *int32                                 `json:"replicas,omitempty"`
+	IndexedOverrides         []IndexedOverrideApplyConfiguration    `json:"indexedOverrides,omitempty"`
+	FailurePolicy            *FailurePolicyApplyConfiguration       `json:"failurePolicy,omitempty"`
+	PodDisruptionBudget      *PodDisruptionBudgetApplyConfiguration `json:"podDisruptionBudget,omitempty"`
+	RestartPriority          *int32                                 `json:"restartPriority,omitempty"`
+	CompletionTimeoutSeconds *int32                                 `json:"completionTimeoutSeconds,omitempty"`
+	NonCritical              *bool                                  `json:"nonCritical,omitempty"`
+	SchedulingGates          []corev1.PodSchedulingGate             `json:"schedulingGates,omitempty"`
+	PodPriorityClassName     *string                                `json:"podPriorityClassName,omitempty"`
+	SchedulerName            *string                                `json:"schedulerName,omitempty"`
+	RestartIsolation         *bool                                  `json:"restartIsolation,omitempty"`
+	MaxFailedJobs            *int32                                 `json:"maxFailedJobs,omitempty"`
+	DependsOn                []string                               `json:"dependsOn,omitempty"`
 }
 
 // ReplicatedJobApplyConfiguration constructs an declarative configuration of the ReplicatedJob type for use with
@@ -48,6 +62,14 @@ func (b *ReplicatedJobApplyConfiguration) WithTemplate(value v1.JobTemplateSpec)
 	return b
 }
 
+// WithPodTemplateName sets the PodTemplateName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PodTemplateName field is set to the value of the last call.
+func (b *ReplicatedJobApplyConfiguration) WithPodTemplateName(value string) *ReplicatedJobApplyConfiguration {
+	b.PodTemplateName = &value
+	return b
+}
+
 // WithReplicas sets the Replicas field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Replicas field is set to the value of the last call.
@@ -55,3 +77,108 @@ func (b *ReplicatedJobApplyConfiguration) WithReplicas(value int32) *ReplicatedJ
 	b.Replicas = &value
 	return b
 }
+
+// WithIndexedOverrides adds the given value to the IndexedOverrides field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the IndexedOverrides field.
+func (b *ReplicatedJobApplyConfiguration) WithIndexedOverrides(values ...*IndexedOverrideApplyConfiguration) *ReplicatedJobApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithIndexedOverrides")
+		}
+		b.IndexedOverrides = append(b.IndexedOverrides, *values[i])
+	}
+	return b
+}
+
+// WithFailurePolicy sets the FailurePolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FailurePolicy field is set to the value of the last call.
+func (b *ReplicatedJobApplyConfiguration) WithFailurePolicy(value *FailurePolicyApplyConfiguration) *ReplicatedJobApplyConfiguration {
+	b.FailurePolicy = value
+	return b
+}
+
+// WithPodDisruptionBudget sets the PodDisruptionBudget field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PodDisruptionBudget field is set to the value of the last call.
+func (b *ReplicatedJobApplyConfiguration) WithPodDisruptionBudget(value *PodDisruptionBudgetApplyConfiguration) *ReplicatedJobApplyConfiguration {
+	b.PodDisruptionBudget = value
+	return b
+}
+
+// WithRestartPriority sets the RestartPriority field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RestartPriority field is set to the value of the last call.
+func (b *ReplicatedJobApplyConfiguration) WithRestartPriority(value int32) *ReplicatedJobApplyConfiguration {
+	b.RestartPriority = &value
+	return b
+}
+
+// WithCompletionTimeoutSeconds sets the CompletionTimeoutSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CompletionTimeoutSeconds field is set to the value of the last call.
+func (b *ReplicatedJobApplyConfiguration) WithCompletionTimeoutSeconds(value int32) *ReplicatedJobApplyConfiguration {
+	b.CompletionTimeoutSeconds = &value
+	return b
+}
+
+// WithNonCritical sets the NonCritical field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NonCritical field is set to the value of the last call.
+func (b *ReplicatedJobApplyConfiguration) WithNonCritical(value bool) *ReplicatedJobApplyConfiguration {
+	b.NonCritical = &value
+	return b
+}
+
+// WithSchedulingGates adds the given value to the SchedulingGates field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the SchedulingGates field.
+func (b *ReplicatedJobApplyConfiguration) WithSchedulingGates(values ...corev1.PodSchedulingGate) *ReplicatedJobApplyConfiguration {
+	for i := range values {
+		b.SchedulingGates = append(b.SchedulingGates, values[i])
+	}
+	return b
+}
+
+// WithPodPriorityClassName sets the PodPriorityClassName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PodPriorityClassName field is set to the value of the last call.
+func (b *ReplicatedJobApplyConfiguration) WithPodPriorityClassName(value string) *ReplicatedJobApplyConfiguration {
+	b.PodPriorityClassName = &value
+	return b
+}
+
+// WithSchedulerName sets the SchedulerName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SchedulerName field is set to the value of the last call.
+func (b *ReplicatedJobApplyConfiguration) WithSchedulerName(value string) *ReplicatedJobApplyConfiguration {
+	b.SchedulerName = &value
+	return b
+}
+
+// WithRestartIsolation sets the RestartIsolation field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RestartIsolation field is set to the value of the last call.
+func (b *ReplicatedJobApplyConfiguration) WithRestartIsolation(value bool) *ReplicatedJobApplyConfiguration {
+	b.RestartIsolation = &value
+	return b
+}
+
+// WithMaxFailedJobs sets the MaxFailedJobs field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxFailedJobs field is set to the value of the last call.
+func (b *ReplicatedJobApplyConfiguration) WithMaxFailedJobs(value int32) *ReplicatedJobApplyConfiguration {
+	b.MaxFailedJobs = &value
+	return b
+}
+
+// WithDependsOn adds the given value to the DependsOn field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the DependsOn field.
+func (b *ReplicatedJobApplyConfiguration) WithDependsOn(values ...string) *ReplicatedJobApplyConfiguration {
+	for i := range values {
+		b.DependsOn = append(b.DependsOn, values[i])
+	}
+	return b
+}
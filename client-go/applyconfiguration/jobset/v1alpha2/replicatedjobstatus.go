@@ -17,12 +17,13 @@ package v1alpha2
 // ReplicatedJobStatusApplyConfiguration represents an declarative configuration of the ReplicatedJobStatus type for use
 // with apply.
 type ReplicatedJobStatusApplyConfiguration struct {
-	Name      *string `json:"name,omitempty"`
-	Ready     *int32  `json:"ready,omitempty"`
-	Succeeded *int32  `json:"succeeded,omitempty"`
-	Failed    *int32  `json:"failed,omitempty"`
-	Active    *int32  `json:"active,omitempty"`
-	Suspended *int32  `json:"suspended,omitempty"`
+	Name        *string                       `json:"name,omitempty"`
+	Ready       *int32                        `json:"ready,omitempty"`
+	Succeeded   *int32                        `json:"succeeded,omitempty"`
+	Failed      *int32                        `json:"failed,omitempty"`
+	Active      *int32                        `json:"active,omitempty"`
+	Suspended   *int32                        `json:"suspended,omitempty"`
+	JobStatuses []JobStatusApplyConfiguration `json:"jobStatuses,omitempty"`
 }
 
 // ReplicatedJobStatusApplyConfiguration constructs an declarative configuration of the ReplicatedJobStatus type for use with
@@ -78,3 +79,16 @@ func (b *ReplicatedJobStatusApplyConfiguration) WithSuspended(value int32) *Repl
 	b.Suspended = &value
 	return b
 }
+
+// WithJobStatuses adds the given value to the JobStatuses field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the JobStatuses field.
+func (b *ReplicatedJobStatusApplyConfiguration) WithJobStatuses(values ...*JobStatusApplyConfiguration) *ReplicatedJobStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithJobStatuses")
+		}
+		b.JobStatuses = append(b.JobStatuses, *values[i])
+	}
+	return b
+}
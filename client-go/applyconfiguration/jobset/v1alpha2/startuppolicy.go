@@ -21,7 +21,9 @@ import (
 // StartupPolicyApplyConfiguration represents an declarative configuration of the StartupPolicy type for use
 // with apply.
 type StartupPolicyApplyConfiguration struct {
-	StartupPolicyOrder *v1alpha2.StartupPolicyOptions `json:"startupPolicyOrder,omitempty"`
+	StartupPolicyOrder  *v1alpha2.StartupPolicyOptions  `json:"startupPolicyOrder,omitempty"`
+	StallTimeoutSeconds *int32                          `json:"stallTimeoutSeconds,omitempty"`
+	Gates               []StartupGateApplyConfiguration `json:"gates,omitempty"`
 }
 
 // StartupPolicyApplyConfiguration constructs an declarative configuration of the StartupPolicy type for use with
@@ -37,3 +39,24 @@ func (b *StartupPolicyApplyConfiguration) WithStartupPolicyOrder(value v1alpha2.
 	b.StartupPolicyOrder = &value
 	return b
 }
+
+// WithStallTimeoutSeconds sets the StallTimeoutSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StallTimeoutSeconds field is set to the value of the last call.
+func (b *StartupPolicyApplyConfiguration) WithStallTimeoutSeconds(value int32) *StartupPolicyApplyConfiguration {
+	b.StallTimeoutSeconds = &value
+	return b
+}
+
+// WithGates adds the given value to the Gates field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Gates field.
+func (b *StartupPolicyApplyConfiguration) WithGates(values ...*StartupGateApplyConfiguration) *StartupPolicyApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithGates")
+		}
+		b.Gates = append(b.Gates, *values[i])
+	}
+	return b
+}
@@ -21,8 +21,13 @@ import (
 // SuccessPolicyApplyConfiguration represents an declarative configuration of the SuccessPolicy type for use
 // with apply.
 type SuccessPolicyApplyConfiguration struct {
-	Operator             *v1alpha2.Operator `json:"operator,omitempty"`
-	TargetReplicatedJobs []string           `json:"targetReplicatedJobs,omitempty"`
+	Operator                     *v1alpha2.Operator                               `json:"operator,omitempty"`
+	TargetReplicatedJobs         []string                                         `json:"targetReplicatedJobs,omitempty"`
+	PodAnnotation                *PodAnnotationSuccessConditionApplyConfiguration `json:"podAnnotation,omitempty"`
+	TotalSucceeded               *int32                                           `json:"totalSucceeded,omitempty"`
+	IndexedJobCompletion         *v1alpha2.IndexedJobCompletion                   `json:"indexedJobCompletion,omitempty"`
+	CompletionGracePeriodSeconds *int32                                           `json:"completionGracePeriodSeconds,omitempty"`
+	CountAcrossRestarts          *bool                                            `json:"countAcrossRestarts,omitempty"`
 }
 
 // SuccessPolicyApplyConfiguration constructs an declarative configuration of the SuccessPolicy type for use with
@@ -48,3 +53,43 @@ func (b *SuccessPolicyApplyConfiguration) WithTargetReplicatedJobs(values ...str
 	}
 	return b
 }
+
+// WithPodAnnotation sets the PodAnnotation field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PodAnnotation field is set to the value of the last call.
+func (b *SuccessPolicyApplyConfiguration) WithPodAnnotation(value *PodAnnotationSuccessConditionApplyConfiguration) *SuccessPolicyApplyConfiguration {
+	b.PodAnnotation = value
+	return b
+}
+
+// WithTotalSucceeded sets the TotalSucceeded field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TotalSucceeded field is set to the value of the last call.
+func (b *SuccessPolicyApplyConfiguration) WithTotalSucceeded(value int32) *SuccessPolicyApplyConfiguration {
+	b.TotalSucceeded = &value
+	return b
+}
+
+// WithIndexedJobCompletion sets the IndexedJobCompletion field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IndexedJobCompletion field is set to the value of the last call.
+func (b *SuccessPolicyApplyConfiguration) WithIndexedJobCompletion(value v1alpha2.IndexedJobCompletion) *SuccessPolicyApplyConfiguration {
+	b.IndexedJobCompletion = &value
+	return b
+}
+
+// WithCompletionGracePeriodSeconds sets the CompletionGracePeriodSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CompletionGracePeriodSeconds field is set to the value of the last call.
+func (b *SuccessPolicyApplyConfiguration) WithCompletionGracePeriodSeconds(value int32) *SuccessPolicyApplyConfiguration {
+	b.CompletionGracePeriodSeconds = &value
+	return b
+}
+
+// WithCountAcrossRestarts sets the CountAcrossRestarts field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CountAcrossRestarts field is set to the value of the last call.
+func (b *SuccessPolicyApplyConfiguration) WithCountAcrossRestarts(value bool) *SuccessPolicyApplyConfiguration {
+	b.CountAcrossRestarts = &value
+	return b
+}
@@ -25,24 +25,48 @@ import (
 func ForKind(kind schema.GroupVersionKind) interface{} {
 	switch kind {
 	// Group=jobset.x-k8s.io, Version=v1alpha2
+	case v1alpha2.SchemeGroupVersion.WithKind("CleanupPolicy"):
+		return &jobsetv1alpha2.CleanupPolicyApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("CompletionPolicy"):
+		return &jobsetv1alpha2.CompletionPolicyApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("Coordinator"):
+		return &jobsetv1alpha2.CoordinatorApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("CoordinatorService"):
+		return &jobsetv1alpha2.CoordinatorServiceApplyConfiguration{}
 	case v1alpha2.SchemeGroupVersion.WithKind("FailurePolicy"):
 		return &jobsetv1alpha2.FailurePolicyApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("IndexedOverride"):
+		return &jobsetv1alpha2.IndexedOverrideApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("JobPlacement"):
+		return &jobsetv1alpha2.JobPlacementApplyConfiguration{}
 	case v1alpha2.SchemeGroupVersion.WithKind("JobSet"):
 		return &jobsetv1alpha2.JobSetApplyConfiguration{}
 	case v1alpha2.SchemeGroupVersion.WithKind("JobSetSpec"):
 		return &jobsetv1alpha2.JobSetSpecApplyConfiguration{}
 	case v1alpha2.SchemeGroupVersion.WithKind("JobSetStatus"):
 		return &jobsetv1alpha2.JobSetStatusApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("JobStatus"):
+		return &jobsetv1alpha2.JobStatusApplyConfiguration{}
 	case v1alpha2.SchemeGroupVersion.WithKind("Network"):
 		return &jobsetv1alpha2.NetworkApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("PodAnnotationSuccessCondition"):
+		return &jobsetv1alpha2.PodAnnotationSuccessConditionApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("PodDisruptionBudget"):
+		return &jobsetv1alpha2.PodDisruptionBudgetApplyConfiguration{}
 	case v1alpha2.SchemeGroupVersion.WithKind("ReplicatedJob"):
 		return &jobsetv1alpha2.ReplicatedJobApplyConfiguration{}
 	case v1alpha2.SchemeGroupVersion.WithKind("ReplicatedJobStatus"):
 		return &jobsetv1alpha2.ReplicatedJobStatusApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("StartupGate"):
+		return &jobsetv1alpha2.StartupGateApplyConfiguration{}
 	case v1alpha2.SchemeGroupVersion.WithKind("StartupPolicy"):
 		return &jobsetv1alpha2.StartupPolicyApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("StartupPolicyStatus"):
+		return &jobsetv1alpha2.StartupPolicyStatusApplyConfiguration{}
 	case v1alpha2.SchemeGroupVersion.WithKind("SuccessPolicy"):
 		return &jobsetv1alpha2.SuccessPolicyApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("UnschedulableJob"):
+		return &jobsetv1alpha2.UnschedulableJobApplyConfiguration{}
 
 	}
 	return nil
@@ -17,13 +17,21 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
+	"math"
 	"os"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -34,6 +42,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	jobset "sigs.k8s.io/jobset/api/jobset/v1alpha2"
+	"sigs.k8s.io/jobset/pkg/constants"
 	"sigs.k8s.io/jobset/pkg/controllers"
 	"sigs.k8s.io/jobset/pkg/util/cert"
 	"sigs.k8s.io/jobset/pkg/webhooks"
@@ -58,6 +67,24 @@ func main() {
 	var probeAddr string
 	var qps float64
 	var burst int
+	var placementInitImage string
+	var jobCreationRetries int
+	var blockOwnerDeletion bool
+	var cleanupFinalizerTimeout time.Duration
+	var checkTopologyCapacity bool
+	var requeueJitterFactor float64
+	var adoptHeadlessServices bool
+	var maxActiveJobSetsPerNamespace int
+	var enableNetworkManagement bool
+	var defaultMaxRestarts int
+	var restartLimitWarningThreshold float64
+	var rejectUnknownTopologyKeys bool
+	var reconcileErrorBackoffThreshold int
+	var reconcileErrorBackoff time.Duration
+	var reconcileTimeout time.Duration
+	var fieldManager string
+	var otlpTracesEndpoint string
+	var otlpTracesInsecure bool
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
@@ -65,6 +92,58 @@ func main() {
 			"Enabling this will ensure there is only one active controller manager.")
 	flag.Float64Var(&qps, "kube-api-qps", 500, "Maximum QPS to use while talking with Kubernetes API")
 	flag.IntVar(&burst, "kube-api-burst", 500, "Maximum burst for throttle while talking with Kubernetes API")
+	flag.StringVar(&placementInitImage, "exclusive-placement-init-image", "",
+		"Image of the init container injected into pods of exclusive placement Jobs using the node selector strategy, "+
+			"which blocks until the node is labeled with the namespaced job name. "+
+			"Injection is disabled if unset. The image must provide a shell and kubectl.")
+	flag.IntVar(&jobCreationRetries, "job-creation-retries", 3,
+		"Number of times the creation of a child Job is retried after a transient API server error.")
+	flag.BoolVar(&blockOwnerDeletion, "block-owner-deletion", true,
+		"Set blockOwnerDeletion on the owner references of child Jobs and Services. "+
+			"Disable this if the deletion of child resources is managed externally, e.g. by GitOps tooling.")
+	flag.DurationVar(&cleanupFinalizerTimeout, "cleanup-finalizer-timeout", constants.DefaultCleanupFinalizerTimeout,
+		"Maximum time the cleanup finalizer of a deleted JobSet waits for its child Jobs to be deleted, "+
+			"before it is removed and the Services of the JobSet are garbage collected.")
+	flag.BoolVar(&checkTopologyCapacity, "check-topology-capacity", false,
+		"Defer the creation of child Jobs using exclusive placement while the cluster has fewer "+
+			"schedulable topology domains than Jobs to place, instead of creating Jobs which cannot schedule.")
+	flag.Float64Var(&requeueJitterFactor, "requeue-jitter-factor", 0,
+		"Maximum jitter added to the requeue durations of JobSets, as a fraction between 0 and 1 of the duration, "+
+			"to spread out the periodic reconciliation of JobSets created at the same time. Disabled if 0.")
+	flag.BoolVar(&adoptHeadlessServices, "adopt-headless-services", false,
+		"Adopt an existing headless service of a JobSet which is not controlled by any object and does not "+
+			"select the pods of the JobSet, instead of reporting it in the NetworkServiceConflict condition.")
+	flag.BoolVar(&enableNetworkManagement, "enable-network-management", true,
+		"Create the headless and coordinator Services of JobSets. Disable this if pod DNS is handled out-of-band, "+
+			"so the controller can run without permissions on Services. The subdomain is still set on the pods.")
+	flag.IntVar(&maxActiveJobSetsPerNamespace, "max-active-jobsets-per-namespace", 0,
+		"Maximum number of active JobSets in a namespace, beyond which the creation of JobSets is rejected. "+
+			"Overridden by the alpha.jobset.sigs.k8s.io/max-active-jobsets annotation of the namespace. Disabled if 0.")
+	flag.IntVar(&defaultMaxRestarts, "default-max-restarts", 0,
+		"The maxRestarts of the failure policy set on JobSets created without a failure policy. "+
+			"A failure policy set on the JobSet always takes precedence. If 0, JobSets without a failure "+
+			"policy fail on the first child Job failure.")
+	flag.Float64Var(&restartLimitWarningThreshold, "restart-limit-warning-threshold", 0,
+		"Fraction between 0 and 1 of the maxRestarts of the failure policy of a JobSet, at which the "+
+			"RestartLimitApproaching condition is set on the JobSet. Disabled if 0.")
+	flag.BoolVar(&rejectUnknownTopologyKeys, "reject-unknown-topology-keys", false,
+		"Reject the creation of JobSets whose exclusive placement topology key is not a label of any node. "+
+			"By default such JobSets are admitted with a warning, since nodes may be labeled later.")
+	flag.IntVar(&reconcileErrorBackoffThreshold, "reconcile-error-backoff-threshold", 0,
+		"Number of consecutive failed reconciliations of a JobSet, after which the JobSet gets the "+
+			"ReconcileBackingOff condition and is only retried after --reconcile-error-backoff. Disabled if 0.")
+	flag.DurationVar(&reconcileErrorBackoff, "reconcile-error-backoff", constants.DefaultReconcileErrorBackoff,
+		"Time after which a JobSet backing off reconciliation errors is reconciled again.")
+	flag.DurationVar(&reconcileTimeout, "reconcile-timeout", 0,
+		"Maximum duration of a single reconciliation of a JobSet. A reconciliation exceeding it is requeued "+
+			"and continues from the progress already made. Disabled if 0.")
+	flag.StringVar(&fieldManager, "field-manager", constants.DefaultFieldManager,
+		"Field manager name of the server-side applies of the child Jobs and Services of JobSets.")
+	flag.StringVar(&otlpTracesEndpoint, "otlp-traces-endpoint", "",
+		"Host and port of the OTLP gRPC endpoint the OpenTelemetry spans of the JobSet reconciliations are exported to. "+
+			"Tracing is disabled if empty.")
+	flag.BoolVar(&otlpTracesInsecure, "otlp-traces-insecure", false,
+		"Export the OpenTelemetry spans to the OTLP endpoint without TLS.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -73,6 +152,27 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	if requeueJitterFactor < 0 || requeueJitterFactor > 1 {
+		setupLog.Error(nil, "invalid requeue jitter factor, must be between 0 and 1", "requeueJitterFactor", requeueJitterFactor)
+		os.Exit(1)
+	}
+	if restartLimitWarningThreshold < 0 || restartLimitWarningThreshold > 1 {
+		setupLog.Error(nil, "invalid restart limit warning threshold, must be between 0 and 1", "restartLimitWarningThreshold", restartLimitWarningThreshold)
+		os.Exit(1)
+	}
+	if defaultMaxRestarts < 0 || defaultMaxRestarts > math.MaxInt32 {
+		setupLog.Error(nil, "invalid default max restarts, must be between 0 and 2147483647", "defaultMaxRestarts", defaultMaxRestarts)
+		os.Exit(1)
+	}
+	if reconcileErrorBackoffThreshold < 0 || reconcileErrorBackoffThreshold > math.MaxInt32 {
+		setupLog.Error(nil, "invalid reconcile error backoff threshold, must be between 0 and 2147483647", "reconcileErrorBackoffThreshold", reconcileErrorBackoffThreshold)
+		os.Exit(1)
+	}
+	if maxActiveJobSetsPerNamespace < 0 {
+		setupLog.Error(nil, "invalid max active jobsets per namespace, must not be negative", "maxActiveJobSetsPerNamespace", maxActiveJobSetsPerNamespace)
+		os.Exit(1)
+	}
+
 	kubeConfig := ctrl.GetConfigOrDie()
 	kubeConfig.QPS = float32(qps)
 	kubeConfig.Burst = burst
@@ -113,6 +213,14 @@ func main() {
 	}
 
 	ctx := ctrl.SetupSignalHandler()
+	var tracerProvider *sdktrace.TracerProvider
+	if otlpTracesEndpoint != "" {
+		tracerProvider, err = setupTracerProvider(ctx, otlpTracesEndpoint, otlpTracesInsecure)
+		if err != nil {
+			setupLog.Error(err, "unable to set up the OTLP trace exporter")
+			os.Exit(1)
+		}
+	}
 	if err := controllers.SetupJobSetIndexes(ctx, mgr.GetFieldIndexer()); err != nil {
 		setupLog.Error(err, "unable to setup jobset reconciler indexes")
 		os.Exit(1)
@@ -122,10 +230,32 @@ func main() {
 		os.Exit(1)
 	}
 
+	reconcilerOpts := controllers.JobSetReconcilerOptions{
+		PlacementInitImage:             placementInitImage,
+		JobCreationRetries:             jobCreationRetries,
+		DisableBlockOwnerDeletion:      !blockOwnerDeletion,
+		CleanupFinalizerTimeout:        cleanupFinalizerTimeout,
+		CheckTopologyCapacity:          checkTopologyCapacity,
+		RequeueJitterFactor:            requeueJitterFactor,
+		AdoptHeadlessServices:          adoptHeadlessServices,
+		DisableNetworkManagement:       !enableNetworkManagement,
+		RestartLimitWarningThreshold:   restartLimitWarningThreshold,
+		ReconcileErrorBackoffThreshold: int32(reconcileErrorBackoffThreshold),
+		ReconcileErrorBackoff:          reconcileErrorBackoff,
+		ReconcileTimeout:               reconcileTimeout,
+		FieldManager:                   fieldManager,
+	}
+	if tracerProvider != nil {
+		reconcilerOpts.TracerProvider = tracerProvider
+	}
 	// Cert won't be ready until manager starts, so start a goroutine here which
 	// will block until the cert is ready before setting up the controllers.
 	// Controllers who register after manager starts will start directly.
-	go setupControllers(mgr, certsReady)
+	go setupControllers(mgr, certsReady, reconcilerOpts, webhooks.JobSetWebhookOptions{
+		MaxActiveJobSetsPerNamespace: maxActiveJobSetsPerNamespace,
+		DefaultMaxRestarts:           int32(defaultMaxRestarts),
+		RejectUnknownTopologyKeys:    rejectUnknownTopologyKeys,
+	})
 
 	setupHealthzAndReadyzCheck(mgr)
 
@@ -134,9 +264,34 @@ func main() {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
+	if tracerProvider != nil {
+		// Flush the spans still buffered by the exporter.
+		if err := tracerProvider.Shutdown(context.Background()); err != nil {
+			setupLog.Error(err, "unable to shut down the tracer provider")
+		}
+	}
+}
+
+// setupTracerProvider returns a tracer provider exporting spans in batches to the given OTLP gRPC
+// endpoint, and registers it as the global tracer provider.
+func setupTracerProvider(ctx context.Context, endpoint string, insecure bool) (*sdktrace.TracerProvider, error) {
+	exporterOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(endpoint)}
+	if insecure {
+		exporterOpts = append(exporterOpts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, exporterOpts...)
+	if err != nil {
+		return nil, err
+	}
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", "jobset-controller"))),
+	)
+	otel.SetTracerProvider(tracerProvider)
+	return tracerProvider, nil
 }
 
-func setupControllers(mgr ctrl.Manager, certsReady chan struct{}) {
+func setupControllers(mgr ctrl.Manager, certsReady chan struct{}, reconcilerOpts controllers.JobSetReconcilerOptions, webhookOpts webhooks.JobSetWebhookOptions) {
 	// The controllers won't work until the webhooks are operating,
 	// and the webhook won't work until the certs are all in places.
 	setupLog.Info("waiting for the cert generation to complete")
@@ -144,7 +299,7 @@ func setupControllers(mgr ctrl.Manager, certsReady chan struct{}) {
 	setupLog.Info("certs ready")
 
 	// Set up JobSet controller.
-	jobSetController := controllers.NewJobSetReconciler(mgr.GetClient(), mgr.GetScheme(), mgr.GetEventRecorderFor("jobset"))
+	jobSetController := controllers.NewJobSetReconciler(mgr.GetClient(), mgr.GetScheme(), mgr.GetEventRecorderFor("jobset"), reconcilerOpts)
 	if err := jobSetController.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "JobSet")
 		os.Exit(1)
@@ -158,7 +313,7 @@ func setupControllers(mgr ctrl.Manager, certsReady chan struct{}) {
 	}
 
 	// Set up JobSet validating/defaulting webhook.
-	jobSetWebHook, err := webhooks.NewJobSetWebhook(mgr.GetClient())
+	jobSetWebHook, err := webhooks.NewJobSetWebhook(mgr.GetClient(), webhookOpts)
 	if err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "JobSet")
 		os.Exit(1)
@@ -16,6 +16,8 @@ limitations under the License.
 
 package constants
 
+import "time"
+
 const (
 	// JobOwnerKey is the field used to build the JobSet index, which enables looking up Jobs
 	// by the owner JobSet quickly.
@@ -29,6 +31,52 @@ const (
 	// the JobSet controller can perform.
 	MaxParallelism = 50
 
+	// PlacementInitContainerName is the name of the init container optionally injected into
+	// pods of exclusive placement Jobs, which waits until their node is labeled for the Job.
+	PlacementInitContainerName = "jobset-placement-init"
+
+	// InstanceIndexEnvVar is the environment variable injected into the containers of the
+	// child Jobs of a JobSet with spec.instances set, containing the instance index.
+	InstanceIndexEnvVar = "INSTANCE_INDEX"
+
+	// RestartCountEnvVar is the environment variable injected into the containers of the child
+	// Jobs of a JobSet with spec.injectRestartCountEnvVar set, containing the restart count.
+	RestartCountEnvVar = "JOBSET_RESTART_COUNT"
+
+	// DefaultCleanupFinalizerTimeout is the default time after the deletion of a JobSet, after
+	// which the cleanup finalizer is removed even if the child Jobs are not deleted yet.
+	DefaultCleanupFinalizerTimeout = 5 * time.Minute
+
+	// InsufficientCapacityRequeueInterval is the interval at which the cluster capacity is
+	// checked again while the creation of child Jobs is deferred due to insufficient capacity.
+	InsufficientCapacityRequeueInterval = 30 * time.Second
+
+	// NetworkReadyPollInterval is the interval at which the endpoints of the headless service
+	// are checked again while the network of an active JobSet is not ready.
+	NetworkReadyPollInterval = 5 * time.Second
+
+	// UnscheduledPodsPollInterval is the interval at which the pods of the active child Jobs of
+	// a JobSet are checked again while some of them are not scheduled, to record the Jobs with
+	// unschedulable pods in the JobSet status.
+	UnscheduledPodsPollInterval = 10 * time.Second
+
+	// IncompleteJobListingRequeueInterval is the interval after which the child Jobs of a JobSet
+	// are listed again when the previous listing missed some of them.
+	IncompleteJobListingRequeueInterval = 5 * time.Second
+
+	// MaxIncompleteJobListingRetries is the number of consecutive incomplete listings of the child
+	// Jobs of a JobSet which are retried, before the listing is taken as is since the missing
+	// finished Jobs were likely deleted.
+	MaxIncompleteJobListingRetries = 3
+
+	// DefaultFieldManager is the default field manager name of the server-side applies of the
+	// child Jobs and Services of JobSets.
+	DefaultFieldManager = "jobset-controller"
+
+	// DefaultReconcileErrorBackoff is the default time after which a JobSet whose reconciliation
+	// failed repeatedly is reconciled again.
+	DefaultReconcileErrorBackoff = 5 * time.Minute
+
 	// Event reason and message for when a JobSet fails due to reaching max restarts
 	// defined in its failure policy.
 	ReachedMaxRestartsReason  = "ReachedMaxRestarts"
@@ -40,6 +88,19 @@ const (
 	FailedJobsReason  = "FailedJobs"
 	FailedJobsMessage = "jobset failed due to one or more job failures"
 
+	// Event reason and message for when a JobSet fails due to exceeding its active deadline.
+	DeadlineExceededReason  = "DeadlineExceeded"
+	DeadlineExceededMessage = "jobset was active longer than specified deadline"
+
+	// Reason and message of the JobFailed condition set in memory on the active Jobs of a
+	// replicated job exceeding its completion timeout, to feed them into the failure policy.
+	CompletionTimeoutExceededReason  = "CompletionTimeoutExceeded"
+	CompletionTimeoutExceededMessage = "replicated job was active longer than its completion timeout"
+
+	// Reason and message for when a draining JobSet fails since some of its child Jobs failed.
+	DrainedReason                = "Drained"
+	DrainedWithFailedJobsMessage = "jobset was drained with one or more job failures"
+
 	// Event reason and message for when a Jobset completes successfully.
 	AllJobsCompletedReason  = "AllJobsCompleted"
 	AllJobsCompletedMessage = "jobset completed successfully"
@@ -64,11 +125,92 @@ const (
 	// Event reason and messages related to JobSet restarts.
 	JobSetRestartReason = "Restarting"
 
+	// Event message for when a JobSet restart is triggered by a change of the restart annotation.
+	RestartTriggeredMessage = "jobset restart triggered by a change of the restart annotation"
+
 	// Event reason and messages related to suspending a JobSet.
 	JobSetSuspendedReason  = "SuspendedJobs"
 	JobSetSuspendedMessage = "jobset is suspended"
 
+	// Event reasons and messages related to the JobSet readiness.
+	AllJobsReadyReason     = "AllJobsReady"
+	AllJobsReadyMessage    = "all jobs of the jobset are ready"
+	NotAllJobsReadyReason  = "NotAllJobsReady"
+	NotAllJobsReadyMessage = "not all jobs of the jobset are ready"
+
 	// Event reason and message related to resuming a JobSet.
 	JobSetResumedReason  = "ResumeJobs"
 	JobSetResumedMessage = "jobset is resumed"
+
+	// Reasons and messages for the Paused condition.
+	JobSetPausedReason    = "Paused"
+	JobSetPausedMessage   = "jobset is paused, the controller does not act on it"
+	JobSetUnpausedReason  = "Unpaused"
+	JobSetUnpausedMessage = "jobset is no longer paused"
+
+	// Reasons and messages for the AdmissionPending condition.
+	AdmissionPendingReason  = "WaitingForExternalController"
+	AdmissionPendingMessage = "jobset is suspended until it is admitted by its external controller"
+	AdmittedReason          = "Admitted"
+	AdmittedMessage         = "jobset was resumed by its external controller"
+
+	// Message suffixes for the InsufficientSuccessfulJobs condition, whose message is prefixed
+	// with the number of succeeded and required jobs (e.g. "12/20 succeeded before deadline").
+	// The reason of the condition is the reason the JobSet failed.
+	InsufficientSuccessfulJobsDeadlineMessage = "succeeded before deadline"
+	InsufficientSuccessfulJobsFailedMessage   = "succeeded before the jobset failed"
+
+	// Event reason and message for when the cleanup of a deleted JobSet times out.
+	CleanupTimedOutReason  = "CleanupTimedOut"
+	CleanupTimedOutMessage = "timed out waiting for child jobs to be deleted, removing the cleanup finalizer"
+
+	// Reasons and message for the InsufficientCapacity condition.
+	InsufficientTopologyDomainsReason = "InsufficientTopologyDomains"
+	SufficientTopologyDomainsReason   = "SufficientTopologyDomains"
+	SufficientTopologyDomainsMessage  = "enough topology domains are available for all replicated jobs"
+
+	// Reasons and message for the NetworkServiceConflict condition.
+	HeadlessServiceConflictReason = "HeadlessServiceConflict"
+	HeadlessServiceValidReason    = "HeadlessServiceValid"
+	HeadlessServiceValidMessage   = "the headless service selects the pods of the jobset"
+
+	// Reasons and messages for the NetworkManagementDisabled condition.
+	NetworkManagementDisabledReason  = "NetworkManagementDisabled"
+	NetworkManagementDisabledMessage = "dns hostnames are enabled but the controller does not manage services, the headless service %q must be created out-of-band"
+	NetworkManagementEnabledReason   = "NetworkManagementEnabled"
+	NetworkManagementEnabledMessage  = "the controller manages the headless service of the jobset"
+
+	// Reasons and messages for the RestartLimitApproaching condition.
+	RestartLimitApproachingReason  = "RestartLimitApproaching"
+	RestartLimitApproachingMessage = "jobset restarted at least %d times out of the %d restarts allowed by its failure policy"
+	RestartLimitNotReachedReason   = "RestartLimitNotReached"
+	RestartLimitNotReachedMessage  = "jobset restarts are below the warning threshold of its restart limit"
+
+	// Reasons and messages for the ReconcileBackingOff condition.
+	ReconcileBackingOffReason  = "ReconcileBackingOff"
+	ReconcileBackingOffMessage = "reconciliation failed %d consecutive times, retrying every %s: %v"
+	ReconcileSucceededReason   = "ReconcileSucceeded"
+	ReconcileSucceededMessage  = "reconciliation succeeded"
+
+	// Reasons and messages for the PotentialDeadlock condition.
+	StartupStalledReason      = "StartupStalled"
+	StartupStalledMessage     = "in order startup made no progress starting replicated job %q for %s"
+	StartupProgressingReason  = "StartupProgressing"
+	StartupProgressingMessage = "in order startup is making progress"
+
+	// Reasons and messages for the JobKeyCollision condition.
+	JobKeyCollisionReason  = "JobKeyCollision"
+	JobKeyCollisionMessage = "child jobs share the same job key: %s"
+	JobKeysDistinctReason  = "JobKeysDistinct"
+	JobKeysDistinctMessage = "all child jobs have distinct job keys"
+
+	// Reasons and messages for the NetworkReady condition.
+	HeadlessServiceNotFoundReason     = "HeadlessServiceNotFound"
+	HeadlessServiceNotFoundMessage    = "the headless service %q does not exist yet"
+	HeadlessServiceNoEndpointsReason  = "HeadlessServiceNoEndpoints"
+	HeadlessServiceNoEndpointsMessage = "the headless service %q has no endpoints yet"
+	HeadlessServiceReadyReason        = "HeadlessServiceReady"
+	HeadlessServiceReadyMessage       = "the headless service %q has endpoints, pod dns hostnames can be resolved"
+	DNSHostnamesDisabledReason        = "DNSHostnamesDisabled"
+	DNSHostnamesDisabledMessage       = "pod dns hostnames are disabled, the jobset does not need a headless service"
 )
@@ -0,0 +1,409 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/ptr"
+
+	jobset "sigs.k8s.io/jobset/api/jobset/v1alpha2"
+	"sigs.k8s.io/jobset/pkg/constants"
+	"sigs.k8s.io/jobset/pkg/util/collections"
+	"sigs.k8s.io/jobset/pkg/util/placement"
+)
+
+// NumInstances returns the number of instances of the replicated jobs the JobSet creates.
+func NumInstances(js *jobset.JobSet) int {
+	return int(ptr.Deref(js.Spec.Instances, 1))
+}
+
+// expectedJobs returns the number of child Jobs the JobSet creates for the replicated job,
+// across all instances.
+func expectedJobs(js *jobset.JobSet, rjob *jobset.ReplicatedJob) int32 {
+	return replicatedJobReplicas(js, rjob) * int32(NumInstances(js))
+}
+
+// numJobsExpected returns the number of child Jobs the JobSet creates for all of its
+// replicated jobs, across all instances.
+func numJobsExpected(js *jobset.JobSet) int {
+	total := 0
+	for i := range js.Spec.ReplicatedJobs {
+		total += int(expectedJobs(js, &js.Spec.ReplicatedJobs[i]))
+	}
+	return total
+}
+
+// addInstanceIndexEnvVar injects the instance index environment variable into all containers
+// of the pod spec.
+func addInstanceIndexEnvVar(podSpec *corev1.PodSpec, instanceIdx int) {
+	env := corev1.EnvVar{Name: constants.InstanceIndexEnvVar, Value: strconv.Itoa(instanceIdx)}
+	for i := range podSpec.InitContainers {
+		podSpec.InitContainers[i].Env = append(podSpec.InitContainers[i].Env, env)
+	}
+	for i := range podSpec.Containers {
+		podSpec.Containers[i].Env = append(podSpec.Containers[i].Env, env)
+	}
+}
+
+// ParseReplicasAnnotation parses the value of the jobset.ReplicasKey annotation into the
+// replicas of each replicated job it lists.
+func ParseReplicasAnnotation(value string) (map[string]int32, error) {
+	replicas := map[string]int32{}
+	for _, entry := range strings.Split(value, ",") {
+		name, count, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid entry %q, must be <replicatedJob>=<replicas>", entry)
+		}
+		if _, ok := replicas[name]; ok {
+			return nil, fmt.Errorf("duplicate replicated job %q", name)
+		}
+		n, err := strconv.ParseInt(count, 10, 32)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid replicas %q of replicated job %q, must be a non-negative integer", count, name)
+		}
+		replicas[name] = int32(n)
+	}
+	return replicas, nil
+}
+
+// replicatedJobReplicas returns the number of replicas the JobSet runs for the replicated job,
+// which is taken from the jobset.ReplicasKey annotation if it lists the replicated job.
+// An invalid annotation, which is rejected by the webhook, is ignored.
+func replicatedJobReplicas(js *jobset.JobSet, rjob *jobset.ReplicatedJob) int32 {
+	value, ok := js.Annotations[jobset.ReplicasKey]
+	if !ok {
+		return rjob.Replicas
+	}
+	replicas, err := ParseReplicasAnnotation(value)
+	if err != nil {
+		return rjob.Replicas
+	}
+	if n, ok := replicas[rjob.Name]; ok {
+		return n
+	}
+	return rjob.Replicas
+}
+
+// jobBeyondReplicas returns true if the index of the child Job is not part of the replicas of
+// its replicated job anymore, because the replicas were scaled down by the jobset.ReplicasKey
+// annotation.
+func jobBeyondReplicas(js *jobset.JobSet, job *batchv1.Job) bool {
+	jobIdx, err := strconv.Atoi(job.Labels[jobset.JobIndexKey])
+	if err != nil || jobIdx > math.MaxInt32 {
+		return false
+	}
+	for i := range js.Spec.ReplicatedJobs {
+		if rjob := &js.Spec.ReplicatedJobs[i]; rjob.Name == job.Labels[jobset.ReplicatedJobNameKey] {
+			return int32(jobIdx) >= replicatedJobReplicas(js, rjob)
+		}
+	}
+	return false
+}
+
+// currentJobNames returns the names of the child Jobs of the current replicas of the replicated
+// jobs, across all instances. The replicas are read from the JobSet on every reconciliation, as
+// they can be scaled by the jobset.ReplicasKey annotation while the JobSet runs.
+func currentJobNames(js *jobset.JobSet) (sets.Set[string], error) {
+	names := sets.New[string]()
+	for i := range js.Spec.ReplicatedJobs {
+		rjob := &js.Spec.ReplicatedJobs[i]
+		for instanceIdx := 0; instanceIdx < NumInstances(js); instanceIdx++ {
+			for jobIdx := 0; jobIdx < int(replicatedJobReplicas(js, rjob)); jobIdx++ {
+				name, err := GenJobName(js, rjob.Name, instanceIdx, jobIdx)
+				if err != nil {
+					return nil, err
+				}
+				names.Insert(name)
+			}
+		}
+	}
+	return names, nil
+}
+
+// jobNameTemplateData contains the values which can be referenced in spec.jobNameTemplate.
+type jobNameTemplateData struct {
+	JobSet        string
+	ReplicatedJob string
+	Instance      int
+	Index         int
+}
+
+// GenJobName returns the name of the child Job with the given index in the given ReplicatedJob
+// and instance. The name is rendered from spec.jobNameTemplate if set. Otherwise it is
+// <jobSetName>-<instanceIndex>-<replicatedJobName>-<jobIndex> if spec.instances is set, and
+// <jobSetName>-<replicatedJobName>-<jobIndex> if not.
+func GenJobName(js *jobset.JobSet, rjobName string, instanceIdx, jobIdx int) (string, error) {
+	if js.Spec.JobNameTemplate == "" {
+		if js.Spec.Instances != nil {
+			return placement.GenJobName(fmt.Sprintf("%s-%d", js.Name, instanceIdx), rjobName, jobIdx), nil
+		}
+		return placement.GenJobName(js.Name, rjobName, jobIdx), nil
+	}
+	tmpl, err := template.New("jobName").Option("missingkey=error").Parse(js.Spec.JobNameTemplate)
+	if err != nil {
+		return "", fmt.Errorf("parsing job name template: %w", err)
+	}
+	var name strings.Builder
+	data := jobNameTemplateData{JobSet: js.Name, ReplicatedJob: rjobName, Instance: instanceIdx, Index: jobIdx}
+	if err := tmpl.Execute(&name, data); err != nil {
+		return "", fmt.Errorf("rendering job name template: %w", err)
+	}
+	return name.String(), nil
+}
+
+// jobKey returns the JobKey of the child Job with the given name, hashed with the hash function
+// selected by the JobKeyHashKey annotation of the JobSet. It defaults to the SHA1 hash, so JobSets
+// without the annotation keep the keys of their existing child Jobs.
+func jobKey(js *jobset.JobSet, jobName string) string {
+	if js.Annotations[jobset.JobKeyHashKey] == jobset.JobKeyHashSHA256 {
+		return sha256Hash(fmt.Sprintf("%s/%s", js.Namespace, jobName))
+	}
+	return jobHashKey(js.Namespace, jobName)
+}
+
+// sha256Hash accepts an input string and returns the SHA256 hash digest of the input string,
+// truncated to the 63 characters allowed in a label value.
+func sha256Hash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])[:validation.LabelValueMaxLength]
+}
+
+// findJobKeyCollisions returns a message listing the child Jobs of the current run of the JobSet
+// sharing the same JobKey label, or an empty string if all of them have distinct keys. Jobs of
+// previous runs are skipped, since their recreated Jobs have the same names and keys.
+func findJobKeyCollisions(ownedJobs *childJobs) string {
+	jobsByKey := map[string][]string{}
+	for _, job := range collections.Concat(ownedJobs.active, ownedJobs.successful, ownedJobs.failed) {
+		key, ok := job.Labels[jobset.JobKey]
+		if !ok {
+			continue
+		}
+		jobsByKey[key] = append(jobsByKey[key], job.Name)
+	}
+	var collisions []string
+	for _, names := range jobsByKey {
+		if len(names) < 2 {
+			continue
+		}
+		sort.Strings(names)
+		collisions = append(collisions, strings.Join(names, ", "))
+	}
+	if len(collisions) == 0 {
+		return ""
+	}
+	sort.Strings(collisions)
+	return fmt.Sprintf(constants.JobKeyCollisionMessage, "["+strings.Join(collisions, "], [")+"]")
+}
+
+// setJobKeyCollisionCondition sets the JobKeyCollision condition of the JobSet based on the message
+// describing the collisions, if any. A JobSet without collisions only gets the condition if it
+// previously had collisions.
+func setJobKeyCollisionCondition(js *jobset.JobSet, collisions string, updateStatusOpts *statusUpdateOpts) {
+	if collisions == "" {
+		if meta.FindStatusCondition(js.Status.Conditions, string(jobset.JobSetJobKeyCollision)) == nil {
+			return
+		}
+		setStatusCondition(js, &conditionOpts{
+			eventType: corev1.EventTypeNormal,
+			condition: &metav1.Condition{
+				Type:    string(jobset.JobSetJobKeyCollision),
+				Status:  metav1.ConditionFalse,
+				Reason:  constants.JobKeysDistinctReason,
+				Message: constants.JobKeysDistinctMessage,
+			},
+		}, updateStatusOpts)
+		return
+	}
+	setStatusCondition(js, &conditionOpts{
+		eventType: corev1.EventTypeWarning,
+		condition: &metav1.Condition{
+			Type:    string(jobset.JobSetJobKeyCollision),
+			Status:  metav1.ConditionTrue,
+			Reason:  constants.JobKeyCollisionReason,
+			Message: collisions,
+		},
+	}, updateStatusOpts)
+}
+
+// jobTemplateForIndex returns the Job template of the Job at the given index of the
+// ReplicatedJob, with the referenced pod template (if any) resolved, and the indexed
+// overrides targeting the index applied in order. Once the JobSet has been restarted, the
+// restart overrides of its failure policy are applied last.
+func jobTemplateForIndex(js *jobset.JobSet, rjob *jobset.ReplicatedJob, jobIdx int) (*batchv1.JobTemplateSpec, error) {
+	template := rjob.Template.DeepCopy()
+	if rjob.PodTemplateName != "" {
+		podTemplate, err := podTemplateForReplicatedJob(js, rjob)
+		if err != nil {
+			return nil, err
+		}
+		template.Spec.Template = *podTemplate.DeepCopy()
+	}
+	for i, override := range rjob.IndexedOverrides {
+		if !IndexedOverrideApplies(&override, jobIdx) {
+			continue
+		}
+		patched, err := ApplyIndexedOverride(template, override.Patch.Raw)
+		if err != nil {
+			return nil, fmt.Errorf("applying indexed override %d of replicatedJob %q: %w", i, rjob.Name, err)
+		}
+		template = patched
+	}
+	if patch := onRestartOverrides(js, rjob); js.Status.Restarts > 0 && patch != nil && len(patch.Raw) > 0 {
+		patched, err := ApplyIndexedOverride(template, patch.Raw)
+		if err != nil {
+			return nil, fmt.Errorf("applying restart overrides of replicatedJob %q: %w", rjob.Name, err)
+		}
+		template = patched
+	}
+	return template, nil
+}
+
+// onRestartOverrides returns the patch applied to the Job template of the replicatedJob when
+// its Jobs are recreated by a restart. The failure policy of the replicatedJob takes
+// precedence over the failure policy of the JobSet.
+func onRestartOverrides(js *jobset.JobSet, rjob *jobset.ReplicatedJob) *runtime.RawExtension {
+	if rjob.FailurePolicy != nil && rjob.FailurePolicy.OnRestartOverrides != nil {
+		return rjob.FailurePolicy.OnRestartOverrides
+	}
+	if js.Spec.FailurePolicy != nil {
+		return js.Spec.FailurePolicy.OnRestartOverrides
+	}
+	return nil
+}
+
+// IndexedOverrideApplies returns true if the indexed override targets the given Job index.
+func IndexedOverrideApplies(override *jobset.IndexedOverride, jobIdx int) bool {
+	endIndex := ptr.Deref(override.EndIndex, override.StartIndex)
+	return int(override.StartIndex) <= jobIdx && jobIdx <= int(endIndex)
+}
+
+// ApplyIndexedOverride applies the strategic merge patch of an indexed override to a copy of
+// the given Job template.
+func ApplyIndexedOverride(template *batchv1.JobTemplateSpec, patch []byte) (*batchv1.JobTemplateSpec, error) {
+	original, err := json.Marshal(template)
+	if err != nil {
+		return nil, err
+	}
+	patched, err := strategicpatch.StrategicMergePatch(original, patch, batchv1.JobTemplateSpec{})
+	if err != nil {
+		return nil, err
+	}
+	var result batchv1.JobTemplateSpec
+	if err := json.Unmarshal(patched, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// JobDiff describes how an existing child Job differs from the Job the JobSet controller would
+// construct for it.
+type JobDiff struct {
+	// Name of the child Job.
+	Name string
+	// Fields are the paths of the parts of the Job which differ, e.g. spec.template.spec.
+	Fields []string
+}
+
+// DiffJobs compares the existing child Jobs of the current run of the JobSet to the Jobs the
+// JobSet controller would construct for them, e.g. to find the JobSets whose Jobs would differ
+// after a controller upgrade. Fields left unset in the constructed Job and labels or annotations
+// it does not set are not compared, as they are defaulted by the API server or set by other
+// controllers, and neither is spec.suspend, which changes over the lifetime of a Job. Jobs of
+// previous runs and Jobs the controller would not construct, e.g. beyond the replicas, are
+// skipped. The placementInitImage is the exclusive placement init container image the
+// controller is configured with, if any. The JobSet and the Jobs are not modified.
+func DiffJobs(js *jobset.JobSet, existing []batchv1.Job, placementInitImage string) ([]JobDiff, error) {
+	expected := map[string]*batchv1.Job{}
+	for i := range js.Spec.ReplicatedJobs {
+		rjob := &js.Spec.ReplicatedJobs[i]
+		for instanceIdx := 0; instanceIdx < NumInstances(js); instanceIdx++ {
+			for jobIdx := 0; jobIdx < int(replicatedJobReplicas(js, rjob)); jobIdx++ {
+				job, err := constructJob(js, rjob, instanceIdx, jobIdx, placementInitImage)
+				if err != nil {
+					return nil, err
+				}
+				expected[job.Name] = job
+			}
+		}
+	}
+
+	var diffs []JobDiff
+	for i := range existing {
+		job := &existing[i]
+		want, ok := expected[job.Name]
+		if !ok || job.Labels[constants.RestartsKey] != strconv.Itoa(int(js.Status.Restarts)) {
+			continue
+		}
+		// The pods of Jobs kept across restarts, by restart isolation or the dependency graph, keep
+		// the restart attempt their Job was created in.
+		if replicatedJobKeptAcrossRestarts(js, job.Labels[jobset.ReplicatedJobNameKey]) {
+			want.Spec.Template.Labels[constants.RestartsKey] = job.Spec.Template.Labels[constants.RestartsKey]
+			want.Spec.Template.Annotations[constants.RestartsKey] = job.Spec.Template.Annotations[constants.RestartsKey]
+		}
+		if fields := diffJob(want, job); len(fields) > 0 {
+			diffs = append(diffs, JobDiff{Name: job.Name, Fields: fields})
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Name < diffs[j].Name
+	})
+	return diffs, nil
+}
+
+// diffJob returns the paths of the parts of the existing Job which don't match the expected Job,
+// ignoring the fields the expected Job leaves unset.
+func diffJob(expected, existing *batchv1.Job) []string {
+	expectedSpec, existingSpec := expected.Spec.DeepCopy(), existing.Spec.DeepCopy()
+	expectedSpec.Suspend, existingSpec.Suspend = nil, nil
+	// The metadata and spec of the pod template are compared separately.
+	expectedTemplate, existingTemplate := expectedSpec.Template, existingSpec.Template
+	expectedSpec.Template, existingSpec.Template = expectedTemplate, expectedTemplate
+
+	parts := []struct {
+		path               string
+		expected, existing any
+	}{
+		{"metadata.labels", expected.Labels, existing.Labels},
+		{"metadata.annotations", expected.Annotations, existing.Annotations},
+		{"spec", expectedSpec, existingSpec},
+		{"spec.template.metadata.labels", expectedTemplate.Labels, existingTemplate.Labels},
+		{"spec.template.metadata.annotations", expectedTemplate.Annotations, existingTemplate.Annotations},
+		{"spec.template.spec", expectedTemplate.Spec, existingTemplate.Spec},
+	}
+	var fields []string
+	for _, part := range parts {
+		if !apiequality.Semantic.DeepDerivative(part.expected, part.existing) {
+			fields = append(fields, part.path)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,980 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	jobset "sigs.k8s.io/jobset/api/jobset/v1alpha2"
+	"sigs.k8s.io/jobset/pkg/constants"
+	testutils "sigs.k8s.io/jobset/pkg/util/testing"
+)
+
+func TestConstructJobsFromTemplateWithInstances(t *testing.T) {
+	js := testutils.MakeJobSet("test-jobset", "default").
+		Instances(2).
+		ReplicatedJob(testutils.MakeReplicatedJob("leader").
+			Job(testutils.MakeJobTemplate("job", "default").
+				PodSpec(corev1.PodSpec{Containers: []corev1.Container{{Name: "leader"}}}).
+				Obj()).
+			Replicas(1).
+			Obj()).
+		ReplicatedJob(testutils.MakeReplicatedJob("workers").
+			Job(testutils.MakeJobTemplate("job", "default").
+				PodSpec(corev1.PodSpec{
+					InitContainers: []corev1.Container{{Name: "init"}},
+					Containers:     []corev1.Container{{Name: "worker"}},
+				}).
+				Obj()).
+			Replicas(3).
+			Obj()).
+		Obj()
+
+	type jobInstance struct {
+		Name          string
+		InstanceLabel string
+		EnvVars       []string
+	}
+	var got []jobInstance
+	for i := range js.Spec.ReplicatedJobs {
+		jobs, err := constructJobsFromTemplate(context.TODO(), js, &js.Spec.ReplicatedJobs[i], &childJobs{}, "")
+		if err != nil {
+			t.Fatalf("constructJobsFromTemplate() error = %v", err)
+		}
+		for _, job := range jobs {
+			var envVars []string
+			podSpec := job.Spec.Template.Spec
+			for _, c := range append(podSpec.InitContainers, podSpec.Containers...) {
+				for _, env := range c.Env {
+					if env.Name == constants.InstanceIndexEnvVar {
+						envVars = append(envVars, c.Name+"="+env.Value)
+					}
+				}
+			}
+			if job.Spec.Template.Labels[jobset.InstanceIndexKey] != job.Labels[jobset.InstanceIndexKey] {
+				t.Errorf("job %q: pod instance label = %q, want %q", job.Name, job.Spec.Template.Labels[jobset.InstanceIndexKey], job.Labels[jobset.InstanceIndexKey])
+			}
+			got = append(got, jobInstance{Name: job.Name, InstanceLabel: job.Labels[jobset.InstanceIndexKey], EnvVars: envVars})
+		}
+	}
+
+	// Every Job of every replicated job is created once per instance.
+	want := []jobInstance{
+		{Name: "test-jobset-0-leader-0", InstanceLabel: "0", EnvVars: []string{"leader=0"}},
+		{Name: "test-jobset-1-leader-0", InstanceLabel: "1", EnvVars: []string{"leader=1"}},
+		{Name: "test-jobset-0-workers-0", InstanceLabel: "0", EnvVars: []string{"init=0", "worker=0"}},
+		{Name: "test-jobset-0-workers-1", InstanceLabel: "0", EnvVars: []string{"init=0", "worker=0"}},
+		{Name: "test-jobset-0-workers-2", InstanceLabel: "0", EnvVars: []string{"init=0", "worker=0"}},
+		{Name: "test-jobset-1-workers-0", InstanceLabel: "1", EnvVars: []string{"init=1", "worker=1"}},
+		{Name: "test-jobset-1-workers-1", InstanceLabel: "1", EnvVars: []string{"init=1", "worker=1"}},
+		{Name: "test-jobset-1-workers-2", InstanceLabel: "1", EnvVars: []string{"init=1", "worker=1"}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected jobs (-want +got):\n%s", diff)
+	}
+}
+
+func TestSetJobSetReadyConditionWithInstances(t *testing.T) {
+	js := testutils.MakeJobSet("test-jobset", "default").
+		Instances(2).
+		ReplicatedJob(testutils.MakeReplicatedJob("workers").Replicas(3).Obj()).
+		Obj()
+
+	tests := []struct {
+		name  string
+		ready int32
+		want  bool
+	}{
+		{
+			name:  "only the jobs of one instance are ready",
+			ready: 3,
+		},
+		{
+			name:  "the jobs of all instances are ready",
+			ready: 6,
+			want:  true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := js.DeepCopy()
+			statuses := []jobset.ReplicatedJobStatus{{Name: "workers", Ready: tc.ready}}
+			setJobSetReadyCondition(js, statuses, &statusUpdateOpts{})
+			if got := meta.IsStatusConditionTrue(js.Status.Conditions, string(jobset.JobSetReady)); got != tc.want {
+				t.Errorf("ready = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseReplicasAnnotation(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    map[string]int32
+		wantErr bool
+	}{
+		{
+			name:  "single replicated job",
+			value: "workers=5",
+			want:  map[string]int32{"workers": 5},
+		},
+		{
+			name:  "multiple replicated jobs",
+			value: "workers=5, driver=0",
+			want:  map[string]int32{"workers": 5, "driver": 0},
+		},
+		{
+			name:    "missing replicas",
+			value:   "workers",
+			wantErr: true,
+		},
+		{
+			name:    "negative replicas",
+			value:   "workers=-1",
+			wantErr: true,
+		},
+		{
+			name:    "replicas overflow",
+			value:   "workers=2147483648",
+			wantErr: true,
+		},
+		{
+			name:    "duplicate replicated job",
+			value:   "workers=1,workers=2",
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseReplicasAnnotation(tc.value)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("unexpected error: got %v, want error %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected replicas (-want/+got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestReconcileReplicasAnnotation(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	jobTemplate := testutils.MakeJobTemplate("job", ns).Obj()
+	jobTemplate.Spec.Parallelism = ptr.To[int32](1)
+	js := testutils.MakeJobSet(jobSetName, ns).
+		SuccessPolicy(&jobset.SuccessPolicy{Operator: jobset.OperatorAll}).
+		ReplicatedJob(testutils.MakeReplicatedJob("workers").Job(jobTemplate).Replicas(2).Obj()).
+		ReplicatedJob(testutils.MakeReplicatedJob("driver").Job(jobTemplate).Replicas(1).Obj()).
+		Obj()
+	js.UID = "test-uid"
+
+	fakeClient := newFakeClientBuilder().
+		WithObjects(js).
+		WithStatusSubresource(js).
+		Build()
+	r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(100), JobSetReconcilerOptions{})
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: jobSetName, Namespace: ns}}
+
+	// Each step sets the annotation and reconciles the JobSet, after which its Jobs must match.
+	steps := []struct {
+		annotation string
+		want       []string
+	}{
+		{
+			want: []string{"test-jobset-driver-0", "test-jobset-workers-0", "test-jobset-workers-1"},
+		},
+		{
+			annotation: "workers=4",
+			want:       []string{"test-jobset-driver-0", "test-jobset-workers-0", "test-jobset-workers-1", "test-jobset-workers-2", "test-jobset-workers-3"},
+		},
+		{
+			annotation: "workers=1,driver=0",
+			want:       []string{"test-jobset-workers-0"},
+		},
+		{
+			want: []string{"test-jobset-driver-0", "test-jobset-workers-0", "test-jobset-workers-1"},
+		},
+	}
+	for i, step := range steps {
+		var current jobset.JobSet
+		if err := fakeClient.Get(context.TODO(), req.NamespacedName, &current); err != nil {
+			t.Fatalf("step %d: unexpected error getting jobset: %v", i, err)
+		}
+		if step.annotation == "" {
+			delete(current.Annotations, jobset.ReplicasKey)
+		} else {
+			if current.Annotations == nil {
+				current.Annotations = map[string]string{}
+			}
+			current.Annotations[jobset.ReplicasKey] = step.annotation
+		}
+		if err := fakeClient.Update(context.TODO(), &current); err != nil {
+			t.Fatalf("step %d: unexpected error updating jobset: %v", i, err)
+		}
+
+		if _, err := r.Reconcile(context.TODO(), req); err != nil {
+			t.Fatalf("step %d: unexpected reconcile error: %v", i, err)
+		}
+
+		var jobs batchv1.JobList
+		if err := fakeClient.List(context.TODO(), &jobs, client.InNamespace(ns)); err != nil {
+			t.Fatalf("step %d: unexpected error listing jobs: %v", i, err)
+		}
+		var got []string
+		for _, job := range jobs.Items {
+			got = append(got, job.Name)
+		}
+		if diff := cmp.Diff(step.want, got); diff != "" {
+			t.Errorf("step %d: unexpected jobs (-want/+got): %s", i, diff)
+		}
+	}
+}
+
+func TestReconcileCompletionWithScaledReplicas(t *testing.T) {
+	tests := []struct {
+		name          string
+		replicas      int32
+		succeeded     []string
+		annotation    string
+		wantCompleted bool
+		wantJobs      []string
+	}{
+		{
+			name:      "jobset waits for the jobs of all replicas",
+			replicas:  3,
+			succeeded: []string{"test-jobset-workers-0", "test-jobset-workers-1"},
+			wantJobs:  []string{"test-jobset-workers-0", "test-jobset-workers-1", "test-jobset-workers-2"},
+		},
+		{
+			name:          "jobset completes once the jobs of the scaled down replicas succeeded",
+			replicas:      3,
+			succeeded:     []string{"test-jobset-workers-0", "test-jobset-workers-1"},
+			annotation:    "workers=2",
+			wantCompleted: true,
+			wantJobs:      []string{"test-jobset-workers-0", "test-jobset-workers-1"},
+		},
+		{
+			name:       "jobset waits for the jobs of the scaled up replicas",
+			replicas:   2,
+			succeeded:  []string{"test-jobset-workers-0", "test-jobset-workers-1"},
+			annotation: "workers=3",
+			wantJobs:   []string{"test-jobset-workers-0", "test-jobset-workers-1", "test-jobset-workers-2"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			jobTemplate := testutils.MakeJobTemplate("job", "default").Obj()
+			jobTemplate.Spec.Parallelism = ptr.To[int32](1)
+			js := testutils.MakeJobSet("test-jobset", "default").
+				SuccessPolicy(&jobset.SuccessPolicy{Operator: jobset.OperatorAll}).
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").Job(jobTemplate).Replicas(tc.replicas).Obj()).
+				Obj()
+			fakeClient := newFakeClientBuilder().
+				WithObjects(js).
+				WithStatusSubresource(js, &batchv1.Job{}).
+				Build()
+			r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(100), JobSetReconcilerOptions{})
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: js.Name, Namespace: js.Namespace}}
+			reconcileJobSet(t, r, req, 1)
+
+			// Complete the succeeded Jobs and scale the replicas, which are both seen by the next reconciliation.
+			for _, name := range tc.succeeded {
+				var job batchv1.Job
+				if err := fakeClient.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: js.Namespace}, &job); err != nil {
+					t.Fatalf("unexpected error getting job %s: %v", name, err)
+				}
+				job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+				if err := fakeClient.Status().Update(context.TODO(), &job); err != nil {
+					t.Fatalf("unexpected error updating job status: %v", err)
+				}
+			}
+			if tc.annotation != "" {
+				var current jobset.JobSet
+				if err := fakeClient.Get(context.TODO(), req.NamespacedName, &current); err != nil {
+					t.Fatalf("unexpected error getting jobset: %v", err)
+				}
+				current.Annotations = map[string]string{jobset.ReplicasKey: tc.annotation}
+				if err := fakeClient.Update(context.TODO(), &current); err != nil {
+					t.Fatalf("unexpected error updating jobset: %v", err)
+				}
+			}
+			reconcileJobSet(t, r, req, 1)
+
+			var got jobset.JobSet
+			if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+				t.Fatalf("unexpected error getting jobset: %v", err)
+			}
+			if completed := JobSetFinished(&got); completed != tc.wantCompleted {
+				t.Errorf("unexpected completion, want %v, got %v with conditions %v", tc.wantCompleted, completed, got.Status.Conditions)
+			}
+			var jobs batchv1.JobList
+			if err := fakeClient.List(context.TODO(), &jobs, client.InNamespace(js.Namespace)); err != nil {
+				t.Fatalf("unexpected error listing jobs: %v", err)
+			}
+			var gotJobs []string
+			for _, job := range jobs.Items {
+				gotJobs = append(gotJobs, job.Name)
+			}
+			if diff := cmp.Diff(tc.wantJobs, gotJobs); diff != "" {
+				t.Errorf("unexpected jobs (-want/+got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestGenJobName(t *testing.T) {
+	tests := []struct {
+		name        string
+		template    string
+		instances   *int32
+		instanceIdx int
+		jobIdx      int
+		want        string
+		wantErr     bool
+	}{
+		{
+			name:   "default job name",
+			jobIdx: 2,
+			want:   "test-jobset-workers-2",
+		},
+		{
+			name:     "custom job name template",
+			template: "{{.ReplicatedJob}}-{{.Index}}-of-{{.JobSet}}",
+			jobIdx:   2,
+			want:     "workers-2-of-test-jobset",
+		},
+		{
+			name:        "default job name with instances",
+			instances:   ptr.To[int32](3),
+			instanceIdx: 1,
+			jobIdx:      2,
+			want:        "test-jobset-1-workers-2",
+		},
+		{
+			name:        "job name template referencing the instance",
+			template:    "{{.JobSet}}-i{{.Instance}}-{{.ReplicatedJob}}-{{.Index}}",
+			instances:   ptr.To[int32](3),
+			instanceIdx: 1,
+			jobIdx:      2,
+			want:        "test-jobset-i1-workers-2",
+		},
+		{
+			name:     "template with pipeline",
+			template: `{{printf "%s-%03d" .ReplicatedJob .Index}}`,
+			jobIdx:   7,
+			want:     "workers-007",
+		},
+		{
+			name:     "template referencing unknown field",
+			template: "{{.Unknown}}-{{.Index}}",
+			wantErr:  true,
+		},
+		{
+			name:     "template which fails to parse",
+			template: "{{.Index",
+			wantErr:  true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet("test-jobset", "default").JobNameTemplate(tc.template).Obj()
+			js.Spec.Instances = tc.instances
+			got, err := GenJobName(js, "workers", tc.instanceIdx, tc.jobIdx)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("GenJobName() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("GenJobName() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestConstructJobsFromTemplateWithJobNameTemplate(t *testing.T) {
+	js := testutils.MakeJobSet("test-jobset", "default").
+		JobNameTemplate("train-{{.ReplicatedJob}}-{{.Index}}").
+		ReplicatedJob(testutils.MakeReplicatedJob("workers").
+			Job(testutils.MakeJobTemplate("job", "default").Obj()).
+			Replicas(3).
+			Obj()).
+		Obj()
+
+	// Jobs which already exist are not constructed again.
+	existing := makeJob(&makeJobArgs{
+		jobSetName:        js.Name,
+		replicatedJobName: "workers",
+		jobName:           "train-workers-1",
+		ns:                js.Namespace,
+		replicas:          3,
+		jobIdx:            1,
+	}).Obj()
+	jobs, err := constructJobsFromTemplate(context.TODO(), js, &js.Spec.ReplicatedJobs[0], &childJobs{active: []*batchv1.Job{existing}}, "")
+	if err != nil {
+		t.Fatalf("constructJobsFromTemplate() error = %v", err)
+	}
+	var got []string
+	for _, job := range jobs {
+		got = append(got, job.Name)
+		if want := jobHashKey(js.Namespace, job.Name); job.Labels[jobset.JobKey] != want {
+			t.Errorf("job %q: job key label = %q, want %q", job.Name, job.Labels[jobset.JobKey], want)
+		}
+	}
+	if diff := cmp.Diff([]string{"train-workers-0", "train-workers-2"}, got); diff != "" {
+		t.Errorf("unexpected job names (-want +got):\n%s", diff)
+	}
+}
+
+func TestJobKey(t *testing.T) {
+	longName := strings.Repeat("a", 60)
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantLen     int
+	}{
+		{
+			name:    "sha1 by default",
+			wantLen: 40,
+		},
+		{
+			name:        "sha1",
+			annotations: map[string]string{jobset.JobKeyHashKey: jobset.JobKeyHashSHA1},
+			wantLen:     40,
+		},
+		{
+			name:        "sha256 truncated to the maximum label value length",
+			annotations: map[string]string{jobset.JobKeyHashKey: jobset.JobKeyHashSHA256},
+			wantLen:     validation.LabelValueMaxLength,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet("js", "default").SetAnnotations(tc.annotations).Obj()
+			// Near-colliding names only differing in their last character.
+			key1 := jobKey(js, longName+"-0")
+			key2 := jobKey(js, longName+"-1")
+			if key1 == key2 {
+				t.Errorf("expected distinct job keys, got %q for both", key1)
+			}
+			for _, key := range []string{key1, key2} {
+				if len(key) != tc.wantLen {
+					t.Errorf("unexpected length of job key %q: got %d, want %d", key, len(key), tc.wantLen)
+				}
+				if errs := validation.IsValidLabelValue(key); len(errs) > 0 {
+					t.Errorf("job key %q is not a valid label value: %v", key, errs)
+				}
+			}
+			if key := jobKey(js, longName+"-0"); key != key1 {
+				t.Errorf("expected stable job key, got %q and %q", key1, key)
+			}
+		})
+	}
+}
+
+func TestJobKeyBackwardCompatible(t *testing.T) {
+	js := testutils.MakeJobSet("js", "default").Obj()
+	if got, want := jobKey(js, "js-workers-0"), jobHashKey("default", "js-workers-0"); got != want {
+		t.Errorf("unexpected default job key: got %q, want %q", got, want)
+	}
+}
+
+func TestFindJobKeyCollisions(t *testing.T) {
+	job := func(name, key string) *batchv1.Job {
+		return testutils.MakeJob(name, "default").JobLabels(map[string]string{jobset.JobKey: key}).Obj()
+	}
+	tests := []struct {
+		name      string
+		ownedJobs *childJobs
+		want      string
+	}{
+		{
+			name: "distinct job keys",
+			ownedJobs: &childJobs{
+				active:     []*batchv1.Job{job("js-a-0", "key-0")},
+				successful: []*batchv1.Job{job("js-a-1", "key-1")},
+				failed:     []*batchv1.Job{job("js-a-2", "key-2")},
+			},
+		},
+		{
+			name: "colliding job keys across active and finished jobs",
+			ownedJobs: &childJobs{
+				active:     []*batchv1.Job{job("js-b-0", "key-1"), job("js-a-0", "key-0")},
+				successful: []*batchv1.Job{job("js-a-1", "key-0")},
+				failed:     []*batchv1.Job{job("js-b-1", "key-1"), job("js-c-0", "key-2")},
+			},
+			want: "child jobs share the same job key: [js-a-0, js-a-1], [js-b-0, js-b-1]",
+		},
+		{
+			name: "jobs of previous runs are skipped",
+			ownedJobs: &childJobs{
+				active: []*batchv1.Job{job("js-a-0", "key-0")},
+				delete: []*batchv1.Job{job("js-a-0", "key-0")},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, findJobKeyCollisions(tc.ownedJobs)); diff != "" {
+				t.Errorf("unexpected collisions (-want/+got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestSetJobKeyCollisionCondition(t *testing.T) {
+	tests := []struct {
+		name             string
+		conditions       []metav1.Condition
+		collisions       string
+		wantCondition    *metav1.Condition
+		wantShouldUpdate bool
+	}{
+		{
+			name: "no collisions without previous condition",
+		},
+		{
+			name:       "collisions",
+			collisions: "child jobs share the same job key: [js-a-0, js-a-1]",
+			wantCondition: &metav1.Condition{
+				Type:    string(jobset.JobSetJobKeyCollision),
+				Status:  metav1.ConditionTrue,
+				Reason:  constants.JobKeyCollisionReason,
+				Message: "child jobs share the same job key: [js-a-0, js-a-1]",
+			},
+			wantShouldUpdate: true,
+		},
+		{
+			name: "different collisions",
+			conditions: []metav1.Condition{{
+				Type:    string(jobset.JobSetJobKeyCollision),
+				Status:  metav1.ConditionTrue,
+				Reason:  constants.JobKeyCollisionReason,
+				Message: "child jobs share the same job key: [js-a-0, js-a-1]",
+			}},
+			collisions: "child jobs share the same job key: [js-b-0, js-b-1]",
+			wantCondition: &metav1.Condition{
+				Type:    string(jobset.JobSetJobKeyCollision),
+				Status:  metav1.ConditionTrue,
+				Reason:  constants.JobKeyCollisionReason,
+				Message: "child jobs share the same job key: [js-b-0, js-b-1]",
+			},
+			wantShouldUpdate: true,
+		},
+		{
+			name: "collisions resolved",
+			conditions: []metav1.Condition{{
+				Type:    string(jobset.JobSetJobKeyCollision),
+				Status:  metav1.ConditionTrue,
+				Reason:  constants.JobKeyCollisionReason,
+				Message: "child jobs share the same job key: [js-a-0, js-a-1]",
+			}},
+			wantCondition: &metav1.Condition{
+				Type:    string(jobset.JobSetJobKeyCollision),
+				Status:  metav1.ConditionFalse,
+				Reason:  constants.JobKeysDistinctReason,
+				Message: constants.JobKeysDistinctMessage,
+			},
+			wantShouldUpdate: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet("js", "default").Obj()
+			js.Status.Conditions = tc.conditions
+			opts := &statusUpdateOpts{}
+			setJobKeyCollisionCondition(js, tc.collisions, opts)
+			if opts.shouldUpdate != tc.wantShouldUpdate {
+				t.Errorf("unexpected shouldUpdate: got %t, want %t", opts.shouldUpdate, tc.wantShouldUpdate)
+			}
+			got := meta.FindStatusCondition(js.Status.Conditions, string(jobset.JobSetJobKeyCollision))
+			if diff := cmp.Diff(tc.wantCondition, got, cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("unexpected condition (-want/+got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestIndexedOverrideApplies(t *testing.T) {
+	tests := []struct {
+		name     string
+		override jobset.IndexedOverride
+		jobIdx   int
+		want     bool
+	}{
+		{
+			name:     "single index matches",
+			override: jobset.IndexedOverride{StartIndex: 1},
+			jobIdx:   1,
+			want:     true,
+		},
+		{
+			name:     "single index does not match other index",
+			override: jobset.IndexedOverride{StartIndex: 1},
+			jobIdx:   2,
+			want:     false,
+		},
+		{
+			name:     "range includes start index",
+			override: jobset.IndexedOverride{StartIndex: 1, EndIndex: ptr.To[int32](3)},
+			jobIdx:   1,
+			want:     true,
+		},
+		{
+			name:     "range includes end index",
+			override: jobset.IndexedOverride{StartIndex: 1, EndIndex: ptr.To[int32](3)},
+			jobIdx:   3,
+			want:     true,
+		},
+		{
+			name:     "range excludes index before start",
+			override: jobset.IndexedOverride{StartIndex: 1, EndIndex: ptr.To[int32](3)},
+			jobIdx:   0,
+			want:     false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := IndexedOverrideApplies(&tc.override, tc.jobIdx); got != tc.want {
+				t.Errorf("IndexedOverrideApplies() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestConstructJobsFromTemplateWithIndexedOverrides(t *testing.T) {
+	var (
+		jobSetName        = "test-jobset"
+		replicatedJobName = "replicated-job"
+		jobName           = "test-job"
+		ns                = "default"
+	)
+	jobTemplate := testutils.MakeJobTemplate(jobName, ns).Obj()
+	jobTemplate.Spec.Template.Spec.Containers = []corev1.Container{{Name: "main", Image: "busybox"}}
+
+	js := testutils.MakeJobSet(jobSetName, ns).
+		ReplicatedJob(testutils.MakeReplicatedJob(replicatedJobName).
+			Job(jobTemplate).
+			Replicas(4).
+			IndexedOverrides(
+				jobset.IndexedOverride{
+					StartIndex: 0,
+					Patch: runtime.RawExtension{Raw: []byte(`{"metadata":{"labels":{"role":"primary"}},` +
+						`"spec":{"template":{"spec":{"containers":[{"name":"main","resources":{"limits":{"memory":"2Gi"}}}]}}}}`)},
+				},
+				jobset.IndexedOverride{
+					StartIndex: 2,
+					EndIndex:   ptr.To[int32](3),
+					Patch:      runtime.RawExtension{Raw: []byte(`{"spec":{"template":{"spec":{"nodeSelector":{"pool":"spot"}}}}}`)},
+				},
+			).
+			Obj()).
+		Obj()
+
+	jobs, err := constructJobsFromTemplate(context.TODO(), js, &js.Spec.ReplicatedJobs[0], &childJobs{}, "")
+	if err != nil {
+		t.Fatalf("constructJobsFromTemplate() error = %v", err)
+	}
+	if len(jobs) != 4 {
+		t.Fatalf("expected 4 jobs, got %d", len(jobs))
+	}
+	for idx, job := range jobs {
+		wantPrimary := idx == 0
+		if gotPrimary := job.Labels["role"] == "primary"; gotPrimary != wantPrimary {
+			t.Errorf("job %d: has primary label = %v, want %v", idx, gotPrimary, wantPrimary)
+		}
+		// Labels of the JobSet must still be set on overridden jobs.
+		if job.Labels[jobset.JobIndexKey] == "" {
+			t.Errorf("job %d: missing job index label", idx)
+		}
+		container := job.Spec.Template.Spec.Containers[0]
+		if container.Image != "busybox" {
+			t.Errorf("job %d: container image = %q, want %q", idx, container.Image, "busybox")
+		}
+		gotLimit, hasLimit := container.Resources.Limits[corev1.ResourceMemory]
+		if hasLimit != wantPrimary || (hasLimit && !gotLimit.Equal(resource.MustParse("2Gi"))) {
+			t.Errorf("job %d: memory limit = %v (set: %v), want set only on job 0", idx, gotLimit.String(), hasLimit)
+		}
+		wantSpot := idx >= 2
+		if gotSpot := job.Spec.Template.Spec.NodeSelector["pool"] == "spot"; gotSpot != wantSpot {
+			t.Errorf("job %d: has spot node selector = %v, want %v", idx, gotSpot, wantSpot)
+		}
+	}
+
+	// The overrides must not modify the template of the ReplicatedJob.
+	if len(js.Spec.ReplicatedJobs[0].Template.Labels) != 0 || js.Spec.ReplicatedJobs[0].Template.Spec.Template.Spec.NodeSelector != nil {
+		t.Errorf("indexed overrides modified the replicatedJob template")
+	}
+
+	// A patch that cannot be applied to the Job template results in an error.
+	js.Spec.ReplicatedJobs[0].IndexedOverrides[0].Patch.Raw = []byte(`{"spec":{"parallelism":"two"}}`)
+	if _, err := constructJobsFromTemplate(context.TODO(), js, &js.Spec.ReplicatedJobs[0], &childJobs{}, ""); err == nil {
+		t.Errorf("expected an error for an invalid indexed override patch")
+	}
+}
+
+func TestConstructJobsFromTemplateWithOnRestartOverrides(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		jobName    = "test-job"
+		ns         = "default"
+	)
+	jobTemplate := testutils.MakeJobTemplate(jobName, ns).Obj()
+	jobTemplate.Spec.Parallelism = ptr.To[int32](4)
+	jobTemplate.Spec.BackoffLimit = ptr.To[int32](0)
+	restartPatch := &runtime.RawExtension{Raw: []byte(`{"spec":{"parallelism":2,"backoffLimit":3}}`)}
+
+	tests := []struct {
+		name             string
+		restarts         int32
+		jobSetPolicy     *jobset.FailurePolicy
+		rjobPolicy       *jobset.FailurePolicy
+		wantParallelism  int32
+		wantBackoffLimit int32
+	}{
+		{
+			name:             "first run does not carry the overrides",
+			jobSetPolicy:     &jobset.FailurePolicy{MaxRestarts: 1, OnRestartOverrides: restartPatch},
+			wantParallelism:  4,
+			wantBackoffLimit: 0,
+		},
+		{
+			name:             "recreated jobs carry the overrides of the jobset failure policy",
+			restarts:         1,
+			jobSetPolicy:     &jobset.FailurePolicy{MaxRestarts: 1, OnRestartOverrides: restartPatch},
+			wantParallelism:  2,
+			wantBackoffLimit: 3,
+		},
+		{
+			name:             "overrides of the replicatedJob failure policy take precedence",
+			restarts:         1,
+			jobSetPolicy:     &jobset.FailurePolicy{MaxRestarts: 1, OnRestartOverrides: restartPatch},
+			rjobPolicy:       &jobset.FailurePolicy{OnRestartOverrides: &runtime.RawExtension{Raw: []byte(`{"spec":{"parallelism":1}}`)}},
+			wantParallelism:  1,
+			wantBackoffLimit: 0,
+		},
+		{
+			name:             "recreated jobs without overrides",
+			restarts:         1,
+			jobSetPolicy:     &jobset.FailurePolicy{MaxRestarts: 1},
+			wantParallelism:  4,
+			wantBackoffLimit: 0,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet(jobSetName, ns).
+				FailurePolicy(tc.jobSetPolicy).
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").
+					Job(jobTemplate).
+					Replicas(2).
+					Obj()).
+				Obj()
+			js.Spec.ReplicatedJobs[0].FailurePolicy = tc.rjobPolicy
+			js.Status.Restarts = tc.restarts
+
+			jobs, err := constructJobsFromTemplate(context.TODO(), js, &js.Spec.ReplicatedJobs[0], &childJobs{}, "")
+			if err != nil {
+				t.Fatalf("constructJobsFromTemplate() error = %v", err)
+			}
+			if len(jobs) != 2 {
+				t.Fatalf("expected 2 jobs, got %d", len(jobs))
+			}
+			for idx, job := range jobs {
+				if got := ptr.Deref(job.Spec.Parallelism, 0); got != tc.wantParallelism {
+					t.Errorf("job %d: parallelism = %d, want %d", idx, got, tc.wantParallelism)
+				}
+				if got := ptr.Deref(job.Spec.BackoffLimit, 0); got != tc.wantBackoffLimit {
+					t.Errorf("job %d: backoffLimit = %d, want %d", idx, got, tc.wantBackoffLimit)
+				}
+			}
+		})
+	}
+}
+
+func TestDiffJobs(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	makeJS := func(image string) *jobset.JobSet {
+		js := testutils.MakeJobSet(jobSetName, ns).
+			ReplicatedJob(testutils.MakeReplicatedJob("workers").
+				Job(testutils.MakeJobTemplate("job", ns).
+					PodSpec(corev1.PodSpec{Containers: []corev1.Container{{Name: "main", Image: image}}}).
+					Obj()).
+				Replicas(2).
+				Obj()).
+			Obj()
+		js.Spec.ReplicatedJobs[0].Template.Spec.Parallelism = ptr.To[int32](2)
+		return js
+	}
+	// makeRestartedJS returns the JobSet after a restart which kept the Jobs of its restart
+	// isolated replicated job.
+	makeRestartedJS := func(image string) *jobset.JobSet {
+		js := makeJS(image)
+		js.Spec.ReplicatedJobs[0].RestartIsolation = ptr.To(true)
+		js.Status.Restarts = 1
+		return js
+	}
+	// existingJobs returns the Jobs constructed for the JobSet, with the fields set by the API
+	// server and the Job controller once created.
+	existingJobs := func(js *jobset.JobSet, placementInitImage string) []batchv1.Job {
+		t.Helper()
+		jobs, err := constructJobsFromTemplate(context.TODO(), js, &js.Spec.ReplicatedJobs[0], &childJobs{}, placementInitImage)
+		if err != nil {
+			t.Fatalf("constructJobsFromTemplate() error = %v", err)
+		}
+		var existing []batchv1.Job
+		for _, job := range jobs {
+			job.Labels["batch.kubernetes.io/controller-uid"] = "uid"
+			job.Spec.BackoffLimit = ptr.To[int32](6)
+			job.Spec.Template.Labels["batch.kubernetes.io/job-name"] = job.Name
+			job.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyOnFailure
+			job.Spec.Template.Spec.DNSPolicy = corev1.DNSClusterFirst
+			job.Spec.Template.Spec.SchedulerName = corev1.DefaultSchedulerName
+			job.Spec.Template.Spec.Containers[0].ImagePullPolicy = corev1.PullIfNotPresent
+			existing = append(existing, *job)
+		}
+		return existing
+	}
+
+	// makeNodeSelectorJS returns the JobSet using the node selector strategy of exclusive
+	// placement, whose Jobs get the placement init container if enabled.
+	makeNodeSelectorJS := func() *jobset.JobSet {
+		js := makeJS("v1")
+		js.Annotations = map[string]string{jobset.ExclusiveKey: "topology", jobset.NodeSelectorStrategyKey: "true"}
+		return js
+	}
+
+	tests := []struct {
+		name               string
+		js                 *jobset.JobSet
+		placementInitImage string
+		existing           func() []batchv1.Job
+		want               []JobDiff
+	}{
+		{
+			name:     "jobs with defaulted fields don't differ",
+			js:       makeJS("v1"),
+			existing: func() []batchv1.Job { return existingJobs(makeJS("v1"), "") },
+		},
+		{
+			name:               "jobs with the placement init container don't differ",
+			js:                 makeNodeSelectorJS(),
+			placementInitImage: "kubectl",
+			existing:           func() []batchv1.Job { return existingJobs(makeNodeSelectorJS(), "kubectl") },
+		},
+		{
+			name: "resumed jobs don't differ",
+			js:   makeJS("v1"),
+			existing: func() []batchv1.Job {
+				jobs := existingJobs(makeJS("v1"), "")
+				for i := range jobs {
+					jobs[i].Spec.Suspend = ptr.To(true)
+				}
+				return jobs
+			},
+		},
+		{
+			name:     "changed pod template",
+			js:       makeJS("v2"),
+			existing: func() []batchv1.Job { return existingJobs(makeJS("v1"), "") },
+			want: []JobDiff{
+				{Name: "test-jobset-workers-0", Fields: []string{"spec.template.spec"}},
+				{Name: "test-jobset-workers-1", Fields: []string{"spec.template.spec"}},
+			},
+		},
+		{
+			name: "changed job spec and labels",
+			js:   makeJS("v1"),
+			existing: func() []batchv1.Job {
+				jobs := existingJobs(makeJS("v1"), "")
+				jobs[1].Spec.Parallelism = ptr.To[int32](4)
+				jobs[1].Labels[jobset.ReplicatedJobReplicas] = "4"
+				return jobs
+			},
+			want: []JobDiff{
+				{Name: "test-jobset-workers-1", Fields: []string{"metadata.labels", "spec"}},
+			},
+		},
+		{
+			name: "jobs of a previous run are skipped",
+			js:   makeJS("v2"),
+			existing: func() []batchv1.Job {
+				jobs := existingJobs(makeJS("v1"), "")
+				for i := range jobs {
+					jobs[i].Labels[constants.RestartsKey] = "-1"
+				}
+				return jobs
+			},
+		},
+		{
+			name: "jobs kept across a restart by restart isolation don't differ",
+			js:   makeRestartedJS("v1"),
+			existing: func() []batchv1.Job {
+				jobs := existingJobs(makeJS("v1"), "")
+				for i := range jobs {
+					jobs[i].Labels[constants.RestartsKey] = "1"
+					jobs[i].Annotations[constants.RestartsKey] = "1"
+				}
+				return jobs
+			},
+		},
+		{
+			name: "jobs beyond the replicas are skipped",
+			js:   makeJS("v2"),
+			existing: func() []batchv1.Job {
+				job := existingJobs(makeJS("v1"), "")[0]
+				job.Name = "test-jobset-workers-2"
+				return []batchv1.Job{job}
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			existing := tc.existing()
+			before := tc.js.DeepCopy()
+			got, err := DiffJobs(tc.js, existing, tc.placementInitImage)
+			if err != nil {
+				t.Fatalf("DiffJobs() error = %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("DiffJobs() mismatch (-want/+got): %s", diff)
+			}
+			if diff := cmp.Diff(before, tc.js); diff != "" {
+				t.Errorf("DiffJobs() modified the JobSet (-want/+got): %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,437 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	jobset "sigs.k8s.io/jobset/api/jobset/v1alpha2"
+	"sigs.k8s.io/jobset/pkg/constants"
+	"sigs.k8s.io/jobset/pkg/util/collections"
+)
+
+// failureAggregationRemaining returns how long the JobSet controller should still wait for
+// further child Job failures before executing the failure policy, or 0 if it should not wait.
+// The failure aggregation window starts when the first child Job of the current run failed.
+func failureAggregationRemaining(js *jobset.JobSet, failedJobs []*batchv1.Job, now time.Time) time.Duration {
+	if js.Spec.FailurePolicy == nil || js.Spec.FailurePolicy.FailureAggregationSeconds == nil {
+		return 0
+	}
+	firstFailureTime := findJobFailureTime(findFirstFailedJob(failedJobs))
+	if firstFailureTime == nil {
+		return 0
+	}
+	window := time.Duration(*js.Spec.FailurePolicy.FailureAggregationSeconds) * time.Second
+	if remaining := firstFailureTime.Add(window).Sub(now); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// messageWithFailedJobs returns the message with the name of the first failed job. If failures
+// are aggregated, the number of failed jobs is included to reflect the blast radius of the failure.
+func messageWithFailedJobs(js *jobset.JobSet, msg string, failedJobs []*batchv1.Job) string {
+	firstFailedJobName := findFirstFailedJob(failedJobs).Name
+	if js.Spec.FailurePolicy == nil || js.Spec.FailurePolicy.FailureAggregationSeconds == nil {
+		return messageWithFirstFailedJob(msg, firstFailedJobName)
+	}
+	return fmt.Sprintf("%s (first failed job: %s, failed jobs: %d)", msg, firstFailedJobName, len(failedJobs))
+}
+
+// stabilizeFinishedJobs moves the successful and failed child Jobs whose terminal condition
+// has not persisted for the stabilization window of the JobSet failure policy back to the
+// active Jobs, so the controller does not act on a flapping Job status. It returns how long
+// the JobSet controller should wait until the first of them is stable, or 0 if none is waiting.
+func stabilizeFinishedJobs(js *jobset.JobSet, ownedJobs *childJobs, now time.Time) time.Duration {
+	if js.Spec.FailurePolicy == nil || js.Spec.FailurePolicy.StabilizationWindowSeconds == nil {
+		return 0
+	}
+	window := time.Duration(*js.Spec.FailurePolicy.StabilizationWindowSeconds) * time.Second
+	var requeueAfter time.Duration
+	stable := func(jobs []*batchv1.Job) []*batchv1.Job {
+		var stableJobs []*batchv1.Job
+		for _, job := range jobs {
+			finishedTime := findJobFinishedTime(job)
+			if finishedTime == nil {
+				stableJobs = append(stableJobs, job)
+				continue
+			}
+			remaining := finishedTime.Add(window).Sub(now)
+			if remaining <= 0 {
+				stableJobs = append(stableJobs, job)
+				continue
+			}
+			if requeueAfter == 0 || remaining < requeueAfter {
+				requeueAfter = remaining
+			}
+			ownedJobs.active = append(ownedJobs.active, job)
+		}
+		return stableJobs
+	}
+	ownedJobs.successful = stable(ownedJobs.successful)
+	ownedJobs.failed = stable(ownedJobs.failed)
+	return requeueAfter
+}
+
+// findJobFinishedTime returns the last transition time of the terminal condition of the Job,
+// or nil if the Job is not finished.
+func findJobFinishedTime(job *batchv1.Job) *metav1.Time {
+	for _, c := range job.Status.Conditions {
+		if (c.Type == batchv1.JobComplete || c.Type == batchv1.JobFailed) && c.Status == corev1.ConditionTrue {
+			return &c.LastTransitionTime
+		}
+	}
+	return nil
+}
+
+// numFailedJobsByReplicatedJob returns the number of failed Jobs of each replicated job.
+func numFailedJobsByReplicatedJob(failedJobs []*batchv1.Job) map[string]int {
+	numFailed := map[string]int{}
+	for _, job := range failedJobs {
+		numFailed[job.Labels[jobset.ReplicatedJobNameKey]]++
+	}
+	return numFailed
+}
+
+// replicatedJobFailuresTolerated returns true if the given number of failed Jobs of the replicated
+// job doesn't exceed its maxFailedJobs. Failures of all the Jobs of the current replicas are
+// never tolerated, e.g. after the replicas were scaled down below maxFailedJobs.
+func replicatedJobFailuresTolerated(js *jobset.JobSet, rjobName string, numFailed int) bool {
+	for _, rjob := range js.Spec.ReplicatedJobs {
+		if rjob.Name == rjobName {
+			return rjob.MaxFailedJobs != nil && numFailed <= int(*rjob.MaxFailedJobs) && numFailed < int(expectedJobs(js, &rjob))
+		}
+	}
+	return false
+}
+
+// numToleratedFailedJobs returns the number of failed Jobs matching the success policy whose
+// replicated jobs tolerate them, which the success policy with the All operator is met without.
+func numToleratedFailedJobs(js *jobset.JobSet, failedJobs []*batchv1.Job) int {
+	total := 0
+	for rjobName, numFailed := range numFailedJobsByReplicatedJob(failedJobs) {
+		if replicatedJobFailuresTolerated(js, rjobName, numFailed) {
+			total += numFailed
+		}
+	}
+	return total
+}
+
+// updateStartTime records the time the JobSet started in its status. The start time is
+// reset while the JobSet is suspended, so the active deadline restarts when it is resumed,
+// while the first start time is kept so the duration of the JobSet covers its whole run.
+func updateStartTime(js *jobset.JobSet, now time.Time, updateStatusOpts *statusUpdateOpts) {
+	if jobSetSuspended(js) {
+		if js.Status.StartTime != nil {
+			js.Status.StartTime = nil
+			updateStatusOpts.shouldUpdate = true
+		}
+		return
+	}
+	if js.Status.StartTime == nil {
+		js.Status.StartTime = &metav1.Time{Time: now}
+		updateStatusOpts.shouldUpdate = true
+	}
+	if js.Status.FirstStartTime == nil {
+		js.Status.FirstStartTime = js.Status.StartTime.DeepCopy()
+		updateStatusOpts.shouldUpdate = true
+	}
+}
+
+// executeActiveDeadlinePolicy fails the JobSet if it has been active for longer than
+// spec.activeDeadlineSeconds, and returns true if it did. Otherwise, it returns the
+// duration after which the JobSet should be requeued to enforce the deadline, or 0 if
+// there is no deadline to enforce.
+func executeActiveDeadlinePolicy(ctx context.Context, js *jobset.JobSet, now time.Time, updateStatusOpts *statusUpdateOpts) (bool, time.Duration) {
+	if js.Spec.ActiveDeadlineSeconds == nil || js.Status.StartTime == nil || jobSetSuspended(js) {
+		return false, 0
+	}
+	deadline := js.Status.StartTime.Add(time.Duration(*js.Spec.ActiveDeadlineSeconds) * time.Second)
+	remaining := deadline.Sub(now)
+	if remaining > 0 {
+		return false, remaining
+	}
+	ctrl.LoggerFrom(ctx).V(2).Info("JobSet active deadline exceeded", "startTime", js.Status.StartTime.UTC(), "activeDeadlineSeconds", *js.Spec.ActiveDeadlineSeconds)
+	setJobSetFailedCondition(ctx, js, constants.DeadlineExceededReason, constants.DeadlineExceededMessage, updateStatusOpts)
+	return true, 0
+}
+
+// executeRestartTrigger restarts the JobSet once for each change of the restart annotation,
+// by comparing its value against the last value observed in the JobSet status. It returns
+// true if a restart was triggered.
+func executeRestartTrigger(ctx context.Context, js *jobset.JobSet, ownedJobs *childJobs, updateStatusOpts *statusUpdateOpts) bool {
+	trigger := js.Annotations[jobset.RestartTriggerKey]
+	if trigger == js.Status.ObservedRestartTrigger {
+		return false
+	}
+	js.Status.ObservedRestartTrigger = trigger
+	updateStatusOpts.shouldUpdate = true
+
+	// There is nothing to restart if no child jobs were created for the current run yet,
+	// e.g. when the annotation is set on creation of the JobSet.
+	if len(ownedJobs.active) == 0 && len(ownedJobs.successful) == 0 && len(ownedJobs.failed) == 0 {
+		return false
+	}
+
+	// Increment JobSet restarts. This will trigger reconciliation and result in deletions
+	// of old jobs not part of the current jobSet run.
+	js.Status.Restarts += 1
+	traceLifecycleEvent(ctx, restartSpanName, js, attribute.Int("jobset.restarts", int(js.Status.Restarts)))
+	enqueueEvent(updateStatusOpts, &eventParams{
+		object:       js,
+		eventType:    corev1.EventTypeNormal,
+		eventReason:  constants.JobSetRestartReason,
+		eventMessage: constants.RestartTriggeredMessage,
+	})
+	ctrl.LoggerFrom(ctx).V(2).Info("restart triggered by annotation", "restart attempt", js.Status.Restarts, "trigger", trigger)
+	return true
+}
+
+// reopenFinishedJobSet clears the terminal condition of a finished JobSet whose restart
+// annotation changed, so the rest of the reconciliation restarts it like an active JobSet
+// instead of skipping it. The completion time and duration of the previous run are cleared,
+// so they are recorded again once the JobSet completes. It returns true if the JobSet was
+// reopened.
+func reopenFinishedJobSet(ctx context.Context, js *jobset.JobSet, updateStatusOpts *statusUpdateOpts) bool {
+	if !JobSetFinished(js) || js.Annotations[jobset.RestartTriggerKey] == js.Status.ObservedRestartTrigger {
+		return false
+	}
+	now := metav1.Now()
+	for i, cond := range js.Status.Conditions {
+		if (cond.Type == string(jobset.JobSetCompleted) || cond.Type == string(jobset.JobSetFailed)) && cond.Status == metav1.ConditionTrue {
+			js.Status.Conditions[i].Status = metav1.ConditionFalse
+			js.Status.Conditions[i].Reason = constants.JobSetRestartReason
+			js.Status.Conditions[i].Message = constants.RestartTriggeredMessage
+			js.Status.Conditions[i].LastTransitionTime = now
+		}
+	}
+	js.Status.CompletionTime = nil
+	js.Status.Duration = nil
+	updateStatusOpts.shouldUpdate = true
+	ctrl.LoggerFrom(ctx).V(2).Info("reopening finished jobset for a restart triggered by annotation", "trigger", js.Annotations[jobset.RestartTriggerKey])
+	return true
+}
+
+// restartLimitWarningRestarts returns the number of restarts of the JobSet at which its restart
+// limit is approaching, i.e. the given fraction of the maxRestarts of its failure policy rounded
+// up, or 0 if there is no warning threshold.
+func restartLimitWarningRestarts(js *jobset.JobSet, threshold float64) int32 {
+	if threshold <= 0 || js.Spec.FailurePolicy == nil || js.Spec.FailurePolicy.MaxRestarts <= 0 {
+		return 0
+	}
+	return int32(math.Ceil(threshold * float64(js.Spec.FailurePolicy.MaxRestarts)))
+}
+
+// setRestartLimitApproachingCondition sets the RestartLimitApproaching condition of the JobSet
+// once its restarts reached the warning threshold of its restart limit. The condition is set
+// to false if the restarts fall below the threshold again, e.g. when the restarts are reset.
+func setRestartLimitApproachingCondition(js *jobset.JobSet, threshold float64, updateStatusOpts *statusUpdateOpts) {
+	warnAt := restartLimitWarningRestarts(js, threshold)
+	if warnAt == 0 || js.Status.Restarts < warnAt {
+		if meta.FindStatusCondition(js.Status.Conditions, string(jobset.JobSetRestartLimitApproaching)) == nil {
+			return
+		}
+		setCondition(js, &conditionOpts{
+			eventType: corev1.EventTypeNormal,
+			condition: &metav1.Condition{
+				Type:    string(jobset.JobSetRestartLimitApproaching),
+				Status:  metav1.ConditionFalse,
+				Reason:  constants.RestartLimitNotReachedReason,
+				Message: constants.RestartLimitNotReachedMessage,
+			},
+		}, updateStatusOpts)
+		return
+	}
+	setCondition(js, &conditionOpts{
+		eventType: corev1.EventTypeWarning,
+		condition: &metav1.Condition{
+			Type:    string(jobset.JobSetRestartLimitApproaching),
+			Status:  metav1.ConditionTrue,
+			Reason:  constants.RestartLimitApproachingReason,
+			Message: fmt.Sprintf(constants.RestartLimitApproachingMessage, warnAt, js.Spec.FailurePolicy.MaxRestarts),
+		},
+	}, updateStatusOpts)
+}
+
+// restartRateLimitWindow is the time window of the failurePolicy.maxRestartsPerHour limit.
+const restartRateLimitWindow = time.Hour
+
+// restartRateLimitRemaining returns how long a restart of the JobSet must be deferred to not
+// exceed the lowest restart rate limit of the failure policies of the failed jobs, or 0 if the
+// JobSet can be restarted now.
+func restartRateLimitRemaining(js *jobset.JobSet, failedJobs []*batchv1.Job, now time.Time) time.Duration {
+	var limit *int32
+	for _, job := range failedJobs {
+		policy := failurePolicyForJob(js, job)
+		if policy != nil && policy.MaxRestartsPerHour != nil && (limit == nil || *policy.MaxRestartsPerHour < *limit) {
+			limit = policy.MaxRestartsPerHour
+		}
+	}
+	if limit == nil {
+		return 0
+	}
+	recent := recentRestartTimes(js.Status.RestartTimes, now)
+	if len(recent) < int(*limit) {
+		return 0
+	}
+	// The restart is allowed once enough of the recent restarts left the window.
+	return recent[len(recent)-int(*limit)].Add(restartRateLimitWindow).Sub(now)
+}
+
+// recordRestartTime records the time of a restart in the JobSet status if the restart rate of
+// the JobSet is limited, dropping the restarts which left the window.
+func recordRestartTime(js *jobset.JobSet, now time.Time) {
+	if !restartRateLimited(js) {
+		return
+	}
+	js.Status.RestartTimes = append(recentRestartTimes(js.Status.RestartTimes, now), metav1.NewTime(now))
+}
+
+// recentRestartTimes returns the restart times within the rate limit window before now.
+func recentRestartTimes(restartTimes []metav1.Time, now time.Time) []metav1.Time {
+	var recent []metav1.Time
+	for _, t := range restartTimes {
+		if t.Add(restartRateLimitWindow).After(now) {
+			recent = append(recent, t)
+		}
+	}
+	return recent
+}
+
+// restartRateLimited returns true if the failure policy of the JobSet or of any of its
+// replicated jobs limits the restart rate.
+func restartRateLimited(js *jobset.JobSet) bool {
+	if js.Spec.FailurePolicy != nil && js.Spec.FailurePolicy.MaxRestartsPerHour != nil {
+		return true
+	}
+	for _, rjob := range js.Spec.ReplicatedJobs {
+		if rjob.FailurePolicy != nil && rjob.FailurePolicy.MaxRestartsPerHour != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// addRestartCountEnvVar injects the restart count environment variable into all containers
+// of the pod spec. Its value matches the restarts label set on the Job and its pods.
+func addRestartCountEnvVar(podSpec *corev1.PodSpec, restarts int32) {
+	env := corev1.EnvVar{Name: constants.RestartCountEnvVar, Value: strconv.Itoa(int(restarts))}
+	for i := range podSpec.InitContainers {
+		podSpec.InitContainers[i].Env = append(podSpec.InitContainers[i].Env, env)
+	}
+	for i := range podSpec.Containers {
+		podSpec.Containers[i].Env = append(podSpec.Containers[i].Env, env)
+	}
+}
+
+// isolateJobsFromRestart moves the active and succeeded Jobs of the replicated jobs with restart
+// isolation to the current restart attempt of the JobSet, so they are kept instead of being
+// deleted and recreated. Replicated jobs with any of the given failed Jobs, which caused the
+// restart, are restarted regardless. If the replicated jobs declare dependencies, the Jobs of
+// the replicated jobs not depending on the failed ones are kept as well.
+func (r *JobSetReconciler) isolateJobsFromRestart(ctx context.Context, js *jobset.JobSet, ownedJobs *childJobs, failedJobs []*batchv1.Job) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	restarted := replicatedJobsToRestart(js, failedJobs)
+	attempt := strconv.Itoa(int(js.Status.Restarts))
+	for _, job := range collections.Concat(ownedJobs.active, ownedJobs.successful) {
+		rjobName := job.Labels[jobset.ReplicatedJobNameKey]
+		if restarted.Has(rjobName) || !replicatedJobKeptAcrossRestarts(js, rjobName) {
+			continue
+		}
+		patch := client.MergeFrom(job.DeepCopy())
+		job.Labels[constants.RestartsKey] = attempt
+		if job.Annotations != nil {
+			job.Annotations[constants.RestartsKey] = attempt
+		}
+		if err := r.Patch(ctx, job, patch); err != nil {
+			return err
+		}
+		log.V(2).Info("kept job across restart", "job", klog.KObj(job), "restart attempt", attempt)
+	}
+	return nil
+}
+
+// replicatedJobRestartIsolated returns true if the replicated job with the given name keeps its
+// Jobs across restarts caused by other replicated jobs.
+func replicatedJobRestartIsolated(js *jobset.JobSet, rjobName string) bool {
+	for _, rjob := range js.Spec.ReplicatedJobs {
+		if rjob.Name == rjobName {
+			return ptr.Deref(rjob.RestartIsolation, false)
+		}
+	}
+	return false
+}
+
+// dependencyGraphDefined returns true if any replicated job of the JobSet depends on another.
+func dependencyGraphDefined(js *jobset.JobSet) bool {
+	for _, rjob := range js.Spec.ReplicatedJobs {
+		if len(rjob.DependsOn) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// replicatedJobKeptAcrossRestarts returns true if the Jobs of the replicated job with the given
+// name may be kept across restarts caused by other replicated jobs, either because of its restart
+// isolation or because the JobSet defines a dependency graph.
+func replicatedJobKeptAcrossRestarts(js *jobset.JobSet, rjobName string) bool {
+	return replicatedJobRestartIsolated(js, rjobName) || dependencyGraphDefined(js)
+}
+
+// replicatedJobsToRestart returns the names of the replicated jobs restarted because of the given
+// failed Jobs: the replicated jobs of the failed Jobs and, if the JobSet defines a dependency
+// graph, the replicated jobs depending on them, directly or transitively. Replicated jobs with
+// restart isolation are kept unless their own Jobs failed, so the restart doesn't propagate
+// through them.
+func replicatedJobsToRestart(js *jobset.JobSet, failedJobs []*batchv1.Job) sets.Set[string] {
+	restarted := sets.New[string]()
+	var queue []string
+	for _, job := range failedJobs {
+		rjobName := job.Labels[jobset.ReplicatedJobNameKey]
+		if !restarted.Has(rjobName) {
+			restarted.Insert(rjobName)
+			queue = append(queue, rjobName)
+		}
+	}
+	for len(queue) > 0 {
+		dependency := queue[0]
+		queue = queue[1:]
+		for _, rjob := range js.Spec.ReplicatedJobs {
+			if restarted.Has(rjob.Name) || ptr.Deref(rjob.RestartIsolation, false) || !slices.Contains(rjob.DependsOn, dependency) {
+				continue
+			}
+			restarted.Insert(rjob.Name)
+			queue = append(queue, rjob.Name)
+		}
+	}
+	return restarted
+}
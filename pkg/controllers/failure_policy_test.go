@@ -0,0 +1,1366 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	jobset "sigs.k8s.io/jobset/api/jobset/v1alpha2"
+	"sigs.k8s.io/jobset/pkg/constants"
+	"sigs.k8s.io/jobset/pkg/util/placement"
+	testutils "sigs.k8s.io/jobset/pkg/util/testing"
+)
+
+func TestExecuteFailurePolicyWithReplicatedJobOverrides(t *testing.T) {
+	now := time.Now()
+	failedJob := func(rjobName string, failureTime time.Time) *batchv1.Job {
+		job := jobWithFailedCondition(rjobName+"-0", failureTime)
+		job.Labels = map[string]string{jobset.ReplicatedJobNameKey: rjobName}
+		return job
+	}
+	makeJobSet := func(policy *jobset.FailurePolicy) *jobset.JobSet {
+		return testutils.MakeJobSet("js", "default").
+			FailurePolicy(policy).
+			ReplicatedJob(testutils.MakeReplicatedJob("trainer").Obj()).
+			ReplicatedJob(testutils.MakeReplicatedJob("checkpoint-saver").
+				FailurePolicy(&jobset.FailurePolicy{Action: jobset.FailurePolicyActionIgnore}).
+				Obj()).
+			ReplicatedJob(testutils.MakeReplicatedJob("evaluator").
+				FailurePolicy(&jobset.FailurePolicy{MaxRestarts: 0}).
+				Obj()).
+			Obj()
+	}
+
+	tests := []struct {
+		name         string
+		js           *jobset.JobSet
+		failedJobs   []*batchv1.Job
+		wantRestarts int32
+		wantReason   string
+	}{
+		{
+			name:         "failure of replicated job without override uses the jobset policy",
+			js:           makeJobSet(&jobset.FailurePolicy{MaxRestarts: 3}),
+			failedJobs:   []*batchv1.Job{failedJob("trainer", now)},
+			wantRestarts: 1,
+		},
+		{
+			name:       "failure of replicated job with ignore override is ignored",
+			js:         makeJobSet(&jobset.FailurePolicy{MaxRestarts: 3}),
+			failedJobs: []*batchv1.Job{failedJob("checkpoint-saver", now)},
+		},
+		{
+			name:       "ignored failure does not prevent failing the jobset",
+			js:         makeJobSet(nil),
+			failedJobs: []*batchv1.Job{failedJob("checkpoint-saver", now.Add(-time.Second)), failedJob("trainer", now)},
+			wantReason: constants.FailedJobsReason,
+		},
+		{
+			name:         "ignored failure along with restartable failure restarts the jobset",
+			js:           makeJobSet(&jobset.FailurePolicy{MaxRestarts: 3}),
+			failedJobs:   []*batchv1.Job{failedJob("checkpoint-saver", now), failedJob("trainer", now)},
+			wantRestarts: 1,
+		},
+		{
+			name:       "override with lower max restarts fails the jobset",
+			js:         makeJobSet(&jobset.FailurePolicy{MaxRestarts: 3}),
+			failedJobs: []*batchv1.Job{failedJob("evaluator", now)},
+			wantReason: constants.ReachedMaxRestartsReason,
+		},
+		{
+			name:       "failure of replicated job with ignore override is ignored without a jobset policy",
+			js:         makeJobSet(nil),
+			failedJobs: []*batchv1.Job{failedJob("checkpoint-saver", now)},
+		},
+		{
+			name:       "failure without any policy fails the jobset",
+			js:         makeJobSet(nil),
+			failedJobs: []*batchv1.Job{failedJob("trainer", now)},
+			wantReason: constants.FailedJobsReason,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var updateStatusOpts statusUpdateOpts
+			if failedJobs := failedJobsNotIgnored(tc.js, tc.failedJobs); len(failedJobs) > 0 {
+				executeFailurePolicy(context.TODO(), tc.js, failedJobs, now, &updateStatusOpts)
+			}
+			if tc.js.Status.Restarts != tc.wantRestarts {
+				t.Errorf("unexpected restarts: got %d, want %d", tc.js.Status.Restarts, tc.wantRestarts)
+			}
+			var gotReason string
+			for _, c := range tc.js.Status.Conditions {
+				if c.Type == string(jobset.JobSetFailed) && c.Status == metav1.ConditionTrue {
+					gotReason = c.Reason
+				}
+			}
+			if gotReason != tc.wantReason {
+				t.Errorf("unexpected failed condition reason: got %q, want %q", gotReason, tc.wantReason)
+			}
+		})
+	}
+}
+
+func TestFailureAggregationRemaining(t *testing.T) {
+	now := time.Now()
+	failedJobs := []*batchv1.Job{
+		jobWithFailedCondition("job-1", now.Add(-10*time.Second)),
+		jobWithFailedCondition("job-2", now.Add(-5*time.Second)),
+	}
+	tests := []struct {
+		name       string
+		js         *jobset.JobSet
+		failedJobs []*batchv1.Job
+		want       time.Duration
+	}{
+		{
+			name:       "no failure policy",
+			js:         testutils.MakeJobSet("js", "default").Obj(),
+			failedJobs: failedJobs,
+		},
+		{
+			name:       "no failure aggregation window",
+			js:         testutils.MakeJobSet("js", "default").FailurePolicy(&jobset.FailurePolicy{MaxRestarts: 1}).Obj(),
+			failedJobs: failedJobs,
+		},
+		{
+			name: "within failure aggregation window of the first failure",
+			js: testutils.MakeJobSet("js", "default").FailurePolicy(&jobset.FailurePolicy{
+				MaxRestarts:               1,
+				FailureAggregationSeconds: ptr.To[int32](30),
+			}).Obj(),
+			failedJobs: failedJobs,
+			want:       20 * time.Second,
+		},
+		{
+			name: "failure aggregation window has passed",
+			js: testutils.MakeJobSet("js", "default").FailurePolicy(&jobset.FailurePolicy{
+				MaxRestarts:               1,
+				FailureAggregationSeconds: ptr.To[int32](10),
+			}).Obj(),
+			failedJobs: failedJobs,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := failureAggregationRemaining(tc.js, tc.failedJobs, now); got != tc.want {
+				t.Errorf("failureAggregationRemaining() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestExecuteFailurePolicyWithAggregatedFailures(t *testing.T) {
+	now := time.Now()
+	ownedJobs := &childJobs{
+		failed: []*batchv1.Job{
+			jobWithFailedCondition("job-1", now.Add(-20*time.Second)),
+			jobWithFailedCondition("job-2", now.Add(-30*time.Second)),
+			jobWithFailedCondition("job-3", now.Add(-25*time.Second)),
+		},
+	}
+	tests := []struct {
+		name         string
+		js           *jobset.JobSet
+		wantRestarts int32
+		wantMessage  string
+	}{
+		{
+			name: "all aggregated failures result in a single restart",
+			js: testutils.MakeJobSet("js", "default").FailurePolicy(&jobset.FailurePolicy{
+				MaxRestarts:               1,
+				FailureAggregationSeconds: ptr.To[int32](10),
+			}).Obj(),
+			wantRestarts: 1,
+		},
+		{
+			name: "failed condition reflects all aggregated failures",
+			js: testutils.MakeJobSet("js", "default").FailurePolicy(&jobset.FailurePolicy{
+				FailureAggregationSeconds: ptr.To[int32](10),
+			}).Obj(),
+			wantMessage: constants.ReachedMaxRestartsMessage + " (first failed job: job-2, failed jobs: 3)",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var updateStatusOpts statusUpdateOpts
+			executeFailurePolicy(context.TODO(), tc.js, ownedJobs.failed, now, &updateStatusOpts)
+			if tc.js.Status.Restarts != tc.wantRestarts {
+				t.Errorf("unexpected restarts: got %d, want %d", tc.js.Status.Restarts, tc.wantRestarts)
+			}
+			var gotMessage string
+			for _, c := range tc.js.Status.Conditions {
+				if c.Type == string(jobset.JobSetFailed) && c.Status == metav1.ConditionTrue {
+					gotMessage = c.Message
+				}
+			}
+			if gotMessage != tc.wantMessage {
+				t.Errorf("unexpected failed condition message: got %q, want %q", gotMessage, tc.wantMessage)
+			}
+		})
+	}
+}
+
+func TestStabilizeFinishedJobs(t *testing.T) {
+	now := time.Now()
+	succeeded := func(name string, finishedAgo time.Duration) *batchv1.Job {
+		job := testutils.MakeJob(name, "default").Obj()
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(now.Add(-finishedAgo))}}
+		return job
+	}
+	tests := []struct {
+		name             string
+		failurePolicy    *jobset.FailurePolicy
+		successful       []*batchv1.Job
+		failed           []*batchv1.Job
+		wantActive       int
+		wantSuccessful   int
+		wantFailed       int
+		wantRequeueAfter time.Duration
+	}{
+		{
+			name:           "no failure policy",
+			successful:     []*batchv1.Job{succeeded("succeeded", time.Second)},
+			failed:         []*batchv1.Job{jobWithFailedCondition("failed", now.Add(-time.Second))},
+			wantSuccessful: 1,
+			wantFailed:     1,
+		},
+		{
+			name:           "no stabilization window",
+			failurePolicy:  &jobset.FailurePolicy{MaxRestarts: 1},
+			successful:     []*batchv1.Job{succeeded("succeeded", time.Second)},
+			failed:         []*batchv1.Job{jobWithFailedCondition("failed", now.Add(-time.Second))},
+			wantSuccessful: 1,
+			wantFailed:     1,
+		},
+		{
+			name:             "jobs finished within the stabilization window are active",
+			failurePolicy:    &jobset.FailurePolicy{StabilizationWindowSeconds: ptr.To[int32](30)},
+			successful:       []*batchv1.Job{succeeded("succeeded", 10*time.Second)},
+			failed:           []*batchv1.Job{jobWithFailedCondition("failed", now.Add(-20*time.Second))},
+			wantActive:       2,
+			wantRequeueAfter: 10 * time.Second,
+		},
+		{
+			name:           "jobs finished before the stabilization window are stable",
+			failurePolicy:  &jobset.FailurePolicy{StabilizationWindowSeconds: ptr.To[int32](30)},
+			successful:     []*batchv1.Job{succeeded("succeeded", time.Minute)},
+			failed:         []*batchv1.Job{jobWithFailedCondition("failed", now.Add(-30*time.Second))},
+			wantSuccessful: 1,
+			wantFailed:     1,
+		},
+		{
+			name:             "only unstable jobs are active",
+			failurePolicy:    &jobset.FailurePolicy{StabilizationWindowSeconds: ptr.To[int32](30)},
+			successful:       []*batchv1.Job{succeeded("succeeded-1", time.Minute), succeeded("succeeded-2", 5*time.Second)},
+			wantActive:       1,
+			wantSuccessful:   1,
+			wantRequeueAfter: 25 * time.Second,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet("js", "default").FailurePolicy(tc.failurePolicy).Obj()
+			ownedJobs := &childJobs{successful: tc.successful, failed: tc.failed}
+			gotRequeueAfter := stabilizeFinishedJobs(js, ownedJobs, now)
+			if gotRequeueAfter != tc.wantRequeueAfter {
+				t.Errorf("unexpected requeue after: got %v, want %v", gotRequeueAfter, tc.wantRequeueAfter)
+			}
+			if len(ownedJobs.active) != tc.wantActive {
+				t.Errorf("unexpected number of active jobs: got %d, want %d", len(ownedJobs.active), tc.wantActive)
+			}
+			if len(ownedJobs.successful) != tc.wantSuccessful {
+				t.Errorf("unexpected number of successful jobs: got %d, want %d", len(ownedJobs.successful), tc.wantSuccessful)
+			}
+			if len(ownedJobs.failed) != tc.wantFailed {
+				t.Errorf("unexpected number of failed jobs: got %d, want %d", len(ownedJobs.failed), tc.wantFailed)
+			}
+		})
+	}
+}
+
+func TestReconcileStabilizationWindow(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+		now        = time.Now().Truncate(time.Second)
+	)
+	tests := []struct {
+		name             string
+		conditionType    batchv1.JobConditionType
+		finishedAgo      time.Duration
+		wantRestarts     int32
+		wantCompleted    bool
+		wantRequeueAfter time.Duration
+	}{
+		{
+			name:             "failure within the stabilization window does not restart the jobset",
+			conditionType:    batchv1.JobFailed,
+			finishedAgo:      10 * time.Second,
+			wantRequeueAfter: 20 * time.Second,
+		},
+		{
+			name:          "stable failure restarts the jobset",
+			conditionType: batchv1.JobFailed,
+			finishedAgo:   time.Minute,
+			wantRestarts:  1,
+		},
+		{
+			name:             "success within the stabilization window does not complete the jobset",
+			conditionType:    batchv1.JobComplete,
+			finishedAgo:      10 * time.Second,
+			wantRequeueAfter: 20 * time.Second,
+		},
+		{
+			name:          "stable success completes the jobset",
+			conditionType: batchv1.JobComplete,
+			finishedAgo:   time.Minute,
+			wantCompleted: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet(jobSetName, ns).
+				SuccessPolicy(&jobset.SuccessPolicy{Operator: jobset.OperatorAll}).
+				FailurePolicy(&jobset.FailurePolicy{MaxRestarts: 1, StabilizationWindowSeconds: ptr.To[int32](30)}).
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").Replicas(1).Obj()).
+				Obj()
+			js.UID = "test-uid"
+			job := makeJob(&makeJobArgs{
+				jobSetName:        jobSetName,
+				replicatedJobName: "workers",
+				jobName:           placement.GenJobName(jobSetName, "workers", 0),
+				ns:                ns,
+				replicas:          1,
+			}).Parallelism(1).Obj()
+			job.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(js, jobset.GroupVersion.WithKind("JobSet"))}
+			job.Status.Conditions = []batchv1.JobCondition{{Type: tc.conditionType, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(now.Add(-tc.finishedAgo))}}
+
+			fakeClient := newFakeClientBuilder().
+				WithObjects(js, job).
+				WithStatusSubresource(js).
+				Build()
+			r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{})
+			r.clock = clocktesting.NewFakeClock(now)
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: jobSetName, Namespace: ns}}
+			result := reconcileJobSet(t, r, req, 1)
+			if result.RequeueAfter != tc.wantRequeueAfter {
+				t.Errorf("unexpected requeue after: got %v, want %v", result.RequeueAfter, tc.wantRequeueAfter)
+			}
+
+			var got jobset.JobSet
+			if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+				t.Fatalf("unexpected error getting jobset: %v", err)
+			}
+			if got.Status.Restarts != tc.wantRestarts {
+				t.Errorf("unexpected restarts: got %d, want %d", got.Status.Restarts, tc.wantRestarts)
+			}
+			if gotCompleted := meta.IsStatusConditionTrue(got.Status.Conditions, string(jobset.JobSetCompleted)); gotCompleted != tc.wantCompleted {
+				t.Errorf("unexpected %s condition: got %t, want %t", jobset.JobSetCompleted, gotCompleted, tc.wantCompleted)
+			}
+		})
+	}
+}
+
+func TestFailedJobsNotIgnoredMaxFailedJobs(t *testing.T) {
+	tests := []struct {
+		name               string
+		maxFailedJobs      *int32
+		replicasAnnotation string
+		failedWorkers      int
+		want               []string
+	}{
+		{
+			name:          "failures are not tolerated without max failed jobs",
+			failedWorkers: 1,
+			want:          []string{"test-jobset-workers-0"},
+		},
+		{
+			name:          "failures below max failed jobs are tolerated",
+			maxFailedJobs: ptr.To[int32](2),
+			failedWorkers: 1,
+		},
+		{
+			name:          "failures reaching max failed jobs are tolerated",
+			maxFailedJobs: ptr.To[int32](2),
+			failedWorkers: 2,
+		},
+		{
+			name:          "all failures are reported once max failed jobs is exceeded",
+			maxFailedJobs: ptr.To[int32](2),
+			failedWorkers: 3,
+			want:          []string{"test-jobset-workers-0", "test-jobset-workers-1", "test-jobset-workers-2"},
+		},
+		{
+			name:               "failures of all the jobs of the scaled down replicas are not tolerated",
+			maxFailedJobs:      ptr.To[int32](2),
+			replicasAnnotation: "workers=2",
+			failedWorkers:      2,
+			want:               []string{"test-jobset-workers-0", "test-jobset-workers-1"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			workers := testutils.MakeReplicatedJob("workers").Replicas(4).Obj()
+			workers.MaxFailedJobs = tc.maxFailedJobs
+			js := testutils.MakeJobSet("test-jobset", "default").
+				ReplicatedJob(testutils.MakeReplicatedJob("coordinator").Replicas(1).Obj()).
+				ReplicatedJob(workers).
+				Obj()
+			if tc.replicasAnnotation != "" {
+				js.Annotations = map[string]string{jobset.ReplicasKey: tc.replicasAnnotation}
+			}
+			var failedJobs []*batchv1.Job
+			for jobIdx := 0; jobIdx < tc.failedWorkers; jobIdx++ {
+				failedJobs = append(failedJobs, makeJob(&makeJobArgs{
+					jobSetName:        js.Name,
+					replicatedJobName: "workers",
+					jobName:           fmt.Sprintf("test-jobset-workers-%d", jobIdx),
+					ns:                js.Namespace,
+					replicas:          4,
+					jobIdx:            jobIdx,
+				}).Obj())
+			}
+
+			var got []string
+			for _, job := range failedJobsNotIgnored(js, failedJobs) {
+				got = append(got, job.Name)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected failed jobs not ignored (-want/+got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestReconcileMaxFailedJobs(t *testing.T) {
+	tests := []struct {
+		name          string
+		failedWorkers int
+		wantCondition jobset.JobSetConditionType
+	}{
+		{
+			name:          "jobset completes with failures below max failed jobs",
+			failedWorkers: 1,
+			wantCondition: jobset.JobSetCompleted,
+		},
+		{
+			name:          "jobset fails with failures above max failed jobs",
+			failedWorkers: 2,
+			wantCondition: jobset.JobSetFailed,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			jobTemplate := testutils.MakeJobTemplate("job", "default").Obj()
+			jobTemplate.Spec.Parallelism = ptr.To[int32](1)
+			js := testutils.MakeJobSet("test-jobset", "default").
+				SuccessPolicy(&jobset.SuccessPolicy{Operator: jobset.OperatorAll}).
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").Job(jobTemplate).Replicas(3).MaxFailedJobs(1).Obj()).
+				Obj()
+			fakeClient := newFakeClientBuilder().
+				WithObjects(js).
+				WithStatusSubresource(js, &batchv1.Job{}).
+				Build()
+			r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(100), JobSetReconcilerOptions{})
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: js.Name, Namespace: js.Namespace}}
+			reconcileJobSet(t, r, req, 1)
+
+			var jobs batchv1.JobList
+			if err := fakeClient.List(context.TODO(), &jobs, client.InNamespace(js.Namespace)); err != nil {
+				t.Fatalf("unexpected error listing jobs: %v", err)
+			}
+			if len(jobs.Items) != 3 {
+				t.Fatalf("expected 3 jobs to be created, got %d", len(jobs.Items))
+			}
+			// Fail the first jobs and complete the others.
+			for i, job := range jobs.Items {
+				condType := batchv1.JobComplete
+				if i < tc.failedWorkers {
+					condType = batchv1.JobFailed
+				}
+				job.Status.Conditions = []batchv1.JobCondition{{Type: condType, Status: corev1.ConditionTrue}}
+				if err := fakeClient.Status().Update(context.TODO(), &job); err != nil {
+					t.Fatalf("unexpected error updating job status: %v", err)
+				}
+			}
+			reconcileJobSet(t, r, req, 1)
+
+			var got jobset.JobSet
+			if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+				t.Fatalf("unexpected error getting jobset: %v", err)
+			}
+			if !meta.IsStatusConditionTrue(got.Status.Conditions, string(tc.wantCondition)) {
+				t.Errorf("expected condition %s, got conditions %v", tc.wantCondition, got.Status.Conditions)
+			}
+			if got.Status.Restarts != 0 {
+				t.Errorf("unexpected restarts: got %d, want 0", got.Status.Restarts)
+			}
+		})
+	}
+}
+
+func TestUpdateStartTime(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	startTime := metav1.NewTime(now.Add(-time.Minute))
+	firstStartTime := metav1.NewTime(now.Add(-time.Hour))
+	tests := []struct {
+		name               string
+		js                 *jobset.JobSet
+		wantStartTime      *metav1.Time
+		wantFirstStartTime *metav1.Time
+		wantUpdate         bool
+	}{
+		{
+			name:               "start times are set when jobset is first reconciled",
+			js:                 testutils.MakeJobSet("js", "default").Obj(),
+			wantStartTime:      &metav1.Time{Time: now},
+			wantFirstStartTime: &metav1.Time{Time: now},
+			wantUpdate:         true,
+		},
+		{
+			name:               "start times are not changed once set",
+			js:                 testutils.MakeJobSet("js", "default").StartTime(&startTime).FirstStartTime(&firstStartTime).Obj(),
+			wantStartTime:      &startTime,
+			wantFirstStartTime: &firstStartTime,
+		},
+		{
+			name:               "first start time is set from the start time of a jobset started before it was tracked",
+			js:                 testutils.MakeJobSet("js", "default").StartTime(&startTime).Obj(),
+			wantStartTime:      &startTime,
+			wantFirstStartTime: &startTime,
+			wantUpdate:         true,
+		},
+		{
+			name: "start times are not set while suspended",
+			js:   testutils.MakeJobSet("js", "default").Suspend(true).Obj(),
+		},
+		{
+			name:               "start time is reset when suspended, first start time is kept",
+			js:                 testutils.MakeJobSet("js", "default").Suspend(true).StartTime(&startTime).FirstStartTime(&firstStartTime).Obj(),
+			wantFirstStartTime: &firstStartTime,
+			wantUpdate:         true,
+		},
+		{
+			name:               "start time is set when resumed, first start time is kept",
+			js:                 testutils.MakeJobSet("js", "default").FirstStartTime(&firstStartTime).Obj(),
+			wantStartTime:      &metav1.Time{Time: now},
+			wantFirstStartTime: &firstStartTime,
+			wantUpdate:         true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			updateStatusOpts := &statusUpdateOpts{}
+			updateStartTime(tc.js, now, updateStatusOpts)
+			if diff := cmp.Diff(tc.wantStartTime, tc.js.Status.StartTime); diff != "" {
+				t.Errorf("unexpected start time (-want/+got): %s", diff)
+			}
+			if diff := cmp.Diff(tc.wantFirstStartTime, tc.js.Status.FirstStartTime); diff != "" {
+				t.Errorf("unexpected first start time (-want/+got): %s", diff)
+			}
+			if updateStatusOpts.shouldUpdate != tc.wantUpdate {
+				t.Errorf("unexpected status update, want %v, got %v", tc.wantUpdate, updateStatusOpts.shouldUpdate)
+			}
+		})
+	}
+}
+
+func TestExecuteActiveDeadlinePolicy(t *testing.T) {
+	now := time.Now()
+	startTime := metav1.NewTime(now.Add(-30 * time.Second))
+	tests := []struct {
+		name             string
+		js               *jobset.JobSet
+		wantExceeded     bool
+		wantRequeueAfter time.Duration
+	}{
+		{
+			name: "no active deadline",
+			js:   testutils.MakeJobSet("js", "default").StartTime(&startTime).Obj(),
+		},
+		{
+			name: "start time not set",
+			js:   testutils.MakeJobSet("js", "default").ActiveDeadlineSeconds(10).Obj(),
+		},
+		{
+			name: "suspended jobset",
+			js:   testutils.MakeJobSet("js", "default").Suspend(true).ActiveDeadlineSeconds(10).StartTime(&startTime).Obj(),
+		},
+		{
+			name:             "deadline not exceeded, requeue until deadline",
+			js:               testutils.MakeJobSet("js", "default").ActiveDeadlineSeconds(60).StartTime(&startTime).Obj(),
+			wantRequeueAfter: 30 * time.Second,
+		},
+		{
+			name:         "deadline exceeded",
+			js:           testutils.MakeJobSet("js", "default").ActiveDeadlineSeconds(10).StartTime(&startTime).Obj(),
+			wantExceeded: true,
+		},
+		{
+			name:         "deadline reached exactly",
+			js:           testutils.MakeJobSet("js", "default").ActiveDeadlineSeconds(30).StartTime(&startTime).Obj(),
+			wantExceeded: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			updateStatusOpts := &statusUpdateOpts{}
+			exceeded, requeueAfter := executeActiveDeadlinePolicy(context.TODO(), tc.js, now, updateStatusOpts)
+			if exceeded != tc.wantExceeded {
+				t.Errorf("unexpected deadline exceeded, want %v, got %v", tc.wantExceeded, exceeded)
+			}
+			if requeueAfter != tc.wantRequeueAfter {
+				t.Errorf("unexpected requeue after, want %v, got %v", tc.wantRequeueAfter, requeueAfter)
+			}
+			if gotFailed := JobSetFinished(tc.js); gotFailed != tc.wantExceeded {
+				t.Errorf("unexpected failed condition, want %v, got %v", tc.wantExceeded, gotFailed)
+			}
+			if tc.wantExceeded {
+				if !updateStatusOpts.shouldUpdate {
+					t.Errorf("expected a status update")
+				}
+				for _, c := range tc.js.Status.Conditions {
+					if c.Type == string(jobset.JobSetFailed) && c.Reason != constants.DeadlineExceededReason {
+						t.Errorf("unexpected failed condition reason, want %s, got %s", constants.DeadlineExceededReason, c.Reason)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestExecuteRestartTrigger(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	activeJobs := childJobs{
+		active: []*batchv1.Job{
+			makeJob(&makeJobArgs{
+				jobSetName:        jobSetName,
+				replicatedJobName: "replicated-job-1",
+				jobName:           "test-jobset-replicated-job-1-0",
+				ns:                ns,
+				replicas:          1,
+			}).Obj(),
+		},
+	}
+	tests := []struct {
+		name         string
+		js           *jobset.JobSet
+		ownedJobs    childJobs
+		wantRestart  bool
+		wantRestarts int32
+		wantObserved string
+		wantUpdate   bool
+	}{
+		{
+			name:      "no restart annotation",
+			js:        testutils.MakeJobSet(jobSetName, ns).Obj(),
+			ownedJobs: activeJobs,
+		},
+		{
+			name: "restart annotation unchanged",
+			js: testutils.MakeJobSet(jobSetName, ns).
+				SetAnnotations(map[string]string{jobset.RestartTriggerKey: "1"}).
+				ObservedRestartTrigger("1").Obj(),
+			ownedJobs:    activeJobs,
+			wantObserved: "1",
+		},
+		{
+			name: "restart annotation set on creation is recorded without restarting",
+			js: testutils.MakeJobSet(jobSetName, ns).
+				SetAnnotations(map[string]string{jobset.RestartTriggerKey: "1"}).Obj(),
+			wantObserved: "1",
+			wantUpdate:   true,
+		},
+		{
+			name: "restart annotation changed",
+			js: testutils.MakeJobSet(jobSetName, ns).
+				SetAnnotations(map[string]string{jobset.RestartTriggerKey: "2"}).
+				ObservedRestartTrigger("1").Obj(),
+			ownedJobs:    activeJobs,
+			wantRestart:  true,
+			wantRestarts: 1,
+			wantObserved: "2",
+			wantUpdate:   true,
+		},
+		{
+			name: "restart annotation removed",
+			js: testutils.MakeJobSet(jobSetName, ns).
+				ObservedRestartTrigger("1").Obj(),
+			ownedJobs:    activeJobs,
+			wantRestart:  true,
+			wantRestarts: 1,
+			wantUpdate:   true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var updateStatusOpts statusUpdateOpts
+			restarted := executeRestartTrigger(context.TODO(), tc.js, &tc.ownedJobs, &updateStatusOpts)
+			if restarted != tc.wantRestart {
+				t.Errorf("executeRestartTrigger() = %t, want %t", restarted, tc.wantRestart)
+			}
+			if tc.js.Status.Restarts != tc.wantRestarts {
+				t.Errorf("unexpected restarts: got %d, want %d", tc.js.Status.Restarts, tc.wantRestarts)
+			}
+			if tc.js.Status.ObservedRestartTrigger != tc.wantObserved {
+				t.Errorf("unexpected observed restart trigger: got %q, want %q", tc.js.Status.ObservedRestartTrigger, tc.wantObserved)
+			}
+			if updateStatusOpts.shouldUpdate != tc.wantUpdate {
+				t.Errorf("unexpected shouldUpdate: got %t, want %t", updateStatusOpts.shouldUpdate, tc.wantUpdate)
+			}
+		})
+	}
+}
+
+func TestExecuteRestartTriggerRestartsOncePerChange(t *testing.T) {
+	js := testutils.MakeJobSet("test-jobset", "default").ObservedRestartTrigger("1").Obj()
+	ownedJobs := childJobs{
+		active: []*batchv1.Job{
+			makeJob(&makeJobArgs{
+				jobSetName:        "test-jobset",
+				replicatedJobName: "replicated-job-1",
+				jobName:           "test-jobset-replicated-job-1-0",
+				ns:                "default",
+				replicas:          1,
+			}).Obj(),
+		},
+	}
+
+	// Bump the trigger and reconcile several times, only the first one restarts the JobSet.
+	js.Annotations = map[string]string{jobset.RestartTriggerKey: "2"}
+	for i := 0; i < 3; i++ {
+		var updateStatusOpts statusUpdateOpts
+		executeRestartTrigger(context.TODO(), js, &ownedJobs, &updateStatusOpts)
+	}
+	if js.Status.Restarts != 1 {
+		t.Errorf("expected exactly 1 restart after bumping the trigger, got %d", js.Status.Restarts)
+	}
+
+	js.Annotations[jobset.RestartTriggerKey] = "3"
+	for i := 0; i < 3; i++ {
+		var updateStatusOpts statusUpdateOpts
+		executeRestartTrigger(context.TODO(), js, &ownedJobs, &updateStatusOpts)
+	}
+	if js.Status.Restarts != 2 {
+		t.Errorf("expected exactly 2 restarts after bumping the trigger twice, got %d", js.Status.Restarts)
+	}
+}
+
+func TestSetRestartLimitApproachingCondition(t *testing.T) {
+	approaching := metav1.Condition{
+		Type:   string(jobset.JobSetRestartLimitApproaching),
+		Status: metav1.ConditionTrue,
+		Reason: constants.RestartLimitApproachingReason,
+	}
+	tests := []struct {
+		name          string
+		failurePolicy *jobset.FailurePolicy
+		restarts      int32
+		threshold     float64
+		conditions    []metav1.Condition
+		wantStatus    metav1.ConditionStatus
+		wantUpdate    bool
+	}{
+		{
+			name:          "restarts below the threshold",
+			failurePolicy: &jobset.FailurePolicy{MaxRestarts: 10},
+			restarts:      7,
+			threshold:     0.8,
+		},
+		{
+			name:          "restarts at the threshold",
+			failurePolicy: &jobset.FailurePolicy{MaxRestarts: 10},
+			restarts:      8,
+			threshold:     0.8,
+			wantStatus:    metav1.ConditionTrue,
+			wantUpdate:    true,
+		},
+		{
+			name:          "threshold is rounded up",
+			failurePolicy: &jobset.FailurePolicy{MaxRestarts: 3},
+			restarts:      2,
+			threshold:     0.8,
+		},
+		{
+			name:          "disabled threshold",
+			failurePolicy: &jobset.FailurePolicy{MaxRestarts: 10},
+			restarts:      9,
+		},
+		{
+			name:      "no failure policy",
+			restarts:  1,
+			threshold: 0.8,
+		},
+		{
+			name:          "condition already set",
+			failurePolicy: &jobset.FailurePolicy{MaxRestarts: 10},
+			restarts:      9,
+			threshold:     0.8,
+			conditions:    []metav1.Condition{approaching},
+			wantStatus:    metav1.ConditionTrue,
+		},
+		{
+			name:          "restarts fall below the threshold",
+			failurePolicy: &jobset.FailurePolicy{MaxRestarts: 10},
+			restarts:      2,
+			threshold:     0.8,
+			conditions:    []metav1.Condition{approaching},
+			wantStatus:    metav1.ConditionFalse,
+			wantUpdate:    true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet("test-jobset", "default").
+				FailurePolicy(tc.failurePolicy).
+				Obj()
+			js.Status.Restarts = tc.restarts
+			js.Status.Conditions = tc.conditions
+			opts := &statusUpdateOpts{}
+			setRestartLimitApproachingCondition(js, tc.threshold, opts)
+
+			cond := meta.FindStatusCondition(js.Status.Conditions, string(jobset.JobSetRestartLimitApproaching))
+			switch {
+			case tc.wantStatus == "" && cond != nil:
+				t.Errorf("unexpected RestartLimitApproaching condition: %v", cond)
+			case tc.wantStatus != "" && cond == nil:
+				t.Errorf("missing RestartLimitApproaching condition")
+			case cond != nil && cond.Status != tc.wantStatus:
+				t.Errorf("unexpected RestartLimitApproaching condition status: got %s, want %s", cond.Status, tc.wantStatus)
+			}
+			if opts.shouldUpdate != tc.wantUpdate {
+				t.Errorf("unexpected status update: got %t, want %t", opts.shouldUpdate, tc.wantUpdate)
+			}
+		})
+	}
+}
+
+func TestReconcileRestartLimitApproaching(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	tests := []struct {
+		name     string
+		restarts int32
+		want     bool
+	}{
+		{
+			name:     "restarts below the threshold",
+			restarts: 7,
+		},
+		{
+			name:     "restarts at the threshold",
+			restarts: 8,
+			want:     true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet(jobSetName, ns).
+				SuccessPolicy(&jobset.SuccessPolicy{Operator: jobset.OperatorAll}).
+				FailurePolicy(&jobset.FailurePolicy{MaxRestarts: 10}).
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").Replicas(1).Obj()).
+				Obj()
+			js.UID = "test-uid"
+			js.Status.Restarts = tc.restarts
+			job := makeJob(&makeJobArgs{
+				jobSetName:        jobSetName,
+				replicatedJobName: "workers",
+				jobName:           placement.GenJobName(jobSetName, "workers", 0),
+				ns:                ns,
+				replicas:          1,
+				restarts:          int(tc.restarts),
+			}).Obj()
+			job.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(js, jobset.GroupVersion.WithKind("JobSet"))}
+			job.Spec.Parallelism = ptr.To[int32](1)
+
+			fakeClient := newFakeClientBuilder().
+				WithObjects(js, job).
+				WithStatusSubresource(js).
+				Build()
+			r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{RestartLimitWarningThreshold: 0.8})
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: jobSetName, Namespace: ns}}
+			reconcileJobSet(t, r, req, 1)
+
+			var got jobset.JobSet
+			if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+				t.Fatalf("unexpected error getting jobset: %v", err)
+			}
+			if gotCond := meta.IsStatusConditionTrue(got.Status.Conditions, string(jobset.JobSetRestartLimitApproaching)); gotCond != tc.want {
+				t.Errorf("unexpected RestartLimitApproaching condition: got %t, want %t", gotCond, tc.want)
+			}
+		})
+	}
+}
+
+func TestRestartRateLimitRemaining(t *testing.T) {
+	now := time.Now()
+	failedJob := func(rjobName string) *batchv1.Job {
+		job := jobWithFailedCondition(rjobName+"-0", now)
+		job.Labels = map[string]string{jobset.ReplicatedJobNameKey: rjobName}
+		return job
+	}
+	restartTimes := func(ago ...time.Duration) []metav1.Time {
+		var times []metav1.Time
+		for _, d := range ago {
+			times = append(times, metav1.NewTime(now.Add(-d)))
+		}
+		return times
+	}
+
+	tests := []struct {
+		name         string
+		policy       *jobset.FailurePolicy
+		rjobPolicy   *jobset.FailurePolicy
+		restartTimes []metav1.Time
+		want         time.Duration
+	}{
+		{
+			name:         "no restart rate limit",
+			policy:       &jobset.FailurePolicy{MaxRestarts: 10},
+			restartTimes: restartTimes(3*time.Minute, 2*time.Minute, time.Minute),
+		},
+		{
+			name:         "restarts below the rate limit",
+			policy:       &jobset.FailurePolicy{MaxRestarts: 10, MaxRestartsPerHour: ptr.To[int32](3)},
+			restartTimes: restartTimes(2*time.Minute, time.Minute),
+		},
+		{
+			name:         "restarts older than an hour are not counted",
+			policy:       &jobset.FailurePolicy{MaxRestarts: 10, MaxRestartsPerHour: ptr.To[int32](2)},
+			restartTimes: restartTimes(2*time.Hour, 61*time.Minute, time.Minute),
+		},
+		{
+			name:         "restart exceeding the rate limit is deferred until the oldest restart leaves the window",
+			policy:       &jobset.FailurePolicy{MaxRestarts: 10, MaxRestartsPerHour: ptr.To[int32](2)},
+			restartTimes: restartTimes(20*time.Minute, 10*time.Minute),
+			want:         40 * time.Minute,
+		},
+		{
+			name:         "restart is deferred until enough restarts leave the window",
+			policy:       &jobset.FailurePolicy{MaxRestarts: 10, MaxRestartsPerHour: ptr.To[int32](2)},
+			restartTimes: restartTimes(30*time.Minute, 20*time.Minute, 10*time.Minute),
+			want:         40 * time.Minute,
+		},
+		{
+			name:         "replicated job failure policy overrides the rate limit",
+			policy:       &jobset.FailurePolicy{MaxRestarts: 10, MaxRestartsPerHour: ptr.To[int32](2)},
+			rjobPolicy:   &jobset.FailurePolicy{MaxRestarts: 10, MaxRestartsPerHour: ptr.To[int32](1)},
+			restartTimes: restartTimes(10 * time.Minute),
+			want:         50 * time.Minute,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet("js", "default").
+				FailurePolicy(tc.policy).
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").FailurePolicy(tc.rjobPolicy).Obj()).
+				Obj()
+			js.Status.RestartTimes = tc.restartTimes
+			if got := restartRateLimitRemaining(js, []*batchv1.Job{failedJob("workers")}, now); got != tc.want {
+				t.Errorf("unexpected remaining time: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestExecuteFailurePolicyThrottlesRestarts(t *testing.T) {
+	start := time.Now()
+	js := testutils.MakeJobSet("js", "default").
+		FailurePolicy(&jobset.FailurePolicy{MaxRestarts: 3, MaxRestartsPerHour: ptr.To[int32](2)}).
+		Obj()
+	failedJobs := []*batchv1.Job{jobWithFailedCondition("job-0", start)}
+
+	steps := []struct {
+		after         time.Duration
+		wantRestarts  int32
+		wantRemaining time.Duration
+		wantFailed    bool
+	}{
+		{after: 0, wantRestarts: 1},
+		{after: time.Minute, wantRestarts: 2},
+		// The third restart within the hour is deferred until the first restart leaves the window.
+		{after: 2 * time.Minute, wantRestarts: 2, wantRemaining: 58 * time.Minute},
+		{after: 59 * time.Minute, wantRestarts: 2, wantRemaining: time.Minute},
+		{after: time.Hour, wantRestarts: 3},
+		// The absolute cap still applies once the rate allows a restart.
+		{after: 3 * time.Hour, wantRestarts: 3, wantFailed: true},
+	}
+	for _, step := range steps {
+		var updateStatusOpts statusUpdateOpts
+		remaining := executeFailurePolicy(context.TODO(), js, failedJobs, start.Add(step.after), &updateStatusOpts)
+		if remaining != step.wantRemaining {
+			t.Errorf("after %v: unexpected remaining time: got %v, want %v", step.after, remaining, step.wantRemaining)
+		}
+		if js.Status.Restarts != step.wantRestarts {
+			t.Errorf("after %v: unexpected restarts: got %d, want %d", step.after, js.Status.Restarts, step.wantRestarts)
+		}
+		if failed := meta.IsStatusConditionTrue(js.Status.Conditions, string(jobset.JobSetFailed)); failed != step.wantFailed {
+			t.Errorf("after %v: unexpected failed condition: got %v, want %v", step.after, failed, step.wantFailed)
+		}
+	}
+	// Only the restarts within the last hour are kept in the status.
+	if got := len(js.Status.RestartTimes); got != 2 {
+		t.Errorf("unexpected number of restart times: got %d, want 2", got)
+	}
+}
+
+func TestReconcileRestartCountEnvVar(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	tests := []struct {
+		name    string
+		inject  *bool
+		wantEnv []string
+	}{
+		{
+			name: "restart count is not injected by default",
+		},
+		{
+			name:    "restart count is injected into recreated jobs",
+			inject:  ptr.To(true),
+			wantEnv: []string{"init=1", "worker=1"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			jobTemplate := testutils.MakeJobTemplate("job", ns).
+				PodSpec(corev1.PodSpec{
+					InitContainers: []corev1.Container{{Name: "init"}},
+					Containers:     []corev1.Container{{Name: "worker"}},
+				}).
+				Obj()
+			jobTemplate.Spec.Parallelism = ptr.To[int32](1)
+			js := testutils.MakeJobSet(jobSetName, ns).
+				SuccessPolicy(&jobset.SuccessPolicy{Operator: jobset.OperatorAll}).
+				FailurePolicy(&jobset.FailurePolicy{MaxRestarts: 3}).
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").Job(jobTemplate).Replicas(1).Obj()).
+				Obj()
+			js.UID = "test-uid"
+			js.Spec.InjectRestartCountEnvVar = tc.inject
+			failed := makeJob(&makeJobArgs{
+				jobSetName:        jobSetName,
+				replicatedJobName: "workers",
+				jobName:           placement.GenJobName(jobSetName, "workers", 0),
+				ns:                ns,
+				replicas:          1,
+			}).Parallelism(1).Obj()
+			failed.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(js, jobset.GroupVersion.WithKind("JobSet"))}
+			failed.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, LastTransitionTime: metav1.Now()}}
+
+			fakeClient := newFakeClientBuilder().
+				WithObjects(js, failed).
+				WithStatusSubresource(js).
+				Build()
+			r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{})
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: jobSetName, Namespace: ns}}
+
+			// The first reconciliation restarts the JobSet, the second one deletes the failed Job,
+			// and the third one recreates it for the new run.
+			reconcileJobSet(t, r, req, 3)
+
+			var jobs batchv1.JobList
+			if err := fakeClient.List(context.TODO(), &jobs, client.InNamespace(ns)); err != nil {
+				t.Fatalf("unexpected error listing jobs: %v", err)
+			}
+			if len(jobs.Items) != 1 {
+				t.Fatalf("expected 1 recreated job, got %d", len(jobs.Items))
+			}
+			job := jobs.Items[0]
+			if job.Labels[constants.RestartsKey] != "1" {
+				t.Fatalf("expected the job of the first restart, got restart attempt %q", job.Labels[constants.RestartsKey])
+			}
+			var gotEnv []string
+			podSpec := job.Spec.Template.Spec
+			for _, c := range append(podSpec.InitContainers, podSpec.Containers...) {
+				for _, env := range c.Env {
+					if env.Name != constants.RestartCountEnvVar {
+						continue
+					}
+					if env.Value != job.Spec.Template.Labels[constants.RestartsKey] {
+						t.Errorf("container %q: restart count %q does not match the restarts label %q", c.Name, env.Value, job.Spec.Template.Labels[constants.RestartsKey])
+					}
+					gotEnv = append(gotEnv, c.Name+"="+env.Value)
+				}
+			}
+			if diff := cmp.Diff(tc.wantEnv, gotEnv); diff != "" {
+				t.Errorf("unexpected restart count env vars (-want/+got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestIsolateJobsFromRestart(t *testing.T) {
+	tests := []struct {
+		name         string
+		isolated     bool
+		failedRJob   string
+		wantRestarts map[string]string
+	}{
+		{
+			name:       "jobs of replicated jobs without restart isolation are restarted",
+			failedRJob: "coordinator",
+			wantRestarts: map[string]string{
+				"test-jobset-coordinator-0": "0",
+				"test-jobset-workers-0":     "0",
+				"test-jobset-workers-1":     "0",
+			},
+		},
+		{
+			name:       "jobs of isolated replicated jobs are kept across a restart caused by another replicated job",
+			isolated:   true,
+			failedRJob: "coordinator",
+			wantRestarts: map[string]string{
+				"test-jobset-coordinator-0": "0",
+				"test-jobset-workers-0":     "1",
+				"test-jobset-workers-1":     "1",
+			},
+		},
+		{
+			name:       "jobs of isolated replicated jobs are restarted when one of them failed",
+			isolated:   true,
+			failedRJob: "workers",
+			wantRestarts: map[string]string{
+				"test-jobset-coordinator-0": "0",
+				"test-jobset-workers-0":     "0",
+				"test-jobset-workers-1":     "0",
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet("test-jobset", "default").
+				ReplicatedJob(testutils.MakeReplicatedJob("coordinator").Replicas(1).Obj()).
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").Replicas(2).RestartIsolation(tc.isolated).Obj()).
+				Obj()
+			js.Status.Restarts = 1
+			ownedJobs := &childJobs{}
+			var jobs []client.Object
+			for _, args := range []*makeJobArgs{
+				{jobSetName: js.Name, replicatedJobName: "coordinator", jobName: "test-jobset-coordinator-0", ns: js.Namespace, replicas: 1, jobIdx: 0},
+				{jobSetName: js.Name, replicatedJobName: "workers", jobName: "test-jobset-workers-0", ns: js.Namespace, replicas: 2, jobIdx: 0},
+				{jobSetName: js.Name, replicatedJobName: "workers", jobName: "test-jobset-workers-1", ns: js.Namespace, replicas: 2, jobIdx: 1},
+			} {
+				job := makeJob(args).Obj()
+				jobs = append(jobs, job)
+				if args.replicatedJobName == tc.failedRJob && args.jobIdx == 0 {
+					ownedJobs.failed = append(ownedJobs.failed, job)
+				} else {
+					ownedJobs.active = append(ownedJobs.active, job)
+				}
+			}
+			fakeClient := newFakeClientBuilder().WithObjects(jobs...).Build()
+			r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(100), JobSetReconcilerOptions{})
+
+			if err := r.isolateJobsFromRestart(context.TODO(), js, ownedJobs, ownedJobs.failed); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var got batchv1.JobList
+			if err := fakeClient.List(context.TODO(), &got); err != nil {
+				t.Fatalf("unexpected error listing jobs: %v", err)
+			}
+			gotRestarts := map[string]string{}
+			for _, job := range got.Items {
+				gotRestarts[job.Name] = job.Labels[constants.RestartsKey]
+				if job.Annotations[constants.RestartsKey] != job.Labels[constants.RestartsKey] {
+					t.Errorf("restart attempt annotation %q of job %s does not match its label %q", job.Annotations[constants.RestartsKey], job.Name, job.Labels[constants.RestartsKey])
+				}
+			}
+			if diff := cmp.Diff(tc.wantRestarts, gotRestarts); diff != "" {
+				t.Errorf("unexpected restart attempts of the jobs (-want/+got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestIsolateJobsFromRestartDependencyGraph(t *testing.T) {
+	// A diamond of replicated jobs, loader -> {trainer, evaluator} -> exporter, along with an
+	// independent monitor.
+	tests := []struct {
+		name          string
+		failedRJob    string
+		isolatedRJob  string
+		wantRestarted []string
+	}{
+		{
+			name:          "failure of a replicated job without dependents restarts only that replicated job",
+			failedRJob:    "exporter",
+			wantRestarted: []string{"exporter"},
+		},
+		{
+			name:          "failure of one side of the diamond restarts its dependents",
+			failedRJob:    "trainer",
+			wantRestarted: []string{"trainer", "exporter"},
+		},
+		{
+			name:          "failure of the root of the diamond restarts all of its transitive dependents",
+			failedRJob:    "loader",
+			wantRestarted: []string{"loader", "trainer", "evaluator", "exporter"},
+		},
+		{
+			name:          "isolated replicated jobs don't propagate the restart to their dependents",
+			failedRJob:    "loader",
+			isolatedRJob:  "trainer",
+			wantRestarted: []string{"loader", "evaluator", "exporter"},
+		},
+		{
+			name:          "failure of the independent replicated job restarts only that replicated job",
+			failedRJob:    "monitor",
+			wantRestarted: []string{"monitor"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dependencies := map[string][]string{
+				"loader":    nil,
+				"trainer":   {"loader"},
+				"evaluator": {"loader"},
+				"exporter":  {"trainer", "evaluator"},
+				"monitor":   nil,
+			}
+			builder := testutils.MakeJobSet("test-jobset", "default")
+			ownedJobs := &childJobs{}
+			var jobs []client.Object
+			for _, name := range []string{"loader", "trainer", "evaluator", "exporter", "monitor"} {
+				builder.ReplicatedJob(testutils.MakeReplicatedJob(name).Replicas(1).DependsOn(dependencies[name]...).RestartIsolation(name == tc.isolatedRJob).Obj())
+				job := makeJob(&makeJobArgs{jobSetName: "test-jobset", replicatedJobName: name, jobName: "test-jobset-" + name + "-0", ns: "default", replicas: 1}).Obj()
+				jobs = append(jobs, job)
+				if name == tc.failedRJob {
+					ownedJobs.failed = append(ownedJobs.failed, job)
+				} else {
+					ownedJobs.active = append(ownedJobs.active, job)
+				}
+			}
+			js := builder.Obj()
+			js.Status.Restarts = 1
+			fakeClient := newFakeClientBuilder().WithObjects(jobs...).Build()
+			r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(100), JobSetReconcilerOptions{})
+
+			if err := r.isolateJobsFromRestart(context.TODO(), js, ownedJobs, ownedJobs.failed); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var got batchv1.JobList
+			if err := fakeClient.List(context.TODO(), &got); err != nil {
+				t.Fatalf("unexpected error listing jobs: %v", err)
+			}
+			var gotRestarted []string
+			for _, job := range got.Items {
+				if job.Labels[constants.RestartsKey] == "0" {
+					gotRestarted = append(gotRestarted, job.Labels[jobset.ReplicatedJobNameKey])
+				}
+			}
+			if diff := cmp.Diff(tc.wantRestarted, gotRestarted, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("unexpected restarted replicated jobs (-want/+got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestReconcileRestartIsolation(t *testing.T) {
+	jobTemplate := testutils.MakeJobTemplate("job", "default").Obj()
+	jobTemplate.Spec.Parallelism = ptr.To[int32](1)
+	js := testutils.MakeJobSet("test-jobset", "default").
+		SuccessPolicy(&jobset.SuccessPolicy{Operator: jobset.OperatorAll}).
+		FailurePolicy(&jobset.FailurePolicy{MaxRestarts: 1}).
+		ReplicatedJob(testutils.MakeReplicatedJob("coordinator").Job(jobTemplate).Replicas(1).Obj()).
+		ReplicatedJob(testutils.MakeReplicatedJob("workers").Job(jobTemplate).Replicas(2).RestartIsolation(true).Obj()).
+		Obj()
+	fakeClient := newFakeClientBuilder().
+		WithObjects(js).
+		WithStatusSubresource(js, &batchv1.Job{}).
+		Build()
+	r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(100), JobSetReconcilerOptions{})
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: js.Name, Namespace: js.Namespace}}
+	listJobs := func(step string) map[string]batchv1.Job {
+		var jobs batchv1.JobList
+		if err := fakeClient.List(context.TODO(), &jobs, client.InNamespace(js.Namespace)); err != nil {
+			t.Fatalf("%s: unexpected error listing jobs: %v", step, err)
+		}
+		byName := map[string]batchv1.Job{}
+		for _, job := range jobs.Items {
+			byName[job.Name] = job
+		}
+		return byName
+	}
+
+	reconcileJobSet(t, r, req, 1)
+	before := listJobs("create jobs")
+	if len(before) != 3 {
+		t.Fatalf("expected 3 jobs to be created, got %d", len(before))
+	}
+
+	// Mark the workers as ready and fail the coordinator Job, which restarts the JobSet.
+	for name, job := range before {
+		if name == "test-jobset-coordinator-0" {
+			job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}}
+		} else {
+			job.Status.Ready = ptr.To[int32](1)
+		}
+		if err := fakeClient.Status().Update(context.TODO(), &job); err != nil {
+			t.Fatalf("unexpected error updating job status: %v", err)
+		}
+	}
+	// The JobSet restarts, deletes the coordinator Job and then recreates it.
+	reconcileJobSet(t, r, req, 3)
+
+	var got jobset.JobSet
+	if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+		t.Fatalf("unexpected error getting jobset: %v", err)
+	}
+	if got.Status.Restarts != 1 {
+		t.Errorf("unexpected restarts: got %d, want 1", got.Status.Restarts)
+	}
+	// The coordinator Job was recreated without its failure, the worker Jobs kept running.
+	after := listJobs("recreate coordinator")
+	for name := range before {
+		job, ok := after[name]
+		if !ok {
+			t.Errorf("expected job %s to exist after the restart", name)
+			continue
+		}
+		if job.Labels[constants.RestartsKey] != "1" {
+			t.Errorf("unexpected restart attempt of job %s: got %q, want %q", name, job.Labels[constants.RestartsKey], "1")
+		}
+		if name == "test-jobset-coordinator-0" {
+			if len(job.Status.Conditions) != 0 {
+				t.Errorf("expected job %s to be recreated, got conditions %v", name, job.Status.Conditions)
+			}
+		} else if ptr.Deref(job.Status.Ready, 0) != 1 {
+			t.Errorf("expected job %s to keep running across the restart", name)
+		}
+	}
+}
@@ -0,0 +1,298 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	jobset "sigs.k8s.io/jobset/api/jobset/v1alpha2"
+	"sigs.k8s.io/jobset/pkg/constants"
+)
+
+// headlessSvcSelector returns the selector of the headless service created for the JobSet, which
+// is the JobSet name label merged with the custom service selector, if any.
+func headlessSvcSelector(js *jobset.JobSet) map[string]string {
+	selector := map[string]string{}
+	if js.Spec.Network != nil {
+		for key, value := range js.Spec.Network.ServiceSelector {
+			selector[key] = value
+		}
+	}
+	selector[jobset.JobSetNameKey] = js.Name
+	return selector
+}
+
+// reconcileExistingHeadlessSvc verifies that an existing headless service selects the pods of
+// the JobSet. A service shared with other JobSets is accepted as long as its selector matches
+// labels of all pods of the JobSet. Otherwise, the headless service is applied again over a
+// service controlled by the JobSet, which updates its selector, and over a service not
+// controlled by any object if adoption is enabled. Any remaining conflict is reported in the
+// NetworkServiceConflict condition.
+func (r *JobSetReconciler) reconcileExistingHeadlessSvc(ctx context.Context, js *jobset.JobSet, svc *corev1.Service, updateStatusOpts *statusUpdateOpts) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	conflict := headlessSvcConflict(js, svc)
+	owned := metav1.IsControlledBy(svc, js)
+	adopt := !owned && r.opts.AdoptHeadlessServices && metav1.GetControllerOf(svc) == nil
+	// The cluster IP of a service is immutable, so a service which is not headless can't be fixed.
+	if conflict != "" && svc.Spec.ClusterIP == corev1.ClusterIPNone && (owned || adopt) {
+		if err := r.applyHeadlessSvc(ctx, js); err != nil {
+			return err
+		}
+		log.V(2).Info("successfully reconciled headless service", "service", klog.KObj(svc), "adopted", adopt)
+		conflict = ""
+	}
+	setNetworkServiceConflictCondition(js, conflict, updateStatusOpts)
+	return nil
+}
+
+// applyHeadlessSvc creates or updates the headless service of the JobSet with a server-side
+// apply, setting the JobSet as its controller owner for garbage collection and reconciliation.
+func (r *JobSetReconciler) applyHeadlessSvc(ctx context.Context, js *jobset.JobSet) error {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetSubdomain(js),
+			Namespace: js.Namespace,
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP:                corev1.ClusterIPNone,
+			Selector:                 headlessSvcSelector(js),
+			PublishNotReadyAddresses: ptr.Deref(js.Spec.Network.PublishNotReadyAddresses, true),
+		},
+	}
+	if err := r.setOwnerReference(js, svc); err != nil {
+		return err
+	}
+	return r.apply(ctx, svc)
+}
+
+// headlessSvcConflict returns a message describing why the service can't be used as the
+// headless service of the JobSet, or an empty string if it can.
+func headlessSvcConflict(js *jobset.JobSet, svc *corev1.Service) string {
+	if svc.Spec.ClusterIP != corev1.ClusterIPNone {
+		return fmt.Sprintf("service %q is not headless, its clusterIP is %q", svc.Name, svc.Spec.ClusterIP)
+	}
+	if !selectsJobSetPods(js, svc.Spec.Selector) {
+		return fmt.Sprintf("service %q has selector %v, which does not select the pods of the jobset", svc.Name, svc.Spec.Selector)
+	}
+	return ""
+}
+
+// selectsJobSetPods returns true if the selector is not empty and only matches labels set on
+// all pods of the JobSet, i.e. the JobSet name label and the JobSet level labels, or labels of
+// the custom service selector.
+func selectsJobSetPods(js *jobset.JobSet, selector map[string]string) bool {
+	if len(selector) == 0 {
+		return false
+	}
+	desired := headlessSvcSelector(js)
+	for key, value := range selector {
+		if desiredValue, ok := desired[key]; ok {
+			if value != desiredValue {
+				return false
+			}
+			continue
+		}
+		if podValue, ok := js.Spec.Labels[key]; !ok || podValue != value {
+			return false
+		}
+	}
+	return true
+}
+
+// setNetworkServiceConflictCondition sets the NetworkServiceConflict condition of the JobSet
+// based on the message describing the conflict, if any. A JobSet without conflict only gets
+// the condition if it previously had a conflict.
+func setNetworkServiceConflictCondition(js *jobset.JobSet, conflict string, updateStatusOpts *statusUpdateOpts) {
+	if conflict == "" {
+		if meta.FindStatusCondition(js.Status.Conditions, string(jobset.JobSetNetworkServiceConflict)) == nil {
+			return
+		}
+		setStatusCondition(js, &conditionOpts{
+			eventType: corev1.EventTypeNormal,
+			condition: &metav1.Condition{
+				Type:    string(jobset.JobSetNetworkServiceConflict),
+				Status:  metav1.ConditionFalse,
+				Reason:  constants.HeadlessServiceValidReason,
+				Message: constants.HeadlessServiceValidMessage,
+			},
+		}, updateStatusOpts)
+		return
+	}
+	setStatusCondition(js, &conditionOpts{
+		eventType: corev1.EventTypeWarning,
+		condition: &metav1.Condition{
+			Type:    string(jobset.JobSetNetworkServiceConflict),
+			Status:  metav1.ConditionTrue,
+			Reason:  constants.HeadlessServiceConflictReason,
+			Message: conflict,
+		},
+	}, updateStatusOpts)
+}
+
+// setNetworkManagementDisabledCondition sets the NetworkManagementDisabled condition of a JobSet
+// with DNS hostnames enabled, whose headless service is not created by the controller since
+// network management is disabled. A JobSet only gets the condition set to false if it previously
+// had the condition.
+func setNetworkManagementDisabledCondition(js *jobset.JobSet, disabled bool, updateStatusOpts *statusUpdateOpts) {
+	if !disabled {
+		if meta.FindStatusCondition(js.Status.Conditions, string(jobset.JobSetNetworkManagementDisabled)) == nil {
+			return
+		}
+		setCondition(js, &conditionOpts{
+			eventType: corev1.EventTypeNormal,
+			condition: &metav1.Condition{
+				Type:    string(jobset.JobSetNetworkManagementDisabled),
+				Status:  metav1.ConditionFalse,
+				Reason:  constants.NetworkManagementEnabledReason,
+				Message: constants.NetworkManagementEnabledMessage,
+			},
+		}, updateStatusOpts)
+		return
+	}
+	setCondition(js, &conditionOpts{
+		eventType: corev1.EventTypeWarning,
+		condition: &metav1.Condition{
+			Type:    string(jobset.JobSetNetworkManagementDisabled),
+			Status:  metav1.ConditionTrue,
+			Reason:  constants.NetworkManagementDisabledReason,
+			Message: fmt.Sprintf(constants.NetworkManagementDisabledMessage, GetSubdomain(js)),
+		},
+	}, updateStatusOpts)
+}
+
+// setNetworkReadyCondition sets the NetworkReady condition of the JobSet, which is true once its
+// headless service exists and has endpoints, so the pod DNS hostnames can be resolved, and right
+// away if pod DNS hostnames are disabled. The condition is not managed if network management is
+// disabled, since the controller may not have permissions on services then. It returns true if the
+// network of the JobSet is not ready yet, so the endpoints must be checked again later.
+func (r *JobSetReconciler) setNetworkReadyCondition(ctx context.Context, js *jobset.JobSet, updateStatusOpts *statusUpdateOpts) (bool, error) {
+	if !dnsHostnamesEnabled(js) {
+		setStatusCondition(js, makeNetworkReadyConditionOpts(metav1.ConditionTrue, constants.DNSHostnamesDisabledReason, constants.DNSHostnamesDisabledMessage), updateStatusOpts)
+		return false, nil
+	}
+	if r.opts.DisableNetworkManagement {
+		return false, nil
+	}
+
+	subdomain := GetSubdomain(js)
+	var svc corev1.Service
+	if err := r.Get(ctx, types.NamespacedName{Name: subdomain, Namespace: js.Namespace}, &svc); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return false, err
+		}
+		setStatusCondition(js, makeNetworkReadyConditionOpts(metav1.ConditionFalse, constants.HeadlessServiceNotFoundReason, fmt.Sprintf(constants.HeadlessServiceNotFoundMessage, subdomain)), updateStatusOpts)
+		return true, nil
+	}
+
+	var slices discoveryv1.EndpointSliceList
+	if err := r.List(ctx, &slices, client.InNamespace(js.Namespace), client.MatchingLabels{discoveryv1.LabelServiceName: subdomain}); err != nil {
+		return false, err
+	}
+	for _, slice := range slices.Items {
+		if len(slice.Endpoints) > 0 {
+			setStatusCondition(js, makeNetworkReadyConditionOpts(metav1.ConditionTrue, constants.HeadlessServiceReadyReason, fmt.Sprintf(constants.HeadlessServiceReadyMessage, subdomain)), updateStatusOpts)
+			return false, nil
+		}
+	}
+	setStatusCondition(js, makeNetworkReadyConditionOpts(metav1.ConditionFalse, constants.HeadlessServiceNoEndpointsReason, fmt.Sprintf(constants.HeadlessServiceNoEndpointsMessage, subdomain)), updateStatusOpts)
+	return true, nil
+}
+
+func makeNetworkReadyConditionOpts(status metav1.ConditionStatus, reason, message string) *conditionOpts {
+	return &conditionOpts{
+		eventType: corev1.EventTypeNormal,
+		condition: &metav1.Condition{
+			Type:    string(jobset.JobSetNetworkReady),
+			Status:  status,
+			Reason:  reason,
+			Message: message,
+		},
+	}
+}
+
+// createCoordinatorSvcIfNecessary creates the Service selecting only the coordinator pod
+// of the JobSet, if spec.network.coordinatorService is set.
+func (r *JobSetReconciler) createCoordinatorSvcIfNecessary(ctx context.Context, js *jobset.JobSet) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	if r.opts.DisableNetworkManagement || js.Spec.Network == nil || js.Spec.Network.CoordinatorService == nil || js.Spec.Coordinator == nil {
+		return nil
+	}
+
+	var svc corev1.Service
+	name := coordinatorServiceName(js)
+	if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: js.Namespace}, &svc); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+		svc := constructCoordinatorService(js)
+
+		// Set controller owner reference for garbage collection and reconcilation.
+		if err := r.setOwnerReference(js, svc); err != nil {
+			return err
+		}
+
+		if err := r.apply(ctx, svc); err != nil {
+			return err
+		}
+		log.V(2).Info("successfully created coordinator service", "service", klog.KObj(svc))
+	}
+	return nil
+}
+
+// constructCoordinatorService returns the Service selecting only the coordinator pod, which
+// is identified by the coordinator label on the pods of its Job and its completion index.
+func constructCoordinatorService(js *jobset.JobSet) *corev1.Service {
+	coordinatorSvc := js.Spec.Network.CoordinatorService
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      coordinatorServiceName(js),
+			Namespace: js.Namespace,
+		},
+		Spec: corev1.ServiceSpec{
+			Type:  coordinatorSvc.Type,
+			Ports: coordinatorSvc.Ports,
+			Selector: map[string]string{
+				jobset.JobSetNameKey:                 js.Name,
+				jobset.CoordinatorKey:                "true",
+				batchv1.JobCompletionIndexAnnotation: strconv.Itoa(int(js.Spec.Coordinator.PodIndex)),
+			},
+		},
+	}
+}
+
+// isCoordinatorJob returns true if the Job at the given index of the ReplicatedJob and instance
+// contains the coordinator pod of the JobSet. The coordinator is always part of the first instance.
+func isCoordinatorJob(js *jobset.JobSet, rjob *jobset.ReplicatedJob, instanceIdx, jobIdx int) bool {
+	return js.Spec.Coordinator != nil && js.Spec.Coordinator.ReplicatedJob == rjob.Name && instanceIdx == 0 && int(js.Spec.Coordinator.JobIndex) == jobIdx
+}
+
+func coordinatorServiceName(js *jobset.JobSet) string {
+	return js.Name + "-coordinator"
+}
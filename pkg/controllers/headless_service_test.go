@@ -0,0 +1,635 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	jobset "sigs.k8s.io/jobset/api/jobset/v1alpha2"
+	"sigs.k8s.io/jobset/pkg/constants"
+	testutils "sigs.k8s.io/jobset/pkg/util/testing"
+)
+
+func TestReconcileExistingHeadlessSvc(t *testing.T) {
+	newJobSet := func() *jobset.JobSet {
+		js := testutils.MakeJobSet("js", "default").
+			EnableDNSHostnames(true).
+			NetworkSubdomain("svc").
+			Obj()
+		js.UID = "js-uid"
+		js.Spec.Labels = map[string]string{"team": "ml"}
+		return js
+	}
+	otherJobSet := testutils.MakeJobSet("other", "default").Obj()
+	otherJobSet.UID = "other-uid"
+	service := func(clusterIP string, selector map[string]string, owner *jobset.JobSet) *corev1.Service {
+		svc := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "default"},
+			Spec:       corev1.ServiceSpec{ClusterIP: clusterIP, Selector: selector},
+		}
+		if owner != nil {
+			svc.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(owner, jobset.GroupVersion.WithKind("JobSet"))}
+		}
+		return svc
+	}
+
+	tests := []struct {
+		name         string
+		svc          func(js *jobset.JobSet) *corev1.Service
+		adopt        bool
+		wantSelector map[string]string
+		wantOwner    string
+		// wantCondition is the status of the NetworkServiceConflict condition, if any.
+		wantCondition metav1.ConditionStatus
+	}{
+		{
+			name: "owned service with the expected selector",
+			svc: func(js *jobset.JobSet) *corev1.Service {
+				return service(corev1.ClusterIPNone, map[string]string{jobset.JobSetNameKey: "js"}, js)
+			},
+			wantSelector: map[string]string{jobset.JobSetNameKey: "js"},
+			wantOwner:    "js",
+		},
+		{
+			name: "owned service with a wrong selector is updated",
+			svc: func(js *jobset.JobSet) *corev1.Service {
+				return service(corev1.ClusterIPNone, map[string]string{"app": "web"}, js)
+			},
+			wantSelector: map[string]string{jobset.JobSetNameKey: "js"},
+			wantOwner:    "js",
+		},
+		{
+			name: "shared service selecting the jobset level labels is accepted",
+			svc: func(js *jobset.JobSet) *corev1.Service {
+				return service(corev1.ClusterIPNone, map[string]string{"team": "ml"}, otherJobSet)
+			},
+			wantSelector: map[string]string{"team": "ml"},
+			wantOwner:    "other",
+		},
+		{
+			name: "not owned service with a wrong selector is a conflict",
+			svc: func(js *jobset.JobSet) *corev1.Service {
+				return service(corev1.ClusterIPNone, map[string]string{"app": "web"}, nil)
+			},
+			wantSelector:  map[string]string{"app": "web"},
+			wantCondition: metav1.ConditionTrue,
+		},
+		{
+			name: "not owned service with a wrong selector is adopted if enabled",
+			svc: func(js *jobset.JobSet) *corev1.Service {
+				return service(corev1.ClusterIPNone, map[string]string{"app": "web"}, nil)
+			},
+			adopt:        true,
+			wantSelector: map[string]string{jobset.JobSetNameKey: "js"},
+			wantOwner:    "js",
+		},
+		{
+			name: "service controlled by another jobset is never adopted",
+			svc: func(js *jobset.JobSet) *corev1.Service {
+				return service(corev1.ClusterIPNone, map[string]string{jobset.JobSetNameKey: "other"}, otherJobSet)
+			},
+			adopt:         true,
+			wantSelector:  map[string]string{jobset.JobSetNameKey: "other"},
+			wantOwner:     "other",
+			wantCondition: metav1.ConditionTrue,
+		},
+		{
+			name: "owned service which is not headless is a conflict",
+			svc: func(js *jobset.JobSet) *corev1.Service {
+				return service("10.0.0.1", map[string]string{jobset.JobSetNameKey: "js"}, js)
+			},
+			wantSelector:  map[string]string{jobset.JobSetNameKey: "js"},
+			wantOwner:     "js",
+			wantCondition: metav1.ConditionTrue,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := newJobSet()
+			svc := tc.svc(js)
+			fakeClient := newFakeClientBuilder().WithObjects(svc).Build()
+			r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{AdoptHeadlessServices: tc.adopt})
+
+			opts := &statusUpdateOpts{}
+			if _, err := r.createHeadlessSvcIfNecessary(context.TODO(), js, opts); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var got corev1.Service
+			if err := fakeClient.Get(context.TODO(), types.NamespacedName{Name: "svc", Namespace: "default"}, &got); err != nil {
+				t.Fatalf("unexpected error getting service: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantSelector, got.Spec.Selector); diff != "" {
+				t.Errorf("unexpected selector (-want/+got): %s", diff)
+			}
+			var gotOwner string
+			if owner := metav1.GetControllerOf(&got); owner != nil {
+				gotOwner = owner.Name
+			}
+			if gotOwner != tc.wantOwner {
+				t.Errorf("expected service controlled by %q, got %q", tc.wantOwner, gotOwner)
+			}
+			var gotCondition metav1.ConditionStatus
+			if cond := meta.FindStatusCondition(js.Status.Conditions, string(jobset.JobSetNetworkServiceConflict)); cond != nil {
+				gotCondition = cond.Status
+			}
+			if gotCondition != tc.wantCondition {
+				t.Errorf("expected %s condition status %q, got %q", jobset.JobSetNetworkServiceConflict, tc.wantCondition, gotCondition)
+			}
+		})
+	}
+}
+
+func TestNetworkServiceConflictConditionCleared(t *testing.T) {
+	js := testutils.MakeJobSet("js", "default").Obj()
+	opts := &statusUpdateOpts{}
+
+	// No condition is added to a JobSet which never had a conflict.
+	setNetworkServiceConflictCondition(js, "", opts)
+	if len(js.Status.Conditions) != 0 || opts.shouldUpdate {
+		t.Fatalf("expected no condition, got %v", js.Status.Conditions)
+	}
+
+	setNetworkServiceConflictCondition(js, "conflict", opts)
+	if !meta.IsStatusConditionTrue(js.Status.Conditions, string(jobset.JobSetNetworkServiceConflict)) {
+		t.Fatalf("expected %s condition to be true, got %v", jobset.JobSetNetworkServiceConflict, js.Status.Conditions)
+	}
+
+	// A different conflict updates the message of the condition.
+	setNetworkServiceConflictCondition(js, "another conflict", opts)
+	if cond := meta.FindStatusCondition(js.Status.Conditions, string(jobset.JobSetNetworkServiceConflict)); cond.Message != "another conflict" {
+		t.Fatalf("unexpected message of the %s condition: got %q, want %q", jobset.JobSetNetworkServiceConflict, cond.Message, "another conflict")
+	}
+
+	// Once the conflict is resolved, the condition is set to false.
+	setNetworkServiceConflictCondition(js, "", opts)
+	if !meta.IsStatusConditionFalse(js.Status.Conditions, string(jobset.JobSetNetworkServiceConflict)) {
+		t.Errorf("expected %s condition to be false, got %v", jobset.JobSetNetworkServiceConflict, js.Status.Conditions)
+	}
+}
+
+func TestReconcileWithNetworkManagementDisabled(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	tests := []struct {
+		name                     string
+		disableNetworkManagement bool
+		wantServices             int
+		// wantCondition is the status of the NetworkManagementDisabled condition, if any.
+		wantCondition metav1.ConditionStatus
+	}{
+		{
+			name:         "network management enabled",
+			wantServices: 1,
+		},
+		{
+			name:                     "network management disabled",
+			disableNetworkManagement: true,
+			wantCondition:            metav1.ConditionTrue,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet(jobSetName, ns).
+				EnableDNSHostnames(true).
+				NetworkSubdomain("svc").
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").Replicas(2).Obj()).
+				Obj()
+			js.Spec.ReplicatedJobs[0].Template.Spec.Parallelism = ptr.To[int32](1)
+			fakeClient := newFakeClientBuilder().
+				WithObjects(js).
+				WithStatusSubresource(js).
+				Build()
+			r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{DisableNetworkManagement: tc.disableNetworkManagement})
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: jobSetName, Namespace: ns}}
+			// With network management enabled, the jobs are only created once the headless service
+			// created by the first reconciliation is observed.
+			reconcileJobSet(t, r, req, 2)
+
+			var services corev1.ServiceList
+			if err := fakeClient.List(context.TODO(), &services, client.InNamespace(ns)); err != nil {
+				t.Fatalf("unexpected error listing services: %v", err)
+			}
+			if len(services.Items) != tc.wantServices {
+				t.Errorf("expected %d services, got %d", tc.wantServices, len(services.Items))
+			}
+
+			// The subdomain is set on the pods regardless of network management.
+			var jobs batchv1.JobList
+			if err := fakeClient.List(context.TODO(), &jobs, client.InNamespace(ns)); err != nil {
+				t.Fatalf("unexpected error listing jobs: %v", err)
+			}
+			if len(jobs.Items) != 2 {
+				t.Fatalf("expected 2 jobs, got %d", len(jobs.Items))
+			}
+			for _, job := range jobs.Items {
+				if got := job.Spec.Template.Spec.Subdomain; got != "svc" {
+					t.Errorf("unexpected subdomain of job %s: got %q, want %q", job.Name, got, "svc")
+				}
+			}
+
+			var got jobset.JobSet
+			if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+				t.Fatalf("unexpected error getting jobset: %v", err)
+			}
+			var gotCondition metav1.ConditionStatus
+			if cond := meta.FindStatusCondition(got.Status.Conditions, string(jobset.JobSetNetworkManagementDisabled)); cond != nil {
+				gotCondition = cond.Status
+			}
+			if gotCondition != tc.wantCondition {
+				t.Errorf("expected %s condition status %q, got %q", jobset.JobSetNetworkManagementDisabled, tc.wantCondition, gotCondition)
+			}
+		})
+	}
+}
+
+func TestReconcileCreatesHeadlessSvcBeforeJobs(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	js := testutils.MakeJobSet(jobSetName, ns).
+		EnableDNSHostnames(true).
+		NetworkSubdomain("svc").
+		ReplicatedJob(testutils.MakeReplicatedJob("workers").Replicas(2).Obj()).
+		Obj()
+	js.Spec.ReplicatedJobs[0].Template.Spec.Parallelism = ptr.To[int32](1)
+
+	// Record the kinds of the created objects, in order.
+	var created []string
+	fakeClient := newFakeClientBuilder().
+		WithObjects(js).
+		WithStatusSubresource(js).
+		WithInterceptorFuncs(withServerSideApply(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				switch obj.(type) {
+				case *corev1.Service:
+					created = append(created, "Service")
+				case *batchv1.Job:
+					created = append(created, "Job")
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		})).
+		Build()
+	r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{})
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: jobSetName, Namespace: ns}}
+
+	// The first reconciliation only creates the headless service, and is requeued.
+	result := reconcileJobSet(t, r, req, 1)
+	if !result.Requeue {
+		t.Errorf("expected the reconciliation to be requeued until the headless service is observed, got %+v", result)
+	}
+	if diff := cmp.Diff([]string{"Service"}, created); diff != "" {
+		t.Errorf("unexpected objects created by the first reconciliation (-want/+got): %s", diff)
+	}
+
+	// Once the headless service is observed, the jobs are created.
+	reconcileJobSet(t, r, req, 1)
+	if diff := cmp.Diff([]string{"Service", "Job", "Job"}, created); diff != "" {
+		t.Errorf("unexpected objects created (-want/+got): %s", diff)
+	}
+}
+
+func TestReconcileHeadlessSvcSelector(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	tests := []struct {
+		name            string
+		serviceSelector map[string]string
+		wantSelector    map[string]string
+	}{
+		{
+			name:         "default selector",
+			wantSelector: map[string]string{jobset.JobSetNameKey: jobSetName},
+		},
+		{
+			name:            "custom selector keys are merged into the default selector",
+			serviceSelector: map[string]string{"role": "worker", "dns": "enabled"},
+			wantSelector:    map[string]string{jobset.JobSetNameKey: jobSetName, "role": "worker", "dns": "enabled"},
+		},
+		{
+			name:            "jobset name key can't be overridden",
+			serviceSelector: map[string]string{jobset.JobSetNameKey: "other", "role": "worker"},
+			wantSelector:    map[string]string{jobset.JobSetNameKey: jobSetName, "role": "worker"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			jobTemplate := testutils.MakeJobTemplate("job", ns).Obj()
+			jobTemplate.Spec.Parallelism = ptr.To[int32](1)
+			js := testutils.MakeJobSet(jobSetName, ns).
+				EnableDNSHostnames(true).
+				NetworkSubdomain("svc").
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").Job(jobTemplate).Replicas(1).Obj()).
+				Obj()
+			js.Spec.Network.ServiceSelector = tc.serviceSelector
+			fakeClient := newFakeClientBuilder().
+				WithObjects(js).
+				WithStatusSubresource(js).
+				Build()
+			r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{})
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: jobSetName, Namespace: ns}}
+			reconcileJobSet(t, r, req, 1)
+
+			var svc corev1.Service
+			if err := fakeClient.Get(context.TODO(), types.NamespacedName{Name: "svc", Namespace: ns}, &svc); err != nil {
+				t.Fatalf("unexpected error getting headless service: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantSelector, svc.Spec.Selector); diff != "" {
+				t.Errorf("unexpected headless service selector (-want/+got): %s", diff)
+			}
+
+			// The service created with the custom selector is not reported as conflicting.
+			reconcileJobSet(t, r, req, 1)
+			var got jobset.JobSet
+			if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+				t.Fatalf("unexpected error getting jobset: %v", err)
+			}
+			if meta.IsStatusConditionTrue(got.Status.Conditions, string(jobset.JobSetNetworkServiceConflict)) {
+				t.Errorf("unexpected %s condition", jobset.JobSetNetworkServiceConflict)
+			}
+		})
+	}
+}
+
+func TestSetNetworkReadyCondition(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	headlessSvc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: ns},
+		Spec:       corev1.ServiceSpec{ClusterIP: corev1.ClusterIPNone},
+	}
+	endpointSlice := func(name string, endpoints ...discoveryv1.Endpoint) *discoveryv1.EndpointSlice {
+		return &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: ns,
+				Labels:    map[string]string{discoveryv1.LabelServiceName: "svc"},
+			},
+			AddressType: discoveryv1.AddressTypeIPv4,
+			Endpoints:   endpoints,
+		}
+	}
+
+	lastTransitionTime := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name                     string
+		dnsHostnames             bool
+		disableNetworkManagement bool
+		objs                     []client.Object
+		conditions               []metav1.Condition
+		wantNotReady             bool
+		// wantCondition is the NetworkReady condition, if any.
+		wantCondition *metav1.Condition
+		// wantTransitionKept is true if the last transition time of the condition is kept.
+		wantTransitionKept bool
+	}{
+		{
+			name: "dns hostnames disabled",
+			wantCondition: &metav1.Condition{
+				Type:   string(jobset.JobSetNetworkReady),
+				Status: metav1.ConditionTrue,
+				Reason: constants.DNSHostnamesDisabledReason,
+			},
+		},
+		{
+			name:         "headless service does not exist",
+			dnsHostnames: true,
+			wantNotReady: true,
+			wantCondition: &metav1.Condition{
+				Type:   string(jobset.JobSetNetworkReady),
+				Status: metav1.ConditionFalse,
+				Reason: constants.HeadlessServiceNotFoundReason,
+			},
+		},
+		{
+			name:         "headless service has no endpoint slices",
+			dnsHostnames: true,
+			objs:         []client.Object{headlessSvc.DeepCopy()},
+			wantNotReady: true,
+			wantCondition: &metav1.Condition{
+				Type:   string(jobset.JobSetNetworkReady),
+				Status: metav1.ConditionFalse,
+				Reason: constants.HeadlessServiceNoEndpointsReason,
+			},
+		},
+		{
+			name:         "endpoint slices of the headless service are empty",
+			dnsHostnames: true,
+			objs:         []client.Object{headlessSvc.DeepCopy(), endpointSlice("svc-a")},
+			wantNotReady: true,
+			wantCondition: &metav1.Condition{
+				Type:   string(jobset.JobSetNetworkReady),
+				Status: metav1.ConditionFalse,
+				Reason: constants.HeadlessServiceNoEndpointsReason,
+			},
+		},
+		{
+			name:         "headless service has endpoints",
+			dnsHostnames: true,
+			objs: []client.Object{
+				headlessSvc.DeepCopy(),
+				endpointSlice("svc-a"),
+				endpointSlice("svc-b", discoveryv1.Endpoint{Addresses: []string{"10.0.0.1"}}),
+			},
+			wantCondition: &metav1.Condition{
+				Type:   string(jobset.JobSetNetworkReady),
+				Status: metav1.ConditionTrue,
+				Reason: constants.HeadlessServiceReadyReason,
+			},
+		},
+		{
+			name:         "endpoints of a ready network are gone",
+			dnsHostnames: true,
+			objs:         []client.Object{headlessSvc.DeepCopy(), endpointSlice("svc-a")},
+			conditions: []metav1.Condition{{
+				Type:   string(jobset.JobSetNetworkReady),
+				Status: metav1.ConditionTrue,
+				Reason: constants.HeadlessServiceReadyReason,
+			}},
+			wantNotReady: true,
+			wantCondition: &metav1.Condition{
+				Type:   string(jobset.JobSetNetworkReady),
+				Status: metav1.ConditionFalse,
+				Reason: constants.HeadlessServiceNoEndpointsReason,
+			},
+		},
+		{
+			name:         "reason of a network which is still not ready is updated",
+			dnsHostnames: true,
+			objs:         []client.Object{headlessSvc.DeepCopy()},
+			conditions: []metav1.Condition{{
+				Type:               string(jobset.JobSetNetworkReady),
+				Status:             metav1.ConditionFalse,
+				LastTransitionTime: lastTransitionTime,
+				Reason:             constants.HeadlessServiceNotFoundReason,
+				Message:            fmt.Sprintf(constants.HeadlessServiceNotFoundMessage, "svc"),
+			}},
+			wantNotReady: true,
+			wantCondition: &metav1.Condition{
+				Type:   string(jobset.JobSetNetworkReady),
+				Status: metav1.ConditionFalse,
+				Reason: constants.HeadlessServiceNoEndpointsReason,
+			},
+			wantTransitionKept: true,
+		},
+		{
+			name:                     "network management disabled",
+			dnsHostnames:             true,
+			disableNetworkManagement: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet(jobSetName, ns).
+				EnableDNSHostnames(tc.dnsHostnames).
+				NetworkSubdomain("svc").
+				Conditions(tc.conditions).
+				Obj()
+			fakeClient := newFakeClientBuilder().
+				WithObjects(tc.objs...).
+				Build()
+			r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{DisableNetworkManagement: tc.disableNetworkManagement})
+
+			updateStatusOpts := &statusUpdateOpts{}
+			gotNotReady, err := r.setNetworkReadyCondition(context.TODO(), js, updateStatusOpts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if gotNotReady != tc.wantNotReady {
+				t.Errorf("unexpected not ready: got %v, want %v", gotNotReady, tc.wantNotReady)
+			}
+			gotCondition := meta.FindStatusCondition(js.Status.Conditions, string(jobset.JobSetNetworkReady))
+			if diff := cmp.Diff(tc.wantCondition, gotCondition, cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime", "Message")); diff != "" {
+				t.Errorf("unexpected NetworkReady condition (-want/+got): %s", diff)
+			}
+			if tc.wantTransitionKept {
+				if !gotCondition.LastTransitionTime.Equal(&lastTransitionTime) {
+					t.Errorf("unexpected last transition time: got %v, want %v", gotCondition.LastTransitionTime, lastTransitionTime)
+				}
+				if want := fmt.Sprintf(constants.HeadlessServiceNoEndpointsMessage, "svc"); gotCondition.Message != want {
+					t.Errorf("unexpected message: got %q, want %q", gotCondition.Message, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateCoordinatorSvcIfNecessary(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	ports := []corev1.ServicePort{{Name: "grpc", Port: 8471}}
+	rjob := testutils.MakeReplicatedJob("leader").Replicas(2).Obj()
+	tests := []struct {
+		name     string
+		js       *jobset.JobSet
+		wantSpec *corev1.ServiceSpec
+	}{
+		{
+			name: "coordinator service not enabled",
+			js: testutils.MakeJobSet(jobSetName, ns).
+				ReplicatedJob(rjob).
+				Coordinator(&jobset.Coordinator{ReplicatedJob: "leader"}).Obj(),
+		},
+		{
+			name: "coordinator service selects only the coordinator pod",
+			js: testutils.MakeJobSet(jobSetName, ns).
+				ReplicatedJob(rjob).
+				Coordinator(&jobset.Coordinator{ReplicatedJob: "leader", JobIndex: 1, PodIndex: 2}).
+				CoordinatorService(&jobset.CoordinatorService{Type: corev1.ServiceTypeLoadBalancer, Ports: ports}).Obj(),
+			wantSpec: &corev1.ServiceSpec{
+				Type:  corev1.ServiceTypeLoadBalancer,
+				Ports: ports,
+				Selector: map[string]string{
+					jobset.JobSetNameKey:                 jobSetName,
+					jobset.CoordinatorKey:                "true",
+					batchv1.JobCompletionIndexAnnotation: "2",
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := JobSetReconciler{Client: newFakeClientBuilder().Build(), Scheme: testScheme}
+
+			if err := r.createCoordinatorSvcIfNecessary(context.TODO(), tc.js); err != nil {
+				t.Fatalf("unexpected error creating coordinator service: %v", err)
+			}
+			var svc corev1.Service
+			err := r.Get(context.TODO(), types.NamespacedName{Name: coordinatorServiceName(tc.js), Namespace: ns}, &svc)
+			if tc.wantSpec == nil {
+				if !k8serrors.IsNotFound(err) {
+					t.Errorf("expected no coordinator service, got error: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error getting coordinator service: %v", err)
+			}
+			if diff := cmp.Diff(*tc.wantSpec, svc.Spec); diff != "" {
+				t.Errorf("unexpected coordinator service spec (-want/+got): %s", diff)
+			}
+			if len(svc.OwnerReferences) != 1 || svc.OwnerReferences[0].Name != jobSetName {
+				t.Errorf("expected coordinator service to be owned by the jobset, got owner references %v", svc.OwnerReferences)
+			}
+		})
+	}
+}
+
+func TestConstructJobLabelsCoordinatorPods(t *testing.T) {
+	js := testutils.MakeJobSet("test-jobset", "default").
+		ReplicatedJob(testutils.MakeReplicatedJob("leader").
+			Job(testutils.MakeJobTemplate("test-job", "default").Obj()).
+			Replicas(2).
+			Obj()).
+		Coordinator(&jobset.Coordinator{ReplicatedJob: "leader", JobIndex: 1}).
+		Obj()
+	for jobIdx, wantLabel := range []bool{false, true} {
+		job, err := constructJob(js, &js.Spec.ReplicatedJobs[0], 0, jobIdx, "")
+		if err != nil {
+			t.Fatalf("constructJob() error = %v", err)
+		}
+		if _, gotLabel := job.Spec.Template.Labels[jobset.CoordinatorKey]; gotLabel != wantLabel {
+			t.Errorf("job %d: expected coordinator label %t, got %t", jobIdx, wantLabel, gotLabel)
+		}
+	}
+}
@@ -22,37 +22,139 @@ import (
 	"sort"
 	"strconv"
 	"sync"
+	"time"
 
 	"k8s.io/utils/clock"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	jobset "sigs.k8s.io/jobset/api/jobset/v1alpha2"
 	"sigs.k8s.io/jobset/pkg/constants"
 	"sigs.k8s.io/jobset/pkg/util/collections"
-	"sigs.k8s.io/jobset/pkg/util/placement"
 )
 
 var apiGVStr = jobset.GroupVersion.String()
 
+// jobCreationBackoff is the backoff between attempts to create a Job after a transient error.
+// The number of steps is derived from JobSetReconcilerOptions.JobCreationRetries.
+var jobCreationBackoff = wait.Backoff{
+	Duration: 100 * time.Millisecond,
+	Factor:   2,
+	Jitter:   0.1,
+}
+
 // JobSetReconciler reconciles a JobSet object
 type JobSetReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
 	Record record.EventRecorder
 	clock  clock.Clock
+	tracer trace.Tracer
+	opts   JobSetReconcilerOptions
+
+	// jobListingRetries counts the consecutive incomplete listings of the child Jobs per JobSet.
+	jobListingRetries jobListingRetries
+}
+
+// JobSetReconcilerOptions contains optional settings for the JobSet reconciler.
+type JobSetReconcilerOptions struct {
+	// PlacementInitImage is the image of the init container injected into pods of
+	// exclusive placement Jobs using the node selector strategy, which waits until the node
+	// the pod is scheduled on is labeled with the namespaced job name. Injection is disabled
+	// when empty.
+	PlacementInitImage string
+
+	// JobCreationRetries is the number of times the creation of a Job is retried
+	// after a transient API server error, before failing the reconciliation.
+	JobCreationRetries int
+
+	// DisableBlockOwnerDeletion sets blockOwnerDeletion to false on the owner references
+	// of the child Jobs and Service, so deleting the JobSet in the foreground does not wait
+	// for them to be deleted. The controller field is always set, since it is used to find
+	// the child Jobs of a JobSet.
+	DisableBlockOwnerDeletion bool
+
+	// CleanupFinalizerTimeout is the maximum time the cleanup finalizer of a deleted JobSet
+	// waits for the child Jobs to be deleted, before it is removed anyway. Defaults to
+	// constants.DefaultCleanupFinalizerTimeout when zero.
+	CleanupFinalizerTimeout time.Duration
+
+	// CheckTopologyCapacity defers the creation of the Jobs of replicated jobs using exclusive
+	// placement while the cluster has fewer schedulable topology domains than Jobs to place.
+	CheckTopologyCapacity bool
+
+	// RequeueJitterFactor adds a random jitter of up to the given fraction of the requeue
+	// duration to every requeue of a JobSet, e.g. for its TTL or active deadline, so JobSets
+	// created at the same time do not requeue at the same time. Jitter only ever delays a
+	// requeue. Disabled when zero.
+	RequeueJitterFactor float64
+
+	// AdoptHeadlessServices adopts an existing headless service of a JobSet which is not
+	// controlled by any object, and updates its selector to select the pods of the JobSet.
+	// Otherwise, such a service not selecting the pods of the JobSet is reported in the
+	// NetworkServiceConflict condition.
+	AdoptHeadlessServices bool
+
+	// DisableNetworkManagement stops the controller from creating, updating and deleting the
+	// headless and coordinator Services of JobSets, e.g. in clusters where pod DNS is handled
+	// out-of-band, so the controller can run without permissions on Services. The subdomain is
+	// still set on the pods of JobSets with DNS hostnames enabled, which get the
+	// NetworkManagementDisabled condition.
+	DisableNetworkManagement bool
+
+	// RestartLimitWarningThreshold is the fraction between 0 and 1 of the maxRestarts of the
+	// JobSet failure policy which, once reached by the restarts of the JobSet, sets the
+	// RestartLimitApproaching condition. Disabled when zero.
+	RestartLimitWarningThreshold float64
+
+	// ReconcileErrorBackoffThreshold is the number of consecutive failed reconciliations of a
+	// JobSet, after which the JobSet gets the ReconcileBackingOff condition and is only retried
+	// after ReconcileErrorBackoff, e.g. while the API server rejects its child Jobs. Disabled
+	// when zero.
+	ReconcileErrorBackoffThreshold int32
+
+	// ReconcileErrorBackoff is the time after which a JobSet backing off reconciliation errors
+	// is reconciled again. Defaults to constants.DefaultReconcileErrorBackoff when zero.
+	ReconcileErrorBackoff time.Duration
+
+	// ReconcileTimeout bounds the duration of a single reconciliation of a JobSet. A
+	// reconciliation exceeding it is requeued, and the next reconciliation continues from the
+	// progress already made, e.g. the child Jobs already created. Disabled when zero.
+	ReconcileTimeout time.Duration
+
+	// FieldManager is the field manager name of the server-side applies of the child Jobs and
+	// Services, which makes the ownership of their fields explicit to other appliers, e.g. a
+	// GitOps tool. Defaults to constants.DefaultFieldManager when empty.
+	FieldManager string
+
+	// TracerProvider provides the tracer used to emit OpenTelemetry spans for the reconciliation
+	// of JobSets and the creation of their Jobs, restarts and completion. Defaults to the global
+	// OpenTelemetry tracer provider when nil, which is a no-op unless one is registered.
+	TracerProvider trace.TracerProvider
 }
 
 type childJobs struct {
@@ -64,6 +166,10 @@ type childJobs struct {
 
 	// Jobs marked for deletion are mutually exclusive with the set of jobs in active, successful, and failed.
 	delete []*batchv1.Job
+
+	// minReadyPods holds, by name of the active jobs, the number of their pods which have been ready
+	// for at least the minReadySeconds of the JobSet. It is nil if the JobSet does not set minReadySeconds.
+	minReadyPods map[string]int32
 }
 
 // statusUpdateOpts tracks if a JobSet status update should be performed at the end of the reconciliation
@@ -81,8 +187,8 @@ type eventParams struct {
 	eventMessage string
 }
 
-func NewJobSetReconciler(client client.Client, scheme *runtime.Scheme, record record.EventRecorder) *JobSetReconciler {
-	return &JobSetReconciler{Client: client, Scheme: scheme, Record: record, clock: clock.RealClock{}}
+func NewJobSetReconciler(client client.Client, scheme *runtime.Scheme, record record.EventRecorder, opts JobSetReconcilerOptions) *JobSetReconciler {
+	return &JobSetReconciler{Client: client, Scheme: scheme, Record: record, clock: clock.RealClock{}, tracer: newTracer(opts.TracerProvider), opts: opts}
 }
 
 //+kubebuilder:rbac:groups="",resources=events,verbs=create;watch;update;patch
@@ -92,28 +198,157 @@ func NewJobSetReconciler(client client.Client, scheme *runtime.Scheme, record re
 //+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=batch,resources=jobs/status,verbs=get;patch;update
 //+kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch
+//+kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+//+kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch
+//+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
-func (r *JobSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *JobSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	// Get JobSet from apiserver.
 	var js jobset.JobSet
 	if err := r.Get(ctx, req.NamespacedName, &js); err != nil {
 		// we'll ignore not-found errors, since there is nothing we can do here.
+		if k8serrors.IsNotFound(err) {
+			// A JobSet deleted without the cleanup finalizer is only reconciled once it is gone.
+			r.jobListingRetries.reset(req.NamespacedName)
+		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	// Trace the reconciliation, the lifecycle steps of the JobSet are traced as child spans.
+	ctx, span := r.tracer.Start(ctx, reconcileSpanName, trace.WithAttributes(jobSetSpanAttributes(&js)...))
+	defer func() { endSpan(span, err) }()
+
+	// A JobSet being deleted only needs its child resources to be torn down.
+	if js.DeletionTimestamp != nil {
+		result, err := r.finalizeJobSet(ctx, &js)
+		return ctrl.Result{RequeueAfter: r.jitterRequeue(result.RequeueAfter)}, err
+	}
+
 	// Track JobSet status updates that should be performed at the end of the reconciliation attempt.
 	updateStatusOpts := statusUpdateOpts{}
 
-	// Reconcile the JobSet.
-	result, err := r.reconcile(ctx, &js, &updateStatusOpts)
+	// Reconcile the JobSet. If the reconciliation fails, the partial changes to the status are
+	// dropped before the error is recorded.
+	originalStatus := js.Status.DeepCopy()
+	reconcileCtx, cancel := r.withReconcileTimeout(ctx)
+	defer cancel()
+	result, err = r.reconcile(reconcileCtx, &js, &updateStatusOpts)
+	if reconcileTimedOut(reconcileCtx) {
+		// The reconciliation was cut short, so it is retried right away without counting it as an
+		// error. The partial changes to the status are dropped, as they may be incomplete.
+		ctrl.LoggerFrom(ctx).V(2).Info("reconciliation exceeded the reconcile timeout, requeueing", "timeout", r.opts.ReconcileTimeout, "error", err)
+		return ctrl.Result{Requeue: true}, nil
+	}
 	if err != nil {
-		return result, err
+		js.Status = *originalStatus
+		return r.backOffReconcileError(ctx, &js, err)
+	}
+	resetReconcileErrors(&js, &updateStatusOpts)
+
+	// Record the generation of the JobSet spec which was reconciled, unless the JobSet is
+	// managed by an external controller which owns its status.
+	if managedByExternalController(&js) == nil {
+		updateObservedGeneration(&js, &updateStatusOpts)
 	}
 
 	// At the end of this Reconcile attempt, do one API call to persist all the JobSet status changes.
-	return ctrl.Result{RequeueAfter: result.RequeueAfter}, r.updateJobSetStatus(ctx, &js, &updateStatusOpts)
+	if err := r.updateJobSetStatus(ctx, &js, &updateStatusOpts); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Label the JobSet with its phase once its status is persisted, unless the JobSet is managed
+	// by an external controller.
+	if managedByExternalController(&js) == nil {
+		if err := r.updatePhaseLabel(ctx, &js); err != nil {
+			ctrl.LoggerFrom(ctx).Error(err, "updating phase label")
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Mirror the persisted status to the status ConfigMap, if requested.
+	if err := r.syncStatusConfigMap(ctx, &js); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "syncing status configmap")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{Requeue: result.Requeue, RequeueAfter: r.jitterRequeue(result.RequeueAfter)}, nil
+}
+
+// jitterRequeue adds a random jitter of up to opts.RequeueJitterFactor times the requeue
+// duration to it, if a requeue is requested.
+func (r *JobSetReconciler) jitterRequeue(requeueAfter time.Duration) time.Duration {
+	if requeueAfter <= 0 || r.opts.RequeueJitterFactor <= 0 {
+		return requeueAfter
+	}
+	return wait.Jitter(requeueAfter, r.opts.RequeueJitterFactor)
+}
+
+// reconcileFinishedJobSet handles a completed or failed JobSet, which is terminal. It deletes
+// the JobSet once its TTL after finished expired, or requeues it until then. Otherwise, the
+// remaining active child Jobs are deleted and the results of the finished ones are released,
+// and the finished child Jobs are deleted according to the cleanup policy.
+// The statuses of the replicated jobs are not recalculated, and the pods, Services and
+// placements of the JobSet are not reconciled, as nothing changes the outcome of the JobSet.
+func (r *JobSetReconciler) reconcileFinishedJobSet(ctx context.Context, js *jobset.JobSet) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	requeueAfter, err := executeTTLAfterFinishedPolicy(ctx, r.Client, r.clock, js)
+	if err != nil {
+		log.Error(err, "executing ttl after finished policy")
+		return ctrl.Result{}, err
+	}
+	if requeueAfter > 0 {
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	ownedJobs, err := r.getChildJobs(ctx, js)
+	if err != nil {
+		log.Error(err, "getting jobs owned by jobset")
+		return ctrl.Result{}, err
+	}
+	if err := r.releaseJobResults(ctx, jobResultsToRelease(js, ownedJobs)); err != nil {
+		log.Error(err, "releasing job results")
+		return ctrl.Result{}, err
+	}
+	// Jobs declared successful by the success policy's pod annotation may still be running,
+	// and are active since the success policy is not evaluated anymore.
+	if err := r.deleteJobs(ctx, ownedJobs.active, defaultDeleteOptions()); err != nil {
+		log.Error(err, "deleting jobs")
+		return ctrl.Result{}, err
+	}
+	if err := r.deleteJobs(ctx, jobsToCleanUp(js, ownedJobs), defaultDeleteOptions()); err != nil {
+		log.Error(err, "cleaning up finished jobs")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// updateObservedGeneration sets the observed generation in the JobSet status, and of its
+// conditions, to the generation of its spec. Conditions whose status didn't change during the
+// reconciliation keep the generation they were set for by updateCondition, and are refreshed
+// here, as they were evaluated against the current spec too.
+func updateObservedGeneration(js *jobset.JobSet, updateStatusOpts *statusUpdateOpts) {
+	if js.Status.ObservedGeneration != js.Generation {
+		js.Status.ObservedGeneration = js.Generation
+		updateStatusOpts.shouldUpdate = true
+	}
+	for i := range js.Status.Conditions {
+		if js.Status.Conditions[i].ObservedGeneration != js.Generation {
+			js.Status.Conditions[i].ObservedGeneration = js.Generation
+			updateStatusOpts.shouldUpdate = true
+		}
+	}
+}
+
+// statusSyncPeriod returns the period at which the status of the active JobSet is resynced,
+// or 0 if the JobSet is only reconciled on changes.
+func statusSyncPeriod(js *jobset.JobSet) time.Duration {
+	if js.Spec.StatusSyncPeriodSeconds == nil {
+		return 0
+	}
+	return time.Duration(*js.Spec.StatusSyncPeriodSeconds) * time.Second
 }
 
 // reconcile is the internal method containing the core JobSet reconciliation logic.
@@ -126,11 +361,35 @@ func (r *JobSetReconciler) reconcile(ctx context.Context, js *jobset.JobSet, upd
 	// for why a JobSet would not be controlled by the default JobSet controller.
 	if manager := managedByExternalController(js); manager != nil {
 		log.V(5).Info("Skipping JobSet managed by a different controller", "managed-by", manager)
+		setAdmissionPendingCondition(js, updateStatusOpts)
 		return ctrl.Result{}, nil
 	}
 
 	log.V(2).Info("Reconciling JobSet")
 
+	// The controller does not act on a paused JobSet, whose child Jobs keep running.
+	setPausedCondition(js, updateStatusOpts)
+	if jobSetPaused(js) {
+		log.V(2).Info("Skipping paused JobSet")
+		return ctrl.Result{}, nil
+	}
+
+	// A finished JobSet whose restart annotation changed is restarted, so it is reopened before
+	// the finished JobSet short circuit below.
+	reopenFinishedJobSet(ctx, js, updateStatusOpts)
+
+	// A finished JobSet only needs to be cleaned up, so the rest of the reconciliation is skipped.
+	if JobSetFinished(js) {
+		return r.reconcileFinishedJobSet(ctx, js)
+	}
+
+	// Ensure the child resources are torn down in order when the JobSet is deleted. Paused and
+	// finished JobSets are left alone, as they are not changed by the controller otherwise.
+	if err := r.ensureCleanupFinalizer(ctx, js); err != nil {
+		log.Error(err, "adding cleanup finalizer")
+		return ctrl.Result{}, err
+	}
+
 	// Get Jobs owned by JobSet.
 	ownedJobs, err := r.getChildJobs(ctx, js)
 	if err != nil {
@@ -138,51 +397,216 @@ func (r *JobSetReconciler) reconcile(ctx context.Context, js *jobset.JobSet, upd
 		return ctrl.Result{}, err
 	}
 
+	// Don't act on a listing missing child Jobs which were already observed, e.g. a partial result
+	// returned while the API server is degraded, as it could complete or fail the JobSet early.
+	// A listing which stays incomplete is taken as is, as the finished Jobs were likely deleted.
+	if incompleteJobListing(js, ownedJobs) {
+		if r.jobListingRetries.retry(js) {
+			log.V(2).Info("listing of child jobs is incomplete, requeueing")
+			return ctrl.Result{RequeueAfter: constants.IncompleteJobListingRequeueInterval}, nil
+		}
+		log.V(2).Info("listing of child jobs is still incomplete, assuming finished jobs were deleted")
+	} else {
+		r.jobListingRetries.reset(client.ObjectKeyFromObject(js))
+	}
+
+	// Report the child Jobs marked for deletion, which are deleted below.
+	updateJobsPendingDeletion(js, ownedJobs, updateStatusOpts)
+
+	// Report child Jobs sharing the same JobKey, whose pods can't be told apart by the key.
+	setJobKeyCollisionCondition(js, findJobKeyCollisions(ownedJobs), updateStatusOpts)
+
+	// Release the finished child Jobs kept for their result which are no longer needed.
+	if err := r.releaseJobResults(ctx, jobResultsToRelease(js, ownedJobs)); err != nil {
+		log.Error(err, "releasing job results")
+		return ctrl.Result{}, err
+	}
+
+	// Treat the finished Jobs whose terminal condition is not stable yet as active.
+	stabilizationRequeue := stabilizeFinishedJobs(js, ownedJobs, r.clock.Now())
+
+	// Treat the active Jobs of replicated jobs exceeding their completion timeout as failed.
+	completionTimeoutRequeue := executeCompletionTimeouts(js, ownedJobs, r.clock.Now())
+
+	// Only count the pods which have been ready for minReadySeconds towards the readiness of their Job.
+	minReadyRequeue, err := r.countMinReadyPods(ctx, js, ownedJobs, r.clock.Now())
+	if err != nil {
+		log.Error(err, "counting pods ready for minReadySeconds")
+		return ctrl.Result{}, err
+	}
+
 	// Calculate JobsReady and update statuses for each ReplicatedJob.
-	rjobStatuses := r.calculateReplicatedJobStatuses(ctx, js, ownedJobs)
+	rjobStatuses, err := r.calculateReplicatedJobStatuses(ctx, js, ownedJobs)
+	if err != nil {
+		log.Error(err, "calculating replicated job statuses")
+		return ctrl.Result{}, err
+	}
 	updateReplicatedJobsStatuses(ctx, js, rjobStatuses, updateStatusOpts)
+	setJobSetReadyCondition(js, rjobStatuses, updateStatusOpts)
+	setRestartLimitApproachingCondition(js, r.opts.RestartLimitWarningThreshold, updateStatusOpts)
+	updateAggregatedResourceRequests(js, updateStatusOpts)
 
-	// If JobSet is already completed or failed, clean up active child jobs and requeue if TTLSecondsAfterFinished is set.
-	if jobSetFinished(js) {
-		requeueAfter, err := executeTTLAfterFinishedPolicy(ctx, r.Client, r.clock, js)
-		if err != nil {
-			log.Error(err, "executing ttl after finished policy")
-			return ctrl.Result{}, err
-		}
-		if requeueAfter > 0 {
-			return ctrl.Result{RequeueAfter: requeueAfter}, nil
-		}
-		if err := r.deleteJobs(ctx, ownedJobs.active); err != nil {
-			log.Error(err, "deleting jobs")
-			return ctrl.Result{}, err
-		}
+	// Record the succeeded Jobs counted towards the success policy across restarts, if requested.
+	recordSucceededJobs(js, ownedJobs, updateStatusOpts)
+
+	// Record the placement of child Jobs using exclusive placement.
+	if err := r.updateJobPlacements(ctx, js, ownedJobs.active, updateStatusOpts); err != nil {
+		log.Error(err, "updating job placements")
+		return ctrl.Result{}, err
+	}
+
+	// Record the child Jobs with pods the scheduler could not schedule.
+	unscheduledRequeue, err := r.updateUnschedulableJobs(ctx, js, ownedJobs.active, updateStatusOpts)
+	if err != nil {
+		log.Error(err, "updating unschedulable jobs")
+		return ctrl.Result{}, err
+	}
+
+	// Track the start time of the JobSet and fail it once its active deadline is exceeded.
+	// The active child jobs are deleted when the failed JobSet is reconciled again.
+	updateStartTime(js, r.clock.Now(), updateStatusOpts)
+	deadlineExceeded, requeueAfter := executeActiveDeadlinePolicy(ctx, js, r.clock.Now(), updateStatusOpts)
+	if deadlineExceeded {
+		setInsufficientSuccessfulJobsCondition(js, rjobStatuses, constants.DeadlineExceededReason, constants.InsufficientSuccessfulJobsDeadlineMessage, updateStatusOpts)
 		return ctrl.Result{}, nil
 	}
+	if completionTimeoutRequeue > 0 && (requeueAfter == 0 || completionTimeoutRequeue < requeueAfter) {
+		requeueAfter = completionTimeoutRequeue
+	}
+	if stabilizationRequeue > 0 && (requeueAfter == 0 || stabilizationRequeue < requeueAfter) {
+		requeueAfter = stabilizationRequeue
+	}
+	if minReadyRequeue > 0 && (requeueAfter == 0 || minReadyRequeue < requeueAfter) {
+		requeueAfter = minReadyRequeue
+	}
+	if unscheduledRequeue > 0 && (requeueAfter == 0 || unscheduledRequeue < requeueAfter) {
+		requeueAfter = unscheduledRequeue
+	}
 
 	// Delete any jobs marked for deletion.
-	if err := r.deleteJobs(ctx, ownedJobs.delete); err != nil {
+	if err := r.deleteJobs(ctx, ownedJobs.delete, restartDeleteOptions(js)); err != nil {
 		log.Error(err, "deleting jobs")
 		return ctrl.Result{}, err
 	}
 
-	// If any jobs have failed, execute the JobSet failure policy (if any).
-	if len(ownedJobs.failed) > 0 {
-		executeFailurePolicy(ctx, js, ownedJobs, updateStatusOpts)
+	// Restart the JobSet if the restart annotation was changed.
+	if restarted := executeRestartTrigger(ctx, js, ownedJobs, updateStatusOpts); restarted {
+		return ctrl.Result{}, nil
+	}
+
+	// A JobSet whose replicated jobs all have zero replicas creates no jobs, so it completes right away.
+	if len(js.Spec.ReplicatedJobs) > 0 && numJobsExpected(js) == 0 {
+		setJobSetCompletedCondition(ctx, js, updateStatusOpts)
 		return ctrl.Result{}, nil
 	}
 
-	// If any jobs have succeeded, execute the JobSet success policy.
-	if len(ownedJobs.successful) > 0 {
-		if completed := executeSuccessPolicy(ctx, js, ownedJobs, updateStatusOpts); completed {
-			return ctrl.Result{}, nil
+	// If any jobs have succeeded, in the current run or in any run if counted across restarts, execute
+	// the JobSet success policy. The success policy is executed first, so failures of jobs not targeted
+	// by the success policy (e.g. workers failing after the driver completed) do not fail a completed JobSet.
+	if (len(ownedJobs.successful) > 0 || len(js.Status.SucceededJobs) > 0) && successPolicyMet(js, ownedJobs) {
+		// Tear down the Jobs which must be gone before the JobSet completes, if any. Their
+		// deletion triggers another reconciliation.
+		if jobs := jobsToTearDownBeforeCompletion(js, ownedJobs); len(jobs) > 0 {
+			log.V(2).Info("waiting for jobs to be torn down before completing", "jobs", len(jobs))
+			if err := r.deleteJobs(ctx, jobs, defaultDeleteOptions()); err != nil {
+				log.Error(err, "tearing down jobs before completion")
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		}
+		// Wait for the pods of the succeeded jobs to terminate within the completion grace
+		// period, if any. The termination of the pods triggers another reconciliation, and the
+		// requeue ends the wait at the end of the grace period.
+		remaining, err := r.completionGracePeriodRemaining(ctx, js, ownedJobs.successful, r.clock.Now())
+		if err != nil {
+			log.Error(err, "checking completion grace period")
+			return ctrl.Result{}, err
+		}
+		if remaining > 0 {
+			log.V(2).Info("waiting for pods of succeeded jobs to terminate", "remaining", remaining)
+			if requeueAfter > 0 && requeueAfter < remaining {
+				remaining = requeueAfter
+			}
+			return ctrl.Result{RequeueAfter: remaining}, nil
+		}
+		setJobSetCompletedCondition(ctx, js, updateStatusOpts)
+		return ctrl.Result{}, nil
+	}
+
+	// A draining JobSet neither restarts nor creates Jobs, it finishes once its active Jobs finished.
+	if jobSetDraining(js) {
+		executeDrain(ctx, js, ownedJobs, rjobStatuses, updateStatusOpts)
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	// If any jobs have failed, execute the JobSet failure policy (if any), once the failure
+	// aggregation window (if any) has passed to collect near-simultaneous failures. Failures
+	// ignored by the failure policy of their replicated job are skipped.
+	if failedJobs := failedJobsNotIgnored(js, ownedJobs.failed); len(failedJobs) > 0 {
+		if remaining := failureAggregationRemaining(js, failedJobs, r.clock.Now()); remaining > 0 {
+			log.V(2).Info("waiting for failure aggregation window", "failedJobs", len(failedJobs), "remaining", remaining)
+			if requeueAfter > 0 && requeueAfter < remaining {
+				remaining = requeueAfter
+			}
+			return ctrl.Result{RequeueAfter: remaining}, nil
+		}
+		// A restart exceeding the restart rate limit is deferred until it no longer exceeds it.
+		restarts := js.Status.Restarts
+		if remaining := executeFailurePolicy(ctx, js, failedJobs, r.clock.Now(), updateStatusOpts); remaining > 0 {
+			if requeueAfter > 0 && requeueAfter < remaining {
+				remaining = requeueAfter
+			}
+			return ctrl.Result{RequeueAfter: remaining}, nil
+		}
+		// Keep the Jobs the restart doesn't need to recreate: those of isolated replicated jobs and,
+		// with a dependency graph, those of replicated jobs not depending on the failed ones.
+		if js.Status.Restarts > restarts {
+			if err := r.isolateJobsFromRestart(ctx, js, ownedJobs, failedJobs); err != nil {
+				log.Error(err, "keeping jobs across restart")
+				return ctrl.Result{}, err
+			}
+		}
+		if cond := meta.FindStatusCondition(js.Status.Conditions, string(jobset.JobSetFailed)); cond != nil && cond.Status == metav1.ConditionTrue {
+			setInsufficientSuccessfulJobsCondition(js, rjobStatuses, cond.Reason, constants.InsufficientSuccessfulJobsFailedMessage, updateStatusOpts)
 		}
+		return ctrl.Result{}, nil
 	}
 
-	// If pod DNS hostnames are enabled, create a headless service for the JobSet
-	if err := r.createHeadlessSvcIfNecessary(ctx, js); err != nil {
+	// If pod DNS hostnames are enabled, create a headless service for the JobSet. Jobs are only
+	// created once the service is observed to exist, so the first pods don't race the programming
+	// of its DNS records.
+	svcCreated, err := r.createHeadlessSvcIfNecessary(ctx, js, updateStatusOpts)
+	if err != nil {
 		log.Error(err, "creating headless service")
 		return ctrl.Result{}, err
 	}
+	if svcCreated {
+		log.V(2).Info("waiting for the headless service to be observed before creating jobs")
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	// Report whether the pod DNS hostnames can be resolved. Endpoints are not watched, so an
+	// active JobSet is polled until its headless service has endpoints.
+	networkNotReady, err := r.setNetworkReadyCondition(ctx, js, updateStatusOpts)
+	if err != nil {
+		log.Error(err, "checking network readiness")
+		return ctrl.Result{}, err
+	}
+	if networkNotReady && !jobSetSuspended(js) && (requeueAfter == 0 || requeueAfter > constants.NetworkReadyPollInterval) {
+		requeueAfter = constants.NetworkReadyPollInterval
+	}
+
+	// If the coordinator Service is enabled, create it.
+	if err := r.createCoordinatorSvcIfNecessary(ctx, js); err != nil {
+		log.Error(err, "creating coordinator service")
+		return ctrl.Result{}, err
+	}
+
+	// Create the PodDisruptionBudgets of the replicated jobs, if any.
+	if err := r.createPodDisruptionBudgetsIfNecessary(ctx, js); err != nil {
+		log.Error(err, "creating pod disruption budgets")
+		return ctrl.Result{}, err
+	}
 
 	// If job has not failed or succeeded, continue creating any
 	// jobs that are ready to be started.
@@ -191,6 +615,11 @@ func (r *JobSetReconciler) reconcile(ctx context.Context, js *jobset.JobSet, upd
 		return ctrl.Result{}, err
 	}
 
+	// Check the cluster capacity again later while the creation of Jobs is deferred.
+	if jobCreationDeferred(js) && (requeueAfter == 0 || requeueAfter > constants.InsufficientCapacityRequeueInterval) {
+		requeueAfter = constants.InsufficientCapacityRequeueInterval
+	}
+
 	// Handle suspending a jobset or resuming a suspended jobset.
 	jobsetSuspended := jobSetSuspended(js)
 	if jobsetSuspended {
@@ -198,37 +627,86 @@ func (r *JobSetReconciler) reconcile(ctx context.Context, js *jobset.JobSet, upd
 			log.Error(err, "suspending jobset")
 			return ctrl.Result{}, err
 		}
+	} else if waitingForJobCreation(js, ownedJobs) {
+		log.V(2).Info("waiting for all jobs to be created before resuming them")
 	} else {
 		if err := r.resumeJobsIfNecessary(ctx, js, ownedJobs.active, rjobStatuses, updateStatusOpts); err != nil {
 			log.Error(err, "resuming jobset")
 			return ctrl.Result{}, err
 		}
 	}
-	return ctrl.Result{}, nil
+	// Detect an in-order startup making no progress for longer than its stall timeout.
+	if remaining := executeStartupStallDetection(js, r.clock.Now(), updateStatusOpts); remaining > 0 && (requeueAfter == 0 || remaining < requeueAfter) {
+		requeueAfter = remaining
+	}
+	// Resync the status of the active JobSet periodically, if configured.
+	if period := statusSyncPeriod(js); period > 0 && (requeueAfter == 0 || requeueAfter > period) {
+		requeueAfter = period
+	}
+	// Requeue the JobSet to enforce its active deadline, completion timeouts or resync its status, if any.
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
+// Services are not watched if network management is disabled, so the controller can run
+// without permissions on Services.
 func (r *JobSetReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&jobset.JobSet{}).
-		Owns(&batchv1.Job{}).
-		Owns(&corev1.Service{}).
+		Owns(&batchv1.Job{})
+	if !r.opts.DisableNetworkManagement {
+		b = b.Owns(&corev1.Service{})
+	}
+	return b.Owns(&policyv1.PodDisruptionBudget{}).
+		// Pods are watched so the success policy's pod annotation is evaluated when it is set, and
+		// the completion grace period ends once the pods of the succeeded jobs terminated.
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(jobSetForPod),
+			builder.WithPredicates(predicate.Or(predicate.AnnotationChangedPredicate{}, predicate.Funcs{UpdateFunc: podTerminated}), predicate.Funcs{
+				CreateFunc:  func(event.CreateEvent) bool { return false },
+				DeleteFunc:  func(event.DeleteEvent) bool { return false },
+				GenericFunc: func(event.GenericEvent) bool { return false },
+			})).
 		Complete(r)
 }
 
+// podTerminated returns true if the pod of the update event terminated, i.e. reached the
+// Succeeded or Failed phase.
+func podTerminated(e event.UpdateEvent) bool {
+	oldPod, oldOK := e.ObjectOld.(*corev1.Pod)
+	newPod, newOK := e.ObjectNew.(*corev1.Pod)
+	return oldOK && newOK && !podPhaseTerminal(oldPod.Status.Phase) && podPhaseTerminal(newPod.Status.Phase)
+}
+
+// podPhaseTerminal returns true if the given pod phase is terminal.
+func podPhaseTerminal(phase corev1.PodPhase) bool {
+	return phase == corev1.PodSucceeded || phase == corev1.PodFailed
+}
+
+// jobSetForPod maps a pod to the JobSet it belongs to, if any.
+func jobSetForPod(_ context.Context, pod client.Object) []reconcile.Request {
+	jobSetName, ok := pod.GetLabels()[jobset.JobSetNameKey]
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: pod.GetNamespace(), Name: jobSetName}}}
+}
+
 func SetupJobSetIndexes(ctx context.Context, indexer client.FieldIndexer) error {
-	return indexer.IndexField(ctx, &batchv1.Job{}, constants.JobOwnerKey, func(obj client.Object) []string {
-		o := obj.(*batchv1.Job)
-		owner := metav1.GetControllerOf(o)
-		if owner == nil {
-			return nil
-		}
-		// ...make sure it's a JobSet...
-		if owner.APIVersion != apiGVStr || owner.Kind != "JobSet" {
-			return nil
-		}
-		return []string{owner.Name}
-	})
+	return indexer.IndexField(ctx, &batchv1.Job{}, constants.JobOwnerKey, jobOwnerIndexFunc)
+}
+
+// jobOwnerIndexFunc returns the name of the JobSet controlling the given Job, if any.
+func jobOwnerIndexFunc(obj client.Object) []string {
+	o := obj.(*batchv1.Job)
+	owner := metav1.GetControllerOf(o)
+	if owner == nil {
+		return nil
+	}
+	// ...make sure it's a JobSet...
+	if owner.APIVersion != apiGVStr || owner.Kind != "JobSet" {
+		return nil
+	}
+	return []string{owner.Name}
 }
 
 // updateJobSetStatus will update the JobSet status if updateStatusOpts requires it,
@@ -277,6 +755,13 @@ func (r *JobSetReconciler) getChildJobs(ctx context.Context, js *jobset.JobSet)
 			continue
 		}
 
+		// Jobs beyond the replicas of their replicated job were scaled down and are
+		// marked for deletion.
+		if jobBeyondReplicas(js, &childJobList.Items[i]) {
+			ownedJobs.delete = append(ownedJobs.delete, &childJobList.Items[i])
+			continue
+		}
+
 		// Jobs with jobset.sigs.k8s.io/restart-attempt == jobset.status.restarts are part of
 		// the current JobSet run, and marked either active, successful, or failed.
 		_, finishedType := JobFinished(&job)
@@ -286,12 +771,31 @@ func (r *JobSetReconciler) getChildJobs(ctx context.Context, js *jobset.JobSet)
 		case batchv1.JobFailed:
 			ownedJobs.failed = append(ownedJobs.failed, &childJobList.Items[i])
 		case batchv1.JobComplete:
+			if !allIndexesSucceeded(js, &job) {
+				ownedJobs.failed = append(ownedJobs.failed, &childJobList.Items[i])
+				continue
+			}
 			ownedJobs.successful = append(ownedJobs.successful, &childJobList.Items[i])
 		}
 	}
 	return &ownedJobs, nil
 }
 
+// updateJobsPendingDeletion records the names of the child Jobs marked for deletion in the
+// JobSet status, if they have changed.
+func updateJobsPendingDeletion(js *jobset.JobSet, ownedJobs *childJobs, updateStatusOpts *statusUpdateOpts) {
+	var names []string
+	for _, job := range ownedJobs.delete {
+		names = append(names, job.Name)
+	}
+	sort.Strings(names)
+	if apiequality.Semantic.DeepEqual(js.Status.JobsPendingDeletion, names) {
+		return
+	}
+	js.Status.JobsPendingDeletion = names
+	updateStatusOpts.shouldUpdate = true
+}
+
 // updateReplicatedJobsStatuses updates the replicatedJob statuses if they have changed.
 func updateReplicatedJobsStatuses(ctx context.Context, js *jobset.JobSet, statuses []jobset.ReplicatedJobStatus, updateStatusOpts *statusUpdateOpts) {
 	// If replicated job statuses haven't changed, there's nothing to do here.
@@ -303,77 +807,131 @@ func updateReplicatedJobsStatuses(ctx context.Context, js *jobset.JobSet, status
 	updateStatusOpts.shouldUpdate = true
 }
 
+// setJobSetReadyCondition sets the ready condition of the JobSet to true if all replicas of every
+// replicatedJob are ready, and to false otherwise. A suspended or finished JobSet is never ready.
+func setJobSetReadyCondition(js *jobset.JobSet, statuses []jobset.ReplicatedJobStatus, updateStatusOpts *statusUpdateOpts) {
+	ready := !jobSetSuspended(js) && !JobSetFinished(js)
+	for _, rjob := range js.Spec.ReplicatedJobs {
+		if !ready {
+			break
+		}
+		status := findReplicatedJobStatus(statuses, rjob.Name)
+		ready = status.Ready == expectedJobs(js, &rjob)
+	}
+	setCondition(js, makeReadyConditionOpts(ready), updateStatusOpts)
+}
+
 // calculateReplicatedJobStatuses uses the JobSet's child jobs to update the statuses
-// of each of its replicatedJobs.
-func (r *JobSetReconciler) calculateReplicatedJobStatuses(ctx context.Context, js *jobset.JobSet, jobs *childJobs) []jobset.ReplicatedJobStatus {
+// of each of its replicatedJobs. The child jobs beyond the current replicas of their
+// replicatedJob, which was scaled down, are marked for deletion by getChildJobs and not
+// counted, so the statuses always reflect the current replicas.
+func (r *JobSetReconciler) calculateReplicatedJobStatuses(ctx context.Context, js *jobset.JobSet, jobs *childJobs) ([]jobset.ReplicatedJobStatus, error) {
 	log := ctrl.LoggerFrom(ctx)
 
-	// Prepare replicatedJobsReady for optimal iteration
-	replicatedJobsReady := map[string]map[string]int32{}
-	for _, replicatedJob := range js.Spec.ReplicatedJobs {
-		replicatedJobsReady[replicatedJob.Name] = map[string]int32{
-			"ready":     0,
-			"succeeded": 0,
-			"failed":    0,
-			"active":    0,
-			"suspended": 0,
+	// Jobs whose pods carry the success policy's pod annotation are counted as succeeded.
+	if err := r.markJobsSucceededByPodAnnotation(ctx, js, jobs); err != nil {
+		return nil, err
+	}
+
+	if len(js.Spec.ReplicatedJobs) == 0 {
+		return nil, nil
+	}
+
+	// Bucket the child jobs by replicated job name, so the statuses of all replicated jobs
+	// are calculated in a single pass over the child jobs.
+	rjStatuses := make([]jobset.ReplicatedJobStatus, len(js.Spec.ReplicatedJobs))
+	statusByName := make(map[string]*jobset.ReplicatedJobStatus, len(js.Spec.ReplicatedJobs))
+	for i, replicatedJob := range js.Spec.ReplicatedJobs {
+		rjStatuses[i].Name = replicatedJob.Name
+		statusByName[replicatedJob.Name] = &rjStatuses[i]
+	}
+	statusForJob := func(job *batchv1.Job) *jobset.ReplicatedJobStatus {
+		status, ok := statusByName[job.Labels[jobset.ReplicatedJobNameKey]]
+		if !ok {
+			log.Error(nil, fmt.Sprintf("job %s missing ReplicatedJobName label or ReplicatedJob not found, can't update status", job.Name))
 		}
+		return status
 	}
 
 	// Calculate jobsReady for each Replicated Job
 	for _, job := range jobs.active {
-		if job.Labels == nil || (job.Labels != nil && job.Labels[jobset.ReplicatedJobNameKey] == "") {
-			log.Error(nil, fmt.Sprintf("job %s missing ReplicatedJobName label, can't update status", job.Name))
+		status := statusForJob(job)
+		if status == nil {
 			continue
 		}
 		ready := ptr.Deref(job.Status.Ready, 0)
+		if jobs.minReadyPods != nil {
+			ready = min(ready, jobs.minReadyPods[job.Name])
+		}
 		// parallelism is always set as it is otherwise defaulted by k8s to 1
 		podsCount := *(job.Spec.Parallelism)
 		if job.Spec.Completions != nil && *job.Spec.Completions < podsCount {
 			podsCount = *job.Spec.Completions
 		}
 		if job.Status.Succeeded+ready >= podsCount {
-			replicatedJobsReady[job.Labels[jobset.ReplicatedJobNameKey]]["ready"]++
+			status.Ready++
 		}
 		if job.Status.Active > 0 {
-			replicatedJobsReady[job.Labels[jobset.ReplicatedJobNameKey]]["active"]++
+			status.Active++
 		}
 		if jobSuspended(job) {
-			replicatedJobsReady[job.Labels[jobset.ReplicatedJobNameKey]]["suspended"]++
+			status.Suspended++
 		}
 	}
 
 	// Calculate succeededJobs
 	for _, job := range jobs.successful {
-		replicatedJobsReady[job.Labels[jobset.ReplicatedJobNameKey]]["succeeded"]++
+		if status := statusForJob(job); status != nil {
+			status.Succeeded++
+		}
 	}
 
 	for _, job := range jobs.failed {
-		replicatedJobsReady[job.Labels[jobset.ReplicatedJobNameKey]]["failed"]++
+		if status := statusForJob(job); status != nil {
+			status.Failed++
+		}
+	}
+
+	// Report the timestamps of each child Job, sorted by name so the statuses are stable.
+	if ptr.Deref(js.Spec.ReportJobStatuses, false) {
+		for _, job := range collections.Concat(jobs.active, jobs.successful, jobs.failed) {
+			if status := statusForJob(job); status != nil {
+				status.JobStatuses = append(status.JobStatuses, jobset.JobStatus{
+					Name:           job.Name,
+					StartTime:      job.Status.StartTime.DeepCopy(),
+					CompletionTime: job.Status.CompletionTime.DeepCopy(),
+				})
+			}
+		}
+		for i := range rjStatuses {
+			sort.Slice(rjStatuses[i].JobStatuses, func(a, b int) bool {
+				return rjStatuses[i].JobStatuses[a].Name < rjStatuses[i].JobStatuses[b].Name
+			})
+		}
 	}
 
-	// Calculate ReplicatedJobsStatus
-	var rjStatus []jobset.ReplicatedJobStatus
-	for name, status := range replicatedJobsReady {
-		rjStatus = append(rjStatus, jobset.ReplicatedJobStatus{
-			Name:      name,
-			Ready:     status["ready"],
-			Succeeded: status["succeeded"],
-			Failed:    status["failed"],
-			Active:    status["active"],
-			Suspended: status["suspended"],
-		})
+	for _, status := range rjStatuses {
+		log.V(5).Info("calculated replicated job status", "replicatedJob", status.Name, "ready", status.Ready, "succeeded", status.Succeeded, "failed", status.Failed, "active", status.Active, "suspended", status.Suspended)
 	}
-	return rjStatus
+	return rjStatuses, nil
 }
 
 func (r *JobSetReconciler) suspendJobs(ctx context.Context, js *jobset.JobSet, activeJobs []*batchv1.Job, updateStatusOpts *statusUpdateOpts) error {
+	// With the RetainPods policy, the JobSet is only marked as suspended in its status
+	// and the child Jobs are left running so their pods are not deleted.
+	if retainPodsOnSuspend(js) {
+		setJobSetSuspendedCondition(js, updateStatusOpts)
+		return nil
+	}
+	log := ctrl.LoggerFrom(ctx)
 	for _, job := range activeJobs {
 		if !jobSuspended(job) {
+			patch := suspensionPatch(job)
 			job.Spec.Suspend = ptr.To(true)
-			if err := r.Update(ctx, job); err != nil {
+			if err := r.Patch(ctx, job, patch, client.FieldOwner(r.fieldManager())); err != nil {
 				return err
 			}
+			log.V(2).Info("suspended job", "replicatedJob", job.Labels[jobset.ReplicatedJobNameKey], "job", klog.KObj(job))
 		}
 	}
 	setJobSetSuspendedCondition(js, updateStatusOpts)
@@ -383,10 +941,16 @@ func (r *JobSetReconciler) suspendJobs(ctx context.Context, js *jobset.JobSet, a
 // resumeJobsIfNecessary iterates through each replicatedJob, resuming any suspended jobs if the JobSet
 // is not suspended.
 func (r *JobSetReconciler) resumeJobsIfNecessary(ctx context.Context, js *jobset.JobSet, activeJobs []*batchv1.Job, replicatedJobStatuses []jobset.ReplicatedJobStatus, updateStatusOpts *statusUpdateOpts) error {
+	log := ctrl.LoggerFrom(ctx)
+
 	// Store node selector for each replicatedJob template.
 	nodeAffinities := map[string]map[string]string{}
 	for _, replicatedJob := range js.Spec.ReplicatedJobs {
-		nodeAffinities[replicatedJob.Name] = replicatedJob.Template.Spec.Template.Spec.NodeSelector
+		podTemplate, err := podTemplateForReplicatedJob(js, &replicatedJob)
+		if err != nil {
+			return err
+		}
+		nodeAffinities[replicatedJob.Name] = podTemplate.Spec.NodeSelector
 	}
 
 	// Map each replicatedJob to a list of its active jobs.
@@ -399,11 +963,17 @@ func (r *JobSetReconciler) resumeJobsIfNecessary(ctx context.Context, js *jobset
 	startupPolicy := js.Spec.StartupPolicy
 	// If JobSpec is unsuspended, ensure all active child Jobs are also
 	// unsuspended and update the suspend condition to true.
-	for _, replicatedJob := range js.Spec.ReplicatedJobs {
+	for i, replicatedJob := range js.Spec.ReplicatedJobs {
 		replicatedJobStatus := findReplicatedJobStatus(replicatedJobStatuses, replicatedJob.Name)
-		// If this replicatedJob has already started, continue.
-		if inOrderStartupPolicy(startupPolicy) && allReplicasStarted(replicatedJob.Replicas, replicatedJobStatus) {
-			continue
+		// If this replicatedJob has already started, continue, unless the next one must wait for
+		// it to pass its startup gate.
+		if inOrderStartupPolicy(startupPolicy) && allReplicasStarted(expectedJobs(js, &replicatedJob), replicatedJobStatus) {
+			if startupGatePassed(js, &replicatedJob, replicatedJobStatus) {
+				continue
+			}
+			setInOrderStartupPolicyInProgressCondition(js, updateStatusOpts)
+			setStartupPolicyStatus(js, i, replicatedJobStatus, r.clock.Now(), updateStatusOpts)
+			return nil
 		}
 		jobsFromRJob := replicatedJobToActiveJobs[replicatedJob.Name]
 		for _, job := range jobsFromRJob {
@@ -413,11 +983,13 @@ func (r *JobSetReconciler) resumeJobsIfNecessary(ctx context.Context, js *jobset
 			if err := r.resumeJob(ctx, job, nodeAffinities); err != nil {
 				return err
 			}
+			log.V(2).Info("resumed job", "replicatedJob", replicatedJob.Name, "job", klog.KObj(job))
 		}
 		// If in order startup policy, we need to return early and allow for
 		// this replicatedJob to become ready before resuming the next.
 		if inOrderStartupPolicy(startupPolicy) {
 			setInOrderStartupPolicyInProgressCondition(js, updateStatusOpts)
+			setStartupPolicyStatus(js, i, replicatedJobStatus, r.clock.Now(), updateStatusOpts)
 			return nil
 		}
 	}
@@ -432,12 +1004,16 @@ func (r *JobSetReconciler) resumeJob(ctx context.Context, job *batchv1.Job, node
 	log := ctrl.LoggerFrom(ctx)
 	// Kubernetes validates that a job template is immutable
 	// so if the job has started i.e., startTime != nil), we must set it to nil first.
+	// The start time is owned by the Job controller, so it is cleared with a merge patch, as an
+	// apply can't remove fields owned by another field manager.
 	if job.Status.StartTime != nil {
+		patch := suspensionPatch(job)
 		job.Status.StartTime = nil
-		if err := r.Status().Update(ctx, job); err != nil {
+		if err := r.Status().Patch(ctx, job, patch, client.FieldOwner(r.fieldManager())); err != nil {
 			return err
 		}
 	}
+	patch := suspensionPatch(job)
 	if job.Labels != nil && job.Labels[jobset.ReplicatedJobNameKey] != "" {
 		// When resuming a job, its nodeSelectors should match that of the replicatedJob template
 		// that it was created from, which may have been updated while it was suspended.
@@ -446,56 +1022,96 @@ func (r *JobSetReconciler) resumeJob(ctx context.Context, job *batchv1.Job, node
 		log.Error(nil, "job missing ReplicatedJobName label")
 	}
 	job.Spec.Suspend = ptr.To(false)
-	return r.Update(ctx, job)
+	return r.Patch(ctx, job, patch, client.FieldOwner(r.fieldManager()))
+}
+
+// suspensionPatch returns a merge patch of the changes made to the given Job to suspend or resume
+// it. Unlike an apply of the whole Job, the patch only sets the changed fields, leaving the
+// ownership of the other fields to their managers, and it fails with a conflict if the Job was
+// changed since it was read.
+func suspensionPatch(job *batchv1.Job) client.Patch {
+	return client.MergeFromWithOptions(job.DeepCopy(), client.MergeFromWithOptimisticLock{})
 }
 
 func (r *JobSetReconciler) createJobs(ctx context.Context, js *jobset.JobSet, ownedJobs *childJobs, replicatedJobStatus []jobset.ReplicatedJobStatus, updateStatusOpts *statusUpdateOpts) error {
 	log := ctrl.LoggerFrom(ctx)
 
 	startupPolicy := js.Spec.StartupPolicy
-	var lock sync.Mutex
 	var finalErrs []error
-	for _, replicatedJob := range js.Spec.ReplicatedJobs {
-		jobs, err := constructJobsFromTemplate(js, &replicatedJob, ownedJobs)
+	var insufficientCapacity []string
+	placedJobs := collections.Concat(ownedJobs.active)
+	for _, i := range replicatedJobCreationOrder(js) {
+		replicatedJob := js.Spec.ReplicatedJobs[i]
+		log := log.WithValues("replicatedJob", replicatedJob.Name)
+		ctx := ctrl.LoggerInto(ctx, log)
+
+		// After a restart, wait for the replicated jobs with a higher restart priority to be ready.
+		if !restartPriorityGatePassed(js, &replicatedJob, replicatedJobStatus) {
+			log.V(2).Info("deferring job creation until the replicated jobs with a higher restart priority are ready")
+			continue
+		}
+
+		jobs, err := constructJobsFromTemplate(ctx, js, &replicatedJob, ownedJobs, r.opts.PlacementInitImage)
 		if err != nil {
 			return err
 		}
 
+		// Defer creating the Jobs if there are not enough topology domains to place them.
+		if r.opts.CheckTopologyCapacity && len(jobs) > 0 {
+			msg, err := r.checkTopologyCapacity(ctx, js, &replicatedJob)
+			if err != nil {
+				return err
+			}
+			if msg != "" {
+				log.V(2).Info("deferring job creation due to insufficient capacity", "reason", msg)
+				insufficientCapacity = append(insufficientCapacity, msg)
+				if inOrderStartupPolicy(startupPolicy) {
+					break
+				}
+				continue
+			}
+		}
+
 		status := findReplicatedJobStatus(replicatedJobStatus, replicatedJob.Name)
 
 		// For startup policy, if the replicatedJob is started we can skip this loop.
-		// Jobs have been created.
-		if !jobSetSuspended(js) && inOrderStartupPolicy(startupPolicy) && allReplicasStarted(replicatedJob.Replicas, status) {
-			continue
+		// Jobs have been created. The next replicatedJob is only created once this one
+		// passed its startup gate, if any.
+		if !jobSetSuspended(js) && inOrderStartupPolicy(startupPolicy) && allReplicasStarted(expectedJobs(js, &replicatedJob), status) {
+			if startupGatePassed(js, &replicatedJob, status) {
+				continue
+			}
+			setInOrderStartupPolicyInProgressCondition(js, updateStatusOpts)
+			setStartupPolicyStatus(js, i, status, r.clock.Now(), updateStatusOpts)
+			if r.opts.CheckTopologyCapacity {
+				setInsufficientCapacityCondition(js, insufficientCapacity, updateStatusOpts)
+			}
+			return nil
 		}
 
-		workqueue.ParallelizeUntil(ctx, constants.MaxParallelism, len(jobs), func(i int) {
-			job := jobs[i]
+		// Assign a topology domain to each Job using the nodeAffinityStrategy implementation of
+		// exclusive placement, excluding the domains of the Jobs placed so far.
+		if err := r.assignTopologyDomains(ctx, js, &replicatedJob, jobs, placedJobs); err != nil {
+			return err
+		}
+		placedJobs = append(placedJobs, jobs...)
 
-			// Set jobset controller as owner of the job for garbage collection and reconcilation.
-			if err := ctrl.SetControllerReference(js, job, r.Scheme); err != nil {
-				lock.Lock()
-				defer lock.Unlock()
+		if len(jobs) > 0 {
+			log.V(2).Info("creating jobs", "count", len(jobs))
+			if err := r.createReplicatedJobJobs(ctx, js, replicatedJob.Name, jobs); err != nil {
 				finalErrs = append(finalErrs, err)
-				return
-			}
-
-			// Create the job.
-			// TODO(#18): Deal with the case where the job exists but is not owned by the jobset.
-			if err := r.Create(ctx, job); err != nil {
-				lock.Lock()
-				defer lock.Unlock()
-				finalErrs = append(finalErrs, fmt.Errorf("job %q creation failed with error: %v", job.Name, err))
-				return
 			}
-			log.V(2).Info("successfully created job", "job", klog.KObj(job))
-		})
+		}
 
 		// If we are using inOrder StartupPolicy, then we return to wait for jobs to be ready.
 		// This updates the StartupPolicy condition and notifies that we are waiting
 		// for this replicated job to start up before moving onto the next one.
 		if !jobSetSuspended(js) && inOrderStartupPolicy(startupPolicy) {
 			setInOrderStartupPolicyInProgressCondition(js, updateStatusOpts)
+			setStartupPolicyStatus(js, i, status, r.clock.Now(), updateStatusOpts)
+			if r.opts.CheckTopologyCapacity {
+				setInsufficientCapacityCondition(js, insufficientCapacity, updateStatusOpts)
+			}
 			return nil
 		}
 	}
@@ -503,15 +1119,90 @@ func (r *JobSetReconciler) createJobs(ctx context.Context, js *jobset.JobSet, ow
 	if allErrs != nil {
 		return allErrs
 	}
+	if r.opts.CheckTopologyCapacity {
+		setInsufficientCapacityCondition(js, insufficientCapacity, updateStatusOpts)
+		if len(insufficientCapacity) > 0 {
+			return nil
+		}
+	}
 	// Skip emitting a condition for StartupPolicy if JobSet is suspended
 	if !jobSetSuspended(js) && inOrderStartupPolicy(startupPolicy) {
 		setInOrderStartupPolicyCompletedCondition(js, updateStatusOpts)
+		clearStartupPolicyStatus(js, updateStatusOpts)
 		return nil
 	}
 	return nil
 }
 
-func (r *JobSetReconciler) deleteJobs(ctx context.Context, jobsForDeletion []*batchv1.Job) error {
+// createReplicatedJobJobs creates the given Jobs of a replicated job in parallel, traced in
+// a child span of the reconciliation.
+func (r *JobSetReconciler) createReplicatedJobJobs(ctx context.Context, js *jobset.JobSet, replicatedJobName string, jobs []*batchv1.Job) error {
+	log := ctrl.LoggerFrom(ctx)
+	ctx, span := startChildSpan(ctx, createJobsSpanName, js)
+	span.SetAttributes(attribute.String("jobset.replicatedjob", replicatedJobName), attribute.Int("jobset.jobs", len(jobs)))
+
+	var lock sync.Mutex
+	var errs []error
+	workqueue.ParallelizeUntil(ctx, constants.MaxParallelism, len(jobs), func(i int) {
+		job := jobs[i]
+
+		// Set jobset controller as owner of the job for garbage collection and reconcilation.
+		if err := r.setOwnerReference(js, job); err != nil {
+			lock.Lock()
+			defer lock.Unlock()
+			errs = append(errs, err)
+			return
+		}
+
+		// Create the job.
+		if err := r.applyJobWithRetry(ctx, job); err != nil {
+			lock.Lock()
+			defer lock.Unlock()
+			errs = append(errs, fmt.Errorf("job %q creation failed with error: %v", job.Name, err))
+			return
+		}
+		log.V(2).Info("successfully created job", "job", klog.KObj(job))
+	})
+	err := errors.Join(errs...)
+	endSpan(span, err)
+	return err
+}
+
+// applyJobWithRetry creates the given Job with a server-side apply, retrying with backoff on
+// transient errors. Applying a Job that already exists with the same fields doesn't change it,
+// which makes retrying a creation that succeeded on the API server side idempotent. A Job of the
+// same name which is not controlled by the owner of the given Job is left alone, as the forced
+// ownership of the apply would take it over.
+func (r *JobSetReconciler) applyJobWithRetry(ctx context.Context, job *batchv1.Job) error {
+	backoff := jobCreationBackoff
+	backoff.Steps = r.opts.JobCreationRetries + 1
+	return retry.OnError(backoff, isRetriableCreateError, func() error {
+		var existing batchv1.Job
+		if err := r.Get(ctx, client.ObjectKeyFromObject(job), &existing); client.IgnoreNotFound(err) != nil {
+			return err
+		} else if err == nil && !sameController(&existing, job) {
+			return fmt.Errorf("job %q already exists and is not controlled by the JobSet", job.Name)
+		}
+		return r.apply(ctx, job)
+	})
+}
+
+// sameController returns true if both objects have the same controller, if any.
+func sameController(a, b metav1.Object) bool {
+	controllerA, controllerB := metav1.GetControllerOf(a), metav1.GetControllerOf(b)
+	if controllerA == nil || controllerB == nil {
+		return controllerA == controllerB
+	}
+	return controllerA.UID == controllerB.UID
+}
+
+// isRetriableCreateError returns true if the Job creation error is transient. Conflicts are not
+// retried, as a server-side apply forcing the ownership of its fields doesn't conflict.
+func isRetriableCreateError(err error) bool {
+	return k8serrors.IsInternalError(err) || k8serrors.IsServerTimeout(err) || k8serrors.IsTimeout(err)
+}
+
+func (r *JobSetReconciler) deleteJobs(ctx context.Context, jobsForDeletion []*batchv1.Job, deleteOpts *client.DeleteOptions) error {
 	log := ctrl.LoggerFrom(ctx)
 	lock := &sync.Mutex{}
 	var finalErrs []error
@@ -523,8 +1214,7 @@ func (r *JobSetReconciler) deleteJobs(ctx context.Context, jobsForDeletion []*ba
 		}
 		// Delete job. This deletion event will trigger another reconciliation,
 		// where the jobs are recreated.
-		foregroundPolicy := metav1.DeletePropagationForeground
-		if err := r.Delete(ctx, targetJob, &client.DeleteOptions{PropagationPolicy: &foregroundPolicy}); client.IgnoreNotFound(err) != nil {
+		if err := r.Delete(ctx, targetJob, deleteOpts); client.IgnoreNotFound(err) != nil {
 			lock.Lock()
 			defer lock.Unlock()
 			log.Error(err, fmt.Sprintf("failed to delete job: %q", targetJob.Name))
@@ -536,15 +1226,63 @@ func (r *JobSetReconciler) deleteJobs(ctx context.Context, jobsForDeletion []*ba
 	return errors.Join(finalErrs...)
 }
 
-// TODO: look into adopting service and updating the selector
-// if it is not matching the job selector.
-func (r *JobSetReconciler) createHeadlessSvcIfNecessary(ctx context.Context, js *jobset.JobSet) error {
+// defaultDeleteOptions returns the options used when deleting child Jobs.
+// Jobs are deleted in the foreground so their pods are removed before the Job is.
+func defaultDeleteOptions() *client.DeleteOptions {
+	return &client.DeleteOptions{PropagationPolicy: ptr.To(metav1.DeletePropagationForeground)}
+}
+
+// restartDeleteOptions returns the options used when deleting child Jobs from a previous run
+// during a JobSet restart. The failure policy may override the propagation policy and the
+// grace period used for these deletions.
+func restartDeleteOptions(js *jobset.JobSet) *client.DeleteOptions {
+	opts := defaultDeleteOptions()
+	if js.Spec.FailurePolicy == nil {
+		return opts
+	}
+	if js.Spec.FailurePolicy.DeletePropagationPolicy != nil {
+		opts.PropagationPolicy = ptr.To(*js.Spec.FailurePolicy.DeletePropagationPolicy)
+	}
+	if js.Spec.FailurePolicy.TerminationGracePeriodOverride != nil {
+		opts.GracePeriodSeconds = ptr.To(*js.Spec.FailurePolicy.TerminationGracePeriodOverride)
+	}
+	return opts
+}
+
+// setOwnerReference sets the JobSet as the controller owner of the given object,
+// honoring the configured blockOwnerDeletion behavior.
+func (r *JobSetReconciler) setOwnerReference(js *jobset.JobSet, obj metav1.Object) error {
+	if err := ctrl.SetControllerReference(js, obj, r.Scheme); err != nil {
+		return err
+	}
+	if r.opts.DisableBlockOwnerDeletion {
+		refs := obj.GetOwnerReferences()
+		for i := range refs {
+			if refs[i].UID == js.UID {
+				refs[i].BlockOwnerDeletion = ptr.To(false)
+			}
+		}
+		obj.SetOwnerReferences(refs)
+	}
+	return nil
+}
+
+// createHeadlessSvcIfNecessary creates the headless service of the JobSet, if pod DNS hostnames
+// are enabled, and returns true if it was created. An existing service is verified to select the
+// pods of the JobSet, see reconcileExistingHeadlessSvc.
+func (r *JobSetReconciler) createHeadlessSvcIfNecessary(ctx context.Context, js *jobset.JobSet, updateStatusOpts *statusUpdateOpts) (bool, error) {
 	log := ctrl.LoggerFrom(ctx)
 
 	// Headless service is only necessary for indexed jobs whose pods need to communicate with
 	// eachother via pod hostnames.
 	if !dnsHostnamesEnabled(js) {
-		return nil
+		return false, nil
+	}
+
+	// The headless service must be created out-of-band if network management is disabled.
+	setNetworkManagementDisabledCondition(js, r.opts.DisableNetworkManagement, updateStatusOpts)
+	if r.opts.DisableNetworkManagement {
+		return false, nil
 	}
 
 	// Check if service already exists. The service name should match the subdomain specified in
@@ -554,73 +1292,108 @@ func (r *JobSetReconciler) createHeadlessSvcIfNecessary(ctx context.Context, js
 	subdomain := GetSubdomain(js)
 	if err := r.Get(ctx, types.NamespacedName{Name: subdomain, Namespace: js.Namespace}, &headlessSvc); err != nil {
 		if !k8serrors.IsNotFound(err) {
-			return err
-		}
-		headlessSvc := corev1.Service{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      subdomain,
-				Namespace: js.Namespace,
-			},
-			Spec: corev1.ServiceSpec{
-				ClusterIP: "None",
-				Selector: map[string]string{
-					jobset.JobSetNameKey: js.Name,
-				},
-				PublishNotReadyAddresses: ptr.Deref(js.Spec.Network.PublishNotReadyAddresses, true),
-			},
-		}
-
-		// Set controller owner reference for garbage collection and reconcilation.
-		if err := ctrl.SetControllerReference(js, &headlessSvc, r.Scheme); err != nil {
-			return err
+			return false, err
 		}
-
 		// Create headless service.
-		if err := r.Create(ctx, &headlessSvc); err != nil {
-			return err
+		if err := r.applyHeadlessSvc(ctx, js); err != nil {
+			return false, err
 		}
-		log.V(2).Info("successfully created headless service", "service", klog.KObj(&headlessSvc))
+		log.V(2).Info("successfully created headless service", "service", klog.KRef(js.Namespace, subdomain))
+		return true, nil
 	}
-	return nil
+	return false, r.reconcileExistingHeadlessSvc(ctx, js, &headlessSvc, updateStatusOpts)
 }
 
-// executeSuccessPolicy checks the completed jobs against the jobset success policy
-// and updates the jobset status to completed if the success policy conditions are met.
-// Returns a boolean value indicating if the jobset was completed or not.
-func executeSuccessPolicy(ctx context.Context, js *jobset.JobSet, ownedJobs *childJobs, updateStatusOpts *statusUpdateOpts) bool {
-	if numJobsMatchingSuccessPolicy(js, ownedJobs.successful) >= numJobsExpectedToSucceed(js) {
-		setJobSetCompletedCondition(js, updateStatusOpts)
-		return true
+// successPolicyMet checks the completed jobs against the jobset success policy, and returns
+// true if the success policy conditions are met. The success policy with the All operator is
+// met without the failed jobs tolerated by the maxFailedJobs of their replicated jobs.
+func successPolicyMet(js *jobset.JobSet, ownedJobs *childJobs) bool {
+	expected := numJobsExpectedToSucceed(js)
+	if js.Spec.SuccessPolicy.TotalSucceeded == nil && js.Spec.SuccessPolicy.Operator == jobset.OperatorAll {
+		expected -= numToleratedFailedJobs(js, ownedJobs.failed)
 	}
-	return false
+	return numSucceededJobsMatchingSuccessPolicy(js, ownedJobs) >= expected
 }
 
-func executeFailurePolicy(ctx context.Context, js *jobset.JobSet, ownedJobs *childJobs, updateStatusOpts *statusUpdateOpts) {
-	// If no failure policy is defined, mark the JobSet as failed.
-	if js.Spec.FailurePolicy == nil {
-		firstFailedJob := findFirstFailedJob(ownedJobs.failed)
+// executeFailurePolicy fails or restarts the JobSet based on the failure policies of the given
+// failed jobs, which are the failure policies of their replicated jobs if set, or the JobSet
+// failure policy otherwise. If the restart would exceed the restart rate limit, it is deferred
+// and the time until the JobSet can be restarted is returned.
+func executeFailurePolicy(ctx context.Context, js *jobset.JobSet, failedJobs []*batchv1.Job, now time.Time, updateStatusOpts *statusUpdateOpts) time.Duration {
+	// If a failed job has no failure policy, mark the JobSet as failed.
+	var jobsWithoutPolicy []*batchv1.Job
+	for _, job := range failedJobs {
+		if failurePolicyForJob(js, job) == nil {
+			jobsWithoutPolicy = append(jobsWithoutPolicy, job)
+		}
+	}
+	if len(jobsWithoutPolicy) > 0 {
+		firstFailedJob := findFirstFailedJob(jobsWithoutPolicy)
 		setJobSetFailedCondition(ctx, js, constants.FailedJobsReason, messageWithFirstFailedJob(constants.FailedJobsMessage, firstFailedJob.Name), updateStatusOpts)
-		return
+		return 0
 	}
 
-	// If JobSet has reached max restarts, fail the JobSet.
-	if js.Status.Restarts >= js.Spec.FailurePolicy.MaxRestarts {
-		firstFailedJob := findFirstFailedJob(ownedJobs.failed)
-		setJobSetFailedCondition(ctx, js, constants.ReachedMaxRestartsReason, messageWithFirstFailedJob(constants.ReachedMaxRestartsMessage, firstFailedJob.Name), updateStatusOpts)
-		return
+	// If JobSet has reached the max restarts of the failure policy of a failed job, fail the JobSet.
+	for _, job := range failedJobs {
+		if js.Status.Restarts >= failurePolicyForJob(js, job).MaxRestarts {
+			setJobSetFailedCondition(ctx, js, constants.ReachedMaxRestartsReason, messageWithFailedJobs(js, constants.ReachedMaxRestartsMessage, failedJobs), updateStatusOpts)
+			return 0
+		}
+	}
+
+	// If the restart would exceed the restart rate limit of the failure policy of a failed job, defer it.
+	if remaining := restartRateLimitRemaining(js, failedJobs, now); remaining > 0 {
+		ctrl.LoggerFrom(ctx).V(2).Info("deferring restart exceeding the restart rate limit", "remaining", remaining)
+		return remaining
 	}
 
 	// To reach this point a job must have failed.
-	failurePolicyRecreateAll(ctx, js, updateStatusOpts)
+	failurePolicyRecreateAll(ctx, js, now, updateStatusOpts)
+	return 0
+}
+
+// failurePolicyForJob returns the failure policy applying to the failure of the given child Job,
+// which is the failure policy of its replicated job if set, or the JobSet failure policy otherwise.
+func failurePolicyForJob(js *jobset.JobSet, job *batchv1.Job) *jobset.FailurePolicy {
+	rjobName := job.Labels[jobset.ReplicatedJobNameKey]
+	for _, rjob := range js.Spec.ReplicatedJobs {
+		if rjob.Name == rjobName && rjob.FailurePolicy != nil {
+			return rjob.FailurePolicy
+		}
+	}
+	return js.Spec.FailurePolicy
+}
+
+// failedJobsNotIgnored returns the failed jobs whose failure policy does not ignore failures.
+// Failures of the Jobs of non-critical replicated jobs are always ignored, and so are failures
+// of the Jobs of replicated jobs which don't exceed their maxFailedJobs.
+func failedJobsNotIgnored(js *jobset.JobSet, failedJobs []*batchv1.Job) []*batchv1.Job {
+	numFailed := numFailedJobsByReplicatedJob(failedJobs)
+	var notIgnored []*batchv1.Job
+	for _, job := range failedJobs {
+		if jobNonCritical(js, job) {
+			continue
+		}
+		if rjobName := job.Labels[jobset.ReplicatedJobNameKey]; replicatedJobFailuresTolerated(js, rjobName, numFailed[rjobName]) {
+			continue
+		}
+		if policy := failurePolicyForJob(js, job); policy != nil && policy.Action == jobset.FailurePolicyActionIgnore {
+			continue
+		}
+		notIgnored = append(notIgnored, job)
+	}
+	return notIgnored
 }
 
-func failurePolicyRecreateAll(ctx context.Context, js *jobset.JobSet, updateStatusOpts *statusUpdateOpts) {
+func failurePolicyRecreateAll(ctx context.Context, js *jobset.JobSet, now time.Time, updateStatusOpts *statusUpdateOpts) {
 	log := ctrl.LoggerFrom(ctx)
 
 	// Increment JobSet restarts. This will trigger reconciliation and result in deletions
 	// of old jobs not part of the current jobSet run.
 	js.Status.Restarts += 1
+	recordRestartTime(js, now)
 	updateStatusOpts.shouldUpdate = true
+	traceLifecycleEvent(ctx, restartSpanName, js, attribute.Int("jobset.restarts", int(js.Status.Restarts)))
 
 	// Emit event for each JobSet restarts for observability and debugability.
 	enqueueEvent(updateStatusOpts, &eventParams{
@@ -631,35 +1404,88 @@ func failurePolicyRecreateAll(ctx context.Context, js *jobset.JobSet, updateStat
 	log.V(2).Info("attempting restart", "restart attempt", js.Status.Restarts)
 }
 
-func constructJobsFromTemplate(js *jobset.JobSet, rjob *jobset.ReplicatedJob, ownedJobs *childJobs) ([]*batchv1.Job, error) {
+func constructJobsFromTemplate(ctx context.Context, js *jobset.JobSet, rjob *jobset.ReplicatedJob, ownedJobs *childJobs, placementInitImage string) ([]*batchv1.Job, error) {
+	log := ctrl.LoggerFrom(ctx)
+
 	var jobs []*batchv1.Job
-	for jobIdx := 0; jobIdx < int(rjob.Replicas); jobIdx++ {
-		jobName := placement.GenJobName(js.Name, rjob.Name, jobIdx)
-		if create := shouldCreateJob(jobName, ownedJobs); !create {
-			continue
-		}
-		job, err := constructJob(js, rjob, jobIdx)
-		if err != nil {
-			return nil, err
+	for instanceIdx := 0; instanceIdx < NumInstances(js); instanceIdx++ {
+		for jobIdx := 0; jobIdx < int(replicatedJobReplicas(js, rjob)); jobIdx++ {
+			jobName, err := GenJobName(js, rjob.Name, instanceIdx, jobIdx)
+			if err != nil {
+				return nil, err
+			}
+			if create := shouldCreateJob(jobName, ownedJobs); !create {
+				log.V(5).Info("skipping existing job", "job", klog.KRef(js.Namespace, jobName))
+				continue
+			}
+			job, err := constructJob(js, rjob, instanceIdx, jobIdx, placementInitImage)
+			if err != nil {
+				return nil, err
+			}
+			jobs = append(jobs, job)
 		}
-		jobs = append(jobs, job)
 	}
 	return jobs, nil
 }
 
-func constructJob(js *jobset.JobSet, rjob *jobset.ReplicatedJob, jobIdx int) (*batchv1.Job, error) {
+func constructJob(js *jobset.JobSet, rjob *jobset.ReplicatedJob, instanceIdx, jobIdx int, placementInitImage string) (*batchv1.Job, error) {
+	// Resolve the Job template, including a referenced pod template and indexed overrides.
+	template, err := jobTemplateForIndex(js, rjob, jobIdx)
+	if err != nil {
+		return nil, err
+	}
+	jobName, err := GenJobName(js, rjob.Name, instanceIdx, jobIdx)
+	if err != nil {
+		return nil, err
+	}
 	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
-			Labels:      collections.CloneMap(rjob.Template.Labels),
-			Annotations: collections.CloneMap(rjob.Template.Annotations),
-			Name:        placement.GenJobName(js.Name, rjob.Name, jobIdx),
+			Labels:      collections.MergeMaps(js.Spec.Labels, template.Labels),
+			Annotations: collections.MergeMaps(js.Spec.Annotations, js.Spec.JobAnnotations, template.Annotations),
+			Name:        jobName,
 			Namespace:   js.Namespace,
 		},
-		Spec: *rjob.Template.Spec.DeepCopy(),
+		Spec: template.Spec,
+	}
+	// Add the JobSet level labels and annotations to the pod template, without overriding the
+	// ones set in the template. Job-only annotations are not propagated to the pods. JobSet
+	// managed labels and annotations are set below.
+	job.Spec.Template.Labels = collections.MergeMaps(js.Spec.Labels, job.Spec.Template.Labels)
+	job.Spec.Template.Annotations = collections.MergeMaps(js.Spec.Annotations, js.Spec.PodAnnotations, job.Spec.Template.Annotations)
+	addImagePullSecrets(&job.Spec.Template.Spec, js.Spec.ImagePullSecrets)
+	addHostAliases(&job.Spec.Template.Spec, js.Spec.HostAliases)
+	addSidecarContainers(&job.Spec.Template.Spec, js.Spec.SidecarContainers)
+	addEnvFrom(&job.Spec.Template.Spec, js.Spec.EnvFrom)
+	addVolumes(&job.Spec.Template.Spec, js.Spec.Volumes, js.Spec.VolumeMounts)
+	setServiceAccountName(&job.Spec.Template.Spec, js.Spec.ServiceAccountName)
+	setRuntimeClassName(&job.Spec.Template.Spec, js.Spec.RuntimeClassName)
+	addSchedulingGates(&job.Spec.Template.Spec, js.Spec.SchedulingGates)
+	addSchedulingGates(&job.Spec.Template.Spec, rjob.SchedulingGates)
+	addReadinessGates(&job.Spec.Template.Spec, js.Spec.PodReadinessGates)
+	setPriorityClassName(&job.Spec.Template.Spec, rjob.PodPriorityClassName)
+	if rjob.SchedulerName != "" {
+		job.Spec.Template.Spec.SchedulerName = rjob.SchedulerName
 	}
+
 	// Label and annotate both job and pod template spec.
-	labelAndAnnotateObject(job, js, rjob, jobIdx)
-	labelAndAnnotateObject(&job.Spec.Template, js, rjob, jobIdx)
+	labelAndAnnotateObject(job, js, rjob, instanceIdx, jobIdx, jobName)
+	labelAndAnnotateObject(&job.Spec.Template, js, rjob, instanceIdx, jobIdx, jobName)
+
+	// Label the pods of the Job containing the coordinator pod, so the coordinator Service
+	// can select it.
+	if isCoordinatorJob(js, rjob, instanceIdx, jobIdx) {
+		job.Spec.Template.Labels[jobset.CoordinatorKey] = "true"
+	}
+
+	// Expose the instance index to the containers if the JobSet has multiple instances.
+	if js.Spec.Instances != nil {
+		addInstanceIndexEnvVar(&job.Spec.Template.Spec, instanceIdx)
+	}
+
+	// Expose the restart count the Job is created for to the containers, if requested.
+	if ptr.Deref(js.Spec.InjectRestartCountEnvVar, false) {
+		addRestartCountEnvVar(&job.Spec.Template.Spec, js.Status.Restarts)
+	}
 
 	// If enableDNSHostnames is set, update job spec to set subdomain as
 	// job name (a headless service with same name as job will be created later).
@@ -669,21 +1495,184 @@ func constructJob(js *jobset.JobSet, rjob *jobset.ReplicatedJob, jobIdx int) (*b
 
 	// If this job is using the nodeSelectorStrategy implementation of exclusive placement,
 	// add the job name label as a nodeSelector, and add a toleration for the no schedule taint.
-	// The node label and node taint must be added to the nodes separately by a user/script.
+	// The node label and node taint must be added to the nodes separately by a user/script,
+	// which the placement init container, if enabled, waits for.
 	_, exclusivePlacement := job.Annotations[jobset.ExclusiveKey]
 	_, nodeSelectorStrategy := job.Annotations[jobset.NodeSelectorStrategyKey]
 	if exclusivePlacement && nodeSelectorStrategy {
 		addNamespacedJobNodeSelector(job)
 		addTaintToleration(job)
+		if placementInitImage != "" {
+			addPlacementInitContainer(job, placementInitImage)
+		}
 	}
 
-	// if Suspend is set, then we assume all jobs will be suspended also.
+	// Spread the pods of the Job to distinct nodes, if requested.
+	if onePodPerNode(js, rjob) {
+		addOnePodPerNodeAntiAffinity(job)
+	}
+
+	// if Suspend is set, then we assume all jobs will be suspended also. Jobs are also created
+	// suspended if requested, to be resumed once all of them were created.
 	jobsetSuspended := jobSetSuspended(js)
-	job.Spec.Suspend = ptr.To(jobsetSuspended)
+	job.Spec.Suspend = ptr.To(jobsetSuspended || createJobsSuspended(js))
+
+	// Delegate the cleanup of finished child Jobs to the Job controller, if requested.
+	setJobTTLAfterFinished(js, job)
+
+	// Bound the runtime of Jobs whose template does not set a deadline.
+	if job.Spec.ActiveDeadlineSeconds == nil {
+		job.Spec.ActiveDeadlineSeconds = js.Spec.DefaultJobActiveDeadlineSeconds
+	}
 
 	return job, nil
 }
 
+// podTemplateForReplicatedJob returns the pod template used by Jobs of the given replicatedJob,
+// which is either the inline template or the entry of spec.podTemplates it references.
+func podTemplateForReplicatedJob(js *jobset.JobSet, rjob *jobset.ReplicatedJob) (*corev1.PodTemplateSpec, error) {
+	if rjob.PodTemplateName == "" {
+		return &rjob.Template.Spec.Template, nil
+	}
+	podTemplate, ok := js.Spec.PodTemplates[rjob.PodTemplateName]
+	if !ok {
+		return nil, fmt.Errorf("pod template %q referenced by replicatedJob %q not found", rjob.PodTemplateName, rjob.Name)
+	}
+	return &podTemplate, nil
+}
+
+// addImagePullSecrets appends the JobSet level image pull secrets to the pod spec,
+// skipping secrets already referenced by the pod spec.
+func addImagePullSecrets(podSpec *corev1.PodSpec, secrets []corev1.LocalObjectReference) {
+	for _, secret := range secrets {
+		if !collections.Contains(podSpec.ImagePullSecrets, secret) {
+			podSpec.ImagePullSecrets = append(podSpec.ImagePullSecrets, secret)
+		}
+	}
+}
+
+// addHostAliases merges the JobSet level host aliases into the pod spec. The hostnames of an
+// alias whose IP is already set in the pod spec are added to that alias, skipping hostnames
+// it already has, and the other aliases are appended.
+func addHostAliases(podSpec *corev1.PodSpec, aliases []corev1.HostAlias) {
+	for _, alias := range aliases {
+		idx := -1
+		for i := range podSpec.HostAliases {
+			if podSpec.HostAliases[i].IP == alias.IP {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			podSpec.HostAliases = append(podSpec.HostAliases, *alias.DeepCopy())
+			continue
+		}
+		for _, hostname := range alias.Hostnames {
+			if !collections.Contains(podSpec.HostAliases[idx].Hostnames, hostname) {
+				podSpec.HostAliases[idx].Hostnames = append(podSpec.HostAliases[idx].Hostnames, hostname)
+			}
+		}
+	}
+}
+
+// setServiceAccountName sets the JobSet level service account name on the pod spec, unless the
+// pod spec sets a service account itself, including with the deprecated serviceAccount field.
+func setServiceAccountName(podSpec *corev1.PodSpec, serviceAccountName string) {
+	if podSpec.ServiceAccountName == "" && podSpec.DeprecatedServiceAccount == "" {
+		podSpec.ServiceAccountName = serviceAccountName
+	}
+}
+
+// setRuntimeClassName sets the JobSet level runtime class name on the pod spec, unless the pod
+// spec sets a runtime class itself.
+func setRuntimeClassName(podSpec *corev1.PodSpec, runtimeClassName string) {
+	if runtimeClassName != "" && podSpec.RuntimeClassName == nil {
+		podSpec.RuntimeClassName = ptr.To(runtimeClassName)
+	}
+}
+
+// setPriorityClassName sets the priority class name of the replicated job on the pod spec. The
+// priority and preemption policy of the pod spec are cleared, since the API server rejects pods
+// whose priority or preemption policy differ from the ones of their priority class.
+func setPriorityClassName(podSpec *corev1.PodSpec, priorityClassName string) {
+	if priorityClassName == "" {
+		return
+	}
+	podSpec.PriorityClassName = priorityClassName
+	podSpec.Priority = nil
+	podSpec.PreemptionPolicy = nil
+}
+
+// addSchedulingGates appends the scheduling gates to the pod spec, skipping gates with names
+// already used by the pod spec.
+func addSchedulingGates(podSpec *corev1.PodSpec, gates []corev1.PodSchedulingGate) {
+	for _, gate := range gates {
+		if !collections.Contains(podSpec.SchedulingGates, gate) {
+			podSpec.SchedulingGates = append(podSpec.SchedulingGates, gate)
+		}
+	}
+}
+
+// addReadinessGates appends the readiness gates to the pod spec, skipping gates with condition
+// types already used by the pod spec.
+func addReadinessGates(podSpec *corev1.PodSpec, gates []corev1.PodReadinessGate) {
+	for _, gate := range gates {
+		if !collections.Contains(podSpec.ReadinessGates, gate) {
+			podSpec.ReadinessGates = append(podSpec.ReadinessGates, gate)
+		}
+	}
+}
+
+// addSidecarContainers appends the JobSet level sidecar containers to the pod spec. Containers
+// with restartPolicy Always are native sidecars, which are added to the init containers.
+func addSidecarContainers(podSpec *corev1.PodSpec, sidecars []corev1.Container) {
+	for i := range sidecars {
+		sidecar := sidecars[i].DeepCopy()
+		if ptr.Deref(sidecar.RestartPolicy, "") == corev1.ContainerRestartPolicyAlways {
+			podSpec.InitContainers = append(podSpec.InitContainers, *sidecar)
+		} else {
+			podSpec.Containers = append(podSpec.Containers, *sidecar)
+		}
+	}
+}
+
+// addEnvFrom prepends the JobSet level envFrom sources to the envFrom sources of every container
+// and init container of the pod spec. Kubernetes gives precedence to the last source defining a
+// key, so the sources of the containers take precedence over the JobSet level ones.
+func addEnvFrom(podSpec *corev1.PodSpec, sources []corev1.EnvFromSource) {
+	if len(sources) == 0 {
+		return
+	}
+	for _, containers := range [][]corev1.Container{podSpec.InitContainers, podSpec.Containers} {
+		for i := range containers {
+			envFrom := make([]corev1.EnvFromSource, 0, len(sources)+len(containers[i].EnvFrom))
+			for _, source := range sources {
+				envFrom = append(envFrom, *source.DeepCopy())
+			}
+			containers[i].EnvFrom = append(envFrom, containers[i].EnvFrom...)
+		}
+	}
+}
+
+// addVolumes appends the JobSet level volumes to the volumes of the pod spec, and the JobSet
+// level volume mounts to the volume mounts of every container and init container of the pod
+// spec, including the sidecar containers added by the JobSet.
+func addVolumes(podSpec *corev1.PodSpec, volumes []corev1.Volume, volumeMounts []corev1.VolumeMount) {
+	for _, volume := range volumes {
+		podSpec.Volumes = append(podSpec.Volumes, *volume.DeepCopy())
+	}
+	if len(volumeMounts) == 0 {
+		return
+	}
+	for _, containers := range [][]corev1.Container{podSpec.InitContainers, podSpec.Containers} {
+		for i := range containers {
+			for _, volumeMount := range volumeMounts {
+				containers[i].VolumeMounts = append(containers[i].VolumeMounts, *volumeMount.DeepCopy())
+			}
+		}
+	}
+}
+
 func addTaintToleration(job *batchv1.Job) {
 	job.Spec.Template.Spec.Tolerations = append(job.Spec.Template.Spec.Tolerations,
 		corev1.Toleration{
@@ -718,26 +1707,32 @@ func shouldCreateJob(jobName string, ownedJobs *childJobs) bool {
 //     annotation applied by the user to indicate they are using the
 //     nodeSelector exclusive placement strategy, where they have manually
 //     labelled the nodes ahead of time with hack/label_nodes/label_nodes.py
-func labelAndAnnotateObject(obj metav1.Object, js *jobset.JobSet, rjob *jobset.ReplicatedJob, jobIdx int) {
-	jobName := placement.GenJobName(js.Name, rjob.Name, jobIdx)
-
+func labelAndAnnotateObject(obj metav1.Object, js *jobset.JobSet, rjob *jobset.ReplicatedJob, instanceIdx, jobIdx int, jobName string) {
 	// Set labels on the object.
 	labels := collections.CloneMap(obj.GetLabels())
 	labels[jobset.JobSetNameKey] = js.Name
+	labels[jobset.JobSetUIDKey] = string(js.UID)
 	labels[jobset.ReplicatedJobNameKey] = rjob.Name
 	labels[constants.RestartsKey] = strconv.Itoa(int(js.Status.Restarts))
-	labels[jobset.ReplicatedJobReplicas] = strconv.Itoa(int(rjob.Replicas))
+	labels[jobset.ReplicatedJobReplicas] = strconv.Itoa(int(replicatedJobReplicas(js, rjob)))
 	labels[jobset.JobIndexKey] = strconv.Itoa(jobIdx)
-	labels[jobset.JobKey] = jobHashKey(js.Namespace, jobName)
+	labels[jobset.JobKey] = jobKey(js, jobName)
 
 	// Set annotations on the object.
 	annotations := collections.CloneMap(obj.GetAnnotations())
 	annotations[jobset.JobSetNameKey] = js.Name
+	annotations[jobset.JobSetUIDKey] = string(js.UID)
 	annotations[jobset.ReplicatedJobNameKey] = rjob.Name
 	annotations[constants.RestartsKey] = strconv.Itoa(int(js.Status.Restarts))
-	annotations[jobset.ReplicatedJobReplicas] = strconv.Itoa(int(rjob.Replicas))
+	annotations[jobset.ReplicatedJobReplicas] = strconv.Itoa(int(replicatedJobReplicas(js, rjob)))
 	annotations[jobset.JobIndexKey] = strconv.Itoa(jobIdx)
-	annotations[jobset.JobKey] = jobHashKey(js.Namespace, jobName)
+	annotations[jobset.JobKey] = jobKey(js, jobName)
+
+	// Set the instance index if the JobSet has multiple instances.
+	if js.Spec.Instances != nil {
+		labels[jobset.InstanceIndexKey] = strconv.Itoa(instanceIdx)
+		annotations[jobset.InstanceIndexKey] = strconv.Itoa(instanceIdx)
+	}
 
 	// Check for JobSet level exclusive placement.
 	if topologyDomain, exists := js.Annotations[jobset.ExclusiveKey]; exists {
@@ -746,6 +1741,10 @@ func labelAndAnnotateObject(obj metav1.Object, js *jobset.JobSet, rjob *jobset.R
 		if value, ok := js.Annotations[jobset.NodeSelectorStrategyKey]; ok {
 			annotations[jobset.NodeSelectorStrategyKey] = value
 		}
+		// Check if we are using nodeAffinityStrategy implementation of exclusive placement at the JobSet level.
+		if value, ok := js.Annotations[jobset.NodeAffinityStrategyKey]; ok {
+			annotations[jobset.NodeAffinityStrategyKey] = value
+		}
 	}
 	// Check for ReplicatedJob level exclusive placement.
 	if topologyDomain, exists := rjob.Template.Annotations[jobset.ExclusiveKey]; exists {
@@ -754,6 +1753,10 @@ func labelAndAnnotateObject(obj metav1.Object, js *jobset.JobSet, rjob *jobset.R
 		if value, ok := rjob.Template.Annotations[jobset.NodeSelectorStrategyKey]; ok {
 			annotations[jobset.NodeSelectorStrategyKey] = value
 		}
+		// Check if we are using nodeAffinityStrategy implementation of exclusive placement at the ReplicatedJob level.
+		if value, ok := rjob.Template.Annotations[jobset.NodeAffinityStrategyKey]; ok {
+			annotations[jobset.NodeAffinityStrategyKey] = value
+		}
 	}
 
 	obj.SetLabels(labels)
@@ -769,6 +1772,19 @@ func JobFinished(job *batchv1.Job) (bool, batchv1.JobConditionType) {
 	return false, ""
 }
 
+// allIndexesSucceeded returns false if the JobSet counts Indexed Jobs as succeeded only once all
+// of their completions succeeded, and the given complete Indexed Job has fewer succeeded indexes
+// than completions, which is possible when the Job has a success policy of its own.
+func allIndexesSucceeded(js *jobset.JobSet, job *batchv1.Job) bool {
+	if js.Spec.SuccessPolicy == nil || js.Spec.SuccessPolicy.IndexedJobCompletion != jobset.IndexedJobCompletionAllIndexes {
+		return true
+	}
+	if ptr.Deref(job.Spec.CompletionMode, batchv1.NonIndexedCompletion) != batchv1.IndexedCompletion || job.Spec.Completions == nil {
+		return true
+	}
+	return job.Status.Succeeded >= *job.Spec.Completions
+}
+
 func GetSubdomain(js *jobset.JobSet) string {
 	// If enableDNSHostnames is set, and subdomain is unset, default the subdomain to be the JobSet name.
 	// This must be done in the controller rather than in the request-time defaulting, since if a JobSet
@@ -808,7 +1824,8 @@ func sha1Hash(s string) string {
 	return hex.EncodeToString(h.Sum(nil))
 }
 
-func jobSetFinished(js *jobset.JobSet) bool {
+// JobSetFinished returns true if the JobSet has completed or failed.
+func JobSetFinished(js *jobset.JobSet) bool {
 	for _, c := range js.Status.Conditions {
 		if (c.Type == string(jobset.JobSetCompleted) || c.Type == string(jobset.JobSetFailed)) && c.Status == metav1.ConditionTrue {
 			return true
@@ -825,6 +1842,24 @@ func jobSetSuspended(js *jobset.JobSet) bool {
 	return ptr.Deref(js.Spec.Suspend, false)
 }
 
+func createJobsSuspended(js *jobset.JobSet) bool {
+	return ptr.Deref(js.Spec.CreateJobsSuspended, false)
+}
+
+// waitingForJobCreation returns true if the child Jobs of a JobSet creating its Jobs suspended
+// must not be resumed yet, since not all of its Jobs were created. With an InOrder startup
+// policy, the Jobs of each replicated job are resumed in turn, so they are never held back.
+func waitingForJobCreation(js *jobset.JobSet, ownedJobs *childJobs) bool {
+	if !createJobsSuspended(js) || inOrderStartupPolicy(js.Spec.StartupPolicy) {
+		return false
+	}
+	return len(ownedJobs.active)+len(ownedJobs.successful)+len(ownedJobs.failed) < numJobsExpected(js)
+}
+
+func retainPodsOnSuspend(js *jobset.JobSet) bool {
+	return js.Spec.OnSuspend == jobset.OnSuspendRetainPods
+}
+
 func jobSuspended(job *batchv1.Job) bool {
 	return ptr.Deref(job.Spec.Suspend, false)
 }
@@ -863,18 +1898,23 @@ func findFirstFailedJob(failedJobs []*batchv1.Job) *batchv1.Job {
 }
 
 // findJobFailureTime is a helper function which extracts the Job failure time from a Job,
-// if the JobFailed condition exists and is true.
+// if the JobFailed condition exists and is true. Complete Indexed Jobs counted as failed
+// since not all of their indexes succeeded fail at the time of their JobComplete condition.
 func findJobFailureTime(job *batchv1.Job) *metav1.Time {
 	if job == nil {
 		return nil
 	}
+	var completionTime *metav1.Time
 	for _, c := range job.Status.Conditions {
 		// If this Job failed before the oldest known Job failiure, update the first failed job.
 		if c.Type == batchv1.JobFailed && c.Status == corev1.ConditionTrue {
 			return &c.LastTransitionTime
 		}
+		if c.Type == batchv1.JobComplete && c.Status == corev1.ConditionTrue {
+			completionTime = &c.LastTransitionTime
+		}
 	}
-	return nil
+	return completionTime
 }
 
 // managedByExternalController returns a pointer to the name of the external controller managing
@@ -920,6 +1960,26 @@ func setCondition(js *jobset.JobSet, condOpts *conditionOpts, updateStatusOpts *
 	})
 }
 
+// setStatusCondition sets the given condition on the JobSet status with the semantics of
+// meta.SetStatusCondition. Unlike setCondition, it adds conditions of any status, and updates the
+// reason and message of a condition whose status is unchanged, keeping its last transition time.
+// It is meant for conditions reporting the current state of resources of the JobSet, rather than
+// the mutually exclusive lifecycle conditions.
+func setStatusCondition(js *jobset.JobSet, condOpts *conditionOpts, updateStatusOpts *statusUpdateOpts) {
+	cond := *condOpts.condition
+	cond.ObservedGeneration = js.Generation
+	if !meta.SetStatusCondition(&js.Status.Conditions, cond) {
+		return
+	}
+	updateStatusOpts.shouldUpdate = true
+	enqueueEvent(updateStatusOpts, &eventParams{
+		object:       js,
+		eventType:    condOpts.eventType,
+		eventReason:  cond.Reason,
+		eventMessage: cond.Message,
+	})
+}
+
 // updateCondition accepts a given condition and does one of the following:
 //  1. If an identical condition already exists, do nothing and return false (indicating
 //     no change was made).
@@ -934,6 +1994,7 @@ func updateCondition(js *jobset.JobSet, opts *conditionOpts) bool {
 	shouldUpdate := false
 	newCond := *opts.condition
 	newCond.LastTransitionTime = metav1.Now()
+	newCond.ObservedGeneration = js.Generation
 
 	for i, currCond := range js.Status.Conditions {
 		// If condition type has a status change, update it.
@@ -968,8 +2029,26 @@ func updateCondition(js *jobset.JobSet, opts *conditionOpts) bool {
 }
 
 // setJobSetCompletedCondition sets a condition on the JobSet status indicating it has completed.
-func setJobSetCompletedCondition(js *jobset.JobSet, updateStatusOpts *statusUpdateOpts) {
+func setJobSetCompletedCondition(ctx context.Context, js *jobset.JobSet, updateStatusOpts *statusUpdateOpts) {
+	traceLifecycleEvent(ctx, completeSpanName, js)
 	setCondition(js, makeCompletedConditionsOpts(), updateStatusOpts)
+	recordCompletionTime(js, updateStatusOpts)
+}
+
+// recordCompletionTime records the time the JobSet completed, taken from its Completed condition,
+// and the duration since its first start time in its status, unless they are already recorded. The
+// duration is computed with the second precision the times are serialized with.
+func recordCompletionTime(js *jobset.JobSet, updateStatusOpts *statusUpdateOpts) {
+	cond := meta.FindStatusCondition(js.Status.Conditions, string(jobset.JobSetCompleted))
+	if js.Status.CompletionTime != nil || cond == nil || cond.Status != metav1.ConditionTrue {
+		return
+	}
+	completionTime := cond.LastTransitionTime.Rfc3339Copy()
+	js.Status.CompletionTime = &completionTime
+	if js.Status.FirstStartTime != nil {
+		js.Status.Duration = &metav1.Duration{Duration: completionTime.Sub(js.Status.FirstStartTime.Rfc3339Copy().Time)}
+	}
+	updateStatusOpts.shouldUpdate = true
 }
 
 // setJobSetFailedCondition sets a condition on the JobSet status indicating it has failed.
@@ -1014,6 +2093,30 @@ func makeFailedConditionOpts(reason, msg string) *conditionOpts {
 	}
 }
 
+// makeReadyConditionOpts returns the options we use to generate the JobSet ready condition.
+func makeReadyConditionOpts(ready bool) *conditionOpts {
+	if ready {
+		return &conditionOpts{
+			eventType: corev1.EventTypeNormal,
+			condition: &metav1.Condition{
+				Type:    string(jobset.JobSetReady),
+				Status:  metav1.ConditionTrue,
+				Reason:  constants.AllJobsReadyReason,
+				Message: constants.AllJobsReadyMessage,
+			},
+		}
+	}
+	return &conditionOpts{
+		eventType: corev1.EventTypeNormal,
+		condition: &metav1.Condition{
+			Type:    string(jobset.JobSetReady),
+			Status:  metav1.ConditionFalse,
+			Reason:  constants.NotAllJobsReadyReason,
+			Message: constants.NotAllJobsReadyMessage,
+		},
+	}
+}
+
 // makeSuspendedConditionOpts returns the options we use to generate the JobSet suspended condition.
 func makeSuspendedConditionOpts() *conditionOpts {
 	return &conditionOpts{
@@ -1064,3 +2167,263 @@ func exclusiveConditions(cond1, cond2 metav1.Condition) bool {
 		cond2.Type == string(jobset.JobSetStartupPolicyInProgress)
 	return inProgressAndCompleted || completedAndInProgress
 }
+
+// jobSetPaused returns true if the JobSet controller must not act on the JobSet.
+func jobSetPaused(js *jobset.JobSet) bool {
+	return ptr.Deref(js.Spec.Paused, false)
+}
+
+// setPausedCondition sets the Paused condition of the JobSet. A JobSet which is not paused
+// only gets the condition if it was previously paused.
+func setPausedCondition(js *jobset.JobSet, updateStatusOpts *statusUpdateOpts) {
+	if jobSetPaused(js) {
+		setCondition(js, &conditionOpts{
+			eventType: corev1.EventTypeNormal,
+			condition: &metav1.Condition{
+				Type:    string(jobset.JobSetPaused),
+				Status:  metav1.ConditionTrue,
+				Reason:  constants.JobSetPausedReason,
+				Message: constants.JobSetPausedMessage,
+			},
+		}, updateStatusOpts)
+		return
+	}
+	if meta.FindStatusCondition(js.Status.Conditions, string(jobset.JobSetPaused)) == nil {
+		return
+	}
+	setCondition(js, &conditionOpts{
+		eventType: corev1.EventTypeNormal,
+		condition: &metav1.Condition{
+			Type:    string(jobset.JobSetPaused),
+			Status:  metav1.ConditionFalse,
+			Reason:  constants.JobSetUnpausedReason,
+			Message: constants.JobSetUnpausedMessage,
+		},
+	}, updateStatusOpts)
+}
+
+// setAdmissionPendingCondition sets the AdmissionPending condition of a JobSet managed by an
+// external controller, which tells a JobSet suspended until the external controller admits it
+// apart from one suspended by the user. A resumed JobSet only gets the condition if it was
+// previously pending.
+func setAdmissionPendingCondition(js *jobset.JobSet, updateStatusOpts *statusUpdateOpts) {
+	if jobSetSuspended(js) {
+		setCondition(js, &conditionOpts{
+			eventType: corev1.EventTypeNormal,
+			condition: &metav1.Condition{
+				Type:    string(jobset.JobSetAdmissionPending),
+				Status:  metav1.ConditionTrue,
+				Reason:  constants.AdmissionPendingReason,
+				Message: constants.AdmissionPendingMessage,
+			},
+		}, updateStatusOpts)
+		return
+	}
+	if meta.FindStatusCondition(js.Status.Conditions, string(jobset.JobSetAdmissionPending)) == nil {
+		return
+	}
+	setCondition(js, &conditionOpts{
+		eventType: corev1.EventTypeNormal,
+		condition: &metav1.Condition{
+			Type:    string(jobset.JobSetAdmissionPending),
+			Status:  metav1.ConditionFalse,
+			Reason:  constants.AdmittedReason,
+			Message: constants.AdmittedMessage,
+		},
+	}, updateStatusOpts)
+}
+
+// jobSetDraining returns true if the JobSet lets its active child Jobs finish without
+// restarting or creating any Jobs.
+func jobSetDraining(js *jobset.JobSet) bool {
+	return js.Spec.Lifecycle == jobset.JobSetLifecycleDrain
+}
+
+// executeDrain marks the draining JobSet as finished once none of its child Jobs are active.
+// The JobSet fails if any of its child Jobs failed, unless the failures are ignored by the
+// failure policy of their replicated job, and completes otherwise.
+func executeDrain(ctx context.Context, js *jobset.JobSet, ownedJobs *childJobs, rjobStatuses []jobset.ReplicatedJobStatus, updateStatusOpts *statusUpdateOpts) {
+	if len(ownedJobs.active) > 0 {
+		ctrl.LoggerFrom(ctx).V(2).Info("waiting for active jobs to finish while draining", "activeJobs", len(ownedJobs.active))
+		return
+	}
+	if failedJobs := failedJobsNotIgnored(js, ownedJobs.failed); len(failedJobs) > 0 {
+		setJobSetFailedCondition(ctx, js, constants.DrainedReason, messageWithFailedJobs(js, constants.DrainedWithFailedJobsMessage, failedJobs), updateStatusOpts)
+		setInsufficientSuccessfulJobsCondition(js, rjobStatuses, constants.DrainedReason, constants.InsufficientSuccessfulJobsFailedMessage, updateStatusOpts)
+		return
+	}
+	setJobSetCompletedCondition(ctx, js, updateStatusOpts)
+}
+
+// withReconcileTimeout returns a context bounding the reconciliation of a JobSet to the reconcile
+// timeout of the controller, so a huge JobSet can't hold a worker indefinitely. The context is
+// returned unchanged if the timeout is disabled.
+func (r *JobSetReconciler) withReconcileTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if r.opts.ReconcileTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, r.opts.ReconcileTimeout)
+}
+
+// reconcileTimedOut returns true if the reconciliation using the given context exceeded the
+// reconcile timeout. Its progress, e.g. the child Jobs already created, is kept, and the next
+// reconciliation continues from there.
+func reconcileTimedOut(ctx context.Context) bool {
+	return errors.Is(ctx.Err(), context.DeadlineExceeded)
+}
+
+// backOffReconcileError counts the failed reconciliation of the JobSet in its status. Once the
+// number of consecutive errors reaches the backoff threshold, the ReconcileBackingOff condition
+// is set and the JobSet is requeued after the reconcile error backoff, instead of being retried
+// by the rate limiter of the controller. The status of the JobSet must be as it was before the
+// failed reconciliation, so partial status changes are not persisted. Conflicts are returned as is,
+// since they are transient.
+func (r *JobSetReconciler) backOffReconcileError(ctx context.Context, js *jobset.JobSet, reconcileErr error) (ctrl.Result, error) {
+	threshold := r.opts.ReconcileErrorBackoffThreshold
+	if threshold <= 0 || k8serrors.IsConflict(reconcileErr) {
+		return ctrl.Result{}, reconcileErr
+	}
+	// The status is not updated anymore once backing off, since every status update triggers
+	// another reconciliation.
+	updateStatusOpts := statusUpdateOpts{}
+	if js.Status.ReconcileErrors < threshold {
+		js.Status.ReconcileErrors++
+		updateStatusOpts.shouldUpdate = true
+	}
+	if js.Status.ReconcileErrors < threshold {
+		return ctrl.Result{}, errors.Join(reconcileErr, r.updateJobSetStatus(ctx, js, &updateStatusOpts))
+	}
+
+	backoff := r.reconcileErrorBackoff()
+	ctrl.LoggerFrom(ctx).Error(reconcileErr, "backing off reconciliation", "consecutiveErrors", js.Status.ReconcileErrors, "backoff", backoff)
+	setCondition(js, &conditionOpts{
+		eventType: corev1.EventTypeWarning,
+		condition: &metav1.Condition{
+			Type:    string(jobset.JobSetReconcileBackingOff),
+			Status:  metav1.ConditionTrue,
+			Reason:  constants.ReconcileBackingOffReason,
+			Message: fmt.Sprintf(constants.ReconcileBackingOffMessage, threshold, backoff, reconcileErr),
+		},
+	}, &updateStatusOpts)
+	if err := r.updateJobSetStatus(ctx, js, &updateStatusOpts); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: backoff}, nil
+}
+
+// resetReconcileErrors resets the consecutive reconciliation errors of the JobSet after a
+// successful reconciliation, and sets the ReconcileBackingOff condition to false if it is set.
+func resetReconcileErrors(js *jobset.JobSet, updateStatusOpts *statusUpdateOpts) {
+	if js.Status.ReconcileErrors != 0 {
+		js.Status.ReconcileErrors = 0
+		updateStatusOpts.shouldUpdate = true
+	}
+	if !meta.IsStatusConditionTrue(js.Status.Conditions, string(jobset.JobSetReconcileBackingOff)) {
+		return
+	}
+	setCondition(js, &conditionOpts{
+		eventType: corev1.EventTypeNormal,
+		condition: &metav1.Condition{
+			Type:    string(jobset.JobSetReconcileBackingOff),
+			Status:  metav1.ConditionFalse,
+			Reason:  constants.ReconcileSucceededReason,
+			Message: constants.ReconcileSucceededMessage,
+		},
+	}, updateStatusOpts)
+}
+
+// reconcileErrorBackoff returns the time after which a JobSet backing off reconciliation errors
+// is reconciled again.
+func (r *JobSetReconciler) reconcileErrorBackoff() time.Duration {
+	if r.opts.ReconcileErrorBackoff > 0 {
+		return r.opts.ReconcileErrorBackoff
+	}
+	return constants.DefaultReconcileErrorBackoff
+}
+
+// incompleteJobListing returns true if fewer child Jobs of a replicated job were listed than the
+// JobSet status reports as finished. The controller doesn't delete finished child Jobs while the
+// JobSet is active, and the Jobs of a previous run or beyond the replicas are listed until the
+// status no longer counts them, so the listing is likely missing Jobs, e.g. if the API server
+// returned a partial result under pressure. Finished Jobs can still be deleted by others though,
+// e.g. with kubectl or by their ttlSecondsAfterFinished, which jobListingRetries accounts for.
+func incompleteJobListing(js *jobset.JobSet, ownedJobs *childJobs) bool {
+	listed := map[string]int32{}
+	for _, job := range collections.Concat(ownedJobs.active, ownedJobs.successful, ownedJobs.failed, ownedJobs.delete) {
+		listed[job.Labels[jobset.ReplicatedJobNameKey]]++
+	}
+	for _, status := range js.Status.ReplicatedJobsStatus {
+		if listed[status.Name] < status.Succeeded+status.Failed {
+			return true
+		}
+	}
+	return false
+}
+
+// jobListingRetries counts the consecutive reconciliations of each JobSet with an incomplete
+// listing of its child Jobs, so the listing is only retried a bounded number of times. Once
+// exhausted, the listing is taken as is, since the missing finished Jobs were likely deleted.
+// The counts are keyed by the name of the JobSet, so they can be forgotten once it is gone.
+type jobListingRetries struct {
+	lock    sync.Mutex
+	retries map[types.NamespacedName]jobListingRetry
+}
+
+// jobListingRetry is the count of the consecutive incomplete listings of the child Jobs of a
+// JobSet. The UID tells the JobSet apart from a later one recreated with the same name.
+type jobListingRetry struct {
+	uid   types.UID
+	count int
+}
+
+// retry records an incomplete listing of the child Jobs of the given JobSet. It returns true if
+// the listing should be retried, or false once the consecutive incomplete listings exceed
+// constants.MaxIncompleteJobListingRetries, which resets the count.
+func (l *jobListingRetries) retry(js *jobset.JobSet) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.retries == nil {
+		l.retries = map[types.NamespacedName]jobListingRetry{}
+	}
+	key := client.ObjectKeyFromObject(js)
+	entry := l.retries[key]
+	if entry.uid != js.UID {
+		entry = jobListingRetry{uid: js.UID}
+	}
+	entry.count++
+	if entry.count > constants.MaxIncompleteJobListingRetries {
+		delete(l.retries, key)
+		return false
+	}
+	l.retries[key] = entry
+	return true
+}
+
+// reset forgets the incomplete listings of the child Jobs of the JobSet with the given name.
+func (l *jobListingRetries) reset(key types.NamespacedName) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	delete(l.retries, key)
+}
+
+// fieldManager returns the field manager name of the server-side applies of the controller.
+func (r *JobSetReconciler) fieldManager() string {
+	if r.opts.FieldManager == "" {
+		return constants.DefaultFieldManager
+	}
+	return r.opts.FieldManager
+}
+
+// apply creates or updates the given object, constructed from the JobSet, with a server-side
+// apply of the fields it sets, forcing their ownership, since the JobSet is their source of truth.
+// The apply patch must include the type of the object, which is set from the scheme of the
+// client. Existing objects are changed with patches of the changed fields instead, as the fields
+// applied when they were created and omitted by a later apply would be removed.
+func (r *JobSetReconciler) apply(ctx context.Context, obj client.Object) error {
+	gvk, err := apiutil.GVKForObject(obj, r.Client.Scheme())
+	if err != nil {
+		return err
+	}
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	return r.Patch(ctx, obj, client.Apply, client.FieldOwner(r.fieldManager()), client.ForceOwnership)
+}
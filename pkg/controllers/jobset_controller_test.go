@@ -15,20 +15,44 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/go-logr/logr/funcr"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/stretchr/testify/assert"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	jobset "sigs.k8s.io/jobset/api/jobset/v1alpha2"
 	"sigs.k8s.io/jobset/pkg/constants"
+	"sigs.k8s.io/jobset/pkg/util/collections"
+	"sigs.k8s.io/jobset/pkg/util/placement"
 	testutils "sigs.k8s.io/jobset/pkg/util/testing"
 )
 
@@ -168,6 +192,26 @@ func TestConstructJobsFromTemplate(t *testing.T) {
 					Suspend(false).Obj(),
 			},
 		},
+		{
+			name: "jobs created suspended with createJobsSuspended",
+			js: testutils.MakeJobSet(jobSetName, ns).
+				CreateJobsSuspended(true).
+				ReplicatedJob(testutils.MakeReplicatedJob(replicatedJobName).
+					Job(testutils.MakeJobTemplate(jobName, ns).Obj()).
+					Replicas(1).
+					Obj()).Obj(),
+			ownedJobs: &childJobs{},
+			want: []*batchv1.Job{
+				makeJob(&makeJobArgs{
+					jobSetName:        jobSetName,
+					replicatedJobName: replicatedJobName,
+					jobName:           "test-jobset-replicated-job-0",
+					ns:                ns,
+					replicas:          1,
+					jobIdx:            0}).
+					Suspend(true).Obj(),
+			},
+		},
 		{
 			name: "one job created, one job not created (already active)",
 			js: testutils.MakeJobSet(jobSetName, ns).
@@ -640,7 +684,7 @@ func TestConstructJobsFromTemplate(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			var got []*batchv1.Job
 			for _, rjob := range tc.js.Spec.ReplicatedJobs {
-				jobs, err := constructJobsFromTemplate(tc.js, &rjob, tc.ownedJobs)
+				jobs, err := constructJobsFromTemplate(context.TODO(), tc.js, &rjob, tc.ownedJobs, "")
 				if err != nil {
 					t.Errorf("constructJobsFromTemplate() error = %v", err)
 					return
@@ -655,553 +699,4393 @@ func TestConstructJobsFromTemplate(t *testing.T) {
 	}
 }
 
-func TestUpdateConditions(t *testing.T) {
+func TestConstructJobsFromTemplateWithPodTemplateName(t *testing.T) {
 	var (
 		jobSetName        = "test-jobset"
 		replicatedJobName = "replicated-job"
+		podTemplateName   = "shared"
 		jobName           = "test-job"
 		ns                = "default"
 	)
+	podTemplate := corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{"app": "test"},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyOnFailure,
+			NodeSelector:  map[string]string{"pool": "test"},
+			Containers:    []corev1.Container{{Name: "main", Image: "busybox"}},
+		},
+	}
+	inlineJobTemplate := testutils.MakeJobTemplate(jobName, ns).Obj()
+	inlineJobTemplate.Spec.Template = podTemplate
+
+	inline := testutils.MakeJobSet(jobSetName, ns).
+		ReplicatedJob(testutils.MakeReplicatedJob(replicatedJobName).
+			Job(inlineJobTemplate).
+			Replicas(2).
+			Obj()).
+		Obj()
+	referenced := testutils.MakeJobSet(jobSetName, ns).
+		PodTemplates(map[string]corev1.PodTemplateSpec{podTemplateName: podTemplate}).
+		ReplicatedJob(testutils.MakeReplicatedJob(replicatedJobName).
+			Job(testutils.MakeJobTemplate(jobName, ns).Obj()).
+			PodTemplateName(podTemplateName).
+			Replicas(2).
+			Obj()).
+		Obj()
+
+	want, err := constructJobsFromTemplate(context.TODO(), inline, &inline.Spec.ReplicatedJobs[0], &childJobs{}, "")
+	if err != nil {
+		t.Fatalf("constructJobsFromTemplate() error = %v", err)
+	}
+	got, err := constructJobsFromTemplate(context.TODO(), referenced, &referenced.Spec.ReplicatedJobs[0], &childJobs{}, "")
+	if err != nil {
+		t.Fatalf("constructJobsFromTemplate() error = %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("referenced pod template produced different jobs (-want +got):\n%s", diff)
+	}
+
+	// Jobs must not share the referenced pod template.
+	got[0].Spec.Template.Spec.NodeSelector["pool"] = "mutated"
+	if referenced.Spec.PodTemplates[podTemplateName].Spec.NodeSelector["pool"] != "test" {
+		t.Errorf("constructed job shares the pod template of the JobSet spec")
+	}
+
+	// A reference that does not resolve results in an error.
+	referenced.Spec.ReplicatedJobs[0].PodTemplateName = "missing"
+	if _, err := constructJobsFromTemplate(context.TODO(), referenced, &referenced.Spec.ReplicatedJobs[0], &childJobs{}, ""); err == nil {
+		t.Errorf("expected an error for a missing pod template")
+	}
+}
+
+func TestConstructJobWithJobSetMetadata(t *testing.T) {
+	var (
+		jobSetName        = "test-jobset"
+		replicatedJobName = "replicated-job"
+		jobName           = "test-jobset-replicated-job-0"
+		ns                = "default"
+	)
+	jobTemplate := testutils.MakeJobTemplate("test-job", ns).Obj()
+	jobTemplate.Labels = map[string]string{"team": "job-template"}
+	jobTemplate.Annotations = map[string]string{"owner": "job-template"}
+	jobTemplate.Spec.Template.Labels = map[string]string{"team": "pod-template"}
+	js := testutils.MakeJobSet(jobSetName, ns).
+		SpecLabels(map[string]string{
+			"team":               "jobset",
+			"cost-center":        "1234",
+			jobset.JobSetNameKey: "user-value",
+		}).
+		SpecAnnotations(map[string]string{
+			"owner":               "jobset",
+			"billing":             "enabled",
+			constants.RestartsKey: "user-value",
+		}).
+		ReplicatedJob(testutils.MakeReplicatedJob(replicatedJobName).
+			Job(jobTemplate).
+			Replicas(1).
+			Obj()).
+		Obj()
+
+	job, err := constructJob(js, &js.Spec.ReplicatedJobs[0], 0, 0, "")
+	if err != nil {
+		t.Fatalf("constructJob() error = %v", err)
+	}
+	managed := makeJob(&makeJobArgs{
+		jobSetName:        jobSetName,
+		replicatedJobName: replicatedJobName,
+		jobName:           jobName,
+		ns:                ns,
+		replicas:          1,
+		jobIdx:            0,
+	}).Obj()
+
+	wantJobLabels := collections.MergeMaps(managed.Labels, map[string]string{"team": "job-template", "cost-center": "1234"})
+	wantJobAnnotations := collections.MergeMaps(managed.Annotations, map[string]string{"owner": "job-template", "billing": "enabled"})
+	wantPodLabels := collections.MergeMaps(managed.Spec.Template.Labels, map[string]string{"team": "pod-template", "cost-center": "1234"})
+	wantPodAnnotations := collections.MergeMaps(managed.Spec.Template.Annotations, map[string]string{"owner": "jobset", "billing": "enabled"})
+	if diff := cmp.Diff(wantJobLabels, job.Labels); diff != "" {
+		t.Errorf("unexpected job labels (-want/+got): %s", diff)
+	}
+	if diff := cmp.Diff(wantJobAnnotations, job.Annotations); diff != "" {
+		t.Errorf("unexpected job annotations (-want/+got): %s", diff)
+	}
+	if diff := cmp.Diff(wantPodLabels, job.Spec.Template.Labels); diff != "" {
+		t.Errorf("unexpected pod labels (-want/+got): %s", diff)
+	}
+	if diff := cmp.Diff(wantPodAnnotations, job.Spec.Template.Annotations); diff != "" {
+		t.Errorf("unexpected pod annotations (-want/+got): %s", diff)
+	}
+}
+
+func TestConstructJobWithJobAndPodAnnotations(t *testing.T) {
+	var (
+		jobSetName        = "test-jobset"
+		replicatedJobName = "replicated-job"
+		jobName           = "test-jobset-replicated-job-0"
+		ns                = "default"
+	)
+	jobTemplate := testutils.MakeJobTemplate("test-job", ns).Obj()
+	jobTemplate.Annotations = map[string]string{"owner": "job-template"}
+	jobTemplate.Spec.Template.Annotations = map[string]string{"sidecar": "pod-template"}
+	js := testutils.MakeJobSet(jobSetName, ns).
+		SpecAnnotations(map[string]string{
+			"owner":   "jobset",
+			"sidecar": "jobset",
+			"shared":  "jobset",
+		}).
+		JobAnnotations(map[string]string{
+			"owner":               "job-annotations",
+			"billing":             "team-a",
+			"shared":              "job-annotations",
+			constants.RestartsKey: "user-value",
+		}).
+		PodAnnotations(map[string]string{
+			"sidecar":             "pod-annotations",
+			"profiling":           "enabled",
+			"shared":              "pod-annotations",
+			constants.RestartsKey: "user-value",
+		}).
+		ReplicatedJob(testutils.MakeReplicatedJob(replicatedJobName).
+			Job(jobTemplate).
+			Replicas(1).
+			Obj()).
+		Obj()
+
+	job, err := constructJob(js, &js.Spec.ReplicatedJobs[0], 0, 0, "")
+	if err != nil {
+		t.Fatalf("constructJob() error = %v", err)
+	}
+	managed := makeJob(&makeJobArgs{
+		jobSetName:        jobSetName,
+		replicatedJobName: replicatedJobName,
+		jobName:           jobName,
+		ns:                ns,
+		replicas:          1,
+		jobIdx:            0,
+	}).Obj()
+
+	wantJobAnnotations := collections.MergeMaps(managed.Annotations, map[string]string{
+		"owner":   "job-template",
+		"sidecar": "jobset",
+		"billing": "team-a",
+		"shared":  "job-annotations",
+	})
+	wantPodAnnotations := collections.MergeMaps(managed.Spec.Template.Annotations, map[string]string{
+		"owner":     "jobset",
+		"sidecar":   "pod-template",
+		"profiling": "enabled",
+		"shared":    "pod-annotations",
+	})
+	if diff := cmp.Diff(wantJobAnnotations, job.Annotations); diff != "" {
+		t.Errorf("unexpected job annotations (-want/+got): %s", diff)
+	}
+	if diff := cmp.Diff(wantPodAnnotations, job.Spec.Template.Annotations); diff != "" {
+		t.Errorf("unexpected pod annotations (-want/+got): %s", diff)
+	}
+}
 
+func TestConstructJobWithImagePullSecrets(t *testing.T) {
 	tests := []struct {
-		name           string
-		js             *jobset.JobSet
-		opts           *conditionOpts
-		expectedUpdate bool
+		name            string
+		templateSecrets []corev1.LocalObjectReference
+		jobSetSecrets   []corev1.LocalObjectReference
+		want            []corev1.LocalObjectReference
 	}{
 		{
-			name: "no existing conditions, not adding conditions",
-			js: testutils.MakeJobSet(jobSetName, ns).
-				ReplicatedJob(testutils.MakeReplicatedJob(replicatedJobName).
-					Job(testutils.MakeJobTemplate(jobName, ns).Obj()).
-					Replicas(1).
-					Obj()).Obj(),
-			opts:           &conditionOpts{},
-			expectedUpdate: false,
+			name: "no image pull secrets",
 		},
 		{
-			name: "no existing conditions, add a condition",
-			js: testutils.MakeJobSet(jobSetName, ns).
-				ReplicatedJob(testutils.MakeReplicatedJob(replicatedJobName).
-					Job(testutils.MakeJobTemplate(jobName, ns).Obj()).
-					Replicas(1).
-					Obj()).Obj(),
-			opts:           makeCompletedConditionsOpts(),
-			expectedUpdate: true,
+			name:          "jobset secrets only",
+			jobSetSecrets: []corev1.LocalObjectReference{{Name: "registry"}},
+			want:          []corev1.LocalObjectReference{{Name: "registry"}},
 		},
 		{
-			name: "suspended",
-			js: testutils.MakeJobSet(jobSetName, ns).
-				ReplicatedJob(testutils.MakeReplicatedJob(replicatedJobName).
-					Job(testutils.MakeJobTemplate(jobName, ns).Obj()).
-					Replicas(1).
-					Obj()).Obj(),
-			opts:           makeSuspendedConditionOpts(),
-			expectedUpdate: true,
+			name:            "template secrets only",
+			templateSecrets: []corev1.LocalObjectReference{{Name: "team"}},
+			want:            []corev1.LocalObjectReference{{Name: "team"}},
 		},
 		{
-			name: "resume (update suspended condition type in-place)",
-			js: testutils.MakeJobSet(jobSetName, ns).
-				ReplicatedJob(testutils.MakeReplicatedJob(replicatedJobName).
-					Job(testutils.MakeJobTemplate(jobName, ns).Obj()).
-					Replicas(1).
-					Obj()).
-				Conditions([]metav1.Condition{
-					// JobSet is currrently suspended.
-					{
-						Type:    string(jobset.JobSetSuspended),
-						Reason:  constants.JobSetSuspendedReason,
-						Message: constants.JobSetSuspendedMessage,
-						Status:  metav1.ConditionStatus(corev1.ConditionTrue),
-					},
-				}).
-				Obj(),
-			opts:           makeResumedConditionOpts(),
-			expectedUpdate: true,
+			name:            "jobset secrets are appended after template secrets",
+			templateSecrets: []corev1.LocalObjectReference{{Name: "team"}},
+			jobSetSecrets:   []corev1.LocalObjectReference{{Name: "registry"}, {Name: "mirror"}},
+			want:            []corev1.LocalObjectReference{{Name: "team"}, {Name: "registry"}, {Name: "mirror"}},
 		},
 		{
-			name: "existing conditions, attempt to add duplicate",
-			js: testutils.MakeJobSet(jobSetName, ns).
-				ReplicatedJob(testutils.MakeReplicatedJob(replicatedJobName).
-					Job(testutils.MakeJobTemplate(jobName, ns).Obj()).
-					Replicas(1).
-					Obj()).
-				Conditions([]metav1.Condition{
-					// JobSet is completed..
-					{
-						Type:    string(jobset.JobSetCompleted),
-						Reason:  constants.AllJobsCompletedReason,
-						Message: constants.AllJobsCompletedMessage,
-						Status:  metav1.ConditionStatus(corev1.ConditionTrue),
-					},
-				}).Obj(),
-			opts:           makeCompletedConditionsOpts(),
-			expectedUpdate: false,
+			name:            "secrets set in the template are not duplicated",
+			templateSecrets: []corev1.LocalObjectReference{{Name: "registry"}, {Name: "team"}},
+			jobSetSecrets:   []corev1.LocalObjectReference{{Name: "team"}, {Name: "mirror"}},
+			want:            []corev1.LocalObjectReference{{Name: "registry"}, {Name: "team"}, {Name: "mirror"}},
 		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			gotUpdate := updateCondition(tc.js, tc.opts)
-			if gotUpdate != tc.expectedUpdate {
-				t.Errorf("updateCondition return mismatch (want: %v, got %v)", tc.expectedUpdate, gotUpdate)
+			js := testutils.MakeJobSet("test-jobset", "default").
+				ImagePullSecrets(tc.jobSetSecrets...).
+				ReplicatedJob(testutils.MakeReplicatedJob("replicated-job").
+					Job(testutils.MakeJobTemplate("test-job", "default").
+						PodSpec(corev1.PodSpec{ImagePullSecrets: tc.templateSecrets}).
+						Obj()).
+					Replicas(2).
+					Obj()).
+				Obj()
+			for jobIdx := 0; jobIdx < 2; jobIdx++ {
+				job, err := constructJob(js, &js.Spec.ReplicatedJobs[0], 0, jobIdx, "")
+				if err != nil {
+					t.Fatalf("constructJob() error = %v", err)
+				}
+				if diff := cmp.Diff(tc.want, job.Spec.Template.Spec.ImagePullSecrets); diff != "" {
+					t.Errorf("unexpected image pull secrets of job %d (-want/+got): %s", jobIdx, diff)
+				}
+			}
+			// The template of the JobSet must not be modified.
+			if diff := cmp.Diff(tc.templateSecrets, js.Spec.ReplicatedJobs[0].Template.Spec.Template.Spec.ImagePullSecrets); diff != "" {
+				t.Errorf("unexpected change of the template image pull secrets (-want/+got): %s", diff)
 			}
 		})
 	}
 }
 
-func TestCalculateReplicatedJobStatuses(t *testing.T) {
-	var (
-		jobSetName = "test-jobset"
-		ns         = "default"
-	)
+func TestConstructJobWithHostAliases(t *testing.T) {
 	tests := []struct {
-		name     string
-		js       *jobset.JobSet
-		jobs     childJobs
-		expected []jobset.ReplicatedJobStatus
+		name            string
+		templateAliases []corev1.HostAlias
+		jobSetAliases   []corev1.HostAlias
+		want            []corev1.HostAlias
 	}{
 		{
-			name: "partial jobs are ready, no succeeded jobs",
-			js: testutils.MakeJobSet(jobSetName, ns).
-				ReplicatedJob(testutils.MakeReplicatedJob("replicated-job-1").
-					Job(testutils.MakeJobTemplate("test-job", ns).Obj()).
-					Replicas(1).
-					Obj()).
-				ReplicatedJob(testutils.MakeReplicatedJob("replicated-job-2").
-					Job(testutils.MakeJobTemplate("test-job", ns).Obj()).
-					Replicas(3).
-					Obj()).Obj(),
-			jobs: childJobs{
-				active: []*batchv1.Job{
-					makeJob(&makeJobArgs{
-						jobSetName:        jobSetName,
-						replicatedJobName: "replicated-job-1",
-						jobName:           "test-jobset-replicated-job-1-test-job-0",
-						ns:                ns,
-						replicas:          1,
-						jobIdx:            0}).
-						Parallelism(1).
-						Completions(2).
-						Ready(1).
-						Succeeded(1).Obj(),
-					makeJob(&makeJobArgs{
-						jobSetName:        jobSetName,
-						replicatedJobName: "replicated-job-2",
-						jobName:           "test-jobset-replicated-job-2-test-job-0",
-						ns:                ns,
-						replicas:          3,
-						jobIdx:            0}).
-						Parallelism(5).
-						Ready(2).
-						Succeeded(3).Obj(),
-					makeJob(&makeJobArgs{
-						jobSetName:        jobSetName,
-						replicatedJobName: "replicated-job-2",
-						jobName:           "test-jobset-replicated-job-2-test-job-1",
-						ns:                ns,
-						replicas:          3,
-						jobIdx:            0}).
-						Parallelism(3).
-						Completions(2).
-						Ready(1).
-						Succeeded(1).Obj(),
-					makeJob(&makeJobArgs{
-						jobSetName:        jobSetName,
-						replicatedJobName: "replicated-job-2",
-						jobName:           "test-jobset-replicated-job-2-test-job-2",
-						ns:                ns,
-						replicas:          3,
-						jobIdx:            0}).
-						Parallelism(2).
-						Completions(3).
-						Ready(2).
-						Succeeded(1).Obj(),
-					makeJob(&makeJobArgs{
-						jobSetName:        jobSetName,
-						replicatedJobName: "replicated-job-2",
-						jobName:           "test-jobset-replicated-job-2-test-job-3",
-						ns:                ns,
-						replicas:          3,
-						jobIdx:            0}).
-						Parallelism(4).
-						Completions(5).
-						Ready(2).
-						Succeeded(1).Obj(),
-				},
-			},
-			expected: []jobset.ReplicatedJobStatus{
-				{
-					Name:      "replicated-job-1",
-					Ready:     1,
-					Succeeded: 0,
-				},
-				{
-					Name:      "replicated-job-2",
-					Ready:     3,
-					Succeeded: 0,
-				},
-			},
+			name: "no host aliases",
 		},
 		{
-			name: "no jobs created",
-			js: testutils.MakeJobSet(jobSetName, ns).
-				ReplicatedJob(testutils.MakeReplicatedJob("replicated-job-1").
-					Job(testutils.MakeJobTemplate("test-job", ns).Obj()).
-					Replicas(1).
-					Obj()).
-				ReplicatedJob(testutils.MakeReplicatedJob("replicated-job-2").
-					Job(testutils.MakeJobTemplate("test-job", ns).Obj()).
-					Replicas(3).
-					Obj()).Obj(),
-			expected: []jobset.ReplicatedJobStatus{
-				{
-					Name:      "replicated-job-1",
-					Ready:     0,
-					Succeeded: 0,
-				},
-				{
-					Name:      "replicated-job-2",
-					Ready:     0,
-					Succeeded: 0,
-				},
+			name:          "jobset aliases only",
+			jobSetAliases: []corev1.HostAlias{{IP: "10.0.0.1", Hostnames: []string{"registry.local"}}},
+			want:          []corev1.HostAlias{{IP: "10.0.0.1", Hostnames: []string{"registry.local"}}},
+		},
+		{
+			name:            "template aliases only",
+			templateAliases: []corev1.HostAlias{{IP: "10.0.0.2", Hostnames: []string{"storage.local"}}},
+			want:            []corev1.HostAlias{{IP: "10.0.0.2", Hostnames: []string{"storage.local"}}},
+		},
+		{
+			name:            "jobset aliases are appended after template aliases",
+			templateAliases: []corev1.HostAlias{{IP: "10.0.0.2", Hostnames: []string{"storage.local"}}},
+			jobSetAliases:   []corev1.HostAlias{{IP: "10.0.0.1", Hostnames: []string{"registry.local"}}},
+			want: []corev1.HostAlias{
+				{IP: "10.0.0.2", Hostnames: []string{"storage.local"}},
+				{IP: "10.0.0.1", Hostnames: []string{"registry.local"}},
 			},
 		},
 		{
-			name: "partial jobs created",
-			js: testutils.MakeJobSet(jobSetName, ns).
-				ReplicatedJob(testutils.MakeReplicatedJob("replicated-job-1").
-					Job(testutils.MakeJobTemplate("test-job", ns).Obj()).
-					Replicas(1).
+			name:            "hostnames of aliases with the same ip are merged",
+			templateAliases: []corev1.HostAlias{{IP: "10.0.0.1", Hostnames: []string{"registry.local", "team.local"}}},
+			jobSetAliases:   []corev1.HostAlias{{IP: "10.0.0.1", Hostnames: []string{"registry.local", "mirror.local"}}},
+			want:            []corev1.HostAlias{{IP: "10.0.0.1", Hostnames: []string{"registry.local", "team.local", "mirror.local"}}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet("test-jobset", "default").
+				HostAliases(tc.jobSetAliases...).
+				ReplicatedJob(testutils.MakeReplicatedJob("replicated-job").
+					Job(testutils.MakeJobTemplate("test-job", "default").
+						PodSpec(corev1.PodSpec{HostAliases: tc.templateAliases}).
+						Obj()).
+					Replicas(2).
 					Obj()).
-				ReplicatedJob(testutils.MakeReplicatedJob("replicated-job-2").
-					Job(testutils.MakeJobTemplate("test-job", ns).Obj()).
-					Replicas(3).
-					Obj()).Obj(),
-			jobs: childJobs{
-				active: []*batchv1.Job{
-					makeJob(&makeJobArgs{
-						jobSetName:        jobSetName,
-						replicatedJobName: "replicated-job-2",
-						jobName:           "test-jobset-replicated-job-2-test-job-0",
-						ns:                ns,
-						replicas:          3,
-						jobIdx:            0}).
-						Parallelism(5).
-						Ready(2).
-						Succeeded(3).Obj(),
-				},
-			},
-			expected: []jobset.ReplicatedJobStatus{
-				{
-					Name:      "replicated-job-1",
-					Ready:     0,
-					Succeeded: 0,
-				},
-				{
-					Name:      "replicated-job-2",
-					Ready:     1,
-					Succeeded: 0,
-				},
-			},
+				Obj()
+			for jobIdx := 0; jobIdx < 2; jobIdx++ {
+				job, err := constructJob(js, &js.Spec.ReplicatedJobs[0], 0, jobIdx, "")
+				if err != nil {
+					t.Fatalf("constructJob() error = %v", err)
+				}
+				if diff := cmp.Diff(tc.want, job.Spec.Template.Spec.HostAliases); diff != "" {
+					t.Errorf("unexpected host aliases of job %d (-want/+got): %s", jobIdx, diff)
+				}
+			}
+			// The template and the aliases of the JobSet must not be modified.
+			if diff := cmp.Diff(tc.templateAliases, js.Spec.ReplicatedJobs[0].Template.Spec.Template.Spec.HostAliases); diff != "" {
+				t.Errorf("unexpected change of the template host aliases (-want/+got): %s", diff)
+			}
+			if diff := cmp.Diff(tc.jobSetAliases, js.Spec.HostAliases); diff != "" {
+				t.Errorf("unexpected change of the jobset host aliases (-want/+got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestConstructJobWithDefaultJobActiveDeadlineSeconds(t *testing.T) {
+	tests := []struct {
+		name             string
+		templateDeadline *int64
+		defaultDeadline  *int64
+		want             *int64
+	}{
+		{
+			name: "no deadline",
 		},
 		{
-			name: "no ready jobs, only succeeded jobs",
-			js: testutils.MakeJobSet(jobSetName, ns).
-				ReplicatedJob(testutils.MakeReplicatedJob("replicated-job-1").
-					Job(testutils.MakeJobTemplate("test-job", ns).Obj()).
-					Replicas(1).
+			name:            "default deadline is inherited",
+			defaultDeadline: ptr.To[int64](3600),
+			want:            ptr.To[int64](3600),
+		},
+		{
+			name:             "deadline set in the template is kept",
+			templateDeadline: ptr.To[int64](60),
+			defaultDeadline:  ptr.To[int64](3600),
+			want:             ptr.To[int64](60),
+		},
+		{
+			name:             "deadline set in the template without default",
+			templateDeadline: ptr.To[int64](60),
+			want:             ptr.To[int64](60),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			jobTemplate := testutils.MakeJobTemplate("test-job", "default").Obj()
+			jobTemplate.Spec.ActiveDeadlineSeconds = tc.templateDeadline
+			js := testutils.MakeJobSet("test-jobset", "default").
+				ReplicatedJob(testutils.MakeReplicatedJob("replicated-job").
+					Job(jobTemplate).
+					Replicas(2).
 					Obj()).
-				ReplicatedJob(testutils.MakeReplicatedJob("replicated-job-2").
-					Job(testutils.MakeJobTemplate("test-job", ns).Obj()).
-					Replicas(3).
-					Obj()).Obj(),
-			jobs: childJobs{
-				successful: []*batchv1.Job{
-					makeJob(&makeJobArgs{
-						jobSetName:        jobSetName,
-						replicatedJobName: "replicated-job-2",
-						jobName:           "test-jobset-replicated-job-2-test-job-0"}).Obj(),
-					makeJob(&makeJobArgs{
-						jobSetName:        jobSetName,
-						replicatedJobName: "replicated-job-1",
-						jobName:           "test-jobset-replicated-job-1-test-job-0"}).Obj(),
-				},
-			},
-			expected: []jobset.ReplicatedJobStatus{
-				{
-					Name:      "replicated-job-1",
-					Ready:     0,
-					Succeeded: 1,
-				},
-				{
-					Name:      "replicated-job-2",
-					Ready:     0,
-					Succeeded: 1,
-				},
-			},
+				Obj()
+			js.Spec.DefaultJobActiveDeadlineSeconds = tc.defaultDeadline
+			for jobIdx := 0; jobIdx < 2; jobIdx++ {
+				job, err := constructJob(js, &js.Spec.ReplicatedJobs[0], 0, jobIdx, "")
+				if err != nil {
+					t.Fatalf("constructJob() error = %v", err)
+				}
+				if diff := cmp.Diff(tc.want, job.Spec.ActiveDeadlineSeconds); diff != "" {
+					t.Errorf("unexpected activeDeadlineSeconds of job %d (-want/+got): %s", jobIdx, diff)
+				}
+			}
+			// The template of the JobSet must not be modified.
+			if diff := cmp.Diff(tc.templateDeadline, js.Spec.ReplicatedJobs[0].Template.Spec.ActiveDeadlineSeconds); diff != "" {
+				t.Errorf("unexpected change of the template activeDeadlineSeconds (-want/+got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestConstructJobsWithServiceAccountName(t *testing.T) {
+	tests := []struct {
+		name                       string
+		serviceAccountName         string
+		templateServiceAccountName string
+		templateServiceAccount     string
+		want                       string
+	}{
+		{
+			name: "no service account",
 		},
 		{
-			name: "no ready jobs, only failed jobs",
-			js: testutils.MakeJobSet(jobSetName, ns).
-				ReplicatedJob(testutils.MakeReplicatedJob("replicated-job-1").
-					Job(testutils.MakeJobTemplate("test-job", ns).Obj()).
-					Replicas(1).
+			name:               "service account is propagated",
+			serviceAccountName: "team-a",
+			want:               "team-a",
+		},
+		{
+			name:                       "service account set in the template takes precedence",
+			serviceAccountName:         "team-a",
+			templateServiceAccountName: "custom",
+			want:                       "custom",
+		},
+		{
+			name:                   "deprecated service account set in the template takes precedence",
+			serviceAccountName:     "team-a",
+			templateServiceAccount: "custom",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			jobTemplate := testutils.MakeJobTemplate("test-job", "default").
+				PodSpec(corev1.PodSpec{ServiceAccountName: tc.templateServiceAccountName, DeprecatedServiceAccount: tc.templateServiceAccount}).
+				Obj()
+			js := testutils.MakeJobSet("test-jobset", "default").
+				ReplicatedJob(testutils.MakeReplicatedJob("replicated-job").
+					Job(jobTemplate).
+					Replicas(2).
 					Obj()).
-				ReplicatedJob(testutils.MakeReplicatedJob("replicated-job-2").
-					Job(testutils.MakeJobTemplate("test-job", ns).Obj()).
-					Replicas(3).
-					Obj()).Obj(),
-			jobs: childJobs{
-				failed: []*batchv1.Job{
-					makeJob(&makeJobArgs{
-						jobSetName:        jobSetName,
-						replicatedJobName: "replicated-job-2",
-						jobName:           "test-jobset-replicated-job-2-test-job-0"}).Obj(),
-					makeJob(&makeJobArgs{
-						jobSetName:        jobSetName,
-						replicatedJobName: "replicated-job-1",
-						jobName:           "test-jobset-replicated-job-1-test-job-0"}).Obj(),
-					makeJob(&makeJobArgs{
-						jobSetName:        jobSetName,
-						replicatedJobName: "replicated-job-1",
-						jobName:           "test-jobset-replicated-job-1-test-job-1"}).Obj(),
-					makeJob(&makeJobArgs{
-						jobSetName:        jobSetName,
-						replicatedJobName: "replicated-job-1",
-						jobName:           "test-jobset-replicated-job-1-test-job-2"}).Obj(),
-				},
-			},
-			expected: []jobset.ReplicatedJobStatus{
-				{
-					Name:   "replicated-job-1",
-					Ready:  0,
-					Failed: 3,
-				},
-				{
-					Name:   "replicated-job-2",
-					Ready:  0,
-					Failed: 1,
-				},
-			},
+				Obj()
+			js.Spec.ServiceAccountName = tc.serviceAccountName
+			jobs, err := constructJobsFromTemplate(context.TODO(), js, &js.Spec.ReplicatedJobs[0], &childJobs{}, "")
+			if err != nil {
+				t.Fatalf("constructJobsFromTemplate() error = %v", err)
+			}
+			if len(jobs) != 2 {
+				t.Fatalf("expected 2 jobs, got %d", len(jobs))
+			}
+			for _, job := range jobs {
+				if got := job.Spec.Template.Spec.ServiceAccountName; got != tc.want {
+					t.Errorf("unexpected serviceAccountName of job %s: got %q, want %q", job.Name, got, tc.want)
+				}
+			}
+			// The template of the JobSet must not be modified.
+			if got := js.Spec.ReplicatedJobs[0].Template.Spec.Template.Spec.ServiceAccountName; got != tc.templateServiceAccountName {
+				t.Errorf("unexpected change of the template serviceAccountName: got %q, want %q", got, tc.templateServiceAccountName)
+			}
+		})
+	}
+}
+
+func TestConstructJobsWithRuntimeClassName(t *testing.T) {
+	tests := []struct {
+		name                     string
+		runtimeClassName         string
+		templateRuntimeClassName *string
+		want                     *string
+	}{
+		{
+			name: "no runtime class",
 		},
 		{
-			name: "active jobs",
-			js: testutils.MakeJobSet(jobSetName, ns).
-				ReplicatedJob(testutils.MakeReplicatedJob("replicated-job-1").
-					Job(testutils.MakeJobTemplate("test-job", ns).Obj()).
-					Replicas(1).
+			name:             "runtime class is propagated",
+			runtimeClassName: "nvidia",
+			want:             ptr.To("nvidia"),
+		},
+		{
+			name:                     "runtime class set in the template takes precedence",
+			runtimeClassName:         "nvidia",
+			templateRuntimeClassName: ptr.To("gvisor"),
+			want:                     ptr.To("gvisor"),
+		},
+		{
+			name:                     "runtime class set in the template is kept",
+			templateRuntimeClassName: ptr.To("gvisor"),
+			want:                     ptr.To("gvisor"),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			jobTemplate := testutils.MakeJobTemplate("test-job", "default").
+				PodSpec(corev1.PodSpec{RuntimeClassName: tc.templateRuntimeClassName}).
+				Obj()
+			js := testutils.MakeJobSet("test-jobset", "default").
+				RuntimeClassName(tc.runtimeClassName).
+				ReplicatedJob(testutils.MakeReplicatedJob("replicated-job").
+					Job(jobTemplate).
+					Replicas(2).
 					Obj()).
-				ReplicatedJob(testutils.MakeReplicatedJob("replicated-job-2").
-					Job(testutils.MakeJobTemplate("test-job", ns).Obj()).
-					Replicas(3).
-					Obj()).Obj(),
-			jobs: childJobs{
-				active: []*batchv1.Job{
-					makeJob(&makeJobArgs{
-						jobSetName:        jobSetName,
-						replicatedJobName: "replicated-job-1",
-						jobName:           "test-jobset-replicated-job-2-test-job-0"}).
-						Parallelism(5).
-						Active(1).
-						Obj(),
-					makeJob(&makeJobArgs{
-						jobSetName:        jobSetName,
-						replicatedJobName: "replicated-job-2",
-						jobName:           "test-jobset-replicated-job-2-test-job-0"}).
-						Parallelism(5).
-						Obj(),
-					makeJob(&makeJobArgs{
-						jobSetName:        jobSetName,
-						replicatedJobName: "replicated-job-2",
-						jobName:           "test-jobset-replicated-job-2-test-job-1"}).
-						Parallelism(1).
-						Active(1).
-						Obj(),
-				},
-			},
-			expected: []jobset.ReplicatedJobStatus{
-				{
-					Name:   "replicated-job-1",
-					Ready:  0,
-					Active: 1,
-				},
-				{
-					Name:   "replicated-job-2",
-					Ready:  0,
-					Active: 1,
-				},
-			},
+				Obj()
+			jobs, err := constructJobsFromTemplate(context.TODO(), js, &js.Spec.ReplicatedJobs[0], &childJobs{}, "")
+			if err != nil {
+				t.Fatalf("constructJobsFromTemplate() error = %v", err)
+			}
+			if len(jobs) != 2 {
+				t.Fatalf("expected 2 jobs, got %d", len(jobs))
+			}
+			for _, job := range jobs {
+				if diff := cmp.Diff(tc.want, job.Spec.Template.Spec.RuntimeClassName); diff != "" {
+					t.Errorf("unexpected runtimeClassName of job %s (-want/+got): %s", job.Name, diff)
+				}
+			}
+			// The template of the JobSet must not be modified.
+			if diff := cmp.Diff(tc.templateRuntimeClassName, js.Spec.ReplicatedJobs[0].Template.Spec.Template.Spec.RuntimeClassName); diff != "" {
+				t.Errorf("unexpected change of the template runtimeClassName (-want/+got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestConstructJobsWithSchedulingGates(t *testing.T) {
+	admission := corev1.PodSchedulingGate{Name: "example.com/admission"}
+	quota := corev1.PodSchedulingGate{Name: "example.com/quota"}
+	topology := corev1.PodSchedulingGate{Name: "example.com/topology"}
+	tests := []struct {
+		name          string
+		templateGates []corev1.PodSchedulingGate
+		jobSetGates   []corev1.PodSchedulingGate
+		rjobGates     []corev1.PodSchedulingGate
+		want          []corev1.PodSchedulingGate
+	}{
+		{
+			name: "no scheduling gates",
 		},
 		{
-			name: "suspended jobs",
-			js: testutils.MakeJobSet(jobSetName, ns).Suspend(true).
-				ReplicatedJob(testutils.MakeReplicatedJob("replicated-job-1").
-					Job(testutils.MakeJobTemplate("test-job", ns).Obj()).
-					Replicas(1).
+			name:        "jobset scheduling gates are added",
+			jobSetGates: []corev1.PodSchedulingGate{admission},
+			want:        []corev1.PodSchedulingGate{admission},
+		},
+		{
+			name:      "replicated job scheduling gates are added",
+			rjobGates: []corev1.PodSchedulingGate{topology},
+			want:      []corev1.PodSchedulingGate{topology},
+		},
+		{
+			name:          "scheduling gates are added after the template gates",
+			templateGates: []corev1.PodSchedulingGate{quota},
+			jobSetGates:   []corev1.PodSchedulingGate{admission},
+			rjobGates:     []corev1.PodSchedulingGate{topology},
+			want:          []corev1.PodSchedulingGate{quota, admission, topology},
+		},
+		{
+			name:          "scheduling gates set in the template are not duplicated",
+			templateGates: []corev1.PodSchedulingGate{admission},
+			jobSetGates:   []corev1.PodSchedulingGate{admission},
+			rjobGates:     []corev1.PodSchedulingGate{admission, topology},
+			want:          []corev1.PodSchedulingGate{admission, topology},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			jobTemplate := testutils.MakeJobTemplate("test-job", "default").
+				PodSpec(corev1.PodSpec{SchedulingGates: tc.templateGates}).
+				Obj()
+			js := testutils.MakeJobSet("test-jobset", "default").
+				ReplicatedJob(testutils.MakeReplicatedJob("replicated-job").
+					Job(jobTemplate).
+					Replicas(2).
 					Obj()).
-				ReplicatedJob(testutils.MakeReplicatedJob("replicated-job-2").
-					Job(testutils.MakeJobTemplate("test-job", ns).Obj()).
-					Replicas(3).
-					Obj()).Obj(),
-			jobs: childJobs{
-				active: []*batchv1.Job{
-					makeJob(&makeJobArgs{
-						jobSetName:        jobSetName,
-						replicatedJobName: "replicated-job-1",
-						jobName:           "test-jobset-replicated-job-2-test-job-0"}).
-						Parallelism(5).
-						Suspend(true).
-						Obj(),
-					makeJob(&makeJobArgs{
-						jobSetName:        jobSetName,
-						replicatedJobName: "replicated-job-2",
-						jobName:           "test-jobset-replicated-job-2-test-job-0"}).
-						Parallelism(5).
-						Obj(),
-					makeJob(&makeJobArgs{
-						jobSetName:        jobSetName,
-						replicatedJobName: "replicated-job-2",
-						jobName:           "test-jobset-replicated-job-2-test-job-1"}).
-						Parallelism(1).
-						Suspend(true).
-						Obj(),
-				},
+				Obj()
+			js.Spec.SchedulingGates = tc.jobSetGates
+			js.Spec.ReplicatedJobs[0].SchedulingGates = tc.rjobGates
+			jobs, err := constructJobsFromTemplate(context.TODO(), js, &js.Spec.ReplicatedJobs[0], &childJobs{}, "")
+			if err != nil {
+				t.Fatalf("constructJobsFromTemplate() error = %v", err)
+			}
+			if len(jobs) != 2 {
+				t.Fatalf("expected 2 jobs, got %d", len(jobs))
+			}
+			for _, job := range jobs {
+				if diff := cmp.Diff(tc.want, job.Spec.Template.Spec.SchedulingGates); diff != "" {
+					t.Errorf("unexpected scheduling gates of job %s (-want/+got): %s", job.Name, diff)
+				}
+			}
+			// The template of the JobSet must not be modified.
+			if diff := cmp.Diff(tc.templateGates, js.Spec.ReplicatedJobs[0].Template.Spec.Template.Spec.SchedulingGates); diff != "" {
+				t.Errorf("unexpected change of the template scheduling gates (-want/+got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestConstructJobsWithPodReadinessGates(t *testing.T) {
+	mesh := corev1.PodReadinessGate{ConditionType: "example.com/mesh-ready"}
+	loadBalancer := corev1.PodReadinessGate{ConditionType: "example.com/lb-ready"}
+	tests := []struct {
+		name          string
+		templateGates []corev1.PodReadinessGate
+		jobSetGates   []corev1.PodReadinessGate
+		want          []corev1.PodReadinessGate
+	}{
+		{
+			name: "no readiness gates",
+		},
+		{
+			name:        "jobset readiness gates are added",
+			jobSetGates: []corev1.PodReadinessGate{mesh},
+			want:        []corev1.PodReadinessGate{mesh},
+		},
+		{
+			name:          "readiness gates are added after the template gates",
+			templateGates: []corev1.PodReadinessGate{loadBalancer},
+			jobSetGates:   []corev1.PodReadinessGate{mesh},
+			want:          []corev1.PodReadinessGate{loadBalancer, mesh},
+		},
+		{
+			name:          "readiness gates set in the template are not duplicated",
+			templateGates: []corev1.PodReadinessGate{mesh},
+			jobSetGates:   []corev1.PodReadinessGate{mesh, loadBalancer},
+			want:          []corev1.PodReadinessGate{mesh, loadBalancer},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			jobTemplate := testutils.MakeJobTemplate("test-job", "default").
+				PodSpec(corev1.PodSpec{ReadinessGates: tc.templateGates}).
+				Obj()
+			js := testutils.MakeJobSet("test-jobset", "default").
+				PodReadinessGates(tc.jobSetGates...).
+				ReplicatedJob(testutils.MakeReplicatedJob("coordinator").
+					Job(jobTemplate).
+					Replicas(1).
+					Obj()).
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").
+					Job(jobTemplate).
+					Replicas(2).
+					Obj()).
+				Obj()
+			for i := range js.Spec.ReplicatedJobs {
+				jobs, err := constructJobsFromTemplate(context.TODO(), js, &js.Spec.ReplicatedJobs[i], &childJobs{}, "")
+				if err != nil {
+					t.Fatalf("constructJobsFromTemplate() error = %v", err)
+				}
+				for _, job := range jobs {
+					if diff := cmp.Diff(tc.want, job.Spec.Template.Spec.ReadinessGates); diff != "" {
+						t.Errorf("unexpected readiness gates of job %s (-want/+got): %s", job.Name, diff)
+					}
+				}
+			}
+			// The template of the JobSet must not be modified.
+			if diff := cmp.Diff(tc.templateGates, js.Spec.ReplicatedJobs[0].Template.Spec.Template.Spec.ReadinessGates); diff != "" {
+				t.Errorf("unexpected change of the template readiness gates (-want/+got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestConstructJobsWithPodPriorityClassName(t *testing.T) {
+	tests := []struct {
+		name                      string
+		templatePriorityClassName string
+		templatePriority          *int32
+		coordinatorPriorityClass  string
+		workersPriorityClass      string
+		wantCoordinator           string
+		wantWorkers               string
+	}{
+		{
+			name: "no priority class names",
+		},
+		{
+			name:                      "template priority class name is kept",
+			templatePriorityClassName: "default-priority",
+			wantCoordinator:           "default-priority",
+			wantWorkers:               "default-priority",
+		},
+		{
+			name:                     "replicated jobs get different priority class names",
+			coordinatorPriorityClass: "high-priority",
+			workersPriorityClass:     "low-priority",
+			wantCoordinator:          "high-priority",
+			wantWorkers:              "low-priority",
+		},
+		{
+			name:                      "replicated job priority class name overrides the template",
+			templatePriorityClassName: "default-priority",
+			templatePriority:          ptr.To[int32](100),
+			coordinatorPriorityClass:  "high-priority",
+			wantCoordinator:           "high-priority",
+			wantWorkers:               "default-priority",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			jobTemplate := testutils.MakeJobTemplate("test-job", "default").
+				PodSpec(corev1.PodSpec{
+					PriorityClassName: tc.templatePriorityClassName,
+					Priority:          tc.templatePriority,
+					PreemptionPolicy:  ptr.To(corev1.PreemptLowerPriority),
+				}).
+				Obj()
+			js := testutils.MakeJobSet("test-jobset", "default").
+				ReplicatedJob(testutils.MakeReplicatedJob("coordinator").
+					Job(jobTemplate).
+					Replicas(1).
+					Obj()).
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").
+					Job(jobTemplate).
+					Replicas(2).
+					Obj()).
+				Obj()
+			js.Spec.ReplicatedJobs[0].PodPriorityClassName = tc.coordinatorPriorityClass
+			js.Spec.ReplicatedJobs[1].PodPriorityClassName = tc.workersPriorityClass
+			for i, want := range []string{tc.wantCoordinator, tc.wantWorkers} {
+				rjob := &js.Spec.ReplicatedJobs[i]
+				jobs, err := constructJobsFromTemplate(context.TODO(), js, rjob, &childJobs{}, "")
+				if err != nil {
+					t.Fatalf("constructJobsFromTemplate() error = %v", err)
+				}
+				for _, job := range jobs {
+					podSpec := job.Spec.Template.Spec
+					if podSpec.PriorityClassName != want {
+						t.Errorf("unexpected priority class name of job %s: got %q, want %q", job.Name, podSpec.PriorityClassName, want)
+					}
+					// The priority and preemption policy are only cleared with a replicated job priority class name.
+					wantPriority, wantPreemptionPolicy := tc.templatePriority, ptr.To(corev1.PreemptLowerPriority)
+					if rjob.PodPriorityClassName != "" {
+						wantPriority, wantPreemptionPolicy = nil, nil
+					}
+					if diff := cmp.Diff(wantPriority, podSpec.Priority); diff != "" {
+						t.Errorf("unexpected priority of job %s (-want/+got): %s", job.Name, diff)
+					}
+					if diff := cmp.Diff(wantPreemptionPolicy, podSpec.PreemptionPolicy); diff != "" {
+						t.Errorf("unexpected preemption policy of job %s (-want/+got): %s", job.Name, diff)
+					}
+				}
+			}
+			// The template of the JobSet must not be modified.
+			if diff := cmp.Diff(tc.templatePriority, js.Spec.ReplicatedJobs[0].Template.Spec.Template.Spec.Priority); diff != "" {
+				t.Errorf("unexpected change of the template priority (-want/+got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestConstructJobsWithSchedulerName(t *testing.T) {
+	tests := []struct {
+		name                  string
+		templateSchedulerName string
+		coordinatorScheduler  string
+		workersScheduler      string
+		wantCoordinator       string
+		wantWorkers           string
+	}{
+		{
+			name: "no scheduler names",
+		},
+		{
+			name:                  "template scheduler name is kept",
+			templateSchedulerName: "default-scheduler",
+			wantCoordinator:       "default-scheduler",
+			wantWorkers:           "default-scheduler",
+		},
+		{
+			name:                 "replicated jobs get different scheduler names",
+			coordinatorScheduler: "default-scheduler",
+			workersScheduler:     "volcano",
+			wantCoordinator:      "default-scheduler",
+			wantWorkers:          "volcano",
+		},
+		{
+			name:                  "replicated job scheduler name overrides the template",
+			templateSchedulerName: "default-scheduler",
+			workersScheduler:      "yunikorn",
+			wantCoordinator:       "default-scheduler",
+			wantWorkers:           "yunikorn",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			jobTemplate := testutils.MakeJobTemplate("test-job", "default").
+				PodSpec(corev1.PodSpec{SchedulerName: tc.templateSchedulerName}).
+				Obj()
+			js := testutils.MakeJobSet("test-jobset", "default").
+				ReplicatedJob(testutils.MakeReplicatedJob("coordinator").
+					Job(jobTemplate).
+					Replicas(1).
+					SchedulerName(tc.coordinatorScheduler).
+					Obj()).
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").
+					Job(jobTemplate).
+					Replicas(2).
+					SchedulerName(tc.workersScheduler).
+					Obj()).
+				Obj()
+			for i, want := range []string{tc.wantCoordinator, tc.wantWorkers} {
+				jobs, err := constructJobsFromTemplate(context.TODO(), js, &js.Spec.ReplicatedJobs[i], &childJobs{}, "")
+				if err != nil {
+					t.Fatalf("constructJobsFromTemplate() error = %v", err)
+				}
+				for _, job := range jobs {
+					if got := job.Spec.Template.Spec.SchedulerName; got != want {
+						t.Errorf("unexpected scheduler name of job %s: got %q, want %q", job.Name, got, want)
+					}
+				}
+			}
+			// The template of the JobSet must not be modified.
+			if got := js.Spec.ReplicatedJobs[1].Template.Spec.Template.Spec.SchedulerName; got != tc.templateSchedulerName {
+				t.Errorf("unexpected change of the template scheduler name: got %q, want %q", got, tc.templateSchedulerName)
+			}
+		})
+	}
+}
+
+func TestWaitingForJobCreation(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	makeJS := func() *testutils.JobSetWrapper {
+		return testutils.MakeJobSet(jobSetName, ns).
+			ReplicatedJob(testutils.MakeReplicatedJob("leader").
+				Job(testutils.MakeJobTemplate("job", ns).Obj()).
+				Replicas(1).
+				Obj()).
+			ReplicatedJob(testutils.MakeReplicatedJob("workers").
+				Job(testutils.MakeJobTemplate("job", ns).Obj()).
+				Replicas(2).
+				Obj())
+	}
+	tests := []struct {
+		name      string
+		js        *jobset.JobSet
+		ownedJobs *childJobs
+		want      bool
+	}{
+		{
+			name: "createJobsSuspended unset",
+			js:   makeJS().Obj(),
+			ownedJobs: &childJobs{
+				active: []*batchv1.Job{testutils.MakeJob("test-jobset-leader-0", ns).Obj()},
 			},
-			expected: []jobset.ReplicatedJobStatus{
-				{
-					Name:      "replicated-job-1",
-					Ready:     0,
-					Suspended: 1,
+			want: false,
+		},
+		{
+			name: "not all jobs created",
+			js:   makeJS().CreateJobsSuspended(true).Obj(),
+			ownedJobs: &childJobs{
+				active: []*batchv1.Job{
+					testutils.MakeJob("test-jobset-leader-0", ns).Obj(),
+					testutils.MakeJob("test-jobset-workers-0", ns).Obj(),
 				},
-				{
-					Name:      "replicated-job-2",
-					Ready:     0,
-					Suspended: 1,
+			},
+			want: true,
+		},
+		{
+			name: "all jobs created",
+			js:   makeJS().CreateJobsSuspended(true).Obj(),
+			ownedJobs: &childJobs{
+				active: []*batchv1.Job{
+					testutils.MakeJob("test-jobset-workers-0", ns).Obj(),
+					testutils.MakeJob("test-jobset-workers-1", ns).Obj(),
 				},
+				successful: []*batchv1.Job{testutils.MakeJob("test-jobset-leader-0", ns).Obj()},
+			},
+			want: false,
+		},
+		{
+			name: "in order startup policy",
+			js: makeJS().
+				CreateJobsSuspended(true).
+				StartupPolicy(&jobset.StartupPolicy{StartupPolicyOrder: jobset.InOrder}).
+				Obj(),
+			ownedJobs: &childJobs{
+				active: []*batchv1.Job{testutils.MakeJob("test-jobset-leader-0", ns).Obj()},
 			},
+			want: false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := waitingForJobCreation(tc.js, tc.ownedJobs); got != tc.want {
+				t.Errorf("waitingForJobCreation() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReconcileCreateJobsSuspended(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	js := testutils.MakeJobSet(jobSetName, ns).
+		CreateJobsSuspended(true).
+		ReplicatedJob(testutils.MakeReplicatedJob("workers").
+			Job(testutils.MakeJobTemplate("job", ns).Obj()).
+			Replicas(2).
+			Obj()).
+		Obj()
+	js.Spec.ReplicatedJobs[0].Template.Spec.Parallelism = ptr.To[int32](1)
+	fakeClient := newFakeClientBuilder().
+		WithObjects(js).
+		WithStatusSubresource(js).
+		Build()
+	r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{})
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: jobSetName, Namespace: ns}}
+
+	checkSuspended := func(want bool) {
+		t.Helper()
+		var jobs batchv1.JobList
+		if err := fakeClient.List(context.TODO(), &jobs, client.InNamespace(ns)); err != nil {
+			t.Fatalf("unexpected error listing jobs: %v", err)
+		}
+		if len(jobs.Items) != 2 {
+			t.Fatalf("expected 2 jobs, got %d", len(jobs.Items))
+		}
+		for _, job := range jobs.Items {
+			if got := ptr.Deref(job.Spec.Suspend, false); got != want {
+				t.Errorf("unexpected suspend of job %s: got %v, want %v", job.Name, got, want)
+			}
+		}
+	}
+
+	// The first reconcile creates all jobs suspended.
+	reconcileJobSet(t, r, req, 1)
+	checkSuspended(true)
+
+	// Once all jobs exist, they are resumed.
+	reconcileJobSet(t, r, req, 1)
+	checkSuspended(false)
+}
+
+func TestReconcileJobSetUID(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	tests := []struct {
+		name string
+		uid  types.UID
+	}{
+		{
+			name: "jobs and pods carry the jobset uid",
+			uid:  "test-uid",
+		},
+		{
+			name: "jobs and pods of a recreated jobset carry the new jobset uid",
+			uid:  "recreated-uid",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet(jobSetName, ns).
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").
+					Job(testutils.MakeJobTemplate("job", ns).Obj()).
+					Replicas(2).
+					Obj()).
+				Obj()
+			js.Spec.ReplicatedJobs[0].Template.Spec.Parallelism = ptr.To[int32](1)
+			js.UID = tc.uid
+			fakeClient := newFakeClientBuilder().
+				WithObjects(js).
+				WithStatusSubresource(js).
+				Build()
+			r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{})
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: jobSetName, Namespace: ns}}
+			reconcileJobSet(t, r, req, 1)
+
+			var jobs batchv1.JobList
+			if err := fakeClient.List(context.TODO(), &jobs, client.InNamespace(ns)); err != nil {
+				t.Fatalf("unexpected error listing jobs: %v", err)
+			}
+			if len(jobs.Items) != 2 {
+				t.Fatalf("expected 2 jobs, got %d", len(jobs.Items))
+			}
+			for _, job := range jobs.Items {
+				owner := metav1.GetControllerOf(&job)
+				if owner == nil {
+					t.Fatalf("job %s has no controller owner", job.Name)
+				}
+				if owner.UID != tc.uid {
+					t.Errorf("unexpected owner uid of job %s: got %q, want %q", job.Name, owner.UID, tc.uid)
+				}
+				for _, m := range []map[string]string{job.Labels, job.Annotations, job.Spec.Template.Labels, job.Spec.Template.Annotations} {
+					if got := m[jobset.JobSetUIDKey]; got != string(owner.UID) {
+						t.Errorf("unexpected %s of job %s: got %q, want %q", jobset.JobSetUIDKey, job.Name, got, owner.UID)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestConstructJobWithSidecarContainers(t *testing.T) {
+	always := corev1.ContainerRestartPolicyAlways
+	main := corev1.Container{Name: "main", Image: "main"}
+	proxy := corev1.Container{Name: "proxy", Image: "proxy"}
+	logs := corev1.Container{Name: "logs", Image: "logs", RestartPolicy: &always}
+	tests := []struct {
+		name               string
+		sidecars           []corev1.Container
+		wantContainers     []corev1.Container
+		wantInitContainers []corev1.Container
+	}{
+		{
+			name:           "no sidecar containers",
+			wantContainers: []corev1.Container{main},
+		},
+		{
+			name:           "sidecar containers are appended to the containers",
+			sidecars:       []corev1.Container{proxy},
+			wantContainers: []corev1.Container{main, proxy},
+		},
+		{
+			name:               "native sidecar containers are appended to the init containers",
+			sidecars:           []corev1.Container{proxy, logs},
+			wantContainers:     []corev1.Container{main, proxy},
+			wantInitContainers: []corev1.Container{logs},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet("test-jobset", "default").
+				ReplicatedJob(testutils.MakeReplicatedJob("leader").
+					Job(testutils.MakeJobTemplate("leader", "default").
+						PodSpec(corev1.PodSpec{Containers: []corev1.Container{main}}).
+						Obj()).
+					Replicas(1).
+					Obj()).
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").
+					Job(testutils.MakeJobTemplate("workers", "default").
+						PodSpec(corev1.PodSpec{Containers: []corev1.Container{main}}).
+						Obj()).
+					Replicas(2).
+					Obj()).
+				Obj()
+			js.Spec.SidecarContainers = tc.sidecars
+			for _, rjob := range js.Spec.ReplicatedJobs {
+				for jobIdx := 0; jobIdx < int(rjob.Replicas); jobIdx++ {
+					job, err := constructJob(js, &rjob, 0, jobIdx, "")
+					if err != nil {
+						t.Fatalf("constructJob() error = %v", err)
+					}
+					if diff := cmp.Diff(tc.wantContainers, job.Spec.Template.Spec.Containers); diff != "" {
+						t.Errorf("unexpected containers of job %s (-want/+got): %s", job.Name, diff)
+					}
+					if diff := cmp.Diff(tc.wantInitContainers, job.Spec.Template.Spec.InitContainers); diff != "" {
+						t.Errorf("unexpected init containers of job %s (-want/+got): %s", job.Name, diff)
+					}
+				}
+				// The template of the JobSet must not be modified.
+				if diff := cmp.Diff([]corev1.Container{main}, rjob.Template.Spec.Template.Spec.Containers); diff != "" {
+					t.Errorf("unexpected change of the template containers (-want/+got): %s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestConstructJobWithEnvFrom(t *testing.T) {
+	shared := corev1.EnvFromSource{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "shared"}}}
+	credentials := corev1.EnvFromSource{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "credentials"}}}
+	own := corev1.EnvFromSource{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "own"}}}
+	tests := []struct {
+		name             string
+		jobSetEnvFrom    []corev1.EnvFromSource
+		containerEnvFrom []corev1.EnvFromSource
+		wantEnvFrom      []corev1.EnvFromSource
+		wantOtherEnvFrom []corev1.EnvFromSource
+		wantInitEnvFrom  []corev1.EnvFromSource
+	}{
+		{
+			name:             "no jobset envFrom",
+			containerEnvFrom: []corev1.EnvFromSource{own},
+			wantEnvFrom:      []corev1.EnvFromSource{own},
+		},
+		{
+			name:             "jobset envFrom is added to all containers",
+			jobSetEnvFrom:    []corev1.EnvFromSource{shared, credentials},
+			wantEnvFrom:      []corev1.EnvFromSource{shared, credentials},
+			wantOtherEnvFrom: []corev1.EnvFromSource{shared, credentials},
+			wantInitEnvFrom:  []corev1.EnvFromSource{shared, credentials},
+		},
+		{
+			name:             "container envFrom is added last to take precedence",
+			jobSetEnvFrom:    []corev1.EnvFromSource{shared},
+			containerEnvFrom: []corev1.EnvFromSource{own},
+			wantEnvFrom:      []corev1.EnvFromSource{shared, own},
+			wantOtherEnvFrom: []corev1.EnvFromSource{shared},
+			wantInitEnvFrom:  []corev1.EnvFromSource{shared},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet("test-jobset", "default").
+				ReplicatedJob(testutils.MakeReplicatedJob("leader").
+					Job(testutils.MakeJobTemplate("leader", "default").
+						PodSpec(corev1.PodSpec{
+							InitContainers: []corev1.Container{{Name: "init"}},
+							Containers:     []corev1.Container{{Name: "main", EnvFrom: tc.containerEnvFrom}, {Name: "other"}},
+						}).
+						Obj()).
+					Replicas(1).
+					Obj()).
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").
+					Job(testutils.MakeJobTemplate("workers", "default").
+						PodSpec(corev1.PodSpec{
+							InitContainers: []corev1.Container{{Name: "init"}},
+							Containers:     []corev1.Container{{Name: "main", EnvFrom: tc.containerEnvFrom}, {Name: "other"}},
+						}).
+						Obj()).
+					Replicas(2).
+					Obj()).
+				Obj()
+			js.Spec.EnvFrom = tc.jobSetEnvFrom
+			for _, rjob := range js.Spec.ReplicatedJobs {
+				for jobIdx := 0; jobIdx < int(rjob.Replicas); jobIdx++ {
+					job, err := constructJob(js, &rjob, 0, jobIdx, "")
+					if err != nil {
+						t.Fatalf("constructJob() error = %v", err)
+					}
+					podSpec := job.Spec.Template.Spec
+					if diff := cmp.Diff(tc.wantEnvFrom, podSpec.Containers[0].EnvFrom); diff != "" {
+						t.Errorf("unexpected envFrom of container %s of job %s (-want/+got): %s", podSpec.Containers[0].Name, job.Name, diff)
+					}
+					if diff := cmp.Diff(tc.wantOtherEnvFrom, podSpec.Containers[1].EnvFrom); diff != "" {
+						t.Errorf("unexpected envFrom of container %s of job %s (-want/+got): %s", podSpec.Containers[1].Name, job.Name, diff)
+					}
+					if diff := cmp.Diff(tc.wantInitEnvFrom, podSpec.InitContainers[0].EnvFrom); diff != "" {
+						t.Errorf("unexpected envFrom of init container of job %s (-want/+got): %s", job.Name, diff)
+					}
+				}
+				// The template of the JobSet must not be modified.
+				if diff := cmp.Diff(tc.containerEnvFrom, rjob.Template.Spec.Template.Spec.Containers[0].EnvFrom); diff != "" {
+					t.Errorf("unexpected change of the template envFrom (-want/+got): %s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestConstructJobWithVolumes(t *testing.T) {
+	shm := corev1.Volume{Name: "shm", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}}}
+	rdma := corev1.Volume{Name: "rdma", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/dev/infiniband"}}}
+	own := corev1.Volume{Name: "own"}
+	shmMount := corev1.VolumeMount{Name: "shm", MountPath: "/dev/shm"}
+	rdmaMount := corev1.VolumeMount{Name: "rdma", MountPath: "/dev/infiniband"}
+	ownMount := corev1.VolumeMount{Name: "own", MountPath: "/own"}
+	tests := []struct {
+		name                 string
+		jobSetVolumes        []corev1.Volume
+		jobSetVolumeMounts   []corev1.VolumeMount
+		wantVolumes          []corev1.Volume
+		wantVolumeMounts     []corev1.VolumeMount
+		wantOtherMounts      []corev1.VolumeMount
+		wantInitVolumeMounts []corev1.VolumeMount
+	}{
+		{
+			name:             "no jobset volumes",
+			wantVolumes:      []corev1.Volume{own},
+			wantVolumeMounts: []corev1.VolumeMount{ownMount},
+		},
+		{
+			name:                 "jobset volumes and mounts are added after the template ones",
+			jobSetVolumes:        []corev1.Volume{shm, rdma},
+			jobSetVolumeMounts:   []corev1.VolumeMount{shmMount, rdmaMount},
+			wantVolumes:          []corev1.Volume{own, shm, rdma},
+			wantVolumeMounts:     []corev1.VolumeMount{ownMount, shmMount, rdmaMount},
+			wantOtherMounts:      []corev1.VolumeMount{shmMount, rdmaMount},
+			wantInitVolumeMounts: []corev1.VolumeMount{shmMount, rdmaMount},
+		},
+		{
+			name:             "jobset volumes without mounts",
+			jobSetVolumes:    []corev1.Volume{shm},
+			wantVolumes:      []corev1.Volume{own, shm},
+			wantVolumeMounts: []corev1.VolumeMount{ownMount},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			podSpec := corev1.PodSpec{
+				InitContainers: []corev1.Container{{Name: "init"}},
+				Containers:     []corev1.Container{{Name: "main", VolumeMounts: []corev1.VolumeMount{ownMount}}, {Name: "other"}},
+				Volumes:        []corev1.Volume{own},
+			}
+			js := testutils.MakeJobSet("test-jobset", "default").
+				ReplicatedJob(testutils.MakeReplicatedJob("leader").
+					Job(testutils.MakeJobTemplate("leader", "default").PodSpec(*podSpec.DeepCopy()).Obj()).
+					Replicas(1).
+					Obj()).
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").
+					Job(testutils.MakeJobTemplate("workers", "default").PodSpec(*podSpec.DeepCopy()).Obj()).
+					Replicas(2).
+					Obj()).
+				Obj()
+			js.Spec.Volumes = tc.jobSetVolumes
+			js.Spec.VolumeMounts = tc.jobSetVolumeMounts
+			for _, rjob := range js.Spec.ReplicatedJobs {
+				for jobIdx := 0; jobIdx < int(rjob.Replicas); jobIdx++ {
+					job, err := constructJob(js, &rjob, 0, jobIdx, "")
+					if err != nil {
+						t.Fatalf("constructJob() error = %v", err)
+					}
+					podSpec := job.Spec.Template.Spec
+					if diff := cmp.Diff(tc.wantVolumes, podSpec.Volumes); diff != "" {
+						t.Errorf("unexpected volumes of job %s (-want/+got): %s", job.Name, diff)
+					}
+					if diff := cmp.Diff(tc.wantVolumeMounts, podSpec.Containers[0].VolumeMounts); diff != "" {
+						t.Errorf("unexpected volume mounts of container %s of job %s (-want/+got): %s", podSpec.Containers[0].Name, job.Name, diff)
+					}
+					if diff := cmp.Diff(tc.wantOtherMounts, podSpec.Containers[1].VolumeMounts); diff != "" {
+						t.Errorf("unexpected volume mounts of container %s of job %s (-want/+got): %s", podSpec.Containers[1].Name, job.Name, diff)
+					}
+					if diff := cmp.Diff(tc.wantInitVolumeMounts, podSpec.InitContainers[0].VolumeMounts); diff != "" {
+						t.Errorf("unexpected volume mounts of init container of job %s (-want/+got): %s", job.Name, diff)
+					}
+				}
+				// The template of the JobSet must not be modified.
+				if diff := cmp.Diff(podSpec, rjob.Template.Spec.Template.Spec); diff != "" {
+					t.Errorf("unexpected change of the template pod spec (-want/+got): %s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateConditions(t *testing.T) {
+	var (
+		jobSetName        = "test-jobset"
+		replicatedJobName = "replicated-job"
+		jobName           = "test-job"
+		ns                = "default"
+	)
+
+	tests := []struct {
+		name           string
+		js             *jobset.JobSet
+		opts           *conditionOpts
+		expectedUpdate bool
+	}{
+		{
+			name: "no existing conditions, not adding conditions",
+			js: testutils.MakeJobSet(jobSetName, ns).
+				ReplicatedJob(testutils.MakeReplicatedJob(replicatedJobName).
+					Job(testutils.MakeJobTemplate(jobName, ns).Obj()).
+					Replicas(1).
+					Obj()).Obj(),
+			opts:           &conditionOpts{},
+			expectedUpdate: false,
+		},
+		{
+			name: "no existing conditions, add a condition",
+			js: testutils.MakeJobSet(jobSetName, ns).
+				ReplicatedJob(testutils.MakeReplicatedJob(replicatedJobName).
+					Job(testutils.MakeJobTemplate(jobName, ns).Obj()).
+					Replicas(1).
+					Obj()).Obj(),
+			opts:           makeCompletedConditionsOpts(),
+			expectedUpdate: true,
+		},
+		{
+			name: "suspended",
+			js: testutils.MakeJobSet(jobSetName, ns).
+				ReplicatedJob(testutils.MakeReplicatedJob(replicatedJobName).
+					Job(testutils.MakeJobTemplate(jobName, ns).Obj()).
+					Replicas(1).
+					Obj()).Obj(),
+			opts:           makeSuspendedConditionOpts(),
+			expectedUpdate: true,
+		},
+		{
+			name: "resume (update suspended condition type in-place)",
+			js: testutils.MakeJobSet(jobSetName, ns).
+				ReplicatedJob(testutils.MakeReplicatedJob(replicatedJobName).
+					Job(testutils.MakeJobTemplate(jobName, ns).Obj()).
+					Replicas(1).
+					Obj()).
+				Conditions([]metav1.Condition{
+					// JobSet is currrently suspended.
+					{
+						Type:    string(jobset.JobSetSuspended),
+						Reason:  constants.JobSetSuspendedReason,
+						Message: constants.JobSetSuspendedMessage,
+						Status:  metav1.ConditionStatus(corev1.ConditionTrue),
+					},
+				}).
+				Obj(),
+			opts:           makeResumedConditionOpts(),
+			expectedUpdate: true,
+		},
+		{
+			name: "existing conditions, attempt to add duplicate",
+			js: testutils.MakeJobSet(jobSetName, ns).
+				ReplicatedJob(testutils.MakeReplicatedJob(replicatedJobName).
+					Job(testutils.MakeJobTemplate(jobName, ns).Obj()).
+					Replicas(1).
+					Obj()).
+				Conditions([]metav1.Condition{
+					// JobSet is completed..
+					{
+						Type:    string(jobset.JobSetCompleted),
+						Reason:  constants.AllJobsCompletedReason,
+						Message: constants.AllJobsCompletedMessage,
+						Status:  metav1.ConditionStatus(corev1.ConditionTrue),
+					},
+				}).Obj(),
+			opts:           makeCompletedConditionsOpts(),
+			expectedUpdate: false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gotUpdate := updateCondition(tc.js, tc.opts)
+			if gotUpdate != tc.expectedUpdate {
+				t.Errorf("updateCondition return mismatch (want: %v, got %v)", tc.expectedUpdate, gotUpdate)
+			}
+		})
+	}
+}
+
+func TestSetJobSetReadyCondition(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	makeJobSet := func() *testutils.JobSetWrapper {
+		return testutils.MakeJobSet(jobSetName, ns).
+			ReplicatedJob(testutils.MakeReplicatedJob("leader").Replicas(1).Obj()).
+			ReplicatedJob(testutils.MakeReplicatedJob("workers").Replicas(3).Obj())
+	}
+	allReady := []jobset.ReplicatedJobStatus{
+		{Name: "leader", Ready: 1, Active: 1},
+		{Name: "workers", Ready: 3, Active: 3},
+	}
+	tests := []struct {
+		name     string
+		js       *jobset.JobSet
+		statuses []jobset.ReplicatedJobStatus
+		// wantStatus is empty if the condition is not expected to be present.
+		wantStatus metav1.ConditionStatus
+		wantUpdate bool
+	}{
+		{
+			name: "no jobs ready, condition not added",
+			js:   makeJobSet().Obj(),
+		},
+		{
+			name: "some replicas of a replicated job not ready",
+			js:   makeJobSet().Obj(),
+			statuses: []jobset.ReplicatedJobStatus{
+				{Name: "leader", Ready: 1, Active: 1},
+				{Name: "workers", Ready: 2, Active: 3},
+			},
+		},
+		{
+			name: "status of a replicated job missing",
+			js:   makeJobSet().Obj(),
+			statuses: []jobset.ReplicatedJobStatus{
+				{Name: "workers", Ready: 3, Active: 3},
+			},
+		},
+		{
+			name:       "all replicas ready",
+			js:         makeJobSet().Obj(),
+			statuses:   allReady,
+			wantStatus: metav1.ConditionTrue,
+			wantUpdate: true,
+		},
+		{
+			name: "all replicas ready, already ready",
+			js: makeJobSet().Conditions([]metav1.Condition{
+				{
+					Type:   string(jobset.JobSetReady),
+					Status: metav1.ConditionTrue,
+					Reason: constants.AllJobsReadyReason,
+				},
+			}).Obj(),
+			statuses:   allReady,
+			wantStatus: metav1.ConditionTrue,
+		},
+		{
+			name: "replica no longer ready",
+			js: makeJobSet().Conditions([]metav1.Condition{
+				{
+					Type:   string(jobset.JobSetReady),
+					Status: metav1.ConditionTrue,
+					Reason: constants.AllJobsReadyReason,
+				},
+			}).Obj(),
+			statuses: []jobset.ReplicatedJobStatus{
+				{Name: "leader", Ready: 0, Active: 1},
+				{Name: "workers", Ready: 3, Active: 3},
+			},
+			wantStatus: metav1.ConditionFalse,
+			wantUpdate: true,
+		},
+		{
+			name:     "suspended jobset is not ready",
+			js:       makeJobSet().Suspend(true).Obj(),
+			statuses: allReady,
+		},
+		{
+			name: "ready jobset is no longer ready when suspended",
+			js: makeJobSet().Suspend(true).Conditions([]metav1.Condition{
+				{
+					Type:   string(jobset.JobSetReady),
+					Status: metav1.ConditionTrue,
+					Reason: constants.AllJobsReadyReason,
+				},
+			}).Obj(),
+			statuses:   allReady,
+			wantStatus: metav1.ConditionFalse,
+			wantUpdate: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			updateStatusOpts := &statusUpdateOpts{}
+			setJobSetReadyCondition(tc.js, tc.statuses, updateStatusOpts)
+			var gotStatus metav1.ConditionStatus
+			for _, c := range tc.js.Status.Conditions {
+				if c.Type == string(jobset.JobSetReady) {
+					gotStatus = c.Status
+				}
+			}
+			if gotStatus != tc.wantStatus {
+				t.Errorf("unexpected %s condition status, want %q, got %q", jobset.JobSetReady, tc.wantStatus, gotStatus)
+			}
+			if updateStatusOpts.shouldUpdate != tc.wantUpdate {
+				t.Errorf("unexpected status update, want %v, got %v", tc.wantUpdate, updateStatusOpts.shouldUpdate)
+			}
+		})
+	}
+}
+
+func TestCalculateReplicatedJobStatuses(t *testing.T) {
+	var (
+		jobSetName     = "test-jobset"
+		ns             = "default"
+		startTime      = metav1.NewTime(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC))
+		completionTime = metav1.NewTime(time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC))
+	)
+	tests := []struct {
+		name     string
+		js       *jobset.JobSet
+		jobs     childJobs
+		expected []jobset.ReplicatedJobStatus
+	}{
+		{
+			name: "partial jobs are ready, no succeeded jobs",
+			js: testutils.MakeJobSet(jobSetName, ns).
+				ReplicatedJob(testutils.MakeReplicatedJob("replicated-job-1").
+					Job(testutils.MakeJobTemplate("test-job", ns).Obj()).
+					Replicas(1).
+					Obj()).
+				ReplicatedJob(testutils.MakeReplicatedJob("replicated-job-2").
+					Job(testutils.MakeJobTemplate("test-job", ns).Obj()).
+					Replicas(3).
+					Obj()).Obj(),
+			jobs: childJobs{
+				active: []*batchv1.Job{
+					makeJob(&makeJobArgs{
+						jobSetName:        jobSetName,
+						replicatedJobName: "replicated-job-1",
+						jobName:           "test-jobset-replicated-job-1-test-job-0",
+						ns:                ns,
+						replicas:          1,
+						jobIdx:            0}).
+						Parallelism(1).
+						Completions(2).
+						Ready(1).
+						Succeeded(1).Obj(),
+					makeJob(&makeJobArgs{
+						jobSetName:        jobSetName,
+						replicatedJobName: "replicated-job-2",
+						jobName:           "test-jobset-replicated-job-2-test-job-0",
+						ns:                ns,
+						replicas:          3,
+						jobIdx:            0}).
+						Parallelism(5).
+						Ready(2).
+						Succeeded(3).Obj(),
+					makeJob(&makeJobArgs{
+						jobSetName:        jobSetName,
+						replicatedJobName: "replicated-job-2",
+						jobName:           "test-jobset-replicated-job-2-test-job-1",
+						ns:                ns,
+						replicas:          3,
+						jobIdx:            0}).
+						Parallelism(3).
+						Completions(2).
+						Ready(1).
+						Succeeded(1).Obj(),
+					makeJob(&makeJobArgs{
+						jobSetName:        jobSetName,
+						replicatedJobName: "replicated-job-2",
+						jobName:           "test-jobset-replicated-job-2-test-job-2",
+						ns:                ns,
+						replicas:          3,
+						jobIdx:            0}).
+						Parallelism(2).
+						Completions(3).
+						Ready(2).
+						Succeeded(1).Obj(),
+					makeJob(&makeJobArgs{
+						jobSetName:        jobSetName,
+						replicatedJobName: "replicated-job-2",
+						jobName:           "test-jobset-replicated-job-2-test-job-3",
+						ns:                ns,
+						replicas:          3,
+						jobIdx:            0}).
+						Parallelism(4).
+						Completions(5).
+						Ready(2).
+						Succeeded(1).Obj(),
+				},
+			},
+			expected: []jobset.ReplicatedJobStatus{
+				{
+					Name:      "replicated-job-1",
+					Ready:     1,
+					Succeeded: 0,
+				},
+				{
+					Name:      "replicated-job-2",
+					Ready:     3,
+					Succeeded: 0,
+				},
+			},
+		},
+		{
+			name: "no jobs created",
+			js: testutils.MakeJobSet(jobSetName, ns).
+				ReplicatedJob(testutils.MakeReplicatedJob("replicated-job-1").
+					Job(testutils.MakeJobTemplate("test-job", ns).Obj()).
+					Replicas(1).
+					Obj()).
+				ReplicatedJob(testutils.MakeReplicatedJob("replicated-job-2").
+					Job(testutils.MakeJobTemplate("test-job", ns).Obj()).
+					Replicas(3).
+					Obj()).Obj(),
+			expected: []jobset.ReplicatedJobStatus{
+				{
+					Name:      "replicated-job-1",
+					Ready:     0,
+					Succeeded: 0,
+				},
+				{
+					Name:      "replicated-job-2",
+					Ready:     0,
+					Succeeded: 0,
+				},
+			},
+		},
+		{
+			name: "partial jobs created",
+			js: testutils.MakeJobSet(jobSetName, ns).
+				ReplicatedJob(testutils.MakeReplicatedJob("replicated-job-1").
+					Job(testutils.MakeJobTemplate("test-job", ns).Obj()).
+					Replicas(1).
+					Obj()).
+				ReplicatedJob(testutils.MakeReplicatedJob("replicated-job-2").
+					Job(testutils.MakeJobTemplate("test-job", ns).Obj()).
+					Replicas(3).
+					Obj()).Obj(),
+			jobs: childJobs{
+				active: []*batchv1.Job{
+					makeJob(&makeJobArgs{
+						jobSetName:        jobSetName,
+						replicatedJobName: "replicated-job-2",
+						jobName:           "test-jobset-replicated-job-2-test-job-0",
+						ns:                ns,
+						replicas:          3,
+						jobIdx:            0}).
+						Parallelism(5).
+						Ready(2).
+						Succeeded(3).Obj(),
+				},
+			},
+			expected: []jobset.ReplicatedJobStatus{
+				{
+					Name:      "replicated-job-1",
+					Ready:     0,
+					Succeeded: 0,
+				},
+				{
+					Name:      "replicated-job-2",
+					Ready:     1,
+					Succeeded: 0,
+				},
+			},
+		},
+		{
+			name: "no ready jobs, only succeeded jobs",
+			js: testutils.MakeJobSet(jobSetName, ns).
+				ReplicatedJob(testutils.MakeReplicatedJob("replicated-job-1").
+					Job(testutils.MakeJobTemplate("test-job", ns).Obj()).
+					Replicas(1).
+					Obj()).
+				ReplicatedJob(testutils.MakeReplicatedJob("replicated-job-2").
+					Job(testutils.MakeJobTemplate("test-job", ns).Obj()).
+					Replicas(3).
+					Obj()).Obj(),
+			jobs: childJobs{
+				successful: []*batchv1.Job{
+					makeJob(&makeJobArgs{
+						jobSetName:        jobSetName,
+						replicatedJobName: "replicated-job-2",
+						jobName:           "test-jobset-replicated-job-2-test-job-0"}).Obj(),
+					makeJob(&makeJobArgs{
+						jobSetName:        jobSetName,
+						replicatedJobName: "replicated-job-1",
+						jobName:           "test-jobset-replicated-job-1-test-job-0"}).Obj(),
+				},
+			},
+			expected: []jobset.ReplicatedJobStatus{
+				{
+					Name:      "replicated-job-1",
+					Ready:     0,
+					Succeeded: 1,
+				},
+				{
+					Name:      "replicated-job-2",
+					Ready:     0,
+					Succeeded: 1,
+				},
+			},
+		},
+		{
+			name: "no ready jobs, only failed jobs",
+			js: testutils.MakeJobSet(jobSetName, ns).
+				ReplicatedJob(testutils.MakeReplicatedJob("replicated-job-1").
+					Job(testutils.MakeJobTemplate("test-job", ns).Obj()).
+					Replicas(1).
+					Obj()).
+				ReplicatedJob(testutils.MakeReplicatedJob("replicated-job-2").
+					Job(testutils.MakeJobTemplate("test-job", ns).Obj()).
+					Replicas(3).
+					Obj()).Obj(),
+			jobs: childJobs{
+				failed: []*batchv1.Job{
+					makeJob(&makeJobArgs{
+						jobSetName:        jobSetName,
+						replicatedJobName: "replicated-job-2",
+						jobName:           "test-jobset-replicated-job-2-test-job-0"}).Obj(),
+					makeJob(&makeJobArgs{
+						jobSetName:        jobSetName,
+						replicatedJobName: "replicated-job-1",
+						jobName:           "test-jobset-replicated-job-1-test-job-0"}).Obj(),
+					makeJob(&makeJobArgs{
+						jobSetName:        jobSetName,
+						replicatedJobName: "replicated-job-1",
+						jobName:           "test-jobset-replicated-job-1-test-job-1"}).Obj(),
+					makeJob(&makeJobArgs{
+						jobSetName:        jobSetName,
+						replicatedJobName: "replicated-job-1",
+						jobName:           "test-jobset-replicated-job-1-test-job-2"}).Obj(),
+				},
+			},
+			expected: []jobset.ReplicatedJobStatus{
+				{
+					Name:   "replicated-job-1",
+					Ready:  0,
+					Failed: 3,
+				},
+				{
+					Name:   "replicated-job-2",
+					Ready:  0,
+					Failed: 1,
+				},
+			},
+		},
+		{
+			name: "active jobs",
+			js: testutils.MakeJobSet(jobSetName, ns).
+				ReplicatedJob(testutils.MakeReplicatedJob("replicated-job-1").
+					Job(testutils.MakeJobTemplate("test-job", ns).Obj()).
+					Replicas(1).
+					Obj()).
+				ReplicatedJob(testutils.MakeReplicatedJob("replicated-job-2").
+					Job(testutils.MakeJobTemplate("test-job", ns).Obj()).
+					Replicas(3).
+					Obj()).Obj(),
+			jobs: childJobs{
+				active: []*batchv1.Job{
+					makeJob(&makeJobArgs{
+						jobSetName:        jobSetName,
+						replicatedJobName: "replicated-job-1",
+						jobName:           "test-jobset-replicated-job-2-test-job-0"}).
+						Parallelism(5).
+						Active(1).
+						Obj(),
+					makeJob(&makeJobArgs{
+						jobSetName:        jobSetName,
+						replicatedJobName: "replicated-job-2",
+						jobName:           "test-jobset-replicated-job-2-test-job-0"}).
+						Parallelism(5).
+						Obj(),
+					makeJob(&makeJobArgs{
+						jobSetName:        jobSetName,
+						replicatedJobName: "replicated-job-2",
+						jobName:           "test-jobset-replicated-job-2-test-job-1"}).
+						Parallelism(1).
+						Active(1).
+						Obj(),
+				},
+			},
+			expected: []jobset.ReplicatedJobStatus{
+				{
+					Name:   "replicated-job-1",
+					Ready:  0,
+					Active: 1,
+				},
+				{
+					Name:   "replicated-job-2",
+					Ready:  0,
+					Active: 1,
+				},
+			},
+		},
+		{
+			name: "job statuses reported",
+			js: testutils.MakeJobSet(jobSetName, ns).ReportJobStatuses(true).
+				ReplicatedJob(testutils.MakeReplicatedJob("replicated-job-1").
+					Job(testutils.MakeJobTemplate("test-job", ns).Obj()).
+					Replicas(3).
+					Obj()).Obj(),
+			jobs: childJobs{
+				active: []*batchv1.Job{
+					makeJob(&makeJobArgs{
+						jobSetName:        jobSetName,
+						replicatedJobName: "replicated-job-1",
+						jobName:           "test-jobset-replicated-job-1-test-job-1"}).
+						Parallelism(1).
+						Active(1).
+						StartTime(&startTime).
+						Obj(),
+				},
+				successful: []*batchv1.Job{
+					makeJob(&makeJobArgs{
+						jobSetName:        jobSetName,
+						replicatedJobName: "replicated-job-1",
+						jobName:           "test-jobset-replicated-job-1-test-job-0"}).
+						Parallelism(1).
+						StartTime(&startTime).
+						CompletionTime(&completionTime).
+						Obj(),
+				},
+				failed: []*batchv1.Job{
+					makeJob(&makeJobArgs{
+						jobSetName:        jobSetName,
+						replicatedJobName: "replicated-job-1",
+						jobName:           "test-jobset-replicated-job-1-test-job-2"}).
+						Parallelism(1).
+						StartTime(&startTime).
+						Obj(),
+				},
+			},
+			expected: []jobset.ReplicatedJobStatus{
+				{
+					Name:      "replicated-job-1",
+					Active:    1,
+					Succeeded: 1,
+					Failed:    1,
+					JobStatuses: []jobset.JobStatus{
+						{
+							Name:           "test-jobset-replicated-job-1-test-job-0",
+							StartTime:      &startTime,
+							CompletionTime: &completionTime,
+						},
+						{
+							Name:      "test-jobset-replicated-job-1-test-job-1",
+							StartTime: &startTime,
+						},
+						{
+							Name:      "test-jobset-replicated-job-1-test-job-2",
+							StartTime: &startTime,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "job statuses not reported by default",
+			js: testutils.MakeJobSet(jobSetName, ns).
+				ReplicatedJob(testutils.MakeReplicatedJob("replicated-job-1").
+					Job(testutils.MakeJobTemplate("test-job", ns).Obj()).
+					Replicas(1).
+					Obj()).Obj(),
+			jobs: childJobs{
+				successful: []*batchv1.Job{
+					makeJob(&makeJobArgs{
+						jobSetName:        jobSetName,
+						replicatedJobName: "replicated-job-1",
+						jobName:           "test-jobset-replicated-job-1-test-job-0"}).
+						Parallelism(1).
+						StartTime(&startTime).
+						CompletionTime(&completionTime).
+						Obj(),
+				},
+			},
+			expected: []jobset.ReplicatedJobStatus{
+				{
+					Name:      "replicated-job-1",
+					Succeeded: 1,
+				},
+			},
+		},
+		{
+			name: "suspended jobs",
+			js: testutils.MakeJobSet(jobSetName, ns).Suspend(true).
+				ReplicatedJob(testutils.MakeReplicatedJob("replicated-job-1").
+					Job(testutils.MakeJobTemplate("test-job", ns).Obj()).
+					Replicas(1).
+					Obj()).
+				ReplicatedJob(testutils.MakeReplicatedJob("replicated-job-2").
+					Job(testutils.MakeJobTemplate("test-job", ns).Obj()).
+					Replicas(3).
+					Obj()).Obj(),
+			jobs: childJobs{
+				active: []*batchv1.Job{
+					makeJob(&makeJobArgs{
+						jobSetName:        jobSetName,
+						replicatedJobName: "replicated-job-1",
+						jobName:           "test-jobset-replicated-job-2-test-job-0"}).
+						Parallelism(5).
+						Suspend(true).
+						Obj(),
+					makeJob(&makeJobArgs{
+						jobSetName:        jobSetName,
+						replicatedJobName: "replicated-job-2",
+						jobName:           "test-jobset-replicated-job-2-test-job-0"}).
+						Parallelism(5).
+						Obj(),
+					makeJob(&makeJobArgs{
+						jobSetName:        jobSetName,
+						replicatedJobName: "replicated-job-2",
+						jobName:           "test-jobset-replicated-job-2-test-job-1"}).
+						Parallelism(1).
+						Suspend(true).
+						Obj(),
+				},
+			},
+			expected: []jobset.ReplicatedJobStatus{
+				{
+					Name:      "replicated-job-1",
+					Ready:     0,
+					Suspended: 1,
+				},
+				{
+					Name:      "replicated-job-2",
+					Ready:     0,
+					Suspended: 1,
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := JobSetReconciler{Client: newFakeClientBuilder().Build()}
+			statuses, err := r.calculateReplicatedJobStatuses(context.TODO(), tc.js, &tc.jobs)
+			if err != nil {
+				t.Fatalf("unexpected error calculating replicated job statuses: %v", err)
+			}
+			less := func(a, b jobset.ReplicatedJobStatus) bool {
+				return a.Name < b.Name
+			}
+			if diff := cmp.Diff(tc.expected, statuses, cmpopts.SortSlices(less)); diff != "" {
+				t.Errorf("calculateReplicatedJobStatuses() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCalculateReplicatedJobStatusesWithPodAnnotation(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+		successAnn = &jobset.PodAnnotationSuccessCondition{Key: "example.com/status", Value: "done"}
+	)
+	job := func(name string) *batchv1.Job {
+		return makeJob(&makeJobArgs{
+			jobSetName:        jobSetName,
+			replicatedJobName: "replicated-job-1",
+			jobName:           name,
+			ns:                ns,
+			replicas:          2,
+		}).Parallelism(2).Completions(2).Active(2).Ready(2).Obj()
+	}
+	podOfAttempt := func(name, jobName, restarts string, annotations map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: ns,
+				Labels: map[string]string{
+					jobset.JobSetNameKey:  jobSetName,
+					jobset.JobKey:         jobHashKey(ns, jobName),
+					constants.RestartsKey: restarts,
+				},
+				Annotations: annotations,
+			},
+		}
+	}
+	pod := func(name, jobName string, annotations map[string]string) *corev1.Pod {
+		return podOfAttempt(name, jobName, "0", annotations)
+	}
+	js := testutils.MakeJobSet(jobSetName, ns).
+		SuccessPolicy(&jobset.SuccessPolicy{Operator: jobset.OperatorAll, PodAnnotation: successAnn}).
+		ReplicatedJob(testutils.MakeReplicatedJob("replicated-job-1").Replicas(2).Obj()).
+		Obj()
+
+	tests := []struct {
+		name string
+		// jobs are the active jobs, which default to job-0 and job-1.
+		jobs           []*batchv1.Job
+		pods           []*corev1.Pod
+		expected       []jobset.ReplicatedJobStatus
+		wantSuccessful int
+	}{
+		{
+			name: "no pods carry the annotation",
+			pods: []*corev1.Pod{
+				pod("pod-0-0", "job-0", nil),
+				pod("pod-0-1", "job-0", nil),
+				pod("pod-1-0", "job-1", map[string]string{successAnn.Key: "running"}),
+			},
+			expected: []jobset.ReplicatedJobStatus{
+				{Name: "replicated-job-1", Ready: 2, Active: 2},
+			},
+		},
+		{
+			name: "only some pods of a job carry the annotation",
+			pods: []*corev1.Pod{
+				pod("pod-0-0", "job-0", map[string]string{successAnn.Key: successAnn.Value}),
+				pod("pod-0-1", "job-0", nil),
+			},
+			expected: []jobset.ReplicatedJobStatus{
+				{Name: "replicated-job-1", Ready: 2, Active: 2},
+			},
+		},
+		{
+			name: "all pods of one job carry the annotation",
+			pods: []*corev1.Pod{
+				pod("pod-0-0", "job-0", map[string]string{successAnn.Key: successAnn.Value}),
+				pod("pod-0-1", "job-0", map[string]string{successAnn.Key: successAnn.Value}),
+				pod("pod-1-0", "job-1", nil),
+			},
+			expected: []jobset.ReplicatedJobStatus{
+				{Name: "replicated-job-1", Ready: 1, Active: 1, Succeeded: 1},
+			},
+			wantSuccessful: 1,
+		},
+		{
+			name: "fewer pods than the parallelism of the job carry the annotation",
+			pods: []*corev1.Pod{
+				pod("pod-0-0", "job-0", map[string]string{successAnn.Key: successAnn.Value}),
+			},
+			expected: []jobset.ReplicatedJobStatus{
+				{Name: "replicated-job-1", Ready: 2, Active: 2},
+			},
+		},
+		{
+			name: "pods of another restart attempt are not counted",
+			pods: []*corev1.Pod{
+				podOfAttempt("pod-0-0-old", "job-0", "1", map[string]string{successAnn.Key: successAnn.Value}),
+				podOfAttempt("pod-0-1-old", "job-0", "1", map[string]string{successAnn.Key: successAnn.Value}),
+				pod("pod-0-0", "job-0", nil),
+			},
+			expected: []jobset.ReplicatedJobStatus{
+				{Name: "replicated-job-1", Ready: 2, Active: 2},
+			},
+		},
+		{
+			name: "pods of a job kept across a restart are counted",
+			jobs: func() []*batchv1.Job {
+				kept := job("job-0")
+				kept.Labels = collections.MergeMaps(kept.Labels, map[string]string{constants.RestartsKey: "1"})
+				return []*batchv1.Job{kept, job("job-1")}
+			}(),
+			pods: []*corev1.Pod{
+				pod("pod-0-0", "job-0", map[string]string{successAnn.Key: successAnn.Value}),
+				pod("pod-0-1", "job-0", map[string]string{successAnn.Key: successAnn.Value}),
+			},
+			expected: []jobset.ReplicatedJobStatus{
+				{Name: "replicated-job-1", Ready: 1, Active: 1, Succeeded: 1},
+			},
+			wantSuccessful: 1,
+		},
+		{
+			name: "failed pods replaced by the job controller are not counted",
+			pods: []*corev1.Pod{
+				pod("pod-0-0", "job-0", map[string]string{successAnn.Key: successAnn.Value}),
+				pod("pod-0-1", "job-0", map[string]string{successAnn.Key: successAnn.Value}),
+				func() *corev1.Pod {
+					p := pod("pod-0-1-failed", "job-0", nil)
+					p.Status.Phase = corev1.PodFailed
+					return p
+				}(),
+			},
+			expected: []jobset.ReplicatedJobStatus{
+				{Name: "replicated-job-1", Ready: 1, Active: 1, Succeeded: 1},
+			},
+			wantSuccessful: 1,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			builder := newFakeClientBuilder()
+			for _, p := range tc.pods {
+				builder = builder.WithObjects(p)
+			}
+			r := JobSetReconciler{Client: builder.Build()}
+			jobs := childJobs{active: tc.jobs}
+			if jobs.active == nil {
+				jobs.active = []*batchv1.Job{job("job-0"), job("job-1")}
+			}
+			statuses, err := r.calculateReplicatedJobStatuses(context.TODO(), js, &jobs)
+			if err != nil {
+				t.Fatalf("unexpected error calculating replicated job statuses: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, statuses); diff != "" {
+				t.Errorf("calculateReplicatedJobStatuses() mismatch (-want +got):\n%s", diff)
+			}
+			if len(jobs.successful) != tc.wantSuccessful {
+				t.Errorf("expected %d successful jobs, got %d", tc.wantSuccessful, len(jobs.successful))
+			}
+		})
+	}
+}
+
+func TestJobSetForPod(t *testing.T) {
+	tests := []struct {
+		name string
+		pod  *corev1.Pod
+		want []reconcile.Request
+	}{
+		{
+			name: "pod of a jobset",
+			pod: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Name:      "pod",
+				Namespace: "default",
+				Labels:    map[string]string{jobset.JobSetNameKey: "test-jobset"},
+			}},
+			want: []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "default", Name: "test-jobset"}}},
+		},
+		{
+			name: "pod not belonging to a jobset",
+			pod:  &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "default"}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, jobSetForPod(context.TODO(), tc.pod)); diff != "" {
+				t.Errorf("unexpected requests (-want/+got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestPodTerminated(t *testing.T) {
+	pod := func(phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "default"}, Status: corev1.PodStatus{Phase: phase}}
+	}
+	tests := []struct {
+		name     string
+		oldPhase corev1.PodPhase
+		newPhase corev1.PodPhase
+		want     bool
+	}{
+		{name: "pod succeeded", oldPhase: corev1.PodRunning, newPhase: corev1.PodSucceeded, want: true},
+		{name: "pod failed", oldPhase: corev1.PodRunning, newPhase: corev1.PodFailed, want: true},
+		{name: "pod started running", oldPhase: corev1.PodPending, newPhase: corev1.PodRunning},
+		{name: "pod already terminated", oldPhase: corev1.PodSucceeded, newPhase: corev1.PodSucceeded},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			e := event.UpdateEvent{ObjectOld: pod(tc.oldPhase), ObjectNew: pod(tc.newPhase)}
+			if got := podTerminated(e); got != tc.want {
+				t.Errorf("podTerminated() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSuspendJobs(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	tests := []struct {
+		name        string
+		js          *jobset.JobSet
+		wantSuspend bool
+	}{
+		{
+			name:        "onSuspend unset, child jobs are suspended",
+			js:          testutils.MakeJobSet(jobSetName, ns).Suspend(true).Obj(),
+			wantSuspend: true,
+		},
+		{
+			name:        "onSuspend DeletePods, child jobs are suspended",
+			js:          testutils.MakeJobSet(jobSetName, ns).Suspend(true).OnSuspend(jobset.OnSuspendDeletePods).Obj(),
+			wantSuspend: true,
+		},
+		{
+			name:        "onSuspend RetainPods, child jobs are left running",
+			js:          testutils.MakeJobSet(jobSetName, ns).Suspend(true).OnSuspend(jobset.OnSuspendRetainPods).Obj(),
+			wantSuspend: false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			job := makeJob(&makeJobArgs{
+				jobSetName:        jobSetName,
+				replicatedJobName: "replicated-job",
+				jobName:           "test-jobset-replicated-job-0",
+				ns:                ns,
+				replicas:          1,
+			}).Suspend(false).Obj()
+			fakeClient := newFakeClientBuilder().WithObjects(job).Build()
+			r := JobSetReconciler{Client: fakeClient}
+
+			var updateStatusOpts statusUpdateOpts
+			if err := r.suspendJobs(context.TODO(), tc.js, []*batchv1.Job{job}, &updateStatusOpts); err != nil {
+				t.Fatalf("unexpected error suspending jobs: %v", err)
+			}
+
+			var gotJob batchv1.Job
+			if err := fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(job), &gotJob); err != nil {
+				t.Fatalf("unexpected error getting job: %v", err)
+			}
+			if got := jobSuspended(&gotJob); got != tc.wantSuspend {
+				t.Errorf("expected job suspend to be %v, got %v", tc.wantSuspend, got)
+			}
+			if !updateStatusOpts.shouldUpdate {
+				t.Errorf("expected the jobset suspended condition to be set")
+			}
+		})
+	}
+}
+
+func TestGetChildJobsWithPartiallyCompleteIndexedJobs(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	completeTime := metav1.NewTime(time.Now().Add(-time.Minute))
+	// job returns a complete child Job with the given completion mode and succeeded completions out of 5.
+	job := func(js *jobset.JobSet, jobIdx int, mode batchv1.CompletionMode, succeeded int32) *batchv1.Job {
+		job := makeJob(&makeJobArgs{
+			jobSetName:        jobSetName,
+			replicatedJobName: "workers",
+			jobName:           placement.GenJobName(jobSetName, "workers", jobIdx),
+			ns:                ns,
+			replicas:          3,
+			jobIdx:            jobIdx,
+		}).Completions(5).Succeeded(succeeded).Obj()
+		job.Spec.CompletionMode = ptr.To(mode)
+		job.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(js, jobset.GroupVersion.WithKind("JobSet"))}
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue, LastTransitionTime: completeTime}}
+		return job
+	}
+
+	tests := []struct {
+		name       string
+		completion jobset.IndexedJobCompletion
+		expected   []jobset.ReplicatedJobStatus
+	}{
+		{
+			name:     "complete jobs are succeeded by default",
+			expected: []jobset.ReplicatedJobStatus{{Name: "workers", Succeeded: 3}},
+		},
+		{
+			name:       "complete jobs are succeeded with the condition completion",
+			completion: jobset.IndexedJobCompletionCondition,
+			expected:   []jobset.ReplicatedJobStatus{{Name: "workers", Succeeded: 3}},
+		},
+		{
+			name:       "partially complete indexed jobs are failed with the all indexes completion",
+			completion: jobset.IndexedJobCompletionAllIndexes,
+			expected:   []jobset.ReplicatedJobStatus{{Name: "workers", Succeeded: 2, Failed: 1}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet(jobSetName, ns).
+				SuccessPolicy(&jobset.SuccessPolicy{Operator: jobset.OperatorAll, IndexedJobCompletion: tc.completion}).
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").Replicas(3).Obj()).
+				Obj()
+			js.UID = "test-uid"
+			fakeClient := newFakeClientBuilder().
+				WithObjects(
+					// 3 of 5 indexes succeeded, e.g. due to a success policy of the Job.
+					job(js, 0, batchv1.IndexedCompletion, 3),
+					job(js, 1, batchv1.IndexedCompletion, 5),
+					// NonIndexed Jobs are always succeeded once complete.
+					job(js, 2, batchv1.NonIndexedCompletion, 3),
+				).
+				Build()
+			r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{})
+
+			ownedJobs, err := r.getChildJobs(context.TODO(), js)
+			if err != nil {
+				t.Fatalf("unexpected error getting child jobs: %v", err)
+			}
+			statuses, err := r.calculateReplicatedJobStatuses(context.TODO(), js, ownedJobs)
+			if err != nil {
+				t.Fatalf("unexpected error calculating replicated job statuses: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, statuses); diff != "" {
+				t.Errorf("calculateReplicatedJobStatuses() mismatch (-want +got):\n%s", diff)
+			}
+			// Partially complete jobs counted as failed fail at the time they completed.
+			if len(ownedJobs.failed) > 0 {
+				firstFailedJob := findFirstFailedJob(ownedJobs.failed)
+				if firstFailedJob == nil || firstFailedJob.Name != placement.GenJobName(jobSetName, "workers", 0) {
+					t.Errorf("unexpected first failed job: %v", firstFailedJob)
+				}
+			}
+		})
+	}
+}
+
+func TestFindFirstFailedJob(t *testing.T) {
+	testCases := []struct {
+		name       string
+		failedJobs []*batchv1.Job
+		expected   *batchv1.Job
+	}{
+		{
+			name:       "No failed jobs",
+			failedJobs: []*batchv1.Job{},
+			expected:   nil,
+		},
+		{
+			name: "Single failed job",
+			failedJobs: []*batchv1.Job{
+				jobWithFailedCondition("job1", time.Now().Add(-1*time.Hour)),
+			},
+			expected: jobWithFailedCondition("job1", time.Now().Add(-1*time.Hour)),
+		},
+		{
+			name: "Multiple failed jobs, earliest first",
+			failedJobs: []*batchv1.Job{
+				jobWithFailedCondition("job1", time.Now().Add(-3*time.Hour)),
+				jobWithFailedCondition("job2", time.Now().Add(-5*time.Hour)),
+			},
+			expected: jobWithFailedCondition("job2", time.Now().Add(-5*time.Hour)),
+		},
+		{
+			name: "Jobs without failed condition",
+			failedJobs: []*batchv1.Job{
+				{ObjectMeta: metav1.ObjectMeta{Name: "job1"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "job2"}},
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			result := findFirstFailedJob(tc.failedJobs)
+			if result != nil && tc.expected != nil {
+				assert.Equal(t, result.Name, tc.expected.Name)
+			} else if result != nil && tc.expected == nil || result == nil && tc.expected != nil {
+				t.Errorf("Expected: %v, got: %v)", result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestDeleteJobsOnRestart(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	tests := []struct {
+		name                   string
+		js                     *jobset.JobSet
+		wantPropagationPolicy  *metav1.DeletionPropagation
+		wantGracePeriodSeconds *int64
+	}{
+		{
+			name:                  "no failure policy, foreground deletion",
+			js:                    testutils.MakeJobSet(jobSetName, ns).Obj(),
+			wantPropagationPolicy: ptr.To(metav1.DeletePropagationForeground),
+		},
+		{
+			name: "failure policy without deletion overrides, foreground deletion",
+			js: testutils.MakeJobSet(jobSetName, ns).
+				FailurePolicy(&jobset.FailurePolicy{MaxRestarts: 1}).Obj(),
+			wantPropagationPolicy: ptr.To(metav1.DeletePropagationForeground),
+		},
+		{
+			name: "background propagation policy",
+			js: testutils.MakeJobSet(jobSetName, ns).
+				FailurePolicy(&jobset.FailurePolicy{
+					MaxRestarts:             1,
+					DeletePropagationPolicy: ptr.To(metav1.DeletePropagationBackground),
+				}).Obj(),
+			wantPropagationPolicy: ptr.To(metav1.DeletePropagationBackground),
+		},
+		{
+			name: "foreground propagation policy with grace period override",
+			js: testutils.MakeJobSet(jobSetName, ns).
+				FailurePolicy(&jobset.FailurePolicy{
+					MaxRestarts:                    1,
+					DeletePropagationPolicy:        ptr.To(metav1.DeletePropagationForeground),
+					TerminationGracePeriodOverride: ptr.To[int64](0),
+				}).Obj(),
+			wantPropagationPolicy:  ptr.To(metav1.DeletePropagationForeground),
+			wantGracePeriodSeconds: ptr.To[int64](0),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			job := makeJob(&makeJobArgs{
+				jobSetName:        jobSetName,
+				replicatedJobName: "replicated-job",
+				jobName:           "test-jobset-replicated-job-0",
+				ns:                ns,
+				replicas:          1,
+				jobIdx:            0,
+			}).Obj()
+
+			var gotOpts []*client.DeleteOptions
+			fakeClient := newFakeClientBuilder().
+				WithObjects(job).
+				WithInterceptorFuncs(withServerSideApply(interceptor.Funcs{
+					Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+						deleteOpts := &client.DeleteOptions{}
+						deleteOpts.ApplyOptions(opts)
+						gotOpts = append(gotOpts, deleteOpts)
+						return c.Delete(ctx, obj, opts...)
+					},
+				})).Build()
+
+			r := JobSetReconciler{Client: fakeClient}
+			if err := r.deleteJobs(context.TODO(), []*batchv1.Job{job}, restartDeleteOptions(tc.js)); err != nil {
+				t.Fatalf("unexpected error deleting jobs: %v", err)
+			}
+			if len(gotOpts) != 1 {
+				t.Fatalf("expected 1 delete call, got %d", len(gotOpts))
+			}
+			if diff := cmp.Diff(tc.wantPropagationPolicy, gotOpts[0].PropagationPolicy); diff != "" {
+				t.Errorf("unexpected propagation policy (-want/+got): %s", diff)
+			}
+			if diff := cmp.Diff(tc.wantGracePeriodSeconds, gotOpts[0].GracePeriodSeconds); diff != "" {
+				t.Errorf("unexpected grace period seconds (-want/+got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestApplyJobWithRetry(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+		jobName    = "test-jobset-replicated-job-0"
+		jobsGR     = schema.GroupResource{Group: "batch", Resource: "jobs"}
+	)
+	// Avoid waiting between attempts in tests.
+	defer func(backoff wait.Backoff) { jobCreationBackoff = backoff }(jobCreationBackoff)
+	jobCreationBackoff = wait.Backoff{Duration: time.Millisecond}
+
+	tests := []struct {
+		name        string
+		retries     int
+		failures    int
+		applyErr    error
+		existingJob bool
+		// existingUncontrolled is true if the existing Job is not controlled by the JobSet.
+		existingUncontrolled bool
+		wantErr              bool
+		wantAttempts         int
+		wantJob              bool
+	}{
+		{
+			name:         "no errors",
+			retries:      3,
+			wantAttempts: 1,
+			wantJob:      true,
+		},
+		{
+			name:         "transient internal errors within the retry limit",
+			retries:      3,
+			failures:     3,
+			applyErr:     k8serrors.NewInternalError(errors.New("etcd hiccup")),
+			wantAttempts: 4,
+			wantJob:      true,
+		},
+		{
+			name:         "transient server timeout errors within the retry limit",
+			retries:      2,
+			failures:     2,
+			applyErr:     k8serrors.NewServerTimeout(jobsGR, "patch", 1),
+			wantAttempts: 3,
+			wantJob:      true,
+		},
+		{
+			name:         "transient errors exceed the retry limit",
+			retries:      2,
+			failures:     5,
+			applyErr:     k8serrors.NewInternalError(errors.New("etcd hiccup")),
+			wantErr:      true,
+			wantAttempts: 3,
+		},
+		{
+			name:         "conflicts are not retried",
+			retries:      3,
+			failures:     1,
+			applyErr:     k8serrors.NewConflict(jobsGR, jobName, errors.New("conflict")),
+			wantErr:      true,
+			wantAttempts: 1,
+		},
+		{
+			name:         "non-retriable errors are not retried",
+			retries:      3,
+			failures:     1,
+			applyErr:     k8serrors.NewBadRequest("bad request"),
+			wantErr:      true,
+			wantAttempts: 1,
+		},
+		{
+			name:         "job already exists",
+			retries:      3,
+			existingJob:  true,
+			wantAttempts: 1,
+			wantJob:      true,
+		},
+		{
+			name:                 "job of the same name not controlled by the jobset is not taken over",
+			retries:              3,
+			existingJob:          true,
+			existingUncontrolled: true,
+			wantErr:              true,
+			wantJob:              true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			job := makeJob(&makeJobArgs{
+				jobSetName:        jobSetName,
+				replicatedJobName: "replicated-job",
+				jobName:           jobName,
+				ns:                ns,
+				replicas:          1,
+				jobIdx:            0,
+			}).Obj()
+
+			js := testutils.MakeJobSet(jobSetName, ns).Obj()
+			js.UID = "test-uid"
+			job.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(js, jobset.GroupVersion.WithKind("JobSet"))}
+			builder := newFakeClientBuilder()
+			if tc.existingJob {
+				existing := job.DeepCopy()
+				if tc.existingUncontrolled {
+					existing.OwnerReferences = nil
+				}
+				builder = builder.WithObjects(existing)
+			}
+			attempts := 0
+			apply := withServerSideApply(interceptor.Funcs{}).Patch
+			fakeClient := builder.WithInterceptorFuncs(interceptor.Funcs{
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					attempts++
+					if attempts <= tc.failures {
+						return tc.applyErr
+					}
+					return apply(ctx, c, obj, patch, opts...)
+				},
+			}).Build()
+
+			r := JobSetReconciler{Client: fakeClient, opts: JobSetReconcilerOptions{JobCreationRetries: tc.retries}}
+			err := r.applyJobWithRetry(context.TODO(), job)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("applyJobWithRetry() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if attempts != tc.wantAttempts {
+				t.Errorf("unexpected number of apply attempts, want %d, got %d", tc.wantAttempts, attempts)
+			}
+			var got batchv1.Job
+			getErr := fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(job), &got)
+			if gotJob := getErr == nil; gotJob != tc.wantJob {
+				t.Errorf("unexpected job existence, want %v, got %v", tc.wantJob, gotJob)
+			}
+			if tc.existingUncontrolled && len(got.OwnerReferences) != 0 {
+				t.Errorf("expected the job not controlled by the jobset to be left alone, got owner references %v", got.OwnerReferences)
+			}
+		})
+	}
+}
+
+// Helper function to create a job object with a failed condition
+func jobWithFailedCondition(name string, failureTime time.Time) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: batchv1.JobStatus{
+			Conditions: []batchv1.JobCondition{
+				{
+					Type:               batchv1.JobFailed,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(failureTime),
+				},
+			},
+		},
+	}
+}
+
+type makeJobArgs struct {
+	jobSetName           string
+	jobSetUID            string
+	replicatedJobName    string
+	jobName              string
+	ns                   string
+	replicas             int
+	jobIdx               int
+	restarts             int
+	topology             string
+	nodeSelectorStrategy bool
+}
+
+// Helper function to create a Job for unit testing.
+func makeJob(args *makeJobArgs) *testutils.JobWrapper {
+	labels := map[string]string{
+		jobset.JobSetNameKey:         args.jobSetName,
+		jobset.JobSetUIDKey:          args.jobSetUID,
+		jobset.ReplicatedJobNameKey:  args.replicatedJobName,
+		jobset.ReplicatedJobReplicas: strconv.Itoa(args.replicas),
+		jobset.JobIndexKey:           strconv.Itoa(args.jobIdx),
+		constants.RestartsKey:        strconv.Itoa(args.restarts),
+		jobset.JobKey:                jobHashKey(args.ns, args.jobName),
+	}
+	annotations := map[string]string{
+		jobset.JobSetNameKey:         args.jobSetName,
+		jobset.JobSetUIDKey:          args.jobSetUID,
+		jobset.ReplicatedJobNameKey:  args.replicatedJobName,
+		jobset.ReplicatedJobReplicas: strconv.Itoa(args.replicas),
+		jobset.JobIndexKey:           strconv.Itoa(args.jobIdx),
+		constants.RestartsKey:        strconv.Itoa(args.restarts),
+		jobset.JobKey:                jobHashKey(args.ns, args.jobName),
+	}
+	// Only set exclusive key if we are using exclusive placement per topology.
+	if args.topology != "" {
+		annotations[jobset.ExclusiveKey] = args.topology
+		// Exclusive placement topology domain must be set in order to use the node selector strategy.
+		if args.nodeSelectorStrategy {
+			annotations[jobset.NodeSelectorStrategyKey] = "true"
+		}
+	}
+	jobWrapper := testutils.MakeJob(args.jobName, args.ns).
+		JobLabels(labels).
+		JobAnnotations(annotations).
+		PodLabels(labels).
+		PodAnnotations(annotations)
+	return jobWrapper
+}
+
+func TestSetOwnerReference(t *testing.T) {
+	js := testutils.MakeJobSet("test-jobset", "default").Obj()
+	js.UID = "test-uid"
+	tests := []struct {
+		name                      string
+		disableBlockOwnerDeletion bool
+		obj                       metav1.Object
+		want                      []metav1.OwnerReference
+	}{
+		{
+			name: "job owner reference blocks owner deletion by default",
+			obj:  &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}},
+			want: []metav1.OwnerReference{{
+				APIVersion:         jobset.GroupVersion.String(),
+				Kind:               "JobSet",
+				Name:               "test-jobset",
+				UID:                "test-uid",
+				Controller:         ptr.To(true),
+				BlockOwnerDeletion: ptr.To(true),
+			}},
+		},
+		{
+			name:                      "job owner reference does not block owner deletion when disabled",
+			disableBlockOwnerDeletion: true,
+			obj:                       &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}},
+			want: []metav1.OwnerReference{{
+				APIVersion:         jobset.GroupVersion.String(),
+				Kind:               "JobSet",
+				Name:               "test-jobset",
+				UID:                "test-uid",
+				Controller:         ptr.To(true),
+				BlockOwnerDeletion: ptr.To(false),
+			}},
+		},
+		{
+			name:                      "service owner reference does not block owner deletion when disabled",
+			disableBlockOwnerDeletion: true,
+			obj:                       &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}},
+			want: []metav1.OwnerReference{{
+				APIVersion:         jobset.GroupVersion.String(),
+				Kind:               "JobSet",
+				Name:               "test-jobset",
+				UID:                "test-uid",
+				Controller:         ptr.To(true),
+				BlockOwnerDeletion: ptr.To(false),
+			}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := JobSetReconciler{Scheme: testScheme, opts: JobSetReconcilerOptions{DisableBlockOwnerDeletion: tc.disableBlockOwnerDeletion}}
+			if err := r.setOwnerReference(js, tc.obj); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, tc.obj.GetOwnerReferences()); diff != "" {
+				t.Errorf("unexpected owner references (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func BenchmarkCalculateReplicatedJobStatuses(b *testing.B) {
+	for _, numJobs := range []int{100, 1000, 10000} {
+		b.Run(fmt.Sprintf("jobs=%d", numJobs), func(b *testing.B) {
+			const numReplicatedJobs = 10
+			jsWrapper := testutils.MakeJobSet("test-jobset", "default")
+			for i := 0; i < numReplicatedJobs; i++ {
+				jsWrapper.ReplicatedJob(testutils.MakeReplicatedJob(fmt.Sprintf("rjob-%d", i)).Replicas(int32(numJobs / numReplicatedJobs)).Obj())
+			}
+			js := jsWrapper.Obj()
+			var jobs childJobs
+			for i := 0; i < numJobs; i++ {
+				job := makeJob(&makeJobArgs{
+					jobSetName:        "test-jobset",
+					replicatedJobName: fmt.Sprintf("rjob-%d", i%numReplicatedJobs),
+					jobName:           fmt.Sprintf("test-jobset-rjob-%d-%d", i%numReplicatedJobs, i/numReplicatedJobs),
+					ns:                "default",
+					replicas:          numJobs / numReplicatedJobs,
+					jobIdx:            i / numReplicatedJobs,
+				}).Parallelism(1).Completions(1).Active(1).Ready(1).Obj()
+				switch i % 3 {
+				case 0:
+					jobs.active = append(jobs.active, job)
+				case 1:
+					jobs.successful = append(jobs.successful, job)
+				default:
+					jobs.failed = append(jobs.failed, job)
+				}
+			}
+			r := JobSetReconciler{Client: newFakeClientBuilder().Build()}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_, _ = r.calculateReplicatedJobStatuses(context.TODO(), js, &jobs)
+			}
+		})
+	}
+}
+
+func TestReconcileUpdatesObservedGeneration(t *testing.T) {
+	jobTemplate := testutils.MakeJobTemplate("job", "default").Obj()
+	jobTemplate.Spec.Parallelism = ptr.To[int32](1)
+	js := testutils.MakeJobSet("test-jobset", "default").
+		SuccessPolicy(&jobset.SuccessPolicy{Operator: jobset.OperatorAll}).
+		ReplicatedJob(testutils.MakeReplicatedJob("workers").Job(jobTemplate).Replicas(1).Obj()).
+		Obj()
+	js.Generation = 1
+	fakeClient := newFakeClientBuilder().
+		WithObjects(js).
+		WithStatusSubresource(js, &batchv1.Job{}).
+		Build()
+	r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{})
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: js.Name, Namespace: js.Namespace}}
+
+	reconcileAndGet := func() *jobset.JobSet {
+		t.Helper()
+		reconcileJobSet(t, r, req, 1)
+		var got jobset.JobSet
+		if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+			t.Fatalf("unexpected error getting jobset: %v", err)
+		}
+		return &got
+	}
+
+	got := reconcileAndGet()
+	if got.Status.ObservedGeneration != 1 {
+		t.Errorf("expected observedGeneration 1 after first reconcile, got %d", got.Status.ObservedGeneration)
+	}
+	if len(got.Status.Conditions) == 0 {
+		t.Fatalf("expected the jobset to have conditions after the first reconcile")
+	}
+
+	// Mutate the spec, which bumps the generation of the JobSet.
+	got.Spec.TTLSecondsAfterFinished = ptr.To[int32](60)
+	got.Generation = 2
+	if err := fakeClient.Update(context.TODO(), got); err != nil {
+		t.Fatalf("unexpected error updating jobset: %v", err)
+	}
+	got = reconcileAndGet()
+	if got.Status.ObservedGeneration != 2 {
+		t.Errorf("expected observedGeneration 2 after reconciling the mutated spec, got %d", got.Status.ObservedGeneration)
+	}
+	// Conditions whose status didn't change also reflect the reconciled generation.
+	for _, cond := range got.Status.Conditions {
+		if cond.ObservedGeneration != 2 {
+			t.Errorf("expected observedGeneration 2 of condition %s after reconciling the mutated spec, got %d", cond.Type, cond.ObservedGeneration)
+		}
+	}
+}
+
+func TestReconcileJobsPendingDeletion(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	jobTemplate := testutils.MakeJobTemplate("job", ns).Obj()
+	jobTemplate.Spec.Parallelism = ptr.To[int32](1)
+	js := testutils.MakeJobSet(jobSetName, ns).
+		ReplicatedJob(testutils.MakeReplicatedJob("workers").Job(jobTemplate).Replicas(2).Obj()).
+		Obj()
+	js.UID = "test-uid"
+	js.Status.Restarts = 1
+	// The Jobs of the previous run are kept by a finalizer, so their deletion is in progress
+	// until the finalizer is removed.
+	var objs []client.Object
+	for idx, name := range []string{"previous-run-1", "previous-run-0"} {
+		job := makeJob(&makeJobArgs{
+			jobSetName:        jobSetName,
+			replicatedJobName: "workers",
+			jobName:           name,
+			ns:                ns,
+			replicas:          2,
+			jobIdx:            idx,
+		}).Obj()
+		job.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(js, jobset.GroupVersion.WithKind("JobSet"))}
+		job.Finalizers = []string{"example.com/teardown"}
+		objs = append(objs, job)
+	}
+	fakeClient := newFakeClientBuilder().
+		WithObjects(append(objs, js)...).
+		WithStatusSubresource(js).
+		Build()
+	r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{})
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: jobSetName, Namespace: ns}}
+
+	reconcileAndGet := func() *jobset.JobSet {
+		t.Helper()
+		reconcileJobSet(t, r, req, 1)
+		var got jobset.JobSet
+		if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+			t.Fatalf("unexpected error getting jobset: %v", err)
+		}
+		return &got
+	}
+
+	got := reconcileAndGet()
+	if diff := cmp.Diff([]string{"previous-run-0", "previous-run-1"}, got.Status.JobsPendingDeletion); diff != "" {
+		t.Errorf("unexpected jobs pending deletion while the jobs are deleted (-want/+got): %s", diff)
+	}
+
+	// Complete the deletion of the Jobs of the previous run.
+	for _, obj := range objs {
+		var job batchv1.Job
+		if err := fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(obj), &job); err != nil {
+			t.Fatalf("unexpected error getting job: %v", err)
+		}
+		job.Finalizers = nil
+		if err := fakeClient.Update(context.TODO(), &job); err != nil {
+			t.Fatalf("unexpected error removing the job finalizer: %v", err)
+		}
+	}
+	got = reconcileAndGet()
+	if len(got.Status.JobsPendingDeletion) != 0 {
+		t.Errorf("expected no jobs pending deletion once the jobs are deleted, got %v", got.Status.JobsPendingDeletion)
+	}
+}
+
+func TestReconcileLogsJobSetAndReplicatedJobContext(t *testing.T) {
+	js := testutils.MakeJobSet("test-jobset", "default").
+		ReplicatedJob(testutils.MakeReplicatedJob("workers").
+			Job(testutils.MakeJobTemplate("test-job", "default").Obj()).
+			Replicas(1).
+			Obj()).
+		Obj()
+	fakeClient := newFakeClientBuilder().
+		WithObjects(js).
+		WithStatusSubresource(js).
+		Build()
+	r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{})
+
+	var lines []string
+	logger := funcr.New(func(prefix, args string) {
+		lines = append(lines, args)
+	}, funcr.Options{Verbosity: 5})
+	ctx := ctrl.LoggerInto(context.TODO(), logger)
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: js.Name, Namespace: js.Namespace}}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("unexpected reconcile error: %v", err)
+	}
+
+	var createLog string
+	for _, line := range lines {
+		if strings.Contains(line, `"msg"="successfully created job"`) {
+			createLog = line
+		}
+	}
+	if createLog == "" {
+		t.Fatalf("expected a log line for the job creation, got:\n%s", strings.Join(lines, "\n"))
+	}
+	for _, want := range []string{
+		`"jobset"={"name"="test-jobset" "namespace"="default"}`,
+		`"replicatedJob"="workers"`,
+	} {
+		if !strings.Contains(createLog, want) {
+			t.Errorf("expected job creation log to contain %s, got: %s", want, createLog)
+		}
+	}
+}
+
+func TestReconcileRequeueJitter(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	startTime := metav1.NewTime(now.Add(-30 * time.Second))
+	// The active deadline of the JobSet is reached in 30 seconds.
+	wantRequeueAfter := 30 * time.Second
+	tests := []struct {
+		name         string
+		jitterFactor float64
+		wantMax      time.Duration
+	}{
+		{
+			name:    "no jitter",
+			wantMax: wantRequeueAfter,
+		},
+		{
+			name:         "requeue is delayed by up to the jitter factor",
+			jitterFactor: 0.5,
+			wantMax:      wantRequeueAfter + wantRequeueAfter/2,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet("test-jobset", "default").
+				Finalizers([]string{jobset.CleanupFinalizer}).
+				ActiveDeadlineSeconds(60).
+				StartTime(&startTime).
+				Obj()
+			fakeClient := newFakeClientBuilder().
+				WithObjects(js).
+				WithStatusSubresource(js).
+				Build()
+			r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{
+				RequeueJitterFactor: tc.jitterFactor,
+			})
+			r.clock = clocktesting.NewFakeClock(now)
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: js.Name, Namespace: js.Namespace}}
+
+			jittered := false
+			for i := 0; i < 20; i++ {
+				result := reconcileJobSet(t, r, req, 1)
+				if result.RequeueAfter < wantRequeueAfter || result.RequeueAfter > tc.wantMax {
+					t.Errorf("expected requeue after in [%v, %v], got %v", wantRequeueAfter, tc.wantMax, result.RequeueAfter)
+				}
+				jittered = jittered || result.RequeueAfter != wantRequeueAfter
+			}
+			if wantJitter := tc.jitterFactor > 0; jittered != wantJitter {
+				t.Errorf("expected jittered requeues %v, got %v", wantJitter, jittered)
+			}
+		})
+	}
+}
+
+func TestReconcileStatusSyncPeriod(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	startTime := metav1.NewTime(now.Add(-30 * time.Second))
+	tests := []struct {
+		name             string
+		js               *jobset.JobSet
+		wantRequeueAfter time.Duration
+	}{
+		{
+			name: "event driven only by default",
+			js:   testutils.MakeJobSet("test-jobset", "default").Obj(),
+		},
+		{
+			name:             "active jobset is requeued after the status sync period",
+			js:               testutils.MakeJobSet("test-jobset", "default").StatusSyncPeriodSeconds(10).Obj(),
+			wantRequeueAfter: 10 * time.Second,
+		},
+		{
+			name: "earlier active deadline takes precedence",
+			// The active deadline of the JobSet is reached in 30 seconds.
+			js: testutils.MakeJobSet("test-jobset", "default").
+				StatusSyncPeriodSeconds(60).
+				ActiveDeadlineSeconds(60).
+				StartTime(&startTime).
+				Obj(),
+			wantRequeueAfter: 30 * time.Second,
+		},
+		{
+			name: "finished jobset is not requeued",
+			js: testutils.MakeJobSet("test-jobset", "default").
+				StatusSyncPeriodSeconds(10).
+				CompletedCondition(metav1.NewTime(now)).
+				Obj(),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := tc.js
+			js.Finalizers = []string{jobset.CleanupFinalizer}
+			fakeClient := newFakeClientBuilder().
+				WithObjects(js).
+				WithStatusSubresource(js).
+				Build()
+			r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{})
+			r.clock = clocktesting.NewFakeClock(now)
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: js.Name, Namespace: js.Namespace}}
+
+			result := reconcileJobSet(t, r, req, 1)
+			if result.RequeueAfter != tc.wantRequeueAfter {
+				t.Errorf("expected requeue after %v, got %v", tc.wantRequeueAfter, result.RequeueAfter)
+			}
+		})
+	}
+}
+
+func TestRecordCompletionTime(t *testing.T) {
+	startTime := metav1.NewTime(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC))
+	completionTime := metav1.NewTime(startTime.Add(90*time.Minute + 500*time.Millisecond))
+	earlierCompletionTime := metav1.NewTime(startTime.Add(time.Hour))
+	tests := []struct {
+		name               string
+		js                 *jobset.JobSet
+		wantCompletionTime *metav1.Time
+		wantDuration       *metav1.Duration
+		wantUpdate         bool
+	}{
+		{
+			name: "active jobset",
+			js:   testutils.MakeJobSet("test-jobset", "default").FirstStartTime(&startTime).Obj(),
+		},
+		{
+			name: "failed jobset",
+			js: testutils.MakeJobSet("test-jobset", "default").
+				FirstStartTime(&startTime).
+				FailedCondition(completionTime).
+				Obj(),
+		},
+		{
+			name: "completed jobset",
+			js: testutils.MakeJobSet("test-jobset", "default").
+				FirstStartTime(&startTime).
+				CompletedCondition(completionTime).
+				Obj(),
+			wantCompletionTime: ptr.To(metav1.NewTime(startTime.Add(90 * time.Minute))),
+			wantDuration:       &metav1.Duration{Duration: 90 * time.Minute},
+			wantUpdate:         true,
+		},
+		{
+			name: "duration of a completed jobset includes its suspension",
+			js: testutils.MakeJobSet("test-jobset", "default").
+				FirstStartTime(&startTime).
+				StartTime(&earlierCompletionTime).
+				CompletedCondition(completionTime).
+				Obj(),
+			wantCompletionTime: ptr.To(metav1.NewTime(startTime.Add(90 * time.Minute))),
+			wantDuration:       &metav1.Duration{Duration: 90 * time.Minute},
+			wantUpdate:         true,
+		},
+		{
+			name: "completed jobset without start time",
+			js: testutils.MakeJobSet("test-jobset", "default").
+				CompletedCondition(completionTime).
+				Obj(),
+			wantCompletionTime: ptr.To(metav1.NewTime(startTime.Add(90 * time.Minute))),
+			wantUpdate:         true,
+		},
+		{
+			name: "recorded completion time is not changed",
+			js: func() *jobset.JobSet {
+				js := testutils.MakeJobSet("test-jobset", "default").
+					FirstStartTime(&startTime).
+					CompletedCondition(completionTime).
+					Obj()
+				js.Status.CompletionTime = &earlierCompletionTime
+				js.Status.Duration = &metav1.Duration{Duration: time.Hour}
+				return js
+			}(),
+			wantCompletionTime: &earlierCompletionTime,
+			wantDuration:       &metav1.Duration{Duration: time.Hour},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := &statusUpdateOpts{}
+			recordCompletionTime(tc.js, opts)
+			if diff := cmp.Diff(tc.wantCompletionTime, tc.js.Status.CompletionTime); diff != "" {
+				t.Errorf("unexpected completion time (-want/+got): %s", diff)
+			}
+			if diff := cmp.Diff(tc.wantDuration, tc.js.Status.Duration); diff != "" {
+				t.Errorf("unexpected duration (-want/+got): %s", diff)
+			}
+			if opts.shouldUpdate != tc.wantUpdate {
+				t.Errorf("unexpected status update: got %v, want %v", opts.shouldUpdate, tc.wantUpdate)
+			}
+		})
+	}
+}
+
+func TestReconcileCompletionTime(t *testing.T) {
+	jobTemplate := testutils.MakeJobTemplate("job", "default").Obj()
+	jobTemplate.Spec.Parallelism = ptr.To[int32](1)
+	js := testutils.MakeJobSet("test-jobset", "default").
+		SuccessPolicy(&jobset.SuccessPolicy{Operator: jobset.OperatorAll}).
+		ReplicatedJob(testutils.MakeReplicatedJob("workers").Job(jobTemplate).Replicas(1).Obj()).
+		Obj()
+	fakeClient := newFakeClientBuilder().
+		WithObjects(js).
+		WithStatusSubresource(js, &batchv1.Job{}).
+		Build()
+	now := time.Now().Add(-time.Hour).Truncate(time.Second)
+	r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(100), JobSetReconcilerOptions{})
+	r.clock = clocktesting.NewFakeClock(now)
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: js.Name, Namespace: js.Namespace}}
+	getJobSet := func(step string) *jobset.JobSet {
+		t.Helper()
+		reconcileJobSet(t, r, req, 1)
+		var got jobset.JobSet
+		if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+			t.Fatalf("%s: unexpected error getting jobset: %v", step, err)
+		}
+		return &got
+	}
+
+	// The start time is recorded when the Jobs are created, the completion time is not.
+	got := getJobSet("create jobs")
+	if diff := cmp.Diff(ptr.To(metav1.NewTime(now)), got.Status.StartTime); diff != "" {
+		t.Errorf("unexpected start time (-want/+got): %s", diff)
+	}
+	if got.Status.CompletionTime != nil || got.Status.Duration != nil {
+		t.Errorf("unexpected completion time %v and duration %v of an active jobset", got.Status.CompletionTime, got.Status.Duration)
+	}
+
+	// The completion time and duration are recorded once the JobSet completes.
+	var jobs batchv1.JobList
+	if err := fakeClient.List(context.TODO(), &jobs, client.InNamespace(js.Namespace)); err != nil {
+		t.Fatalf("unexpected error listing jobs: %v", err)
+	}
+	for i := range jobs.Items {
+		jobs.Items[i].Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+		if err := fakeClient.Status().Update(context.TODO(), &jobs.Items[i]); err != nil {
+			t.Fatalf("unexpected error updating job status: %v", err)
+		}
+	}
+	got = getJobSet("complete")
+	cond := meta.FindStatusCondition(got.Status.Conditions, string(jobset.JobSetCompleted))
+	if cond == nil || cond.Status != metav1.ConditionTrue {
+		t.Fatalf("expected the jobset to be completed, got conditions %v", got.Status.Conditions)
+	}
+	if diff := cmp.Diff(&cond.LastTransitionTime, got.Status.CompletionTime); diff != "" {
+		t.Errorf("unexpected completion time (-want/+got): %s", diff)
+	}
+	wantDuration := &metav1.Duration{Duration: cond.LastTransitionTime.Sub(now)}
+	if diff := cmp.Diff(wantDuration, got.Status.Duration); diff != "" {
+		t.Errorf("unexpected duration (-want/+got): %s", diff)
+	}
+
+	// The completion time and duration are not changed by later reconciles.
+	completed := got.Status
+	r.clock = clocktesting.NewFakeClock(now.Add(time.Hour))
+	got = getJobSet("reconcile completed")
+	if diff := cmp.Diff(completed.CompletionTime, got.Status.CompletionTime); diff != "" {
+		t.Errorf("unexpected change of the completion time (-want/+got): %s", diff)
+	}
+	if diff := cmp.Diff(completed.Duration, got.Status.Duration); diff != "" {
+		t.Errorf("unexpected change of the duration (-want/+got): %s", diff)
+	}
+}
+
+func TestReconcileCompletionTimeAfterSuspension(t *testing.T) {
+	jobTemplate := testutils.MakeJobTemplate("job", "default").Obj()
+	jobTemplate.Spec.Parallelism = ptr.To[int32](1)
+	js := testutils.MakeJobSet("test-jobset", "default").
+		SuccessPolicy(&jobset.SuccessPolicy{Operator: jobset.OperatorAll}).
+		ReplicatedJob(testutils.MakeReplicatedJob("workers").Job(jobTemplate).Replicas(1).Obj()).
+		Obj()
+	fakeClient := newFakeClientBuilder().
+		WithObjects(js).
+		WithStatusSubresource(js, &batchv1.Job{}).
+		Build()
+	start := time.Now().Add(-time.Hour).Truncate(time.Second)
+	r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(100), JobSetReconcilerOptions{})
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: js.Name, Namespace: js.Namespace}}
+	reconcileAt := func(step string, now time.Time) *jobset.JobSet {
+		t.Helper()
+		r.clock = clocktesting.NewFakeClock(now)
+		reconcileJobSet(t, r, req, 1)
+		var got jobset.JobSet
+		if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+			t.Fatalf("%s: unexpected error getting jobset: %v", step, err)
+		}
+		return &got
+	}
+	setSuspend := func(suspend bool) {
+		var got jobset.JobSet
+		if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+			t.Fatalf("unexpected error getting jobset: %v", err)
+		}
+		got.Spec.Suspend = ptr.To(suspend)
+		if err := fakeClient.Update(context.TODO(), &got); err != nil {
+			t.Fatalf("unexpected error updating jobset: %v", err)
+		}
+	}
+
+	reconcileAt("create jobs", start)
+
+	// Suspending and resuming the JobSet restarts its start time, but not its first start time.
+	setSuspend(true)
+	got := reconcileAt("suspend", start.Add(10*time.Minute))
+	if got.Status.StartTime != nil {
+		t.Errorf("expected the start time to be reset while suspended, got %v", got.Status.StartTime)
+	}
+	setSuspend(false)
+	resumed := start.Add(20 * time.Minute)
+	got = reconcileAt("resume", resumed)
+	if diff := cmp.Diff(ptr.To(metav1.NewTime(resumed)), got.Status.StartTime); diff != "" {
+		t.Errorf("unexpected start time after resuming (-want/+got): %s", diff)
+	}
+	if diff := cmp.Diff(ptr.To(metav1.NewTime(start)), got.Status.FirstStartTime); diff != "" {
+		t.Errorf("unexpected first start time after resuming (-want/+got): %s", diff)
+	}
+
+	// The duration of the completed JobSet covers its whole run, including its suspension.
+	var jobs batchv1.JobList
+	if err := fakeClient.List(context.TODO(), &jobs, client.InNamespace(js.Namespace)); err != nil {
+		t.Fatalf("unexpected error listing jobs: %v", err)
+	}
+	for i := range jobs.Items {
+		jobs.Items[i].Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+		if err := fakeClient.Status().Update(context.TODO(), &jobs.Items[i]); err != nil {
+			t.Fatalf("unexpected error updating job status: %v", err)
+		}
+	}
+	got = reconcileAt("complete", start.Add(30*time.Minute))
+	cond := meta.FindStatusCondition(got.Status.Conditions, string(jobset.JobSetCompleted))
+	if cond == nil || cond.Status != metav1.ConditionTrue {
+		t.Fatalf("expected the jobset to be completed, got conditions %v", got.Status.Conditions)
+	}
+	wantDuration := &metav1.Duration{Duration: cond.LastTransitionTime.Sub(start)}
+	if diff := cmp.Diff(wantDuration, got.Status.Duration); diff != "" {
+		t.Errorf("unexpected duration (-want/+got): %s", diff)
+	}
+}
+
+func TestReconcileFinishedJobSetShortCircuit(t *testing.T) {
+	tests := []struct {
+		name string
+		// restartTrigger is the value of the restart annotation set after the JobSet completed.
+		restartTrigger string
+		wantJobs       []string
+		wantRestarts   int32
+		wantFinished   bool
+		// wantMinimalWork is true if no pods must be listed and nothing must be created or updated.
+		wantMinimalWork bool
+	}{
+		{
+			name:            "completed jobset only cleans up",
+			wantJobs:        []string{"test-jobset-workers-0"},
+			wantFinished:    true,
+			wantMinimalWork: true,
+		},
+		{
+			name:           "restart annotation restarts completed jobset",
+			restartTrigger: "restart",
+			wantJobs:       []string{"test-jobset-workers-0", "test-jobset-workers-1", "test-jobset-workers-2"},
+			wantRestarts:   1,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet("test-jobset", "default").
+				Finalizers([]string{jobset.CleanupFinalizer}).
+				SuccessPolicy(&jobset.SuccessPolicy{
+					Operator:      jobset.OperatorAll,
+					PodAnnotation: &jobset.PodAnnotationSuccessCondition{Key: "done", Value: "true"},
+				}).
+				EnableDNSHostnames(true).
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").
+					Job(testutils.MakeJobTemplate("job", "default").Obj()).
+					Replicas(3).
+					Obj()).
+				CompletedCondition(metav1.Now()).
+				Obj()
+			js.UID = "test-uid"
+			js.Spec.ReplicatedJobs[0].Template.Spec.Parallelism = ptr.To[int32](1)
+			if tc.restartTrigger != "" {
+				js.Annotations = map[string]string{jobset.RestartTriggerKey: tc.restartTrigger}
+			}
+			childJob := func(jobIdx int, condition batchv1.JobConditionType) *batchv1.Job {
+				job := makeJob(&makeJobArgs{
+					jobSetName:        js.Name,
+					replicatedJobName: "workers",
+					jobName:           fmt.Sprintf("%s-workers-%d", js.Name, jobIdx),
+					ns:                js.Namespace,
+					replicas:          3,
+					jobIdx:            jobIdx,
+				}).Parallelism(1).Obj()
+				job.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(js, jobset.GroupVersion.WithKind("JobSet"))}
+				if condition != "" {
+					job.Status.Conditions = []batchv1.JobCondition{{Type: condition, Status: corev1.ConditionTrue}}
+				}
+				return job
+			}
+
+			var podLists, creates, updates int
+			fakeClient := newFakeClientBuilder().
+				WithObjects(js, childJob(0, batchv1.JobComplete), childJob(1, "")).
+				WithStatusSubresource(js).
+				WithInterceptorFuncs(withServerSideApply(interceptor.Funcs{
+					List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+						if _, ok := list.(*corev1.PodList); ok {
+							podLists++
+						}
+						return c.List(ctx, list, opts...)
+					},
+					Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+						creates++
+						return c.Create(ctx, obj, opts...)
+					},
+					SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+						updates++
+						return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+					},
+				})).
+				Build()
+			r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{})
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: js.Name, Namespace: js.Namespace}}
+
+			// The restarted JobSet deletes the Jobs of the previous run and then recreates them.
+			reconcileJobSet(t, r, req, 3)
+
+			if minimalWork := podLists == 0 && creates == 0 && updates == 0; minimalWork != tc.wantMinimalWork {
+				t.Errorf("unexpected work done, want minimal work %v, got %d pod lists, %d creates and %d status updates", tc.wantMinimalWork, podLists, creates, updates)
+			}
+			var jobs batchv1.JobList
+			if err := fakeClient.List(context.TODO(), &jobs, client.InNamespace(js.Namespace)); err != nil {
+				t.Fatalf("unexpected error listing jobs: %v", err)
+			}
+			var gotJobs []string
+			for _, job := range jobs.Items {
+				gotJobs = append(gotJobs, job.Name)
+			}
+			if diff := cmp.Diff(tc.wantJobs, gotJobs); diff != "" {
+				t.Errorf("unexpected jobs (-want/+got): %s", diff)
+			}
+			var got jobset.JobSet
+			if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+				t.Fatalf("unexpected error getting jobset: %v", err)
+			}
+			if got.Status.Restarts != tc.wantRestarts || got.Status.ObservedRestartTrigger != tc.restartTrigger {
+				t.Errorf("unexpected restarts %d and observed trigger %q, want %d and %q", got.Status.Restarts, got.Status.ObservedRestartTrigger, tc.wantRestarts, tc.restartTrigger)
+			}
+			if finished := JobSetFinished(&got); finished != tc.wantFinished {
+				t.Errorf("unexpected finished jobset, want %v, got %v with conditions %v", tc.wantFinished, finished, got.Status.Conditions)
+			}
+		})
+	}
+}
+
+func TestReconcileZeroReplicaReplicatedJob(t *testing.T) {
+	tests := []struct {
+		name           string
+		workerReplicas int32
+		wantCreated    []string
+		wantStatuses   []jobset.ReplicatedJobStatus
+		// wantCompletedOnCreate is true if the JobSet completes without any job succeeding.
+		wantCompletedOnCreate bool
+	}{
+		{
+			name:           "zero replica replicated job creates no jobs and does not block completion",
+			workerReplicas: 2,
+			wantCreated:    []string{"test-jobset-workers-0", "test-jobset-workers-1"},
+			wantStatuses: []jobset.ReplicatedJobStatus{
+				{Name: "workers", Succeeded: 2},
+				{Name: "disabled"},
+			},
+		},
+		{
+			name:                  "jobset with only zero replica replicated jobs completes right away",
+			wantCompletedOnCreate: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet("test-jobset", "default").
+				Finalizers([]string{jobset.CleanupFinalizer}).
+				SuccessPolicy(&jobset.SuccessPolicy{Operator: jobset.OperatorAll}).
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").Replicas(tc.workerReplicas).Obj()).
+				ReplicatedJob(testutils.MakeReplicatedJob("disabled").Replicas(0).Obj()).
+				Obj()
+			js.UID = "test-uid"
+			var created []string
+			fakeClient := newFakeClientBuilder().
+				WithObjects(js).
+				WithStatusSubresource(js).
+				WithInterceptorFuncs(withServerSideApply(interceptor.Funcs{
+					Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+						if _, ok := obj.(*batchv1.Job); ok {
+							created = append(created, obj.GetName())
+						}
+						return c.Create(ctx, obj, opts...)
+					},
+				})).
+				Build()
+			r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{})
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: js.Name, Namespace: js.Namespace}}
+			getJobSet := func() *jobset.JobSet {
+				t.Helper()
+				var got jobset.JobSet
+				if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+					t.Fatalf("unexpected error getting jobset: %v", err)
+				}
+				return &got
+			}
+
+			reconcileJobSet(t, r, req, 1)
+			if diff := cmp.Diff(tc.wantCreated, created, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("unexpected created jobs (-want/+got): %s", diff)
+			}
+			if got := JobSetFinished(getJobSet()); got != tc.wantCompletedOnCreate {
+				t.Fatalf("expected jobset finished to be %t after the first reconcile, got %t", tc.wantCompletedOnCreate, got)
+			}
+			if tc.wantCompletedOnCreate {
+				return
+			}
+
+			// Complete the jobs of the workers.
+			var jobs batchv1.JobList
+			if err := fakeClient.List(context.TODO(), &jobs, client.InNamespace(js.Namespace)); err != nil {
+				t.Fatalf("unexpected error listing jobs: %v", err)
+			}
+			for i := range jobs.Items {
+				jobs.Items[i].Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+				if err := fakeClient.Status().Update(context.TODO(), &jobs.Items[i]); err != nil {
+					t.Fatalf("unexpected error updating job status: %v", err)
+				}
+			}
+			reconcileJobSet(t, r, req, 1)
+			got := getJobSet()
+			if !meta.IsStatusConditionTrue(got.Status.Conditions, string(jobset.JobSetCompleted)) {
+				t.Errorf("expected jobset to be completed, got conditions %v", got.Status.Conditions)
+			}
+			if diff := cmp.Diff(tc.wantStatuses, got.Status.ReplicatedJobsStatus); diff != "" {
+				t.Errorf("unexpected replicated job statuses (-want/+got): %s", diff)
+			}
+		})
+	}
+}
+
+// testScheme contains the JobSet API and the built-in types, like the scheme of the manager.
+var testScheme = func() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(jobset.AddToScheme(scheme))
+	return scheme
+}()
+
+// newFakeClientBuilder returns a fake client builder using testScheme and the Job owner index of
+// the JobSet controller, which emulates server-side applies, see withServerSideApply.
+func newFakeClientBuilder() *fake.ClientBuilder {
+	return fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithIndex(&batchv1.Job{}, constants.JobOwnerKey, jobOwnerIndexFunc).
+		WithInterceptorFuncs(withServerSideApply(interceptor.Funcs{}))
+}
+
+// reconcileJobSet reconciles the JobSet of the request the given number of times, failing the test
+// on a reconcile error, and returns the result of the last reconciliation.
+func reconcileJobSet(t *testing.T, r *JobSetReconciler, req ctrl.Request, times int) ctrl.Result {
+	t.Helper()
+	var result ctrl.Result
+	for i := 0; i < times; i++ {
+		var err error
+		if result, err = r.Reconcile(context.TODO(), req); err != nil {
+			t.Fatalf("unexpected reconcile error: %v", err)
+		}
+	}
+	return result
+}
+
+func TestSetPausedCondition(t *testing.T) {
+	js := testutils.MakeJobSet("js", "default").Obj()
+	opts := &statusUpdateOpts{}
+
+	// No condition is added to a JobSet which was never paused.
+	setPausedCondition(js, opts)
+	if len(js.Status.Conditions) != 0 || opts.shouldUpdate {
+		t.Fatalf("expected no condition, got %v", js.Status.Conditions)
+	}
+
+	js.Spec.Paused = ptr.To(true)
+	setPausedCondition(js, opts)
+	if !meta.IsStatusConditionTrue(js.Status.Conditions, string(jobset.JobSetPaused)) {
+		t.Fatalf("expected %s condition to be true, got %v", jobset.JobSetPaused, js.Status.Conditions)
+	}
+
+	js.Spec.Paused = ptr.To(false)
+	setPausedCondition(js, opts)
+	if !meta.IsStatusConditionFalse(js.Status.Conditions, string(jobset.JobSetPaused)) {
+		t.Errorf("expected %s condition to be false, got %v", jobset.JobSetPaused, js.Status.Conditions)
+	}
+}
+
+func TestReconcilePausedJobSet(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	js := testutils.MakeJobSet(jobSetName, ns).
+		Finalizers([]string{jobset.CleanupFinalizer}).
+		FailurePolicy(&jobset.FailurePolicy{MaxRestarts: 3}).
+		ReplicatedJob(testutils.MakeReplicatedJob("workers").Replicas(2).Obj()).
+		Obj()
+	js.Spec.Paused = ptr.To(true)
+	js.UID = "test-uid"
+	// The first job failed and the second one is missing, so an active JobSet would be
+	// restarted, and the missing job would be created.
+	failedJob := makeJob(&makeJobArgs{
+		jobSetName:        jobSetName,
+		replicatedJobName: "workers",
+		jobName:           placement.GenJobName(jobSetName, "workers", 0),
+		ns:                ns,
+		replicas:          2,
+		jobIdx:            0,
+	}).Obj()
+	failedJob.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(js, jobset.GroupVersion.WithKind("JobSet"))}
+	failedJob.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}}
+
+	var creates, deletes int
+	fakeClient := newFakeClientBuilder().
+		WithObjects(js, failedJob).
+		WithStatusSubresource(js).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				creates++
+				return c.Create(ctx, obj, opts...)
+			},
+			Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+				deletes++
+				return c.Delete(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{})
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: jobSetName, Namespace: ns}}
+	getJobSet := func() *jobset.JobSet {
+		t.Helper()
+		var got jobset.JobSet
+		if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+			t.Fatalf("unexpected error getting jobset: %v", err)
+		}
+		return &got
+	}
+
+	reconcileJobSet(t, r, req, 2)
+	if creates != 0 || deletes != 0 {
+		t.Errorf("expected no jobs to be created or deleted while paused, got %d creates and %d deletes", creates, deletes)
+	}
+	got := getJobSet()
+	if got.Status.Restarts != 0 {
+		t.Errorf("expected paused jobset not to be restarted, got %d restarts", got.Status.Restarts)
+	}
+	if !meta.IsStatusConditionTrue(got.Status.Conditions, string(jobset.JobSetPaused)) {
+		t.Errorf("expected %s condition to be true, got %v", jobset.JobSetPaused, got.Status.Conditions)
+	}
+
+	// Once unpaused, the failure policy is executed.
+	got.Spec.Paused = ptr.To(false)
+	if err := fakeClient.Update(context.TODO(), got); err != nil {
+		t.Fatalf("unexpected error updating jobset: %v", err)
+	}
+	reconcileJobSet(t, r, req, 1)
+	got = getJobSet()
+	if got.Status.Restarts != 1 {
+		t.Errorf("expected unpaused jobset to be restarted, got %d restarts", got.Status.Restarts)
+	}
+	if !meta.IsStatusConditionFalse(got.Status.Conditions, string(jobset.JobSetPaused)) {
+		t.Errorf("expected %s condition to be false, got %v", jobset.JobSetPaused, got.Status.Conditions)
+	}
+}
+
+func TestReconcileAdmissionPendingCondition(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	pendingCondition := metav1.Condition{
+		Type:   string(jobset.JobSetAdmissionPending),
+		Status: metav1.ConditionTrue,
+		Reason: constants.AdmissionPendingReason,
+	}
+
+	tests := []struct {
+		name       string
+		managedBy  string
+		suspend    bool
+		conditions []metav1.Condition
+		// wantCondition is the status of the AdmissionPending condition, if any.
+		wantCondition metav1.ConditionStatus
+	}{
+		{
+			name:          "suspended jobset managed by an external controller is pending",
+			managedBy:     "kueue.x-k8s.io/multikueue",
+			suspend:       true,
+			wantCondition: metav1.ConditionTrue,
+		},
+		{
+			name:          "resumed jobset managed by an external controller is no longer pending",
+			managedBy:     "kueue.x-k8s.io/multikueue",
+			conditions:    []metav1.Condition{pendingCondition},
+			wantCondition: metav1.ConditionFalse,
+		},
+		{
+			name:      "jobset managed by an external controller which was never suspended",
+			managedBy: "kueue.x-k8s.io/multikueue",
+		},
+		{
+			name:      "suspended jobset managed by the jobset controller",
+			managedBy: jobset.JobSetControllerName,
+			suspend:   true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet(jobSetName, ns).
+				ManagedBy(tc.managedBy).
+				Suspend(tc.suspend).
+				Conditions(tc.conditions).
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").Replicas(1).Obj()).
+				Obj()
+			fakeClient := newFakeClientBuilder().
+				WithObjects(js).
+				WithStatusSubresource(js).
+				Build()
+			r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{})
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: jobSetName, Namespace: ns}}
+			reconcileJobSet(t, r, req, 1)
+
+			var got jobset.JobSet
+			if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+				t.Fatalf("unexpected error getting jobset: %v", err)
+			}
+			var gotCondition metav1.ConditionStatus
+			if cond := meta.FindStatusCondition(got.Status.Conditions, string(jobset.JobSetAdmissionPending)); cond != nil {
+				gotCondition = cond.Status
+			}
+			if gotCondition != tc.wantCondition {
+				t.Errorf("expected %s condition status %q, got %q", jobset.JobSetAdmissionPending, tc.wantCondition, gotCondition)
+			}
+		})
+	}
+}
+
+func TestReconcileDrain(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	tests := []struct {
+		name          string
+		lifecycle     jobset.JobSetLifecycle
+		jobConditions []*batchv1.JobConditionType
+		wantRestarts  int32
+		wantCondition jobset.JobSetConditionType
+		wantReason    string
+		wantJobs      int
+	}{
+		{
+			name:          "failed job restarts the jobset without drain",
+			lifecycle:     jobset.JobSetLifecycleNormal,
+			jobConditions: []*batchv1.JobConditionType{ptr.To(batchv1.JobFailed), nil},
+			wantRestarts:  1,
+			wantJobs:      2,
+		},
+		{
+			name:          "failed job does not restart a draining jobset with active jobs",
+			lifecycle:     jobset.JobSetLifecycleDrain,
+			jobConditions: []*batchv1.JobConditionType{ptr.To(batchv1.JobFailed), nil},
+			wantJobs:      2,
+		},
+		{
+			name:          "draining jobset fails once no jobs are active",
+			lifecycle:     jobset.JobSetLifecycleDrain,
+			jobConditions: []*batchv1.JobConditionType{ptr.To(batchv1.JobFailed), ptr.To(batchv1.JobComplete)},
+			wantCondition: jobset.JobSetFailed,
+			wantReason:    constants.DrainedReason,
+			wantJobs:      2,
+		},
+		{
+			name:          "draining jobset completes once no jobs are active",
+			lifecycle:     jobset.JobSetLifecycleDrain,
+			jobConditions: []*batchv1.JobConditionType{ptr.To(batchv1.JobComplete)},
+			wantCondition: jobset.JobSetCompleted,
+			wantReason:    constants.AllJobsCompletedReason,
+			wantJobs:      1,
+		},
+		{
+			name:      "draining jobset creates no jobs",
+			lifecycle: jobset.JobSetLifecycleDrain,
+			// With no active jobs and no failures, the JobSet completes right away.
+			wantCondition: jobset.JobSetCompleted,
+			wantReason:    constants.AllJobsCompletedReason,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet(jobSetName, ns).
+				SuccessPolicy(&jobset.SuccessPolicy{Operator: jobset.OperatorAll}).
+				FailurePolicy(&jobset.FailurePolicy{MaxRestarts: 3}).
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").Replicas(2).Obj()).
+				Obj()
+			js.UID = "test-uid"
+			js.Spec.Lifecycle = tc.lifecycle
+			objs := []client.Object{js}
+			for idx, condition := range tc.jobConditions {
+				job := makeJob(&makeJobArgs{
+					jobSetName:        jobSetName,
+					replicatedJobName: "workers",
+					jobName:           placement.GenJobName(jobSetName, "workers", idx),
+					ns:                ns,
+					replicas:          2,
+					jobIdx:            idx,
+				}).Obj()
+				job.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(js, jobset.GroupVersion.WithKind("JobSet"))}
+				job.Spec.Parallelism = ptr.To[int32](1)
+				if condition != nil {
+					job.Status.Conditions = []batchv1.JobCondition{{Type: *condition, Status: corev1.ConditionTrue, LastTransitionTime: metav1.Now()}}
+				}
+				objs = append(objs, job)
+			}
+
+			fakeClient := newFakeClientBuilder().
+				WithObjects(objs...).
+				WithStatusSubresource(js).
+				Build()
+			r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{})
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: jobSetName, Namespace: ns}}
+			reconcileJobSet(t, r, req, 1)
+
+			var got jobset.JobSet
+			if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+				t.Fatalf("unexpected error getting jobset: %v", err)
+			}
+			if got.Status.Restarts != tc.wantRestarts {
+				t.Errorf("unexpected restarts: got %d, want %d", got.Status.Restarts, tc.wantRestarts)
+			}
+			for _, conditionType := range []jobset.JobSetConditionType{jobset.JobSetCompleted, jobset.JobSetFailed} {
+				cond := meta.FindStatusCondition(got.Status.Conditions, string(conditionType))
+				wantTrue := conditionType == tc.wantCondition
+				if gotTrue := cond != nil && cond.Status == metav1.ConditionTrue; gotTrue != wantTrue {
+					t.Errorf("unexpected %s condition: got %t, want %t", conditionType, gotTrue, wantTrue)
+				}
+				if wantTrue && cond.Reason != tc.wantReason {
+					t.Errorf("unexpected %s condition reason: got %q, want %q", conditionType, cond.Reason, tc.wantReason)
+				}
+			}
+
+			var jobs batchv1.JobList
+			if err := fakeClient.List(context.TODO(), &jobs, client.InNamespace(ns)); err != nil {
+				t.Fatalf("unexpected error listing jobs: %v", err)
+			}
+			if len(jobs.Items) != tc.wantJobs {
+				t.Errorf("unexpected number of jobs: got %d, want %d", len(jobs.Items), tc.wantJobs)
+			}
+		})
+	}
+}
+
+func TestReconcileTimeout(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	js := testutils.MakeJobSet(jobSetName, ns).
+		SuccessPolicy(&jobset.SuccessPolicy{Operator: jobset.OperatorAll}).
+		ReplicatedJob(testutils.MakeReplicatedJob("workers").Replicas(3).Obj()).
+		Obj()
+	js.Spec.ReplicatedJobs[0].Template.Spec.Parallelism = ptr.To[int32](1)
+	js.UID = "test-uid"
+
+	// While slow, the API server only creates the first Job, the other creations hang until
+	// their request is canceled.
+	var lock sync.Mutex
+	slow := true
+	var creates, failedCreates int
+	fakeClient := newFakeClientBuilder().
+		WithObjects(js).
+		WithStatusSubresource(js).
+		WithInterceptorFuncs(withServerSideApply(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if _, ok := obj.(*batchv1.Job); ok {
+					lock.Lock()
+					creates++
+					hang := slow && creates > 1
+					if hang {
+						failedCreates++
+					}
+					lock.Unlock()
+					if hang {
+						<-ctx.Done()
+						return ctx.Err()
+					}
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		})).
+		Build()
+	r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(100), JobSetReconcilerOptions{ReconcileTimeout: 50 * time.Millisecond})
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: jobSetName, Namespace: ns}}
+	listJobs := func() []batchv1.Job {
+		t.Helper()
+		var jobs batchv1.JobList
+		if err := fakeClient.List(context.TODO(), &jobs, client.InNamespace(ns)); err != nil {
+			t.Fatalf("unexpected error listing jobs: %v", err)
+		}
+		return jobs.Items
+	}
+
+	// The reconciliation is cut short by the timeout and requeued without an error.
+	start := time.Now()
+	result := reconcileJobSet(t, r, req, 1)
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("reconciliation did not respect its timeout, took %v", elapsed)
+	}
+	if !result.Requeue {
+		t.Errorf("expected the timed out reconciliation to be requeued, got %+v", result)
+	}
+	if failedCreates == 0 {
+		t.Fatalf("expected job creations to hang")
+	}
+	if got := len(listJobs()); got != 1 {
+		t.Fatalf("expected 1 job created before the timeout, got %d", got)
+	}
+	var got jobset.JobSet
+	if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+		t.Fatalf("unexpected error getting jobset: %v", err)
+	}
+	if got.Status.ReconcileErrors != 0 {
+		t.Errorf("expected the timeout not to count as a reconcile error, got %d errors", got.Status.ReconcileErrors)
+	}
+
+	// The next reconciliation continues from the job already created.
+	lock.Lock()
+	slow = false
+	lock.Unlock()
+	result = reconcileJobSet(t, r, req, 1)
+	if result.Requeue {
+		t.Errorf("unexpected requeue of a completed reconciliation")
+	}
+	jobs := listJobs()
+	if len(jobs) != 3 {
+		t.Fatalf("expected 3 jobs, got %d", len(jobs))
+	}
+	for i, job := range jobs {
+		if want := placement.GenJobName(jobSetName, "workers", i); job.Name != want {
+			t.Errorf("unexpected job name: got %s, want %s", job.Name, want)
+		}
+	}
+}
+
+func TestReconcileErrorBackoff(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	tests := []struct {
+		name string
+		opts JobSetReconcilerOptions
+		// errors is the number of reconciliations failing to create the child Jobs.
+		errors         int
+		wantErr        bool
+		wantRequeue    time.Duration
+		wantErrors     int32
+		wantBackingOff bool
+	}{
+		{
+			name:    "backoff disabled",
+			errors:  5,
+			wantErr: true,
+		},
+		{
+			name:       "errors below the threshold",
+			opts:       JobSetReconcilerOptions{ReconcileErrorBackoffThreshold: 3},
+			errors:     2,
+			wantErr:    true,
+			wantErrors: 2,
+		},
+		{
+			name:           "errors reach the threshold",
+			opts:           JobSetReconcilerOptions{ReconcileErrorBackoffThreshold: 3},
+			errors:         3,
+			wantRequeue:    constants.DefaultReconcileErrorBackoff,
+			wantErrors:     3,
+			wantBackingOff: true,
+		},
+		{
+			name:           "errors beyond the threshold",
+			opts:           JobSetReconcilerOptions{ReconcileErrorBackoffThreshold: 3, ReconcileErrorBackoff: time.Hour},
+			errors:         5,
+			wantRequeue:    time.Hour,
+			wantErrors:     3,
+			wantBackingOff: true,
 		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			r := JobSetReconciler{Client: (fake.NewClientBuilder()).Build()}
-			statuses := r.calculateReplicatedJobStatuses(context.TODO(), tc.js, &tc.jobs)
-			less := func(a, b jobset.ReplicatedJobStatus) bool {
-				return a.Name < b.Name
+			js := testutils.MakeJobSet(jobSetName, ns).
+				SuccessPolicy(&jobset.SuccessPolicy{Operator: jobset.OperatorAll}).
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").Replicas(1).Obj()).
+				Obj()
+			js.UID = "test-uid"
+
+			failCreate := true
+			fakeClient := newFakeClientBuilder().
+				WithObjects(js).
+				WithStatusSubresource(js).
+				WithInterceptorFuncs(withServerSideApply(interceptor.Funcs{
+					Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+						if _, ok := obj.(*batchv1.Job); ok && failCreate {
+							return k8serrors.NewBadRequest("invalid job template")
+						}
+						return c.Create(ctx, obj, opts...)
+					},
+				})).
+				Build()
+			r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(100), tc.opts)
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: jobSetName, Namespace: ns}}
+
+			var result ctrl.Result
+			var err error
+			for i := 0; i < tc.errors; i++ {
+				result, err = r.Reconcile(context.TODO(), req)
 			}
-			if diff := cmp.Diff(tc.expected, statuses, cmpopts.SortSlices(less)); diff != "" {
-				t.Errorf("calculateReplicatedJobStatuses() mismatch (-want +got):\n%s", diff)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("unexpected reconcile error: got %v, want error %t", err, tc.wantErr)
+			}
+			if result.RequeueAfter != tc.wantRequeue {
+				t.Errorf("unexpected requeue: got %v, want %v", result.RequeueAfter, tc.wantRequeue)
+			}
+			var got jobset.JobSet
+			if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+				t.Fatalf("unexpected error getting jobset: %v", err)
+			}
+			if got.Status.ReconcileErrors != tc.wantErrors {
+				t.Errorf("unexpected reconcile errors: got %d, want %d", got.Status.ReconcileErrors, tc.wantErrors)
+			}
+			if backingOff := meta.IsStatusConditionTrue(got.Status.Conditions, string(jobset.JobSetReconcileBackingOff)); backingOff != tc.wantBackingOff {
+				t.Errorf("unexpected ReconcileBackingOff condition: got %t, want %t", backingOff, tc.wantBackingOff)
+			}
+
+			// A successful reconciliation resets the errors and the backoff.
+			failCreate = false
+			reconcileJobSet(t, r, req, 1)
+			if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+				t.Fatalf("unexpected error getting jobset: %v", err)
+			}
+			if got.Status.ReconcileErrors != 0 {
+				t.Errorf("expected reconcile errors to be reset, got %d", got.Status.ReconcileErrors)
+			}
+			if meta.IsStatusConditionTrue(got.Status.Conditions, string(jobset.JobSetReconcileBackingOff)) {
+				t.Errorf("expected ReconcileBackingOff condition to be reset, got conditions %v", got.Status.Conditions)
 			}
 		})
 	}
 }
 
-func TestFindFirstFailedJob(t *testing.T) {
-	testCases := []struct {
-		name       string
-		failedJobs []*batchv1.Job
-		expected   *batchv1.Job
+func TestIncompleteJobListing(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	job := func(rjobName string, jobIdx int) *batchv1.Job {
+		return makeJob(&makeJobArgs{
+			jobSetName:        jobSetName,
+			replicatedJobName: rjobName,
+			jobName:           placement.GenJobName(jobSetName, rjobName, jobIdx),
+			ns:                ns,
+			replicas:          2,
+			jobIdx:            jobIdx,
+		}).Obj()
+	}
+	tests := []struct {
+		name     string
+		statuses []jobset.ReplicatedJobStatus
+		jobs     childJobs
+		want     bool
 	}{
 		{
-			name:       "No failed jobs",
-			failedJobs: []*batchv1.Job{},
-			expected:   nil,
+			name: "no status yet",
+			jobs: childJobs{active: []*batchv1.Job{job("workers", 0)}},
 		},
 		{
-			name: "Single failed job",
-			failedJobs: []*batchv1.Job{
-				jobWithFailedCondition("job1", time.Now().Add(-1*time.Hour)),
-			},
-			expected: jobWithFailedCondition("job1", time.Now().Add(-1*time.Hour)),
+			name:     "all finished jobs listed",
+			statuses: []jobset.ReplicatedJobStatus{{Name: "workers", Succeeded: 1, Failed: 1}},
+			jobs:     childJobs{successful: []*batchv1.Job{job("workers", 0)}, failed: []*batchv1.Job{job("workers", 1)}},
 		},
 		{
-			name: "Multiple failed jobs, earliest first",
-			failedJobs: []*batchv1.Job{
-				jobWithFailedCondition("job1", time.Now().Add(-3*time.Hour)),
-				jobWithFailedCondition("job2", time.Now().Add(-5*time.Hour)),
-			},
-			expected: jobWithFailedCondition("job2", time.Now().Add(-5*time.Hour)),
+			name:     "finished jobs counted as active are listed",
+			statuses: []jobset.ReplicatedJobStatus{{Name: "workers", Succeeded: 2}},
+			jobs:     childJobs{active: []*batchv1.Job{job("workers", 0), job("workers", 1)}},
 		},
 		{
-			name: "Jobs without failed condition",
-			failedJobs: []*batchv1.Job{
-				{ObjectMeta: metav1.ObjectMeta{Name: "job1"}},
-				{ObjectMeta: metav1.ObjectMeta{Name: "job2"}},
+			name:     "jobs of the previous run are listed",
+			statuses: []jobset.ReplicatedJobStatus{{Name: "workers", Succeeded: 1, Failed: 1}},
+			jobs:     childJobs{delete: []*batchv1.Job{job("workers", 0), job("workers", 1)}},
+		},
+		{
+			name:     "finished job missing from the listing",
+			statuses: []jobset.ReplicatedJobStatus{{Name: "workers", Succeeded: 2}},
+			jobs:     childJobs{successful: []*batchv1.Job{job("workers", 0)}},
+			want:     true,
+		},
+		{
+			name: "jobs of another replicated job don't make up for missing jobs",
+			statuses: []jobset.ReplicatedJobStatus{
+				{Name: "leader", Succeeded: 1},
+				{Name: "workers", Succeeded: 1},
 			},
-			expected: nil,
+			jobs: childJobs{successful: []*batchv1.Job{job("workers", 0), job("workers", 1)}},
+			want: true,
 		},
 	}
-
-	for _, tc := range testCases {
-		tc := tc
+	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			result := findFirstFailedJob(tc.failedJobs)
-			if result != nil && tc.expected != nil {
-				assert.Equal(t, result.Name, tc.expected.Name)
-			} else if result != nil && tc.expected == nil || result == nil && tc.expected != nil {
-				t.Errorf("Expected: %v, got: %v)", result, tc.expected)
+			js := testutils.MakeJobSet(jobSetName, ns).Obj()
+			js.Status.ReplicatedJobsStatus = tc.statuses
+			if got := incompleteJobListing(js, &tc.jobs); got != tc.want {
+				t.Errorf("incompleteJobListing() = %v, want %v", got, tc.want)
 			}
 		})
 	}
 }
 
-// Helper function to create a job object with a failed condition
-func jobWithFailedCondition(name string, failureTime time.Time) *batchv1.Job {
-	return &batchv1.Job{
-		ObjectMeta: metav1.ObjectMeta{Name: name},
-		Status: batchv1.JobStatus{
-			Conditions: []batchv1.JobCondition{
-				{
-					Type:               batchv1.JobFailed,
-					Status:             corev1.ConditionTrue,
-					LastTransitionTime: metav1.NewTime(failureTime),
-				},
+func TestReconcileIncompleteJobListing(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	js := testutils.MakeJobSet(jobSetName, ns).
+		SuccessPolicy(&jobset.SuccessPolicy{Operator: jobset.OperatorAll}).
+		ReplicatedJob(testutils.MakeReplicatedJob("workers").
+			Job(testutils.MakeJobTemplate("job", ns).Obj()).
+			Replicas(3).
+			Obj()).
+		Obj()
+	js.Spec.ReplicatedJobs[0].Template.Spec.Parallelism = ptr.To[int32](1)
+
+	// While the listing is short, the API server only returns the first listed Job.
+	var lock sync.Mutex
+	short := false
+	fakeClient := newFakeClientBuilder().
+		WithObjects(js).
+		WithStatusSubresource(js).
+		WithInterceptorFuncs(withServerSideApply(interceptor.Funcs{
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				if err := c.List(ctx, list, opts...); err != nil {
+					return err
+				}
+				lock.Lock()
+				defer lock.Unlock()
+				if jobs, ok := list.(*batchv1.JobList); ok && short && len(jobs.Items) > 1 {
+					jobs.Items = jobs.Items[:1]
+				}
+				return nil
+			},
+		})).
+		Build()
+	r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(100), JobSetReconcilerOptions{})
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: jobSetName, Namespace: ns}}
+	reconcile := func() ctrl.Result {
+		t.Helper()
+		result := reconcileJobSet(t, r, req, 1)
+		return result
+	}
+	setShort := func(val bool) {
+		lock.Lock()
+		short = val
+		lock.Unlock()
+	}
+	completeJob := func(jobIdx int) {
+		t.Helper()
+		var job batchv1.Job
+		key := types.NamespacedName{Name: placement.GenJobName(jobSetName, "workers", jobIdx), Namespace: ns}
+		if err := fakeClient.Get(context.TODO(), key, &job); err != nil {
+			t.Fatalf("unexpected error getting job: %v", err)
+		}
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+		if err := fakeClient.Status().Update(context.TODO(), &job); err != nil {
+			t.Fatalf("unexpected error updating job: %v", err)
+		}
+	}
+	getJobSet := func() *jobset.JobSet {
+		t.Helper()
+		var got jobset.JobSet
+		if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+			t.Fatalf("unexpected error getting jobset: %v", err)
+		}
+		return &got
+	}
+
+	// Create the jobs, and observe two of them succeeding.
+	reconcile()
+	completeJob(0)
+	completeJob(1)
+	reconcile()
+	if got := findReplicatedJobStatus(getJobSet().Status.ReplicatedJobsStatus, "workers").Succeeded; got != 2 {
+		t.Fatalf("expected 2 succeeded jobs, got %d", got)
+	}
+
+	// The last job succeeds, but the listing misses the succeeded jobs, so the JobSet is neither
+	// completed nor are the missing jobs recreated.
+	completeJob(2)
+	setShort(true)
+	result := reconcile()
+	if result.RequeueAfter != constants.IncompleteJobListingRequeueInterval {
+		t.Errorf("unexpected requeue after: got %v, want %v", result.RequeueAfter, constants.IncompleteJobListingRequeueInterval)
+	}
+	got := getJobSet()
+	if meta.IsStatusConditionTrue(got.Status.Conditions, string(jobset.JobSetCompleted)) || meta.IsStatusConditionTrue(got.Status.Conditions, string(jobset.JobSetFailed)) {
+		t.Errorf("unexpected terminal condition on an incomplete listing: %+v", got.Status.Conditions)
+	}
+	if succeeded := findReplicatedJobStatus(got.Status.ReplicatedJobsStatus, "workers").Succeeded; succeeded != 2 {
+		t.Errorf("expected the status to be kept on an incomplete listing, got %d succeeded jobs", succeeded)
+	}
+
+	// Once the listing is complete again, the JobSet completes.
+	setShort(false)
+	reconcile()
+	var jobs batchv1.JobList
+	if err := fakeClient.List(context.TODO(), &jobs, client.InNamespace(ns)); err != nil {
+		t.Fatalf("unexpected error listing jobs: %v", err)
+	}
+	if len(jobs.Items) != 3 {
+		t.Errorf("expected 3 jobs, got %d", len(jobs.Items))
+	}
+	if !meta.IsStatusConditionTrue(getJobSet().Status.Conditions, string(jobset.JobSetCompleted)) {
+		t.Errorf("expected the jobset to be completed once the listing is complete")
+	}
+}
+
+func TestReconcileDeletedFinishedJob(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	js := testutils.MakeJobSet(jobSetName, ns).
+		SuccessPolicy(&jobset.SuccessPolicy{Operator: jobset.OperatorAll}).
+		ReplicatedJob(testutils.MakeReplicatedJob("workers").
+			Job(testutils.MakeJobTemplate("job", ns).Obj()).
+			Replicas(2).
+			Obj()).
+		ReplicatedJob(testutils.MakeReplicatedJob("leader").
+			Job(testutils.MakeJobTemplate("job", ns).Obj()).
+			Replicas(1).
+			Obj()).
+		Obj()
+	for i := range js.Spec.ReplicatedJobs {
+		js.Spec.ReplicatedJobs[i].Template.Spec.Parallelism = ptr.To[int32](1)
+	}
+	fakeClient := newFakeClientBuilder().
+		WithObjects(js).
+		WithStatusSubresource(js).
+		Build()
+	r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(100), JobSetReconcilerOptions{})
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: jobSetName, Namespace: ns}}
+	reconcile := func() ctrl.Result {
+		t.Helper()
+		result := reconcileJobSet(t, r, req, 1)
+		return result
+	}
+	succeeded := func() int32 {
+		t.Helper()
+		var got jobset.JobSet
+		if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+			t.Fatalf("unexpected error getting jobset: %v", err)
+		}
+		return findReplicatedJobStatus(got.Status.ReplicatedJobsStatus, "workers").Succeeded
+	}
+
+	// Create the jobs, and observe the workers succeeding while the leader is still running.
+	reconcile()
+	var job batchv1.Job
+	for jobIdx := 1; jobIdx >= 0; jobIdx-- {
+		key := types.NamespacedName{Name: placement.GenJobName(jobSetName, "workers", jobIdx), Namespace: ns}
+		if err := fakeClient.Get(context.TODO(), key, &job); err != nil {
+			t.Fatalf("unexpected error getting job: %v", err)
+		}
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+		if err := fakeClient.Status().Update(context.TODO(), &job); err != nil {
+			t.Fatalf("unexpected error updating job: %v", err)
+		}
+	}
+	reconcile()
+	if got := succeeded(); got != 2 {
+		t.Fatalf("expected 2 succeeded jobs, got %d", got)
+	}
+
+	// A succeeded job is deleted, e.g. by its ttlSecondsAfterFinished. The listing is retried a
+	// bounded number of times, after which it is taken as is instead of requeueing forever.
+	if err := fakeClient.Delete(context.TODO(), &job); err != nil {
+		t.Fatalf("unexpected error deleting job: %v", err)
+	}
+	for i := 0; i < constants.MaxIncompleteJobListingRetries; i++ {
+		if result := reconcile(); result.RequeueAfter != constants.IncompleteJobListingRequeueInterval {
+			t.Fatalf("retry %d: unexpected requeue after: got %v, want %v", i, result.RequeueAfter, constants.IncompleteJobListingRequeueInterval)
+		}
+	}
+	if result := reconcile(); result.RequeueAfter == constants.IncompleteJobListingRequeueInterval {
+		t.Errorf("expected the listing to be taken as is once the retries are exhausted")
+	}
+	if got := succeeded(); got != 1 {
+		t.Errorf("expected the status to reflect the deleted job, got %d succeeded jobs", got)
+	}
+	if result := reconcile(); result.RequeueAfter == constants.IncompleteJobListingRequeueInterval {
+		t.Errorf("expected the listing to be complete once the status reflects the deleted job")
+	}
+}
+
+func TestJobListingRetriesForgottenOnDeletion(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	tests := []struct {
+		name string
+		objs func(js *jobset.JobSet) []client.Object
+	}{
+		{
+			name: "jobset being deleted",
+			objs: func(js *jobset.JobSet) []client.Object {
+				js.Finalizers = []string{jobset.CleanupFinalizer}
+				js.DeletionTimestamp = ptr.To(metav1.Now())
+				return []client.Object{js}
 			},
 		},
+		{
+			name: "jobset not found",
+			objs: func(js *jobset.JobSet) []client.Object { return nil },
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet(jobSetName, ns).Obj()
+			js.UID = "uid"
+			fakeClient := newFakeClientBuilder().WithObjects(tc.objs(js.DeepCopy())...).Build()
+			r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(100), JobSetReconcilerOptions{})
+			r.jobListingRetries.retry(js)
+
+			reconcileJobSet(t, r, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(js)}, 1)
+			if len(r.jobListingRetries.retries) != 0 {
+				t.Errorf("expected the incomplete listings to be forgotten, got %v", r.jobListingRetries.retries)
+			}
+		})
 	}
 }
 
-type makeJobArgs struct {
-	jobSetName           string
-	replicatedJobName    string
-	jobName              string
-	ns                   string
-	replicas             int
-	jobIdx               int
-	restarts             int
-	topology             string
-	nodeSelectorStrategy bool
+func TestJobListingRetriesRecreatedJobSet(t *testing.T) {
+	js := testutils.MakeJobSet("test-jobset", "default").Obj()
+	js.UID = "old"
+	var l jobListingRetries
+	for i := 0; i < constants.MaxIncompleteJobListingRetries; i++ {
+		l.retry(js)
+	}
+
+	// A JobSet recreated with the same name doesn't inherit the retries of the previous one.
+	js.UID = "new"
+	if !l.retry(js) {
+		t.Errorf("expected the listing of the recreated jobset to be retried")
+	}
+	if got := l.retries[client.ObjectKeyFromObject(js)].count; got != 1 {
+		t.Errorf("expected 1 incomplete listing of the recreated jobset, got %d", got)
+	}
 }
 
-// Helper function to create a Job for unit testing.
-func makeJob(args *makeJobArgs) *testutils.JobWrapper {
-	labels := map[string]string{
-		jobset.JobSetNameKey:         args.jobSetName,
-		jobset.ReplicatedJobNameKey:  args.replicatedJobName,
-		jobset.ReplicatedJobReplicas: strconv.Itoa(args.replicas),
-		jobset.JobIndexKey:           strconv.Itoa(args.jobIdx),
-		constants.RestartsKey:        strconv.Itoa(args.restarts),
-		jobset.JobKey:                jobHashKey(args.ns, args.jobName),
+// withServerSideApply adds the emulation of server-side applies, which the fake client does not
+// support, to the given interceptor functions. An applied object is created if it doesn't exist
+// and replaces the existing object otherwise, using the Create and Update interceptors if set.
+func withServerSideApply(funcs interceptor.Funcs) interceptor.Funcs {
+	create, update, patch := funcs.Create, funcs.Update, funcs.Patch
+	funcs.Patch = func(ctx context.Context, c client.WithWatch, obj client.Object, p client.Patch, opts ...client.PatchOption) error {
+		if p.Type() != types.ApplyPatchType {
+			if patch != nil {
+				return patch(ctx, c, obj, p, opts...)
+			}
+			return c.Patch(ctx, obj, p, opts...)
+		}
+		patchOpts := (&client.PatchOptions{}).ApplyOptions(opts)
+		fieldOwner := client.FieldOwner(patchOpts.FieldManager)
+		existing := obj.DeepCopyObject().(client.Object)
+		if err := c.Get(ctx, client.ObjectKeyFromObject(obj), existing); err != nil {
+			if !k8serrors.IsNotFound(err) {
+				return err
+			}
+			if create != nil {
+				return create(ctx, c, obj, fieldOwner)
+			}
+			return c.Create(ctx, obj, fieldOwner)
+		}
+		obj.SetResourceVersion(existing.GetResourceVersion())
+		if update != nil {
+			return update(ctx, c, obj, fieldOwner)
+		}
+		return c.Update(ctx, obj, fieldOwner)
 	}
-	annotations := map[string]string{
-		jobset.JobSetNameKey:         args.jobSetName,
-		jobset.ReplicatedJobNameKey:  args.replicatedJobName,
-		jobset.ReplicatedJobReplicas: strconv.Itoa(args.replicas),
-		jobset.JobIndexKey:           strconv.Itoa(args.jobIdx),
-		constants.RestartsKey:        strconv.Itoa(args.restarts),
-		jobset.JobKey:                jobHashKey(args.ns, args.jobName),
+	return funcs
+}
+
+func TestServerSideApply(t *testing.T) {
+	tests := []struct {
+		name             string
+		fieldManager     string
+		wantFieldManager string
+	}{
+		{
+			name:             "default field manager",
+			wantFieldManager: constants.DefaultFieldManager,
+		},
+		{
+			name:             "configured field manager",
+			fieldManager:     "gitops-jobset",
+			wantFieldManager: "gitops-jobset",
+		},
 	}
-	// Only set exclusive key if we are using exclusive placement per topology.
-	if args.topology != "" {
-		annotations[jobset.ExclusiveKey] = args.topology
-		// Exclusive placement topology domain must be set in order to use the node selector strategy.
-		if args.nodeSelectorStrategy {
-			annotations[jobset.NodeSelectorStrategyKey] = "true"
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			jobTemplate := testutils.MakeJobTemplate("job", "default").Obj()
+			js := testutils.MakeJobSet("test-jobset", "default").
+				SuccessPolicy(&jobset.SuccessPolicy{Operator: jobset.OperatorAll}).
+				EnableDNSHostnames(true).
+				NetworkSubdomain("test-jobset").
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").Job(jobTemplate).Replicas(2).Obj()).
+				Obj()
+			// Record the kinds and field managers of the applies, and reject any other writes.
+			applies := map[string][]string{}
+			fakeClient := newFakeClientBuilder().
+				WithObjects(js).
+				WithStatusSubresource(js).
+				WithInterceptorFuncs(withServerSideApply(interceptor.Funcs{
+					Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+						createOpts := (&client.CreateOptions{}).ApplyOptions(opts)
+						kind := obj.GetObjectKind().GroupVersionKind().Kind
+						applies[kind] = append(applies[kind], createOpts.FieldManager)
+						return c.Create(ctx, obj, opts...)
+					},
+					Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+						updateOpts := (&client.UpdateOptions{}).ApplyOptions(opts)
+						kind := obj.GetObjectKind().GroupVersionKind().Kind
+						applies[kind] = append(applies[kind], updateOpts.FieldManager)
+						return c.Update(ctx, obj, opts...)
+					},
+				})).
+				Build()
+			r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(100), JobSetReconcilerOptions{FieldManager: tc.fieldManager})
+			if err := r.applyHeadlessSvc(context.TODO(), js); err != nil {
+				t.Fatalf("unexpected error applying headless service: %v", err)
+			}
+			if err := r.createJobs(context.TODO(), js, &childJobs{}, nil, &statusUpdateOpts{}); err != nil {
+				t.Fatalf("unexpected error creating jobs: %v", err)
+			}
+			want := map[string][]string{
+				"Service": {tc.wantFieldManager},
+				"Job":     {tc.wantFieldManager, tc.wantFieldManager},
+			}
+			if diff := cmp.Diff(want, applies); diff != "" {
+				t.Errorf("unexpected field managers of the applies (-want/+got): %s", diff)
+			}
+
+			// Applying the same objects again leaves them unchanged.
+			var svcBefore, svcAfter corev1.Service
+			var jobsBefore, jobsAfter batchv1.JobList
+			svcKey := types.NamespacedName{Name: js.Name, Namespace: js.Namespace}
+			if err := fakeClient.Get(context.TODO(), svcKey, &svcBefore); err != nil {
+				t.Fatalf("unexpected error getting headless service: %v", err)
+			}
+			if err := fakeClient.List(context.TODO(), &jobsBefore); err != nil {
+				t.Fatalf("unexpected error listing jobs: %v", err)
+			}
+			if err := r.applyHeadlessSvc(context.TODO(), js); err != nil {
+				t.Fatalf("unexpected error applying headless service again: %v", err)
+			}
+			if err := r.createJobs(context.TODO(), js, &childJobs{}, nil, &statusUpdateOpts{}); err != nil {
+				t.Fatalf("unexpected error applying jobs again: %v", err)
+			}
+			if err := fakeClient.Get(context.TODO(), svcKey, &svcAfter); err != nil {
+				t.Fatalf("unexpected error getting headless service: %v", err)
+			}
+			if err := fakeClient.List(context.TODO(), &jobsAfter); err != nil {
+				t.Fatalf("unexpected error listing jobs: %v", err)
+			}
+			// The emulated applies bump the resource versions, unlike server-side applies without changes.
+			objs := []client.Object{&svcBefore, &svcAfter}
+			for i := range jobsBefore.Items {
+				objs = append(objs, &jobsBefore.Items[i], &jobsAfter.Items[i])
+			}
+			for _, obj := range objs {
+				obj.SetResourceVersion("")
+			}
+			if diff := cmp.Diff(svcBefore, svcAfter); diff != "" {
+				t.Errorf("headless service changed by applying it again (-want/+got): %s", diff)
+			}
+			if diff := cmp.Diff(jobsBefore.Items, jobsAfter.Items); diff != "" {
+				t.Errorf("jobs changed by applying them again (-want/+got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestSuspendAndResumeJobsPatch(t *testing.T) {
+	js := testutils.MakeJobSet("test-jobset", "default").
+		ReplicatedJob(testutils.MakeReplicatedJob("workers").Replicas(1).Obj()).
+		Obj()
+	job := makeJob(&makeJobArgs{jobSetName: js.Name, replicatedJobName: "workers", jobName: "test-jobset-workers-0", ns: js.Namespace, replicas: 1}).Obj()
+	job.Status.StartTime = ptr.To(metav1.Now())
+
+	// Record the field managers and the contents of the patches, and reject applies and updates
+	// of the Jobs.
+	var fieldManagers []string
+	var patches []map[string]any
+	recordPatch := func(obj client.Object, patch client.Patch, fieldManager string) error {
+		if patch.Type() == types.ApplyPatchType {
+			t.Errorf("unexpected apply of %s", obj.GetName())
 		}
+		data, err := patch.Data(obj)
+		if err != nil {
+			return err
+		}
+		var content map[string]any
+		if err := json.Unmarshal(data, &content); err != nil {
+			return err
+		}
+		fieldManagers = append(fieldManagers, fieldManager)
+		patches = append(patches, content)
+		return nil
+	}
+	fakeClient := newFakeClientBuilder().
+		WithObjects(job).
+		WithStatusSubresource(job).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				if err := recordPatch(obj, patch, (&client.PatchOptions{}).ApplyOptions(opts).FieldManager); err != nil {
+					return err
+				}
+				return c.Patch(ctx, obj, patch, opts...)
+			},
+			SubResourcePatch: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+				if err := recordPatch(obj, patch, (&client.SubResourcePatchOptions{}).ApplyOptions(opts).FieldManager); err != nil {
+					return err
+				}
+				return c.SubResource(subResourceName).Patch(ctx, obj, patch, opts...)
+			},
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				t.Errorf("unexpected update of %s", obj.GetName())
+				return c.Update(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(100), JobSetReconcilerOptions{FieldManager: "gitops-jobset"})
+	getJob := func() *batchv1.Job {
+		t.Helper()
+		var got batchv1.Job
+		if err := fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(job), &got); err != nil {
+			t.Fatalf("unexpected error getting job: %v", err)
+		}
+		return &got
+	}
+
+	// A Job changed since it was read is not suspended.
+	stale := getJob()
+	stale.ResourceVersion = "1"
+	if err := r.suspendJobs(context.TODO(), js, []*batchv1.Job{stale}, &statusUpdateOpts{}); !k8serrors.IsConflict(err) {
+		t.Errorf("expected a conflict suspending a stale job, got %v", err)
+	}
+
+	fieldManagers, patches = nil, nil
+	if err := r.suspendJobs(context.TODO(), js, []*batchv1.Job{getJob()}, &statusUpdateOpts{}); err != nil {
+		t.Fatalf("unexpected error suspending jobs: %v", err)
+	}
+	if got := getJob(); !jobSuspended(got) || got.Labels[jobset.ReplicatedJobNameKey] != "workers" {
+		t.Errorf("expected the job to be suspended with its labels kept, got suspend %v and labels %v", got.Spec.Suspend, got.Labels)
+	}
+	nodeSelectors := map[string]map[string]string{"workers": {"pool": "a"}}
+	if err := r.resumeJob(context.TODO(), getJob(), nodeSelectors); err != nil {
+		t.Fatalf("unexpected error resuming job: %v", err)
+	}
+	got := getJob()
+	if jobSuspended(got) || got.Status.StartTime != nil {
+		t.Errorf("expected the job to be resumed with its start time cleared, got suspend %v and start time %v", got.Spec.Suspend, got.Status.StartTime)
+	}
+	if diff := cmp.Diff(nodeSelectors["workers"], got.Spec.Template.Spec.NodeSelector); diff != "" {
+		t.Errorf("unexpected node selector of the resumed job (-want/+got): %s", diff)
+	}
+	if diff := cmp.Diff([]string{"gitops-jobset", "gitops-jobset", "gitops-jobset"}, fieldManagers); diff != "" {
+		t.Errorf("unexpected field managers of the patches (-want/+got): %s", diff)
+	}
+	// The patches only contain the changed fields, along with the resource version they
+	// are conditional on.
+	for _, patch := range patches {
+		metadata, _ := patch["metadata"].(map[string]any)
+		if _, ok := metadata["resourceVersion"]; !ok || len(metadata) != 1 {
+			t.Errorf("expected the patch metadata to only contain the resource version, got %v", metadata)
+		}
+		delete(patch, "metadata")
+	}
+	wantPatches := []map[string]any{
+		{"spec": map[string]any{"suspend": true}},
+		{"status": map[string]any{"startTime": nil}},
+		{"spec": map[string]any{"suspend": false, "template": map[string]any{"spec": map[string]any{"nodeSelector": map[string]any{"pool": "a"}}}}},
+	}
+	if diff := cmp.Diff(wantPatches, patches); diff != "" {
+		t.Errorf("unexpected patches (-want/+got): %s", diff)
 	}
-	jobWrapper := testutils.MakeJob(args.jobName, args.ns).
-		JobLabels(labels).
-		JobAnnotations(annotations).
-		PodLabels(labels).
-		PodAnnotations(annotations)
-	return jobWrapper
 }
@@ -0,0 +1,332 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	jobset "sigs.k8s.io/jobset/api/jobset/v1alpha2"
+	"sigs.k8s.io/jobset/pkg/constants"
+)
+
+// Phases of a JobSet, reported in its phase label and status ConfigMap.
+const (
+	jobSetPhaseRunning   = "Running"
+	jobSetPhaseSuspended = "Suspended"
+	jobSetPhaseCompleted = "Completed"
+	jobSetPhaseFailed    = "Failed"
+)
+
+// jobSetPhase returns the phase of the JobSet derived from its conditions and spec.suspend.
+func jobSetPhase(js *jobset.JobSet) string {
+	switch {
+	case meta.IsStatusConditionTrue(js.Status.Conditions, string(jobset.JobSetCompleted)):
+		return jobSetPhaseCompleted
+	case meta.IsStatusConditionTrue(js.Status.Conditions, string(jobset.JobSetFailed)):
+		return jobSetPhaseFailed
+	case jobSetSuspended(js):
+		return jobSetPhaseSuspended
+	default:
+		return jobSetPhaseRunning
+	}
+}
+
+// updatePhaseLabel sets the phase label of the JobSet to its current phase, if it changed. Only
+// the phase label is patched, so the other labels of the JobSet are left untouched.
+func (r *JobSetReconciler) updatePhaseLabel(ctx context.Context, js *jobset.JobSet) error {
+	phase := jobSetPhase(js)
+	if js.Labels[jobset.JobSetPhaseKey] == phase {
+		return nil
+	}
+	patch := client.MergeFrom(js.DeepCopy())
+	if js.Labels == nil {
+		js.Labels = map[string]string{}
+	}
+	js.Labels[jobset.JobSetPhaseKey] = phase
+	if err := r.Patch(ctx, js, patch); err != nil {
+		return err
+	}
+	ctrl.LoggerFrom(ctx).V(2).Info("updated phase label", "phase", phase)
+	return nil
+}
+
+// syncStatusConfigMap creates or updates the status ConfigMap of a JobSet with the
+// StatusConfigMapKey annotation, so tools which can't watch JobSets can read its status.
+// The ConfigMap is owned by the JobSet, so it is garbage collected along with it.
+func (r *JobSetReconciler) syncStatusConfigMap(ctx context.Context, js *jobset.JobSet) error {
+	if _, ok := js.Annotations[jobset.StatusConfigMapKey]; !ok {
+		return nil
+	}
+	log := ctrl.LoggerFrom(ctx)
+
+	var cm corev1.ConfigMap
+	if err := r.Get(ctx, types.NamespacedName{Name: statusConfigMapName(js), Namespace: js.Namespace}, &cm); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+		cm := constructStatusConfigMap(js)
+
+		// Set controller owner reference for garbage collection.
+		if err := r.setOwnerReference(js, cm); err != nil {
+			return err
+		}
+
+		if err := r.Create(ctx, cm); err != nil {
+			return err
+		}
+		log.V(2).Info("successfully created status configmap", "configMap", klog.KObj(cm))
+		return nil
+	}
+
+	// Do not overwrite a ConfigMap with the same name which is not owned by the JobSet.
+	if !metav1.IsControlledBy(&cm, js) {
+		log.V(2).Info("skipping status configmap not controlled by the jobset", "configMap", klog.KObj(&cm))
+		return nil
+	}
+	data := statusConfigMapData(js)
+	if apiequality.Semantic.DeepEqual(cm.Data, data) {
+		return nil
+	}
+	cm.Data = data
+	return r.Update(ctx, &cm)
+}
+
+// constructStatusConfigMap returns the status ConfigMap of the JobSet.
+func constructStatusConfigMap(js *jobset.JobSet) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      statusConfigMapName(js),
+			Namespace: js.Namespace,
+			Labels: map[string]string{
+				jobset.JobSetNameKey: js.Name,
+			},
+		},
+		Data: statusConfigMapData(js),
+	}
+}
+
+// statusConfigMapName returns the name of the status ConfigMap of the JobSet.
+func statusConfigMapName(js *jobset.JobSet) string {
+	return js.Name + "-status"
+}
+
+// statusConfigMapData returns the data of the status ConfigMap of the JobSet, i.e. its phase
+// and restarts, and the number of Jobs of each replicated job in each state.
+func statusConfigMapData(js *jobset.JobSet) map[string]string {
+	data := map[string]string{
+		"phase":    jobSetPhase(js),
+		"restarts": strconv.Itoa(int(js.Status.Restarts)),
+	}
+	for _, status := range js.Status.ReplicatedJobsStatus {
+		data[status.Name+".ready"] = strconv.Itoa(int(status.Ready))
+		data[status.Name+".succeeded"] = strconv.Itoa(int(status.Succeeded))
+		data[status.Name+".failed"] = strconv.Itoa(int(status.Failed))
+		data[status.Name+".active"] = strconv.Itoa(int(status.Active))
+		data[status.Name+".suspended"] = strconv.Itoa(int(status.Suspended))
+	}
+	return data
+}
+
+// countMinReadyPods records, for each active child Job, how many of its pods have been ready for
+// at least the minReadySeconds of the JobSet, so a Job is only counted as ready once enough of its
+// pods stayed ready. It returns how long the JobSet controller should wait until the next pod has
+// been ready for long enough, or 0 if none is waiting.
+func (r *JobSetReconciler) countMinReadyPods(ctx context.Context, js *jobset.JobSet, ownedJobs *childJobs, now time.Time) (time.Duration, error) {
+	if js.Spec.MinReadySeconds == nil || *js.Spec.MinReadySeconds == 0 || len(ownedJobs.active) == 0 {
+		return 0, nil
+	}
+	minReady := time.Duration(*js.Spec.MinReadySeconds) * time.Second
+
+	var podList corev1.PodList
+	if err := r.List(ctx, &podList, client.InNamespace(js.Namespace), client.MatchingLabels{jobset.JobSetNameKey: js.Name}); err != nil {
+		return 0, err
+	}
+
+	var requeueAfter time.Duration
+	readyPodsByJobKey := map[string]int32{}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		readySince := podReadySince(pod)
+		if readySince == nil {
+			continue
+		}
+		remaining := readySince.Add(minReady).Sub(now)
+		if remaining > 0 {
+			if requeueAfter == 0 || remaining < requeueAfter {
+				requeueAfter = remaining
+			}
+			continue
+		}
+		readyPodsByJobKey[pod.Labels[jobset.JobKey]]++
+	}
+
+	ownedJobs.minReadyPods = make(map[string]int32, len(ownedJobs.active))
+	for _, job := range ownedJobs.active {
+		ownedJobs.minReadyPods[job.Name] = readyPodsByJobKey[job.Labels[jobset.JobKey]]
+	}
+	return requeueAfter, nil
+}
+
+// podReadySince returns the time since which the pod is ready, or nil if the pod is not ready
+// or is being deleted.
+func podReadySince(pod *corev1.Pod) *time.Time {
+	if pod.DeletionTimestamp != nil {
+		return nil
+	}
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady && c.Status == corev1.ConditionTrue {
+			return &c.LastTransitionTime.Time
+		}
+	}
+	return nil
+}
+
+// updateUnschedulableJobs records the active child Jobs with pending pods the scheduler could not
+// schedule in the JobSet status, if they have changed. Since the scheduling of a pod doesn't
+// change its Job, it returns how long the JobSet controller should wait until checking the pods
+// again while some of them are not scheduled, or 0 if all of them are.
+func (r *JobSetReconciler) updateUnschedulableJobs(ctx context.Context, js *jobset.JobSet, activeJobs []*batchv1.Job, updateStatusOpts *statusUpdateOpts) (time.Duration, error) {
+	unschedulableJobs, unscheduled, err := r.calculateUnschedulableJobs(ctx, js, activeJobs)
+	if err != nil {
+		return 0, err
+	}
+	if !apiequality.Semantic.DeepEqual(js.Status.UnschedulableJobs, unschedulableJobs) {
+		js.Status.UnschedulableJobs = unschedulableJobs
+		updateStatusOpts.shouldUpdate = true
+	}
+	if unscheduled {
+		return constants.UnscheduledPodsPollInterval, nil
+	}
+	return 0, nil
+}
+
+// calculateUnschedulableJobs returns the active child Jobs with pending pods the scheduler could
+// not schedule, sorted by Job name, with the scheduler message of the first of these pods by name.
+// It also returns true if any pending pod of the active Jobs is not scheduled yet.
+func (r *JobSetReconciler) calculateUnschedulableJobs(ctx context.Context, js *jobset.JobSet, activeJobs []*batchv1.Job) ([]jobset.UnschedulableJob, bool, error) {
+	if len(activeJobs) == 0 {
+		return nil, false, nil
+	}
+	var podList corev1.PodList
+	if err := r.List(ctx, &podList, client.InNamespace(js.Namespace), client.MatchingLabels{jobset.JobSetNameKey: js.Name}); err != nil {
+		return nil, false, err
+	}
+	sort.Slice(podList.Items, func(i, j int) bool {
+		return podList.Items[i].Name < podList.Items[j].Name
+	})
+
+	// The JobKey of the Job is read from its label, since it depends on the hash function the
+	// Job was created with.
+	activeJobKeys := map[string]string{}
+	for _, job := range activeJobs {
+		activeJobKeys[job.Labels[jobset.JobKey]] = job.Name
+	}
+	unscheduled := false
+	messages := map[string]string{}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		jobName, ok := activeJobKeys[pod.Labels[jobset.JobKey]]
+		if !ok || pod.Status.Phase != corev1.PodPending || podScheduled(pod) || podDeleted(pod) {
+			continue
+		}
+		unscheduled = true
+		if cond := podUnschedulableCondition(pod); cond != nil {
+			if _, ok := messages[jobName]; !ok {
+				messages[jobName] = cond.Message
+			}
+		}
+	}
+
+	var unschedulableJobs []jobset.UnschedulableJob
+	for jobName, message := range messages {
+		unschedulableJobs = append(unschedulableJobs, jobset.UnschedulableJob{Name: jobName, Message: message})
+	}
+	sort.Slice(unschedulableJobs, func(i, j int) bool {
+		return unschedulableJobs[i].Name < unschedulableJobs[j].Name
+	})
+	return unschedulableJobs, unscheduled, nil
+}
+
+// podUnschedulableCondition returns the PodScheduled condition of the pod if the scheduler
+// could not schedule it, or nil otherwise.
+func podUnschedulableCondition(pod *corev1.Pod) *corev1.PodCondition {
+	for i, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodScheduled && c.Status == corev1.ConditionFalse && c.Reason == corev1.PodReasonUnschedulable {
+			return &pod.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// aggregatedResourceRequests returns the total of the container resource requests of all the
+// pods of the JobSet, i.e. the requests of the containers of each replicated job multiplied by
+// its parallelism, its replicas and the number of instances of the JobSet.
+func aggregatedResourceRequests(js *jobset.JobSet) corev1.ResourceList {
+	total := corev1.ResourceList{}
+	for i := range js.Spec.ReplicatedJobs {
+		rjob := &js.Spec.ReplicatedJobs[i]
+		pods := int64(ptr.Deref(rjob.Template.Spec.Parallelism, 1)) * int64(replicatedJobReplicas(js, rjob)) * int64(NumInstances(js))
+		if pods == 0 {
+			continue
+		}
+		for _, c := range rjob.Template.Spec.Template.Spec.Containers {
+			for name, request := range c.Resources.Requests {
+				sum := total[name]
+				sum.Add(multiplyQuantity(request, pods))
+				total[name] = sum
+			}
+		}
+	}
+	if len(total) == 0 {
+		return nil
+	}
+	return total
+}
+
+// multiplyQuantity returns the quantity multiplied by n, which must be positive. The quantity
+// is multiplied in milli units unless this overflows.
+func multiplyQuantity(q resource.Quantity, n int64) resource.Quantity {
+	if milli := q.MilliValue(); milli <= math.MaxInt64/n {
+		return *resource.NewMilliQuantity(milli*n, q.Format)
+	}
+	return *resource.NewQuantity(q.Value()*n, q.Format)
+}
+
+// updateAggregatedResourceRequests sets the aggregated resource requests in the JobSet status,
+// if they changed.
+func updateAggregatedResourceRequests(js *jobset.JobSet, updateStatusOpts *statusUpdateOpts) {
+	requests := aggregatedResourceRequests(js)
+	if apiequality.Semantic.DeepEqual(js.Status.AggregatedResourceRequests, requests) {
+		return
+	}
+	js.Status.AggregatedResourceRequests = requests
+	updateStatusOpts.shouldUpdate = true
+}
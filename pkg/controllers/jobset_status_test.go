@@ -0,0 +1,817 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	jobset "sigs.k8s.io/jobset/api/jobset/v1alpha2"
+	"sigs.k8s.io/jobset/pkg/constants"
+	"sigs.k8s.io/jobset/pkg/util/placement"
+	testutils "sigs.k8s.io/jobset/pkg/util/testing"
+)
+
+func TestJobSetPhase(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	tests := []struct {
+		name string
+		js   *jobset.JobSet
+		want string
+	}{
+		{
+			name: "running",
+			js:   testutils.MakeJobSet(jobSetName, ns).Obj(),
+			want: jobSetPhaseRunning,
+		},
+		{
+			name: "suspended",
+			js:   testutils.MakeJobSet(jobSetName, ns).Suspend(true).Obj(),
+			want: jobSetPhaseSuspended,
+		},
+		{
+			name: "completed",
+			js:   testutils.MakeJobSet(jobSetName, ns).CompletedCondition(metav1.Now()).Obj(),
+			want: jobSetPhaseCompleted,
+		},
+		{
+			name: "failed",
+			js:   testutils.MakeJobSet(jobSetName, ns).FailedCondition(metav1.Now()).Obj(),
+			want: jobSetPhaseFailed,
+		},
+		{
+			name: "failed while suspended",
+			js:   testutils.MakeJobSet(jobSetName, ns).Suspend(true).FailedCondition(metav1.Now()).Obj(),
+			want: jobSetPhaseFailed,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := jobSetPhase(tc.js); got != tc.want {
+				t.Errorf("unexpected phase: got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReconcilePhaseLabel(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	tests := []struct {
+		name      string
+		js        *testutils.JobSetWrapper
+		wantPhase string
+	}{
+		{
+			name:      "running jobset",
+			js:        testutils.MakeJobSet(jobSetName, ns),
+			wantPhase: jobSetPhaseRunning,
+		},
+		{
+			name:      "suspended jobset",
+			js:        testutils.MakeJobSet(jobSetName, ns).Suspend(true),
+			wantPhase: jobSetPhaseSuspended,
+		},
+		{
+			name:      "completed jobset",
+			js:        testutils.MakeJobSet(jobSetName, ns).CompletedCondition(metav1.Now()),
+			wantPhase: jobSetPhaseCompleted,
+		},
+		{
+			name:      "failed jobset",
+			js:        testutils.MakeJobSet(jobSetName, ns).FailedCondition(metav1.Now()),
+			wantPhase: jobSetPhaseFailed,
+		},
+		{
+			name: "stale phase label is updated",
+			js: testutils.MakeJobSet(jobSetName, ns).
+				SetLabels(map[string]string{"team": "a", jobset.JobSetPhaseKey: jobSetPhaseSuspended}).
+				FailedCondition(metav1.Now()),
+			wantPhase: jobSetPhaseFailed,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := tc.js.
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").Replicas(1).Obj()).
+				Obj()
+			js.Spec.ReplicatedJobs[0].Template.Spec.Parallelism = ptr.To[int32](1)
+			if js.Labels == nil {
+				js.Labels = map[string]string{"team": "a"}
+			}
+			js.UID = "test-uid"
+
+			fakeClient := newFakeClientBuilder().
+				WithObjects(js).
+				WithStatusSubresource(js).
+				Build()
+			r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(100), JobSetReconcilerOptions{})
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: jobSetName, Namespace: ns}}
+			reconcileJobSet(t, r, req, 1)
+
+			var got jobset.JobSet
+			if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+				t.Fatalf("unexpected error getting jobset: %v", err)
+			}
+			if phase := got.Labels[jobset.JobSetPhaseKey]; phase != tc.wantPhase {
+				t.Errorf("unexpected phase label: got %q, want %q", phase, tc.wantPhase)
+			}
+			if team := got.Labels["team"]; team != "a" {
+				t.Errorf("expected the user labels to be preserved, got team label %q", team)
+			}
+		})
+	}
+}
+
+func TestStatusConfigMapData(t *testing.T) {
+	tests := []struct {
+		name       string
+		suspend    *bool
+		conditions []metav1.Condition
+		status     jobset.JobSetStatus
+		want       map[string]string
+	}{
+		{
+			name: "running",
+			status: jobset.JobSetStatus{
+				Restarts: 2,
+				ReplicatedJobsStatus: []jobset.ReplicatedJobStatus{
+					{Name: "driver", Ready: 1, Active: 1},
+					{Name: "workers", Ready: 2, Succeeded: 1, Failed: 1, Active: 3},
+				},
+			},
+			want: map[string]string{
+				"phase":             "Running",
+				"restarts":          "2",
+				"driver.ready":      "1",
+				"driver.succeeded":  "0",
+				"driver.failed":     "0",
+				"driver.active":     "1",
+				"driver.suspended":  "0",
+				"workers.ready":     "2",
+				"workers.succeeded": "1",
+				"workers.failed":    "1",
+				"workers.active":    "3",
+				"workers.suspended": "0",
+			},
+		},
+		{
+			name:    "suspended",
+			suspend: ptr.To(true),
+			want:    map[string]string{"phase": "Suspended", "restarts": "0"},
+		},
+		{
+			name: "completed",
+			status: jobset.JobSetStatus{
+				Conditions: []metav1.Condition{{Type: string(jobset.JobSetCompleted), Status: metav1.ConditionTrue}},
+			},
+			want: map[string]string{"phase": "Completed", "restarts": "0"},
+		},
+		{
+			name: "failed",
+			status: jobset.JobSetStatus{
+				Restarts:   3,
+				Conditions: []metav1.Condition{{Type: string(jobset.JobSetFailed), Status: metav1.ConditionTrue}},
+			},
+			want: map[string]string{"phase": "Failed", "restarts": "3"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet("js", "default").Obj()
+			js.Spec.Suspend = tc.suspend
+			js.Status = tc.status
+			if diff := cmp.Diff(tc.want, statusConfigMapData(js)); diff != "" {
+				t.Errorf("unexpected status configmap data (-want/+got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestSyncStatusConfigMap(t *testing.T) {
+	newJobSet := func(annotations map[string]string) *jobset.JobSet {
+		js := testutils.MakeJobSet("js", "default").SetAnnotations(annotations).Obj()
+		js.UID = "test-uid"
+		js.Status.Restarts = 1
+		return js
+	}
+	tests := []struct {
+		name     string
+		js       *jobset.JobSet
+		existing func(js *jobset.JobSet) *corev1.ConfigMap
+		want     map[string]string
+		wantNone bool
+	}{
+		{
+			name:     "no status configmap without the annotation",
+			js:       newJobSet(nil),
+			wantNone: true,
+		},
+		{
+			name: "status configmap is created",
+			js:   newJobSet(map[string]string{jobset.StatusConfigMapKey: "true"}),
+			want: map[string]string{"phase": "Running", "restarts": "1"},
+		},
+		{
+			name: "status configmap is updated",
+			js:   newJobSet(map[string]string{jobset.StatusConfigMapKey: "true"}),
+			existing: func(js *jobset.JobSet) *corev1.ConfigMap {
+				cm := constructStatusConfigMap(js)
+				cm.Data = map[string]string{"phase": "Running", "restarts": "0"}
+				cm.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(js, jobset.GroupVersion.WithKind("JobSet"))}
+				return cm
+			},
+			want: map[string]string{"phase": "Running", "restarts": "1"},
+		},
+		{
+			name: "configmap not controlled by the jobset is not updated",
+			js:   newJobSet(map[string]string{jobset.StatusConfigMapKey: "true"}),
+			existing: func(js *jobset.JobSet) *corev1.ConfigMap {
+				cm := constructStatusConfigMap(js)
+				cm.Data = map[string]string{"owner": "someone-else"}
+				return cm
+			},
+			want: map[string]string{"owner": "someone-else"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			builder := newFakeClientBuilder().WithObjects(tc.js)
+			if tc.existing != nil {
+				builder = builder.WithObjects(tc.existing(tc.js))
+			}
+			fakeClient := builder.Build()
+			r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{})
+			if err := r.syncStatusConfigMap(context.TODO(), tc.js); err != nil {
+				t.Fatalf("unexpected error syncing status configmap: %v", err)
+			}
+
+			var cm corev1.ConfigMap
+			err := fakeClient.Get(context.TODO(), types.NamespacedName{Name: "js-status", Namespace: "default"}, &cm)
+			if tc.wantNone {
+				if !k8serrors.IsNotFound(err) {
+					t.Fatalf("expected no status configmap, got error %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error getting status configmap: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, cm.Data); diff != "" {
+				t.Errorf("unexpected status configmap data (-want/+got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestReconcileStatusConfigMap(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	js := testutils.MakeJobSet(jobSetName, ns).
+		SetAnnotations(map[string]string{jobset.StatusConfigMapKey: "true"}).
+		SuccessPolicy(&jobset.SuccessPolicy{Operator: jobset.OperatorAll}).
+		ReplicatedJob(testutils.MakeReplicatedJob("workers").Replicas(1).Obj()).
+		Obj()
+	js.UID = "test-uid"
+	job := makeJob(&makeJobArgs{
+		jobSetName:        jobSetName,
+		jobSetUID:         string(js.UID),
+		replicatedJobName: "workers",
+		jobName:           placement.GenJobName(jobSetName, "workers", 0),
+		ns:                ns,
+		replicas:          1,
+	}).Parallelism(1).Ready(1).Active(1).Obj()
+	job.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(js, jobset.GroupVersion.WithKind("JobSet"))}
+
+	fakeClient := newFakeClientBuilder().
+		WithObjects(js, job).
+		WithStatusSubresource(js).
+		Build()
+	r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{})
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: jobSetName, Namespace: ns}}
+	assertStatusConfigMap := func(wantPhase, wantReady string) {
+		t.Helper()
+		reconcileJobSet(t, r, req, 1)
+		var got jobset.JobSet
+		if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+			t.Fatalf("unexpected error getting jobset: %v", err)
+		}
+		var cm corev1.ConfigMap
+		if err := fakeClient.Get(context.TODO(), types.NamespacedName{Name: jobSetName + "-status", Namespace: ns}, &cm); err != nil {
+			t.Fatalf("unexpected error getting status configmap: %v", err)
+		}
+		if !metav1.IsControlledBy(&cm, &got) {
+			t.Errorf("expected status configmap to be controlled by the jobset, got owner references %v", cm.OwnerReferences)
+		}
+		if diff := cmp.Diff(statusConfigMapData(&got), cm.Data); diff != "" {
+			t.Errorf("status configmap does not match the jobset status (-want/+got): %s", diff)
+		}
+		if cm.Data["phase"] != wantPhase {
+			t.Errorf("unexpected phase: got %q, want %q", cm.Data["phase"], wantPhase)
+		}
+		if cm.Data["workers.ready"] != wantReady {
+			t.Errorf("unexpected ready jobs: got %q, want %q", cm.Data["workers.ready"], wantReady)
+		}
+	}
+
+	// The status configmap is created for the running JobSet.
+	assertStatusConfigMap("Running", "1")
+
+	// The status configmap is updated once the JobSet completed.
+	var completed batchv1.Job
+	if err := fakeClient.Get(context.TODO(), types.NamespacedName{Name: job.Name, Namespace: ns}, &completed); err != nil {
+		t.Fatalf("unexpected error getting job: %v", err)
+	}
+	completed.Status.Active = 0
+	completed.Status.Ready = ptr.To[int32](0)
+	completed.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	if err := fakeClient.Status().Update(context.TODO(), &completed); err != nil {
+		t.Fatalf("unexpected error completing job: %v", err)
+	}
+	assertStatusConfigMap("Completed", "0")
+}
+
+func TestCountMinReadyPods(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	// Pod conditions are serialized with a precision of seconds.
+	now := time.Now().Truncate(time.Second)
+	readyPod := func(name, jobName string, readyFor time.Duration) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: ns,
+				Labels:    map[string]string{jobset.JobSetNameKey: jobSetName, jobset.JobKey: jobHashKey(ns, jobName)},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{
+					Type:               corev1.PodReady,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(now.Add(-readyFor)),
+				}},
+			},
+		}
+	}
+	notReadyPod := func(name, jobName string) *corev1.Pod {
+		pod := readyPod(name, jobName, time.Minute)
+		pod.Status.Conditions[0].Status = corev1.ConditionFalse
+		return pod
+	}
+
+	tests := []struct {
+		name             string
+		minReadySeconds  *int32
+		pods             []*corev1.Pod
+		wantReady        int32
+		wantRequeueAfter time.Duration
+	}{
+		{
+			name: "minReadySeconds unset counts the ready pods reported by the jobs",
+			pods: []*corev1.Pod{
+				readyPod("pod-0-0", "job-0", time.Second),
+				readyPod("pod-0-1", "job-0", time.Second),
+				readyPod("pod-1-0", "job-1", time.Second),
+			},
+			wantReady: 2,
+		},
+		{
+			name:            "pods ready for minReadySeconds count towards their job",
+			minReadySeconds: ptr.To[int32](30),
+			pods: []*corev1.Pod{
+				readyPod("pod-0-0", "job-0", time.Minute),
+				readyPod("pod-0-1", "job-0", 30*time.Second),
+				readyPod("pod-1-0", "job-1", time.Minute),
+			},
+			wantReady: 2,
+		},
+		{
+			name:            "job with a pod ready for less than minReadySeconds is not ready",
+			minReadySeconds: ptr.To[int32](30),
+			pods: []*corev1.Pod{
+				readyPod("pod-0-0", "job-0", time.Minute),
+				readyPod("pod-0-1", "job-0", 10*time.Second),
+				readyPod("pod-1-0", "job-1", time.Minute),
+			},
+			wantReady:        1,
+			wantRequeueAfter: 20 * time.Second,
+		},
+		{
+			name:            "no job is ready while all pods are below minReadySeconds",
+			minReadySeconds: ptr.To[int32](30),
+			pods: []*corev1.Pod{
+				readyPod("pod-0-0", "job-0", 5*time.Second),
+				readyPod("pod-0-1", "job-0", 10*time.Second),
+				readyPod("pod-1-0", "job-1", 25*time.Second),
+			},
+			wantReady:        0,
+			wantRequeueAfter: 5 * time.Second,
+		},
+		{
+			name:            "pods which are no longer ready are not counted",
+			minReadySeconds: ptr.To[int32](30),
+			pods: []*corev1.Pod{
+				readyPod("pod-0-0", "job-0", time.Minute),
+				notReadyPod("pod-0-1", "job-0"),
+				readyPod("pod-1-0", "job-1", time.Minute),
+			},
+			wantReady: 1,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet(jobSetName, ns).
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").Replicas(2).Obj()).
+				Obj()
+			js.Spec.MinReadySeconds = tc.minReadySeconds
+			jobs := &childJobs{}
+			for i, parallelism := range []int32{2, 1} {
+				jobName := fmt.Sprintf("job-%d", i)
+				jobs.active = append(jobs.active, makeJob(&makeJobArgs{
+					jobSetName:        jobSetName,
+					replicatedJobName: "workers",
+					jobName:           jobName,
+					ns:                ns,
+					replicas:          2,
+					jobIdx:            i,
+				}).Parallelism(parallelism).Ready(parallelism).Active(parallelism).Obj())
+			}
+
+			objs := []client.Object{js}
+			for _, pod := range tc.pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := newFakeClientBuilder().WithObjects(objs...).Build()
+			r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{})
+
+			requeueAfter, err := r.countMinReadyPods(context.TODO(), js, jobs, now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if requeueAfter != tc.wantRequeueAfter {
+				t.Errorf("unexpected requeue after: got %v, want %v", requeueAfter, tc.wantRequeueAfter)
+			}
+			statuses, err := r.calculateReplicatedJobStatuses(context.TODO(), js, jobs)
+			if err != nil {
+				t.Fatalf("unexpected error calculating replicated job statuses: %v", err)
+			}
+			if got := findReplicatedJobStatus(statuses, "workers").Ready; got != tc.wantReady {
+				t.Errorf("unexpected number of ready jobs: got %d, want %d", got, tc.wantReady)
+			}
+		})
+	}
+}
+
+func TestCalculateUnschedulableJobs(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+		noDomain   = "0/3 nodes are available: 3 node(s) didn't match pod anti-affinity rules."
+		noCapacity = "0/3 nodes are available: 3 Insufficient google.com/tpu."
+	)
+	js := testutils.MakeJobSet(jobSetName, ns).Obj()
+	job := func(name string) *batchv1.Job {
+		return makeJob(&makeJobArgs{
+			jobSetName:        jobSetName,
+			replicatedJobName: "replicated-job",
+			jobName:           name,
+			ns:                ns,
+			replicas:          2,
+		}).Obj()
+	}
+	// pod returns a pod of the Job in the given phase, scheduled on the given node if any, with
+	// the given message of the scheduler if it could not be scheduled.
+	pod := func(name, jobName string, phase corev1.PodPhase, nodeName, unschedulableMessage string) *corev1.Pod {
+		p := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: ns,
+				Labels: map[string]string{
+					jobset.JobSetNameKey: jobSetName,
+					jobset.JobKey:        jobHashKey(ns, jobName),
+				},
+			},
+			Spec:   corev1.PodSpec{NodeName: nodeName},
+			Status: corev1.PodStatus{Phase: phase},
+		}
+		if unschedulableMessage != "" {
+			p.Status.Conditions = []corev1.PodCondition{{
+				Type:    corev1.PodScheduled,
+				Status:  corev1.ConditionFalse,
+				Reason:  corev1.PodReasonUnschedulable,
+				Message: unschedulableMessage,
+			}}
+		}
+		return p
+	}
+
+	tests := []struct {
+		name            string
+		jobs            []*batchv1.Job
+		objects         []client.Object
+		want            []jobset.UnschedulableJob
+		wantUnscheduled bool
+	}{
+		{
+			name: "no active jobs",
+			objects: []client.Object{
+				pod("test-jobset-replicated-job-0-0-abcde", "test-jobset-replicated-job-0", corev1.PodPending, "", noDomain),
+			},
+		},
+		{
+			name: "all pods scheduled",
+			jobs: []*batchv1.Job{job("test-jobset-replicated-job-0")},
+			objects: []client.Object{
+				pod("test-jobset-replicated-job-0-0-abcde", "test-jobset-replicated-job-0", corev1.PodRunning, "node-a", ""),
+				pod("test-jobset-replicated-job-0-1-abcde", "test-jobset-replicated-job-0", corev1.PodPending, "node-b", ""),
+			},
+		},
+		{
+			name: "pod not scheduled yet",
+			jobs: []*batchv1.Job{job("test-jobset-replicated-job-0")},
+			objects: []client.Object{
+				pod("test-jobset-replicated-job-0-0-abcde", "test-jobset-replicated-job-0", corev1.PodPending, "", ""),
+			},
+			wantUnscheduled: true,
+		},
+		{
+			name: "unschedulable pods recorded with the message of the first pod of each job",
+			jobs: []*batchv1.Job{job("test-jobset-replicated-job-1"), job("test-jobset-replicated-job-0")},
+			objects: []client.Object{
+				pod("test-jobset-replicated-job-0-1-abcde", "test-jobset-replicated-job-0", corev1.PodPending, "", noCapacity),
+				pod("test-jobset-replicated-job-0-0-abcde", "test-jobset-replicated-job-0", corev1.PodPending, "", noDomain),
+				pod("test-jobset-replicated-job-1-0-abcde", "test-jobset-replicated-job-1", corev1.PodPending, "", noCapacity),
+			},
+			want: []jobset.UnschedulableJob{
+				{Name: "test-jobset-replicated-job-0", Message: noDomain},
+				{Name: "test-jobset-replicated-job-1", Message: noCapacity},
+			},
+			wantUnscheduled: true,
+		},
+		{
+			name: "unschedulable pods of jobs which are not active are skipped",
+			jobs: []*batchv1.Job{job("test-jobset-replicated-job-0")},
+			objects: []client.Object{
+				pod("test-jobset-replicated-job-0-0-abcde", "test-jobset-replicated-job-0", corev1.PodRunning, "node-a", ""),
+				pod("test-jobset-replicated-job-1-0-abcde", "test-jobset-replicated-job-1", corev1.PodPending, "", noDomain),
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := JobSetReconciler{Client: newFakeClientBuilder().WithObjects(tc.objects...).Build()}
+			got, unscheduled, err := r.calculateUnschedulableJobs(context.TODO(), js, tc.jobs)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("calculateUnschedulableJobs() mismatch (-want +got):\n%s", diff)
+			}
+			if unscheduled != tc.wantUnscheduled {
+				t.Errorf("unexpected unscheduled pods, want %v, got %v", tc.wantUnscheduled, unscheduled)
+			}
+		})
+	}
+}
+
+func TestUpdateUnschedulableJobs(t *testing.T) {
+	js := testutils.MakeJobSet("test-jobset", "default").Obj()
+	job := makeJob(&makeJobArgs{
+		jobSetName:        js.Name,
+		replicatedJobName: "replicated-job",
+		jobName:           "test-jobset-replicated-job-0",
+		ns:                js.Namespace,
+		replicas:          1,
+	}).Obj()
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-jobset-replicated-job-0-0-abcde",
+			Namespace: js.Namespace,
+			Labels: map[string]string{
+				jobset.JobSetNameKey: js.Name,
+				jobset.JobKey:        jobHashKey(js.Namespace, job.Name),
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			Conditions: []corev1.PodCondition{{
+				Type:    corev1.PodScheduled,
+				Status:  corev1.ConditionFalse,
+				Reason:  corev1.PodReasonUnschedulable,
+				Message: "0/3 nodes are available",
+			}},
+		},
+	}
+	r := JobSetReconciler{Client: newFakeClientBuilder().WithObjects(pod).Build()}
+
+	// The unschedulable Job is recorded, and the pods are checked again until they are scheduled.
+	opts := &statusUpdateOpts{}
+	requeueAfter, err := r.updateUnschedulableJobs(context.TODO(), js, []*batchv1.Job{job}, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []jobset.UnschedulableJob{{Name: job.Name, Message: "0/3 nodes are available"}}
+	if diff := cmp.Diff(want, js.Status.UnschedulableJobs); diff != "" {
+		t.Errorf("unexpected unschedulable jobs (-want +got):\n%s", diff)
+	}
+	if !opts.shouldUpdate {
+		t.Errorf("expected a status update recording the unschedulable jobs")
+	}
+	if requeueAfter != constants.UnscheduledPodsPollInterval {
+		t.Errorf("unexpected requeue, want %v, got %v", constants.UnscheduledPodsPollInterval, requeueAfter)
+	}
+
+	// Unchanged unschedulable Jobs don't update the status.
+	opts = &statusUpdateOpts{}
+	if _, err := r.updateUnschedulableJobs(context.TODO(), js, []*batchv1.Job{job}, opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.shouldUpdate {
+		t.Errorf("unexpected status update for unchanged unschedulable jobs")
+	}
+
+	// The Job is removed once it is no longer active.
+	opts = &statusUpdateOpts{}
+	requeueAfter, err = r.updateUnschedulableJobs(context.TODO(), js, nil, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if js.Status.UnschedulableJobs != nil || !opts.shouldUpdate {
+		t.Errorf("expected the unschedulable jobs to be cleared, got %v", js.Status.UnschedulableJobs)
+	}
+	if requeueAfter != 0 {
+		t.Errorf("unexpected requeue after %v", requeueAfter)
+	}
+}
+
+// requestsJob returns a replicated job whose pods have a container with the requests.
+func requestsJob(name string, replicas int32, parallelism *int32, requests ...corev1.ResourceList) jobset.ReplicatedJob {
+	var containers []corev1.Container
+	for _, r := range requests {
+		containers = append(containers, corev1.Container{Name: "c", Resources: corev1.ResourceRequirements{Requests: r}})
+	}
+	jobTemplate := testutils.MakeJobTemplate("job", "default").PodSpec(corev1.PodSpec{Containers: containers}).Obj()
+	jobTemplate.Spec.Parallelism = parallelism
+	return testutils.MakeReplicatedJob(name).Job(jobTemplate).Replicas(replicas).Obj()
+}
+
+// resourceListStrings returns the quantities of the resource list in their canonical form, so
+// they can be compared.
+func resourceListStrings(list corev1.ResourceList) map[corev1.ResourceName]string {
+	if list == nil {
+		return nil
+	}
+	out := map[corev1.ResourceName]string{}
+	for name, q := range list {
+		out[name] = q.String()
+	}
+	return out
+}
+
+func TestAggregatedResourceRequests(t *testing.T) {
+	cpuMem := func(cpu, mem string) corev1.ResourceList {
+		return corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu), corev1.ResourceMemory: resource.MustParse(mem)}
+	}
+	tests := []struct {
+		name string
+		js   *jobset.JobSet
+		want map[corev1.ResourceName]string
+	}{
+		{
+			name: "no requests",
+			js:   testutils.MakeJobSet("js", "default").ReplicatedJob(requestsJob("workers", 2, nil)).Obj(),
+		},
+		{
+			name: "multiple replicated jobs",
+			js: testutils.MakeJobSet("js", "default").
+				ReplicatedJob(requestsJob("driver", 1, nil, cpuMem("500m", "1Gi"))).
+				ReplicatedJob(requestsJob("workers", 3, ptr.To[int32](4), cpuMem("2", "4Gi"), corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")})).
+				Obj(),
+			want: map[corev1.ResourceName]string{
+				corev1.ResourceCPU:    "24500m",
+				corev1.ResourceMemory: "49Gi",
+				"nvidia.com/gpu":      "12",
+			},
+		},
+		{
+			name: "replicas annotation and instances",
+			js: testutils.MakeJobSet("js", "default").
+				SetAnnotations(map[string]string{jobset.ReplicasKey: "workers=1"}).
+				Instances(2).
+				ReplicatedJob(requestsJob("workers", 3, ptr.To[int32](2), cpuMem("1", "1Gi"))).
+				Obj(),
+			want: map[corev1.ResourceName]string{
+				corev1.ResourceCPU:    "4",
+				corev1.ResourceMemory: "4Gi",
+			},
+		},
+		{
+			name: "replicated job scaled to zero",
+			js: testutils.MakeJobSet("js", "default").
+				ReplicatedJob(requestsJob("workers", 0, nil, cpuMem("1", "1Gi"))).
+				Obj(),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := resourceListStrings(aggregatedResourceRequests(tc.js))
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected aggregated resource requests (-want/+got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestReconcileAggregatedResourceRequests(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	requests := corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("2Gi")}
+	js := testutils.MakeJobSet(jobSetName, ns).
+		SuccessPolicy(&jobset.SuccessPolicy{Operator: jobset.OperatorAll}).
+		ReplicatedJob(requestsJob("driver", 1, ptr.To[int32](1), requests)).
+		ReplicatedJob(requestsJob("workers", 2, ptr.To[int32](2), requests)).
+		Obj()
+	js.UID = "test-uid"
+
+	fakeClient := newFakeClientBuilder().
+		WithObjects(js).
+		WithStatusSubresource(js).
+		Build()
+	r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(100), JobSetReconcilerOptions{})
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: jobSetName, Namespace: ns}}
+
+	// Each step sets the replicas annotation and reconciles the JobSet, after which the
+	// aggregated resource requests must match.
+	steps := []struct {
+		annotation string
+		want       map[corev1.ResourceName]string
+	}{
+		{
+			want: map[corev1.ResourceName]string{corev1.ResourceCPU: "5", corev1.ResourceMemory: "10Gi"},
+		},
+		{
+			annotation: "workers=4",
+			want:       map[corev1.ResourceName]string{corev1.ResourceCPU: "9", corev1.ResourceMemory: "18Gi"},
+		},
+	}
+	for i, step := range steps {
+		var current jobset.JobSet
+		if err := fakeClient.Get(context.TODO(), req.NamespacedName, &current); err != nil {
+			t.Fatalf("step %d: unexpected error getting jobset: %v", i, err)
+		}
+		if step.annotation != "" {
+			current.Annotations = map[string]string{jobset.ReplicasKey: step.annotation}
+			if err := fakeClient.Update(context.TODO(), &current); err != nil {
+				t.Fatalf("step %d: unexpected error updating jobset: %v", i, err)
+			}
+		}
+
+		if _, err := r.Reconcile(context.TODO(), req); err != nil {
+			t.Fatalf("step %d: unexpected reconcile error: %v", i, err)
+		}
+
+		var got jobset.JobSet
+		if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+			t.Fatalf("step %d: unexpected error getting jobset: %v", i, err)
+		}
+		if diff := cmp.Diff(step.want, resourceListStrings(got.Status.AggregatedResourceRequests)); diff != "" {
+			t.Errorf("step %d: unexpected aggregated resource requests (-want/+got): %s", i, diff)
+		}
+	}
+}
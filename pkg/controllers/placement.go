@@ -0,0 +1,361 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	jobset "sigs.k8s.io/jobset/api/jobset/v1alpha2"
+	"sigs.k8s.io/jobset/pkg/constants"
+	"sigs.k8s.io/jobset/pkg/util/placement"
+)
+
+// updateJobPlacements records the placement of each active child Job using exclusive placement
+// in the JobSet status, if it has changed.
+func (r *JobSetReconciler) updateJobPlacements(ctx context.Context, js *jobset.JobSet, activeJobs []*batchv1.Job, updateStatusOpts *statusUpdateOpts) error {
+	placements, err := r.calculateJobPlacements(ctx, js, activeJobs)
+	if err != nil {
+		return err
+	}
+	if apiequality.Semantic.DeepEqual(js.Status.JobPlacements, placements) {
+		return nil
+	}
+	js.Status.JobPlacements = placements
+	updateStatusOpts.shouldUpdate = true
+	return nil
+}
+
+// calculateJobPlacements returns the placement of each active child Job using exclusive placement,
+// sorted by Job name. The topology domain and node are read from the leader pod of each Job once it
+// has been scheduled.
+func (r *JobSetReconciler) calculateJobPlacements(ctx context.Context, js *jobset.JobSet, activeJobs []*batchv1.Job) ([]jobset.JobPlacement, error) {
+	var placements []jobset.JobPlacement
+	jobKeys := map[string]string{}
+	for _, job := range activeJobs {
+		if topologyKey, ok := job.Annotations[jobset.ExclusiveKey]; ok {
+			placements = append(placements, jobset.JobPlacement{Name: job.Name, TopologyKey: topologyKey})
+			jobKeys[job.Name] = job.Labels[jobset.JobKey]
+		}
+	}
+	if len(placements) == 0 {
+		return nil, nil
+	}
+
+	// Find the scheduled leader pod of each Job.
+	var podList corev1.PodList
+	if err := r.List(ctx, &podList, client.InNamespace(js.Namespace), client.MatchingLabels{jobset.JobSetNameKey: js.Name}); err != nil {
+		return nil, err
+	}
+	leaderPods := map[string]*corev1.Pod{}
+	for i, pod := range podList.Items {
+		if placement.IsLeaderPod(&pod) && podScheduled(&pod) && !podDeleted(&pod) {
+			leaderPods[pod.Labels[jobset.JobKey]] = &podList.Items[i]
+		}
+	}
+
+	for i := range placements {
+		// The JobKey of the Job is read from its label, since it depends on the hash function
+		// the Job was created with.
+		leaderPod, ok := leaderPods[jobKeys[placements[i].Name]]
+		if !ok {
+			continue
+		}
+		placements[i].NodeName = leaderPod.Spec.NodeName
+
+		var node corev1.Node
+		if err := r.Get(ctx, types.NamespacedName{Name: leaderPod.Spec.NodeName}, &node); err != nil {
+			// A node may not exist temporarily due to a maintenance event or other scenarios.
+			if client.IgnoreNotFound(err) != nil {
+				return nil, err
+			}
+			continue
+		}
+		placements[i].TopologyDomain = node.Labels[placements[i].TopologyKey]
+	}
+
+	sort.Slice(placements, func(i, j int) bool {
+		return placements[i].Name < placements[j].Name
+	})
+	return placements, nil
+}
+
+// addPlacementInitContainer injects an init container into the pod template of a Job using
+// the node selector strategy of exclusive placement, which blocks until the node the pod was
+// scheduled on carries the namespaced job name label. This removes the need to rely on an
+// external process having labeled the nodes before the workload starts. Only the node selector
+// strategy labels nodes, so it must not be injected into Jobs using another strategy, whose pods
+// would wait forever. The image must provide a shell and kubectl, and the pod's service account
+// must be allowed to get nodes.
+func addPlacementInitContainer(job *batchv1.Job, image string) {
+	job.Spec.Template.Spec.InitContainers = append(job.Spec.Template.Spec.InitContainers, corev1.Container{
+		Name:    constants.PlacementInitContainerName,
+		Image:   image,
+		Command: []string{"/bin/sh", "-c", placementInitScript(namespacedJobName(job.Namespace, job.Name))},
+		Env: []corev1.EnvVar{
+			{
+				Name: "NODE_NAME",
+				ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"},
+				},
+			},
+		},
+	})
+}
+
+// placementInitScript returns the shell script polling the node labels until the
+// namespaced job label matches the given value.
+func placementInitScript(value string) string {
+	// Dots in the label key must be escaped in the jsonpath expression.
+	key := strings.ReplaceAll(jobset.NamespacedJobKey, ".", `\.`)
+	return fmt.Sprintf(`until [ "$(kubectl get node "${NODE_NAME}" -o jsonpath='{.metadata.labels.%s}')" = "%s" ]; do sleep 5; done`, key, value)
+}
+
+// assignTopologyDomains injects a required node affinity to a distinct topology domain into the
+// pod template of each of the given Jobs using the nodeAffinityStrategy implementation of exclusive
+// placement. The domains are those of the schedulable nodes matching the node selector of the pod
+// template, excluding the domains assigned to the already placed Jobs of the JobSet.
+func (r *JobSetReconciler) assignTopologyDomains(ctx context.Context, js *jobset.JobSet, rjob *jobset.ReplicatedJob, jobs, placedJobs []*batchv1.Job) error {
+	if len(jobs) == 0 || !usingNodeAffinityStrategy(jobs[0]) {
+		return nil
+	}
+	topologyKey := jobs[0].Annotations[jobset.ExclusiveKey]
+	podTemplate, err := podTemplateForReplicatedJob(js, rjob)
+	if err != nil {
+		return err
+	}
+	domains, err := r.schedulableTopologyDomains(ctx, podTemplate, topologyKey)
+	if err != nil {
+		return err
+	}
+	for _, job := range placedJobs {
+		if domain := assignedTopologyDomain(job, topologyKey); domain != "" {
+			delete(domains, domain)
+		}
+	}
+	free := make([]string, 0, len(domains))
+	for domain := range domains {
+		free = append(free, domain)
+	}
+	sort.Strings(free)
+
+	if len(free) < len(jobs) {
+		return fmt.Errorf("replicatedJob %q requires %d free topology domains of %q, but only %d are available", rjob.Name, len(jobs), topologyKey, len(free))
+	}
+	for i, job := range jobs {
+		addTopologyNodeAffinity(job, topologyKey, free[i])
+		ctrl.LoggerFrom(ctx).V(2).Info("assigned topology domain", "job", job.Name, "topologyKey", topologyKey, "domain", free[i])
+	}
+	return nil
+}
+
+// usingNodeAffinityStrategy returns true if the Job uses the nodeAffinityStrategy implementation
+// of exclusive placement.
+func usingNodeAffinityStrategy(job *batchv1.Job) bool {
+	_, exclusivePlacement := job.Annotations[jobset.ExclusiveKey]
+	_, nodeAffinityStrategy := job.Annotations[jobset.NodeAffinityStrategyKey]
+	return exclusivePlacement && nodeAffinityStrategy
+}
+
+// addTopologyNodeAffinity requires the pods of the Job to be scheduled on nodes of the given
+// topology domain. Node selector terms are ORed, so the requirement is added to each existing term.
+func addTopologyNodeAffinity(job *batchv1.Job, topologyKey, domain string) {
+	podSpec := &job.Spec.Template.Spec
+	if podSpec.Affinity == nil {
+		podSpec.Affinity = &corev1.Affinity{}
+	}
+	if podSpec.Affinity.NodeAffinity == nil {
+		podSpec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	nodeAffinity := podSpec.Affinity.NodeAffinity
+	if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+	}
+	nodeSelector := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(nodeSelector.NodeSelectorTerms) == 0 {
+		nodeSelector.NodeSelectorTerms = []corev1.NodeSelectorTerm{{}}
+	}
+	for i := range nodeSelector.NodeSelectorTerms {
+		nodeSelector.NodeSelectorTerms[i].MatchExpressions = append(nodeSelector.NodeSelectorTerms[i].MatchExpressions,
+			corev1.NodeSelectorRequirement{
+				Key:      topologyKey,
+				Operator: corev1.NodeSelectorOpIn,
+				Values:   []string{domain},
+			})
+	}
+}
+
+// assignedTopologyDomain returns the topology domain assigned to the Job by the nodeAffinityStrategy
+// implementation of exclusive placement, or an empty string if none is assigned.
+func assignedTopologyDomain(job *batchv1.Job, topologyKey string) string {
+	if !usingNodeAffinityStrategy(job) || job.Annotations[jobset.ExclusiveKey] != topologyKey {
+		return ""
+	}
+	affinity := job.Spec.Template.Spec.Affinity
+	if affinity == nil || affinity.NodeAffinity == nil || affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return ""
+	}
+	terms := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	if len(terms) == 0 {
+		return ""
+	}
+	// The assigned requirement is added last to every term, after any requirement of the template.
+	var domain string
+	for _, requirement := range terms[0].MatchExpressions {
+		if requirement.Key == topologyKey && requirement.Operator == corev1.NodeSelectorOpIn && len(requirement.Values) == 1 {
+			domain = requirement.Values[0]
+		}
+	}
+	return domain
+}
+
+// checkTopologyCapacity checks if the cluster has enough schedulable topology domains to place
+// each Job of the replicated job exclusively, if it uses exclusive placement. It returns a
+// message describing the missing capacity, or an empty string if there is enough capacity.
+// Nodes are listed from the informer cache of the controller.
+func (r *JobSetReconciler) checkTopologyCapacity(ctx context.Context, js *jobset.JobSet, rjob *jobset.ReplicatedJob) (string, error) {
+	topologyKey, exclusive := exclusiveTopologyKey(js, rjob)
+	if !exclusive {
+		return "", nil
+	}
+	podTemplate, err := podTemplateForReplicatedJob(js, rjob)
+	if err != nil {
+		return "", err
+	}
+
+	domains, err := r.schedulableTopologyDomains(ctx, podTemplate, topologyKey)
+	if err != nil {
+		return "", err
+	}
+	required := int(expectedJobs(js, rjob))
+	if len(domains) >= required {
+		return "", nil
+	}
+	return fmt.Sprintf("replicatedJob %q requires %d topology domains of %q, but only %d are available", rjob.Name, required, topologyKey, len(domains)), nil
+}
+
+// schedulableTopologyDomains returns the topology domains of the schedulable nodes matching the
+// node selector of the pod template.
+func (r *JobSetReconciler) schedulableTopologyDomains(ctx context.Context, podTemplate *corev1.PodTemplateSpec, topologyKey string) (map[string]bool, error) {
+	var nodes corev1.NodeList
+	if err := r.List(ctx, &nodes, client.MatchingLabels(podTemplate.Spec.NodeSelector), client.HasLabels{topologyKey}); err != nil {
+		return nil, err
+	}
+	domains := map[string]bool{}
+	for _, node := range nodes.Items {
+		if nodeSchedulable(&node) {
+			domains[node.Labels[topologyKey]] = true
+		}
+	}
+	return domains, nil
+}
+
+// exclusiveTopologyKey returns the topology key used for the exclusive placement of the Jobs of
+// the replicated job, and whether exclusive placement is used.
+func exclusiveTopologyKey(js *jobset.JobSet, rjob *jobset.ReplicatedJob) (string, bool) {
+	if topologyKey, ok := rjob.Template.Annotations[jobset.ExclusiveKey]; ok {
+		return topologyKey, true
+	}
+	topologyKey, ok := js.Annotations[jobset.ExclusiveKey]
+	return topologyKey, ok
+}
+
+// nodeSchedulable returns true if the node is ready and not cordoned.
+func nodeSchedulable(node *corev1.Node) bool {
+	if node.Spec.Unschedulable {
+		return false
+	}
+	for _, c := range node.Status.Conditions {
+		if c.Type == corev1.NodeReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// setInsufficientCapacityCondition sets the InsufficientCapacity condition of the JobSet based on
+// the messages describing the missing capacity of the replicated jobs whose creation is deferred.
+func setInsufficientCapacityCondition(js *jobset.JobSet, messages []string, updateStatusOpts *statusUpdateOpts) {
+	if len(messages) == 0 {
+		setCondition(js, &conditionOpts{
+			eventType: corev1.EventTypeNormal,
+			condition: &metav1.Condition{
+				Type:    string(jobset.JobSetInsufficientCapacity),
+				Status:  metav1.ConditionFalse,
+				Reason:  constants.SufficientTopologyDomainsReason,
+				Message: constants.SufficientTopologyDomainsMessage,
+			},
+		}, updateStatusOpts)
+		return
+	}
+	setCondition(js, &conditionOpts{
+		eventType: corev1.EventTypeWarning,
+		condition: &metav1.Condition{
+			Type:    string(jobset.JobSetInsufficientCapacity),
+			Status:  metav1.ConditionTrue,
+			Reason:  constants.InsufficientTopologyDomainsReason,
+			Message: strings.Join(messages, "; "),
+		},
+	}, updateStatusOpts)
+}
+
+// jobCreationDeferred returns true if the creation of Jobs of the JobSet is deferred due to
+// insufficient capacity.
+func jobCreationDeferred(js *jobset.JobSet) bool {
+	return meta.IsStatusConditionTrue(js.Status.Conditions, string(jobset.JobSetInsufficientCapacity))
+}
+
+// onePodPerNode returns true if the OnePodPerNodeKey annotation is set on the JobSet or on the
+// template of the replicated job.
+func onePodPerNode(js *jobset.JobSet, rjob *jobset.ReplicatedJob) bool {
+	if _, ok := js.Annotations[jobset.OnePodPerNodeKey]; ok {
+		return true
+	}
+	_, ok := rjob.Template.Annotations[jobset.OnePodPerNodeKey]
+	return ok
+}
+
+// addOnePodPerNodeAntiAffinity adds a required pod anti-affinity to the pod template of the Job,
+// which keeps its pods from being scheduled on a node already running a pod of the Job.
+func addOnePodPerNodeAntiAffinity(job *batchv1.Job) {
+	podSpec := &job.Spec.Template.Spec
+	if podSpec.Affinity == nil {
+		podSpec.Affinity = &corev1.Affinity{}
+	}
+	if podSpec.Affinity.PodAntiAffinity == nil {
+		podSpec.Affinity.PodAntiAffinity = &corev1.PodAntiAffinity{}
+	}
+	podSpec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(podSpec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution,
+		corev1.PodAffinityTerm{
+			LabelSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{
+					Key:      jobset.JobKey,
+					Operator: metav1.LabelSelectorOpIn,
+					Values:   []string{job.Spec.Template.Labels[jobset.JobKey]},
+				},
+			}},
+			TopologyKey: corev1.LabelHostname,
+		})
+}
@@ -0,0 +1,617 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	jobset "sigs.k8s.io/jobset/api/jobset/v1alpha2"
+	"sigs.k8s.io/jobset/pkg/constants"
+	testutils "sigs.k8s.io/jobset/pkg/util/testing"
+)
+
+func TestCalculateJobPlacements(t *testing.T) {
+	var (
+		jobSetName  = "test-jobset"
+		ns          = "default"
+		topologyKey = "cloud.google.com/gke-nodepool"
+	)
+	js := testutils.MakeJobSet(jobSetName, ns).Obj()
+	job := func(name, topology string) *batchv1.Job {
+		return makeJob(&makeJobArgs{
+			jobSetName:        jobSetName,
+			replicatedJobName: "replicated-job",
+			jobName:           name,
+			ns:                ns,
+			replicas:          2,
+			topology:          topology,
+		}).Obj()
+	}
+	leaderPod := func(name, jobName, nodeName string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: ns,
+				Labels: map[string]string{
+					jobset.JobSetNameKey: jobSetName,
+					jobset.JobKey:        jobHashKey(ns, jobName),
+				},
+				Annotations: map[string]string{
+					batchv1.JobCompletionIndexAnnotation: "0",
+					jobset.ExclusiveKey:                  topologyKey,
+				},
+			},
+			Spec: corev1.PodSpec{NodeName: nodeName},
+		}
+	}
+	node := func(name, domain string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{topologyKey: domain},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		jobs     []*batchv1.Job
+		objects  []client.Object
+		expected []jobset.JobPlacement
+	}{
+		{
+			name: "jobs not using exclusive placement",
+			jobs: []*batchv1.Job{job("test-jobset-replicated-job-0", "")},
+		},
+		{
+			name: "exclusive placement, pods not scheduled yet",
+			jobs: []*batchv1.Job{job("test-jobset-replicated-job-0", topologyKey)},
+			objects: []client.Object{
+				leaderPod("test-jobset-replicated-job-0-0-abcde", "test-jobset-replicated-job-0", ""),
+			},
+			expected: []jobset.JobPlacement{
+				{Name: "test-jobset-replicated-job-0", TopologyKey: topologyKey},
+			},
+		},
+		{
+			name: "exclusive placement, leader pods scheduled",
+			jobs: []*batchv1.Job{
+				job("test-jobset-replicated-job-1", topologyKey),
+				job("test-jobset-replicated-job-0", topologyKey),
+			},
+			objects: []client.Object{
+				leaderPod("test-jobset-replicated-job-0-0-abcde", "test-jobset-replicated-job-0", "node-a"),
+				leaderPod("test-jobset-replicated-job-1-0-abcde", "test-jobset-replicated-job-1", "node-b"),
+				node("node-a", "pool-a"),
+				node("node-b", "pool-b"),
+			},
+			expected: []jobset.JobPlacement{
+				{Name: "test-jobset-replicated-job-0", TopologyKey: topologyKey, TopologyDomain: "pool-a", NodeName: "node-a"},
+				{Name: "test-jobset-replicated-job-1", TopologyKey: topologyKey, TopologyDomain: "pool-b", NodeName: "node-b"},
+			},
+		},
+		{
+			name: "exclusive placement, node not found",
+			jobs: []*batchv1.Job{job("test-jobset-replicated-job-0", topologyKey)},
+			objects: []client.Object{
+				leaderPod("test-jobset-replicated-job-0-0-abcde", "test-jobset-replicated-job-0", "node-a"),
+			},
+			expected: []jobset.JobPlacement{
+				{Name: "test-jobset-replicated-job-0", TopologyKey: topologyKey, NodeName: "node-a"},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := JobSetReconciler{Client: newFakeClientBuilder().WithObjects(tc.objects...).Build()}
+			placements, err := r.calculateJobPlacements(context.TODO(), js, tc.jobs)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, placements); diff != "" {
+				t.Errorf("calculateJobPlacements() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAddPlacementInitContainer(t *testing.T) {
+	var (
+		jobSetName  = "test-jobset"
+		ns          = "default"
+		image       = "registry.k8s.io/kubectl:v1.29.3"
+		topologyKey = "cloud.google.com/gke-nodepool"
+	)
+	podSpec := corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: "user-init", Image: "busybox"}},
+		Containers:     []corev1.Container{{Name: "main", Image: "busybox"}},
+	}
+	wantInitContainer := func(jobName string) corev1.Container {
+		return corev1.Container{
+			Name:    constants.PlacementInitContainerName,
+			Image:   image,
+			Command: []string{"/bin/sh", "-c", placementInitScript(namespacedJobName(ns, jobName))},
+			Env: []corev1.EnvVar{
+				{
+					Name: "NODE_NAME",
+					ValueFrom: &corev1.EnvVarSource{
+						FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"},
+					},
+				},
+			},
+		}
+	}
+	exclusiveJS := func(annotations map[string]string) *jobset.JobSet {
+		return testutils.MakeJobSet(jobSetName, ns).
+			SetAnnotations(annotations).
+			ReplicatedJob(testutils.MakeReplicatedJob("rjob").
+				Job(testutils.MakeJobTemplate("job", ns).PodSpec(podSpec).Obj()).
+				Obj()).
+			Obj()
+	}
+	tests := []struct {
+		name               string
+		js                 *jobset.JobSet
+		image              string
+		wantInitContainers []corev1.Container
+	}{
+		{
+			name: "no exclusive placement",
+			js: testutils.MakeJobSet(jobSetName, ns).
+				ReplicatedJob(testutils.MakeReplicatedJob("rjob").
+					Job(testutils.MakeJobTemplate("job", ns).PodSpec(podSpec).Obj()).
+					Obj()).
+				Obj(),
+			image:              image,
+			wantInitContainers: podSpec.InitContainers,
+		},
+		{
+			name:               "exclusive placement with the default pod affinity strategy",
+			js:                 exclusiveJS(map[string]string{jobset.ExclusiveKey: topologyKey}),
+			image:              image,
+			wantInitContainers: podSpec.InitContainers,
+		},
+		{
+			name:               "exclusive placement with the node selector strategy",
+			js:                 exclusiveJS(map[string]string{jobset.ExclusiveKey: topologyKey, jobset.NodeSelectorStrategyKey: "true"}),
+			image:              image,
+			wantInitContainers: append(podSpec.DeepCopy().InitContainers, wantInitContainer("test-jobset-rjob-0")),
+		},
+		{
+			name: "replicated job level exclusive placement with the node selector strategy",
+			js: testutils.MakeJobSet(jobSetName, ns).
+				ReplicatedJob(testutils.MakeReplicatedJob("rjob").
+					Job(testutils.MakeJobTemplate("job", ns).
+						SetAnnotations(map[string]string{jobset.ExclusiveKey: topologyKey, jobset.NodeSelectorStrategyKey: "true"}).
+						PodSpec(podSpec).
+						Obj()).
+					Obj()).
+				Obj(),
+			image:              image,
+			wantInitContainers: append(podSpec.DeepCopy().InitContainers, wantInitContainer("test-jobset-rjob-0")),
+		},
+		{
+			name:               "injection disabled",
+			js:                 exclusiveJS(map[string]string{jobset.ExclusiveKey: topologyKey, jobset.NodeSelectorStrategyKey: "true"}),
+			wantInitContainers: podSpec.InitContainers,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			job, err := constructJob(tc.js, &tc.js.Spec.ReplicatedJobs[0], 0, 0, tc.image)
+			if err != nil {
+				t.Fatalf("constructJob() error = %v", err)
+			}
+			if diff := cmp.Diff(tc.wantInitContainers, job.Spec.Template.Spec.InitContainers); diff != "" {
+				t.Errorf("unexpected init containers (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAddTopologyNodeAffinity(t *testing.T) {
+	zoneRequirement := corev1.NodeSelectorRequirement{
+		Key:      "zone",
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   []string{"zone-1"},
+	}
+	gpuRequirement := corev1.NodeSelectorRequirement{
+		Key:      "accelerator",
+		Operator: corev1.NodeSelectorOpExists,
+	}
+	tests := []struct {
+		name     string
+		affinity *corev1.Affinity
+		want     *corev1.Affinity
+	}{
+		{
+			name: "no affinity",
+			want: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{
+							{MatchExpressions: []corev1.NodeSelectorRequirement{zoneRequirement}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "pod affinity is kept",
+			affinity: &corev1.Affinity{
+				PodAffinity: &corev1.PodAffinity{},
+			},
+			want: &corev1.Affinity{
+				PodAffinity: &corev1.PodAffinity{},
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{
+							{MatchExpressions: []corev1.NodeSelectorRequirement{zoneRequirement}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "requirement is added to each node selector term",
+			affinity: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{
+							{MatchExpressions: []corev1.NodeSelectorRequirement{gpuRequirement}},
+							{MatchFields: []corev1.NodeSelectorRequirement{{Key: "metadata.name", Operator: corev1.NodeSelectorOpIn, Values: []string{"node-a"}}}},
+						},
+					},
+				},
+			},
+			want: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{
+							{MatchExpressions: []corev1.NodeSelectorRequirement{gpuRequirement, zoneRequirement}},
+							{
+								MatchExpressions: []corev1.NodeSelectorRequirement{zoneRequirement},
+								MatchFields:      []corev1.NodeSelectorRequirement{{Key: "metadata.name", Operator: corev1.NodeSelectorOpIn, Values: []string{"node-a"}}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			job := testutils.MakeJob("job", "default").
+				Affinity(tc.affinity).
+				JobAnnotations(map[string]string{jobset.ExclusiveKey: "zone", jobset.NodeAffinityStrategyKey: "true"}).
+				Obj()
+			addTopologyNodeAffinity(job, "zone", "zone-1")
+			if diff := cmp.Diff(tc.want, job.Spec.Template.Spec.Affinity); diff != "" {
+				t.Errorf("unexpected affinity (-want/+got): %s", diff)
+			}
+			if got := assignedTopologyDomain(job, "zone"); got != "zone-1" {
+				t.Errorf("unexpected assigned topology domain: got %q, want %q", got, "zone-1")
+			}
+		})
+	}
+}
+
+func TestAssignTopologyDomains(t *testing.T) {
+	nodes := []client.Object{
+		makeNode("node-a", "zone-1", true, false),
+		makeNode("node-b", "zone-1", true, false),
+		makeNode("node-c", "zone-2", true, false),
+		makeNode("node-d", "zone-3", true, false),
+		makeNode("node-e", "zone-4", true, true),
+		makeNode("node-f", "zone-5", false, false),
+	}
+	nodeAffinityStrategy := map[string]string{jobset.ExclusiveKey: "zone", jobset.NodeAffinityStrategyKey: "true"}
+	newJobSet := func(annotations map[string]string, replicas int32) *jobset.JobSet {
+		return testutils.MakeJobSet("js", "default").
+			SetAnnotations(annotations).
+			ReplicatedJob(testutils.MakeReplicatedJob("workers").Replicas(replicas).Obj()).
+			Obj()
+	}
+	placedJob := func(domain string) *batchv1.Job {
+		job := testutils.MakeJob("placed", "default").JobAnnotations(nodeAffinityStrategy).Obj()
+		addTopologyNodeAffinity(job, "zone", domain)
+		return job
+	}
+
+	tests := []struct {
+		name        string
+		js          *jobset.JobSet
+		placedJobs  []*batchv1.Job
+		wantDomains []string
+		wantErr     bool
+	}{
+		{
+			name:        "distinct schedulable domains are assigned in order",
+			js:          newJobSet(nodeAffinityStrategy, 3),
+			wantDomains: []string{"zone-1", "zone-2", "zone-3"},
+		},
+		{
+			name:        "domains of placed jobs are skipped",
+			js:          newJobSet(nodeAffinityStrategy, 2),
+			placedJobs:  []*batchv1.Job{placedJob("zone-1")},
+			wantDomains: []string{"zone-2", "zone-3"},
+		},
+		{
+			name:       "not enough free domains",
+			js:         newJobSet(nodeAffinityStrategy, 3),
+			placedJobs: []*batchv1.Job{placedJob("zone-2")},
+			wantErr:    true,
+		},
+		{
+			name:        "no node affinity without the strategy annotation",
+			js:          newJobSet(map[string]string{jobset.ExclusiveKey: "zone"}, 2),
+			wantDomains: []string{"", ""},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := newFakeClientBuilder().WithObjects(nodes...).Build()
+			r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{})
+			rjob := &tc.js.Spec.ReplicatedJobs[0]
+			var jobs []*batchv1.Job
+			for jobIdx := 0; jobIdx < int(rjob.Replicas); jobIdx++ {
+				job, err := constructJob(tc.js, rjob, 0, jobIdx, "")
+				if err != nil {
+					t.Fatalf("constructJob() error = %v", err)
+				}
+				jobs = append(jobs, job)
+			}
+
+			err := r.assignTopologyDomains(context.TODO(), tc.js, rjob, jobs, tc.placedJobs)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.wantErr {
+				return
+			}
+			var gotDomains []string
+			for _, job := range jobs {
+				gotDomains = append(gotDomains, assignedTopologyDomain(job, "zone"))
+			}
+			if diff := cmp.Diff(tc.wantDomains, gotDomains); diff != "" {
+				t.Errorf("unexpected assigned domains (-want/+got): %s", diff)
+			}
+		})
+	}
+}
+
+func makeNode(name, zone string, ready, unschedulable bool) *corev1.Node {
+	readyStatus := corev1.ConditionFalse
+	if ready {
+		readyStatus = corev1.ConditionTrue
+	}
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{"zone": zone, "pool": "gpu"},
+		},
+		Spec: corev1.NodeSpec{Unschedulable: unschedulable},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: readyStatus}},
+		},
+	}
+}
+
+func TestCheckTopologyCapacity(t *testing.T) {
+	nodes := []client.Object{
+		makeNode("node-a", "zone-1", true, false),
+		makeNode("node-b", "zone-1", true, false),
+		makeNode("node-c", "zone-2", true, false),
+		makeNode("node-d", "zone-3", true, true),
+		makeNode("node-e", "zone-4", false, false),
+	}
+	tests := []struct {
+		name         string
+		js           *jobset.JobSet
+		wantDeferred bool
+	}{
+		{
+			name: "enough topology domains",
+			js: testutils.MakeJobSet("js", "default").
+				SetAnnotations(map[string]string{jobset.ExclusiveKey: "zone"}).
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").Replicas(2).Obj()).
+				Obj(),
+		},
+		{
+			name: "cordoned and not ready nodes are not counted",
+			js: testutils.MakeJobSet("js", "default").
+				SetAnnotations(map[string]string{jobset.ExclusiveKey: "zone"}).
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").Replicas(3).Obj()).
+				Obj(),
+			wantDeferred: true,
+		},
+		{
+			name: "replicated job level exclusive placement",
+			js: testutils.MakeJobSet("js", "default").
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").
+					Job(testutils.MakeJobTemplate("job", "default").SetAnnotations(map[string]string{jobset.ExclusiveKey: "zone"}).Obj()).
+					Replicas(3).
+					Obj()).
+				Obj(),
+			wantDeferred: true,
+		},
+		{
+			name: "nodes not matching the node selector are not counted",
+			js: testutils.MakeJobSet("js", "default").
+				SetAnnotations(map[string]string{jobset.ExclusiveKey: "zone"}).
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").
+					Job(testutils.MakeJobTemplate("job", "default").PodSpec(corev1.PodSpec{NodeSelector: map[string]string{"pool": "cpu"}}).Obj()).
+					Replicas(1).
+					Obj()).
+				Obj(),
+			wantDeferred: true,
+		},
+		{
+			name: "no exclusive placement",
+			js: testutils.MakeJobSet("js", "default").
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").Replicas(10).Obj()).
+				Obj(),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := newFakeClientBuilder().WithObjects(nodes...).Build()
+			r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{CheckTopologyCapacity: true})
+			msg, err := r.checkTopologyCapacity(context.TODO(), tc.js, &tc.js.Spec.ReplicatedJobs[0])
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if gotDeferred := msg != ""; gotDeferred != tc.wantDeferred {
+				t.Errorf("checkTopologyCapacity() deferred = %v (%q), want %v", gotDeferred, msg, tc.wantDeferred)
+			}
+		})
+	}
+}
+
+func TestReconcileDefersJobCreationOnInsufficientCapacity(t *testing.T) {
+	js := testutils.MakeJobSet("test-jobset", "default").
+		SetAnnotations(map[string]string{jobset.ExclusiveKey: "zone"}).
+		ReplicatedJob(testutils.MakeReplicatedJob("workers").
+			Job(testutils.MakeJobTemplate("job", "default").Obj()).
+			Replicas(3).
+			Obj()).
+		Obj()
+	cordoned := makeNode("node-c", "zone-3", true, true)
+	fakeClient := newFakeClientBuilder().
+		WithObjects(js, makeNode("node-a", "zone-1", true, false), makeNode("node-b", "zone-2", true, false), cordoned).
+		WithStatusSubresource(js).
+		Build()
+	r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{CheckTopologyCapacity: true})
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: js.Name, Namespace: js.Namespace}}
+
+	reconcileAndCheck := func(wantJobs int, wantCondition metav1.ConditionStatus) ctrl.Result {
+		t.Helper()
+		result := reconcileJobSet(t, r, req, 1)
+		var jobs batchv1.JobList
+		if err := fakeClient.List(context.TODO(), &jobs, client.InNamespace(js.Namespace)); err != nil {
+			t.Fatalf("unexpected error listing jobs: %v", err)
+		}
+		if len(jobs.Items) != wantJobs {
+			t.Errorf("expected %d jobs, got %d", wantJobs, len(jobs.Items))
+		}
+		var got jobset.JobSet
+		if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+			t.Fatalf("unexpected error getting jobset: %v", err)
+		}
+		cond := meta.FindStatusCondition(got.Status.Conditions, string(jobset.JobSetInsufficientCapacity))
+		if cond == nil || cond.Status != wantCondition {
+			t.Errorf("expected %s condition with status %s, got %v", jobset.JobSetInsufficientCapacity, wantCondition, cond)
+		}
+		return result
+	}
+
+	// Only two of the three nodes are schedulable, so job creation is deferred.
+	result := reconcileAndCheck(0, metav1.ConditionTrue)
+	if result.RequeueAfter != constants.InsufficientCapacityRequeueInterval {
+		t.Errorf("expected requeue after %v, got %v", constants.InsufficientCapacityRequeueInterval, result.RequeueAfter)
+	}
+
+	// Once the third topology domain becomes schedulable, the jobs are created.
+	cordoned.Spec.Unschedulable = false
+	if err := fakeClient.Update(context.TODO(), cordoned); err != nil {
+		t.Fatalf("unexpected error updating node: %v", err)
+	}
+	reconcileAndCheck(3, metav1.ConditionFalse)
+}
+
+func TestConstructJobWithOnePodPerNode(t *testing.T) {
+	jobKey := jobHashKey("default", "test-jobset-workers-0")
+	onePodPerNodeTerm := corev1.PodAffinityTerm{
+		LabelSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+			{
+				Key:      jobset.JobKey,
+				Operator: metav1.LabelSelectorOpIn,
+				Values:   []string{jobKey},
+			},
+		}},
+		TopologyKey: corev1.LabelHostname,
+	}
+	existingTerm := corev1.PodAffinityTerm{
+		LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+		TopologyKey:   corev1.LabelTopologyZone,
+	}
+	tests := []struct {
+		name                string
+		jobSetAnnotations   map[string]string
+		templateAnnotations map[string]string
+		affinity            *corev1.Affinity
+		want                *corev1.Affinity
+	}{
+		{
+			name: "no anti-affinity by default",
+		},
+		{
+			name:              "annotation on the jobset",
+			jobSetAnnotations: map[string]string{jobset.OnePodPerNodeKey: ""},
+			want: &corev1.Affinity{PodAntiAffinity: &corev1.PodAntiAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{onePodPerNodeTerm},
+			}},
+		},
+		{
+			name:                "annotation on the replicated job template",
+			templateAnnotations: map[string]string{jobset.OnePodPerNodeKey: "true"},
+			want: &corev1.Affinity{PodAntiAffinity: &corev1.PodAntiAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{onePodPerNodeTerm},
+			}},
+		},
+		{
+			name:              "anti-affinity of the template is kept",
+			jobSetAnnotations: map[string]string{jobset.OnePodPerNodeKey: ""},
+			affinity: &corev1.Affinity{PodAntiAffinity: &corev1.PodAntiAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{existingTerm},
+			}},
+			want: &corev1.Affinity{PodAntiAffinity: &corev1.PodAntiAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{existingTerm, onePodPerNodeTerm},
+			}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			jobTemplate := testutils.MakeJobTemplate("job", "default").
+				PodSpec(corev1.PodSpec{Affinity: tc.affinity}).
+				Obj()
+			jobTemplate.Annotations = tc.templateAnnotations
+			js := testutils.MakeJobSet("test-jobset", "default").
+				SetAnnotations(tc.jobSetAnnotations).
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").Job(jobTemplate).Replicas(1).Obj()).
+				Obj()
+			job, err := constructJob(js, &js.Spec.ReplicatedJobs[0], 0, 0, "")
+			if err != nil {
+				t.Fatalf("unexpected error constructing job: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, job.Spec.Template.Spec.Affinity); diff != "" {
+				t.Errorf("unexpected affinity (-want/+got): %s", diff)
+			}
+			if diff := cmp.Diff(tc.affinity, js.Spec.ReplicatedJobs[0].Template.Spec.Template.Spec.Affinity); diff != "" {
+				t.Errorf("unexpected change of the replicated job template (-want/+got): %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	policyv1 "k8s.io/api/policy/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	jobset "sigs.k8s.io/jobset/api/jobset/v1alpha2"
+)
+
+// createPodDisruptionBudgetsIfNecessary creates a PodDisruptionBudget for the pods of each
+// replicatedJob with spec.replicatedJobs[].podDisruptionBudget set.
+func (r *JobSetReconciler) createPodDisruptionBudgetsIfNecessary(ctx context.Context, js *jobset.JobSet) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	for _, rjob := range js.Spec.ReplicatedJobs {
+		if rjob.PodDisruptionBudget == nil {
+			continue
+		}
+
+		var pdb policyv1.PodDisruptionBudget
+		name := podDisruptionBudgetName(js, &rjob)
+		if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: js.Namespace}, &pdb); err != nil {
+			if !k8serrors.IsNotFound(err) {
+				return err
+			}
+			pdb := constructPodDisruptionBudget(js, &rjob)
+
+			// Set controller owner reference for garbage collection and reconcilation.
+			if err := r.setOwnerReference(js, pdb); err != nil {
+				return err
+			}
+
+			if err := r.Create(ctx, pdb); err != nil {
+				return err
+			}
+			log.V(2).Info("successfully created pod disruption budget", "replicatedJob", rjob.Name, "podDisruptionBudget", klog.KObj(pdb))
+		}
+	}
+	return nil
+}
+
+// constructPodDisruptionBudget returns the PodDisruptionBudget selecting the pods of the
+// replicatedJob by the JobSet and replicatedJob name labels.
+func constructPodDisruptionBudget(js *jobset.JobSet, rjob *jobset.ReplicatedJob) *policyv1.PodDisruptionBudget {
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      podDisruptionBudgetName(js, rjob),
+			Namespace: js.Namespace,
+			Labels: map[string]string{
+				jobset.JobSetNameKey:        js.Name,
+				jobset.ReplicatedJobNameKey: rjob.Name,
+			},
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable:   rjob.PodDisruptionBudget.MinAvailable,
+			MaxUnavailable: rjob.PodDisruptionBudget.MaxUnavailable,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					jobset.JobSetNameKey:        js.Name,
+					jobset.ReplicatedJobNameKey: rjob.Name,
+				},
+			},
+		},
+	}
+}
+
+func podDisruptionBudgetName(js *jobset.JobSet, rjob *jobset.ReplicatedJob) string {
+	return js.Name + "-" + rjob.Name
+}
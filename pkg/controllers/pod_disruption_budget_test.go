@@ -0,0 +1,113 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	jobset "sigs.k8s.io/jobset/api/jobset/v1alpha2"
+	testutils "sigs.k8s.io/jobset/pkg/util/testing"
+)
+
+func TestCreatePodDisruptionBudgetsIfNecessary(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	tests := []struct {
+		name      string
+		js        *jobset.JobSet
+		wantSpecs map[string]policyv1.PodDisruptionBudgetSpec
+	}{
+		{
+			name: "no pod disruption budget",
+			js: testutils.MakeJobSet(jobSetName, ns).
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").Replicas(2).Obj()).
+				Obj(),
+		},
+		{
+			name: "pod disruption budgets select the pods of their replicated job",
+			js: testutils.MakeJobSet(jobSetName, ns).
+				ReplicatedJob(testutils.MakeReplicatedJob("driver").
+					PodDisruptionBudget(&jobset.PodDisruptionBudget{MinAvailable: ptr.To(intstr.FromInt32(1))}).
+					Obj()).
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").
+					Replicas(4).
+					PodDisruptionBudget(&jobset.PodDisruptionBudget{MaxUnavailable: ptr.To(intstr.FromString("25%"))}).
+					Obj()).
+				ReplicatedJob(testutils.MakeReplicatedJob("evaluator").Obj()).
+				Obj(),
+			wantSpecs: map[string]policyv1.PodDisruptionBudgetSpec{
+				"test-jobset-driver": {
+					MinAvailable: ptr.To(intstr.FromInt32(1)),
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							jobset.JobSetNameKey:        jobSetName,
+							jobset.ReplicatedJobNameKey: "driver",
+						},
+					},
+				},
+				"test-jobset-workers": {
+					MaxUnavailable: ptr.To(intstr.FromString("25%")),
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							jobset.JobSetNameKey:        jobSetName,
+							jobset.ReplicatedJobNameKey: "workers",
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := JobSetReconciler{Client: newFakeClientBuilder().Build(), Scheme: testScheme}
+
+			if err := r.createPodDisruptionBudgetsIfNecessary(context.TODO(), tc.js); err != nil {
+				t.Fatalf("unexpected error creating pod disruption budgets: %v", err)
+			}
+			// Creating the pod disruption budgets again is a no-op.
+			if err := r.createPodDisruptionBudgetsIfNecessary(context.TODO(), tc.js); err != nil {
+				t.Fatalf("unexpected error creating existing pod disruption budgets: %v", err)
+			}
+
+			var pdbs policyv1.PodDisruptionBudgetList
+			if err := r.List(context.TODO(), &pdbs, client.InNamespace(ns)); err != nil {
+				t.Fatalf("unexpected error listing pod disruption budgets: %v", err)
+			}
+			gotSpecs := map[string]policyv1.PodDisruptionBudgetSpec{}
+			for _, pdb := range pdbs.Items {
+				gotSpecs[pdb.Name] = pdb.Spec
+				owner := metav1.GetControllerOf(&pdb)
+				if owner == nil || owner.Kind != "JobSet" || owner.Name != jobSetName {
+					t.Errorf("expected pod disruption budget %q to be controlled by the jobset, got owner references %v", pdb.Name, pdb.OwnerReferences)
+				}
+			}
+			if len(tc.wantSpecs) == 0 {
+				tc.wantSpecs = map[string]policyv1.PodDisruptionBudgetSpec{}
+			}
+			if diff := cmp.Diff(tc.wantSpecs, gotSpecs); diff != "" {
+				t.Errorf("unexpected pod disruption budgets (-want/+got): %s", diff)
+			}
+		})
+	}
+}
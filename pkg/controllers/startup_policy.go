@@ -14,8 +14,14 @@ limitations under the License.
 package controllers
 
 import (
+	"fmt"
+	"sort"
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	jobset "sigs.k8s.io/jobset/api/jobset/v1alpha2"
 	"sigs.k8s.io/jobset/pkg/constants"
@@ -34,6 +40,38 @@ func inOrderStartupPolicy(sp *jobset.StartupPolicy) bool {
 	return sp != nil && sp.StartupPolicyOrder == jobset.InOrder
 }
 
+// startupGatePassed returns false if the startup policy gates the replicated job on a condition
+// it did not reach yet, in which case the replicated jobs listed after it must not be started.
+func startupGatePassed(js *jobset.JobSet, rjob *jobset.ReplicatedJob, rjobStatus jobset.ReplicatedJobStatus) bool {
+	if js.Spec.StartupPolicy == nil {
+		return true
+	}
+	for _, gate := range js.Spec.StartupPolicy.Gates {
+		if gate.ReplicatedJob != rjob.Name {
+			continue
+		}
+		switch gate.Condition {
+		case jobset.StartupGateCompletedSuccessfully:
+			return rjobStatus.Succeeded >= expectedJobs(js, rjob)
+		}
+	}
+	return true
+}
+
+// waitingOnStartupGate returns true if all Jobs of the replicated job currently being started by
+// the in-order startup policy have started, but it did not pass its startup gate yet.
+func waitingOnStartupGate(js *jobset.JobSet, status *jobset.StartupPolicyStatus) bool {
+	for i := range js.Spec.ReplicatedJobs {
+		rjob := &js.Spec.ReplicatedJobs[i]
+		if rjob.Name != status.CurrentReplicatedJob {
+			continue
+		}
+		rjobStatus := findReplicatedJobStatus(js.Status.ReplicatedJobsStatus, rjob.Name)
+		return allReplicasStarted(expectedJobs(js, rjob), rjobStatus) && !startupGatePassed(js, rjob, rjobStatus)
+	}
+	return false
+}
+
 // setInOrderStartupPolicyInProgressCondition sets a condition on the JobSet status indicating it is
 // currently executing an in-order startup policy.
 func setInOrderStartupPolicyInProgressCondition(js *jobset.JobSet, updateStatusOpts *statusUpdateOpts) {
@@ -62,3 +100,120 @@ func setInOrderStartupPolicyCompletedCondition(js *jobset.JobSet, updateStatusOp
 		},
 	}, updateStatusOpts)
 }
+
+// setStartupPolicyStatus records the replicated job at the given index as the one currently
+// being started by the in-order startup policy, along with its number of started Jobs. The
+// last progress time is updated when either of them changes.
+func setStartupPolicyStatus(js *jobset.JobSet, rjobIdx int, rjobStatus jobset.ReplicatedJobStatus, now time.Time, updateStatusOpts *statusUpdateOpts) {
+	status := &jobset.StartupPolicyStatus{
+		CurrentReplicatedJob:    js.Spec.ReplicatedJobs[rjobIdx].Name,
+		RemainingReplicatedJobs: int32(len(js.Spec.ReplicatedJobs) - rjobIdx - 1),
+		StartedJobs:             rjobStatus.Failed + rjobStatus.Ready + rjobStatus.Succeeded,
+	}
+	if old := js.Status.StartupPolicyStatus; old != nil && old.CurrentReplicatedJob == status.CurrentReplicatedJob && old.StartedJobs == status.StartedJobs {
+		return
+	}
+	status.LastProgressTime = ptr.To(metav1.NewTime(now))
+	js.Status.StartupPolicyStatus = status
+	updateStatusOpts.shouldUpdate = true
+}
+
+// clearStartupPolicyStatus clears the progress of the in-order startup policy once it has completed.
+func clearStartupPolicyStatus(js *jobset.JobSet, updateStatusOpts *statusUpdateOpts) {
+	if js.Status.StartupPolicyStatus == nil {
+		return
+	}
+	js.Status.StartupPolicyStatus = nil
+	updateStatusOpts.shouldUpdate = true
+}
+
+// executeStartupStallDetection sets the PotentialDeadlock condition once the in-order startup of
+// the JobSet made no progress for longer than startupPolicy.stallTimeoutSeconds. It returns how
+// long until the startup is considered stalled, or 0 if it is not being tracked or already stalled.
+// Waiting for a replicated job to pass its startup gate, e.g. to complete, is not a stall.
+func executeStartupStallDetection(js *jobset.JobSet, now time.Time, updateStatusOpts *statusUpdateOpts) time.Duration {
+	sp := js.Spec.StartupPolicy
+	status := js.Status.StartupPolicyStatus
+	if !inOrderStartupPolicy(sp) || sp.StallTimeoutSeconds == nil || status == nil || status.LastProgressTime == nil || jobSetSuspended(js) || waitingOnStartupGate(js, status) {
+		setPotentialDeadlockCondition(js, "", updateStatusOpts)
+		return 0
+	}
+	timeout := time.Duration(*sp.StallTimeoutSeconds) * time.Second
+	if remaining := status.LastProgressTime.Add(timeout).Sub(now); remaining > 0 {
+		setPotentialDeadlockCondition(js, "", updateStatusOpts)
+		return remaining
+	}
+	setPotentialDeadlockCondition(js, fmt.Sprintf(constants.StartupStalledMessage, status.CurrentReplicatedJob, timeout), updateStatusOpts)
+	return 0
+}
+
+// setPotentialDeadlockCondition sets the PotentialDeadlock condition of the JobSet with the given
+// message if the startup stalled, i.e. the message is not empty. A JobSet whose startup did not
+// stall only gets the condition if it previously had it.
+func setPotentialDeadlockCondition(js *jobset.JobSet, stalledMsg string, updateStatusOpts *statusUpdateOpts) {
+	if stalledMsg == "" {
+		if meta.FindStatusCondition(js.Status.Conditions, string(jobset.JobSetPotentialDeadlock)) == nil {
+			return
+		}
+		setCondition(js, &conditionOpts{
+			eventType: corev1.EventTypeNormal,
+			condition: &metav1.Condition{
+				Type:    string(jobset.JobSetPotentialDeadlock),
+				Status:  metav1.ConditionFalse,
+				Reason:  constants.StartupProgressingReason,
+				Message: constants.StartupProgressingMessage,
+			},
+		}, updateStatusOpts)
+		return
+	}
+	setCondition(js, &conditionOpts{
+		eventType: corev1.EventTypeWarning,
+		condition: &metav1.Condition{
+			Type:    string(jobset.JobSetPotentialDeadlock),
+			Status:  metav1.ConditionTrue,
+			Reason:  constants.StartupStalledReason,
+			Message: stalledMsg,
+		},
+	}, updateStatusOpts)
+}
+
+// replicatedJobCreationOrder returns the indexes of the replicated jobs in the order in which
+// their Jobs are created. After a restart, replicated jobs with a higher restart priority are
+// created first, unless the InOrder startup policy requires the spec order. The Jobs of lower
+// priorities are only created once the higher priorities are ready, see restartPriorityGatePassed.
+func replicatedJobCreationOrder(js *jobset.JobSet) []int {
+	order := make([]int, len(js.Spec.ReplicatedJobs))
+	for i := range order {
+		order[i] = i
+	}
+	if js.Status.Restarts == 0 || inOrderStartupPolicy(js.Spec.StartupPolicy) {
+		return order
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return js.Spec.ReplicatedJobs[order[a]].RestartPriority > js.Spec.ReplicatedJobs[order[b]].RestartPriority
+	})
+	return order
+}
+
+// restartPriorityGatePassed returns false if the JobSet was restarted and a replicated job with
+// a higher restart priority than the given one does not have all of its Jobs ready or succeeded
+// yet, in which case the Jobs of the given replicated job must not be created yet. Creating the
+// Jobs in priority order alone would only order the create calls, not bring the higher priority
+// replicated jobs back first. The gate does not apply to suspended JobSets, whose Jobs can't
+// become ready, nor with the InOrder startup policy, which gates on the spec order instead.
+func restartPriorityGatePassed(js *jobset.JobSet, rjob *jobset.ReplicatedJob, statuses []jobset.ReplicatedJobStatus) bool {
+	if js.Status.Restarts == 0 || jobSetSuspended(js) || inOrderStartupPolicy(js.Spec.StartupPolicy) {
+		return true
+	}
+	for i := range js.Spec.ReplicatedJobs {
+		higher := &js.Spec.ReplicatedJobs[i]
+		if higher.RestartPriority <= rjob.RestartPriority {
+			continue
+		}
+		status := findReplicatedJobStatus(statuses, higher.Name)
+		if status.Ready+status.Succeeded < expectedJobs(js, higher) {
+			return false
+		}
+	}
+	return true
+}
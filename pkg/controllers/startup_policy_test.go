@@ -14,11 +14,27 @@ limitations under the License.
 package controllers
 
 import (
+	"context"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	jobset "sigs.k8s.io/jobset/api/jobset/v1alpha2"
+	"sigs.k8s.io/jobset/pkg/constants"
+	testutils "sigs.k8s.io/jobset/pkg/util/testing"
 )
 
 func TestInOrderStartupPolicy(t *testing.T) {
@@ -115,3 +131,420 @@ func TestReplicatedJobStarted(t *testing.T) {
 		})
 	}
 }
+
+func TestStartupGatePassed(t *testing.T) {
+	warmupGate := jobset.StartupGate{ReplicatedJob: "warmup", Condition: jobset.StartupGateCompletedSuccessfully}
+	tests := []struct {
+		name       string
+		gates      []jobset.StartupGate
+		rjobStatus jobset.ReplicatedJobStatus
+		want       bool
+	}{
+		{
+			name:       "no startup gates",
+			rjobStatus: jobset.ReplicatedJobStatus{Name: "warmup", Ready: 2},
+			want:       true,
+		},
+		{
+			name:       "startup gate on another replicated job",
+			gates:      []jobset.StartupGate{{ReplicatedJob: "trainer", Condition: jobset.StartupGateCompletedSuccessfully}},
+			rjobStatus: jobset.ReplicatedJobStatus{Name: "warmup", Ready: 2},
+			want:       true,
+		},
+		{
+			name:       "gated replicated job is ready",
+			gates:      []jobset.StartupGate{warmupGate},
+			rjobStatus: jobset.ReplicatedJobStatus{Name: "warmup", Ready: 2},
+		},
+		{
+			name:       "gated replicated job partially succeeded",
+			gates:      []jobset.StartupGate{warmupGate},
+			rjobStatus: jobset.ReplicatedJobStatus{Name: "warmup", Ready: 1, Succeeded: 1},
+		},
+		{
+			name:       "gated replicated job completed successfully",
+			gates:      []jobset.StartupGate{warmupGate},
+			rjobStatus: jobset.ReplicatedJobStatus{Name: "warmup", Succeeded: 2},
+			want:       true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet("test-jobset", "default").
+				StartupPolicy(&jobset.StartupPolicy{StartupPolicyOrder: jobset.InOrder, Gates: tc.gates}).
+				ReplicatedJob(testutils.MakeReplicatedJob("warmup").Replicas(2).Obj()).
+				ReplicatedJob(testutils.MakeReplicatedJob("trainer").Replicas(2).Obj()).
+				Obj()
+			if got := startupGatePassed(js, &js.Spec.ReplicatedJobs[0], tc.rjobStatus); got != tc.want {
+				t.Errorf("unexpected result: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReconcileStartupPolicyStatus(t *testing.T) {
+	jobTemplate := testutils.MakeJobTemplate("job", "default").Obj()
+	jobTemplate.Spec.Parallelism = ptr.To[int32](1)
+	js := testutils.MakeJobSet("test-jobset", "default").
+		StartupPolicy(&jobset.StartupPolicy{StartupPolicyOrder: jobset.InOrder}).
+		ReplicatedJob(testutils.MakeReplicatedJob("leader").Job(jobTemplate).Replicas(1).Obj()).
+		ReplicatedJob(testutils.MakeReplicatedJob("workers").Job(jobTemplate).Replicas(2).Obj()).
+		ReplicatedJob(testutils.MakeReplicatedJob("evaluator").Job(jobTemplate).Replicas(1).Obj()).
+		Obj()
+	fakeClient := newFakeClientBuilder().
+		WithObjects(js).
+		WithStatusSubresource(js, &batchv1.Job{}).
+		Build()
+	now := metav1.NewTime(time.Now().Truncate(time.Second))
+	r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(100), JobSetReconcilerOptions{})
+	r.clock = clocktesting.NewFakeClock(now.Time)
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: js.Name, Namespace: js.Namespace}}
+
+	// Each reconcile starts the next replicated job once all Jobs of the previous one are ready.
+	steps := []*jobset.StartupPolicyStatus{
+		{CurrentReplicatedJob: "leader", RemainingReplicatedJobs: 2, LastProgressTime: &now},
+		{CurrentReplicatedJob: "workers", RemainingReplicatedJobs: 1, LastProgressTime: &now},
+		{CurrentReplicatedJob: "evaluator", RemainingReplicatedJobs: 0, LastProgressTime: &now},
+		nil,
+	}
+	for i, want := range steps {
+		if _, err := r.Reconcile(context.TODO(), req); err != nil {
+			t.Fatalf("step %d: unexpected reconcile error: %v", i, err)
+		}
+		var got jobset.JobSet
+		if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+			t.Fatalf("step %d: unexpected error getting jobset: %v", i, err)
+		}
+		if diff := cmp.Diff(want, got.Status.StartupPolicyStatus); diff != "" {
+			t.Errorf("step %d: unexpected startup policy status (-want/+got): %s", i, diff)
+		}
+
+		// Mark all created Jobs as ready.
+		var jobs batchv1.JobList
+		if err := fakeClient.List(context.TODO(), &jobs, client.InNamespace(js.Namespace)); err != nil {
+			t.Fatalf("step %d: unexpected error listing jobs: %v", i, err)
+		}
+		for j := range jobs.Items {
+			jobs.Items[j].Status.Ready = ptr.To[int32](1)
+			if err := fakeClient.Status().Update(context.TODO(), &jobs.Items[j]); err != nil {
+				t.Fatalf("step %d: unexpected error updating job status: %v", i, err)
+			}
+		}
+	}
+}
+
+func TestExecuteStartupStallDetection(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	stalledCondition := metav1.Condition{Type: string(jobset.JobSetPotentialDeadlock), Status: metav1.ConditionTrue, Reason: constants.StartupStalledReason}
+	tests := []struct {
+		name             string
+		stallTimeout     *int32
+		suspend          bool
+		lastProgressAgo  *time.Duration
+		conditions       []metav1.Condition
+		gates            []jobset.StartupGate
+		rjobStatuses     []jobset.ReplicatedJobStatus
+		wantCondition    *metav1.ConditionStatus
+		wantRequeueAfter time.Duration
+	}{
+		{
+			name:            "no stall timeout",
+			lastProgressAgo: ptr.To(time.Hour),
+		},
+		{
+			name:             "startup is progressing",
+			stallTimeout:     ptr.To[int32](60),
+			lastProgressAgo:  ptr.To(20 * time.Second),
+			wantRequeueAfter: 40 * time.Second,
+		},
+		{
+			name:            "startup stalled",
+			stallTimeout:    ptr.To[int32](60),
+			lastProgressAgo: ptr.To(2 * time.Minute),
+			wantCondition:   ptr.To(metav1.ConditionTrue),
+		},
+		{
+			name:             "stalled startup is progressing again",
+			stallTimeout:     ptr.To[int32](60),
+			lastProgressAgo:  ptr.To(10 * time.Second),
+			conditions:       []metav1.Condition{stalledCondition},
+			wantCondition:    ptr.To(metav1.ConditionFalse),
+			wantRequeueAfter: 50 * time.Second,
+		},
+		{
+			name:          "stalled startup completed",
+			stallTimeout:  ptr.To[int32](60),
+			conditions:    []metav1.Condition{stalledCondition},
+			wantCondition: ptr.To(metav1.ConditionFalse),
+		},
+		{
+			name:            "suspended jobset does not stall",
+			stallTimeout:    ptr.To[int32](60),
+			suspend:         true,
+			lastProgressAgo: ptr.To(2 * time.Minute),
+		},
+		{
+			name:            "waiting for a gated replicated job to complete does not stall",
+			stallTimeout:    ptr.To[int32](60),
+			lastProgressAgo: ptr.To(2 * time.Minute),
+			gates:           []jobset.StartupGate{{ReplicatedJob: "leader", Condition: jobset.StartupGateCompletedSuccessfully}},
+			rjobStatuses:    []jobset.ReplicatedJobStatus{{Name: "leader", Ready: 1}},
+		},
+		{
+			name:            "gated replicated job which is not ready stalls",
+			stallTimeout:    ptr.To[int32](60),
+			lastProgressAgo: ptr.To(2 * time.Minute),
+			gates:           []jobset.StartupGate{{ReplicatedJob: "leader", Condition: jobset.StartupGateCompletedSuccessfully}},
+			rjobStatuses:    []jobset.ReplicatedJobStatus{{Name: "leader"}},
+			wantCondition:   ptr.To(metav1.ConditionTrue),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet("test-jobset", "default").
+				StartupPolicy(&jobset.StartupPolicy{StartupPolicyOrder: jobset.InOrder, StallTimeoutSeconds: tc.stallTimeout, Gates: tc.gates}).
+				ReplicatedJob(testutils.MakeReplicatedJob("leader").Replicas(1).Obj()).
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").Obj()).
+				Suspend(tc.suspend).
+				Obj()
+			js.Status.Conditions = tc.conditions
+			js.Status.ReplicatedJobsStatus = tc.rjobStatuses
+			if tc.lastProgressAgo != nil {
+				js.Status.StartupPolicyStatus = &jobset.StartupPolicyStatus{
+					CurrentReplicatedJob:    "leader",
+					RemainingReplicatedJobs: 1,
+					LastProgressTime:        ptr.To(metav1.NewTime(now.Add(-*tc.lastProgressAgo))),
+				}
+			}
+			gotRequeueAfter := executeStartupStallDetection(js, now, &statusUpdateOpts{})
+			if gotRequeueAfter != tc.wantRequeueAfter {
+				t.Errorf("unexpected requeue after: got %v, want %v", gotRequeueAfter, tc.wantRequeueAfter)
+			}
+			cond := meta.FindStatusCondition(js.Status.Conditions, string(jobset.JobSetPotentialDeadlock))
+			if tc.wantCondition == nil {
+				if cond != nil {
+					t.Errorf("unexpected %s condition: %v", jobset.JobSetPotentialDeadlock, cond)
+				}
+				return
+			}
+			if cond == nil || cond.Status != *tc.wantCondition {
+				t.Errorf("unexpected %s condition: got %v, want status %s", jobset.JobSetPotentialDeadlock, cond, *tc.wantCondition)
+			}
+		})
+	}
+}
+
+func TestReconcileStalledStartup(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	jobTemplate := testutils.MakeJobTemplate("job", "default").Obj()
+	jobTemplate.Spec.Parallelism = ptr.To[int32](1)
+	js := testutils.MakeJobSet("test-jobset", "default").
+		StartupPolicy(&jobset.StartupPolicy{StartupPolicyOrder: jobset.InOrder, StallTimeoutSeconds: ptr.To[int32](60)}).
+		ReplicatedJob(testutils.MakeReplicatedJob("leader").Job(jobTemplate).Replicas(1).Obj()).
+		ReplicatedJob(testutils.MakeReplicatedJob("workers").Job(jobTemplate).Replicas(2).Obj()).
+		Obj()
+	fakeClient := newFakeClientBuilder().
+		WithObjects(js).
+		WithStatusSubresource(js, &batchv1.Job{}).
+		Build()
+	clock := clocktesting.NewFakeClock(now)
+	r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(100), JobSetReconcilerOptions{})
+	r.clock = clock
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: js.Name, Namespace: js.Namespace}}
+
+	// The first reconcile creates the leader Job, which never becomes ready.
+	result := reconcileJobSet(t, r, req, 1)
+	if result.RequeueAfter != time.Minute {
+		t.Errorf("unexpected requeue after: got %v, want %v", result.RequeueAfter, time.Minute)
+	}
+
+	// Once the stall timeout passed without progress, the startup is reported as stalled.
+	clock.Step(2 * time.Minute)
+	reconcileJobSet(t, r, req, 1)
+	var got jobset.JobSet
+	if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+		t.Fatalf("unexpected error getting jobset: %v", err)
+	}
+	if !meta.IsStatusConditionTrue(got.Status.Conditions, string(jobset.JobSetPotentialDeadlock)) {
+		t.Errorf("expected the %s condition to be true, got conditions %v", jobset.JobSetPotentialDeadlock, got.Status.Conditions)
+	}
+}
+
+func TestReconcileStartupGates(t *testing.T) {
+	jobTemplate := testutils.MakeJobTemplate("job", "default").Obj()
+	jobTemplate.Spec.Parallelism = ptr.To[int32](1)
+	js := testutils.MakeJobSet("test-jobset", "default").
+		StartupPolicy(&jobset.StartupPolicy{
+			StartupPolicyOrder: jobset.InOrder,
+			Gates:              []jobset.StartupGate{{ReplicatedJob: "warmup", Condition: jobset.StartupGateCompletedSuccessfully}},
+		}).
+		SuccessPolicy(&jobset.SuccessPolicy{Operator: jobset.OperatorAll}).
+		ReplicatedJob(testutils.MakeReplicatedJob("warmup").Job(jobTemplate).Replicas(1).Obj()).
+		ReplicatedJob(testutils.MakeReplicatedJob("trainer").Job(jobTemplate).Replicas(2).Obj()).
+		Obj()
+	fakeClient := newFakeClientBuilder().
+		WithObjects(js).
+		WithStatusSubresource(js, &batchv1.Job{}).
+		Build()
+	r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(100), JobSetReconcilerOptions{})
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: js.Name, Namespace: js.Namespace}}
+
+	// updateJobs applies the given change to the status of all Jobs and returns the number of
+	// Jobs of each replicated job.
+	updateJobs := func(step string, update func(*batchv1.Job)) map[string]int {
+		var jobs batchv1.JobList
+		if err := fakeClient.List(context.TODO(), &jobs, client.InNamespace(js.Namespace)); err != nil {
+			t.Fatalf("%s: unexpected error listing jobs: %v", step, err)
+		}
+		counts := map[string]int{}
+		for i := range jobs.Items {
+			counts[jobs.Items[i].Labels[jobset.ReplicatedJobNameKey]]++
+			if update == nil {
+				continue
+			}
+			update(&jobs.Items[i])
+			if err := fakeClient.Status().Update(context.TODO(), &jobs.Items[i]); err != nil {
+				t.Fatalf("%s: unexpected error updating job status: %v", step, err)
+			}
+		}
+		return counts
+	}
+
+	// The trainer Jobs wait while the warmup Job is only ready.
+	reconcileJobSet(t, r, req, 1)
+	updateJobs("warmup ready", func(job *batchv1.Job) {
+		job.Status.Ready = ptr.To[int32](1)
+	})
+	reconcileJobSet(t, r, req, 1)
+	if diff := cmp.Diff(map[string]int{"warmup": 1}, updateJobs("warmup ready", nil)); diff != "" {
+		t.Errorf("unexpected jobs while the warmup is running (-want/+got): %s", diff)
+	}
+	var got jobset.JobSet
+	if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+		t.Fatalf("unexpected error getting jobset: %v", err)
+	}
+	if got.Status.StartupPolicyStatus == nil || got.Status.StartupPolicyStatus.CurrentReplicatedJob != "warmup" {
+		t.Errorf("expected the startup policy to wait for the warmup replicated job, got %v", got.Status.StartupPolicyStatus)
+	}
+
+	// The trainer Jobs are created once the warmup Job completed successfully.
+	updateJobs("warmup completed", func(job *batchv1.Job) {
+		job.Status.Ready = ptr.To[int32](0)
+		job.Status.Succeeded = 1
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	})
+	reconcileJobSet(t, r, req, 1)
+	if diff := cmp.Diff(map[string]int{"warmup": 1, "trainer": 2}, updateJobs("warmup completed", nil)); diff != "" {
+		t.Errorf("unexpected jobs once the warmup completed (-want/+got): %s", diff)
+	}
+}
+
+func TestCreateJobsRestartPriority(t *testing.T) {
+	makeJobSet := func() *testutils.JobSetWrapper {
+		return testutils.MakeJobSet("test-jobset", "default").
+			ReplicatedJob(testutils.MakeReplicatedJob("workers").
+				Job(testutils.MakeJobTemplate("job", "default").Obj()).
+				Replicas(3).
+				Obj()).
+			ReplicatedJob(testutils.MakeReplicatedJob("evaluator").
+				Job(testutils.MakeJobTemplate("job", "default").Obj()).
+				RestartPriority(1).
+				Replicas(1).
+				Obj()).
+			ReplicatedJob(testutils.MakeReplicatedJob("coordinator").
+				Job(testutils.MakeJobTemplate("job", "default").Obj()).
+				RestartPriority(10).
+				Replicas(1).
+				Obj())
+	}
+	// activeJob returns the existing Job of a replicated job with a single replica.
+	activeJob := func(rjobName string) *batchv1.Job {
+		return &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "test-jobset-" + rjobName + "-0"}}
+	}
+	tests := []struct {
+		name      string
+		js        *jobset.JobSet
+		restarts  int32
+		ownedJobs childJobs
+		statuses  []jobset.ReplicatedJobStatus
+		want      []string
+	}{
+		{
+			name: "initial creation uses the spec order",
+			js:   makeJobSet().Obj(),
+			want: []string{"workers", "evaluator", "coordinator"},
+		},
+		{
+			name:     "restart only creates the highest priority replicated job",
+			js:       makeJobSet().Obj(),
+			restarts: 1,
+			want:     []string{"coordinator"},
+		},
+		{
+			name:      "restart waits for the higher priority replicated jobs to be ready",
+			js:        makeJobSet().Obj(),
+			restarts:  1,
+			ownedJobs: childJobs{active: []*batchv1.Job{activeJob("coordinator")}},
+			statuses:  []jobset.ReplicatedJobStatus{{Name: "coordinator", Active: 1}},
+		},
+		{
+			name:      "restart creates the next priority once the higher priorities are ready",
+			js:        makeJobSet().Obj(),
+			restarts:  1,
+			ownedJobs: childJobs{active: []*batchv1.Job{activeJob("coordinator")}},
+			statuses:  []jobset.ReplicatedJobStatus{{Name: "coordinator", Active: 1, Ready: 1}},
+			want:      []string{"evaluator"},
+		},
+		{
+			name:      "restart creates the lowest priority once all higher priorities are ready",
+			js:        makeJobSet().Obj(),
+			restarts:  1,
+			ownedJobs: childJobs{active: []*batchv1.Job{activeJob("coordinator"), activeJob("evaluator")}},
+			statuses: []jobset.ReplicatedJobStatus{
+				{Name: "coordinator", Active: 1, Ready: 1},
+				{Name: "evaluator", Active: 1, Ready: 1},
+			},
+			want: []string{"workers"},
+		},
+		{
+			name:     "restart of a suspended jobset creates all the replicated jobs by priority",
+			js:       makeJobSet().Suspend(true).Obj(),
+			restarts: 1,
+			want:     []string{"coordinator", "evaluator", "workers"},
+		},
+		{
+			name:     "restart with in order startup policy uses the spec order",
+			js:       makeJobSet().StartupPolicy(&jobset.StartupPolicy{StartupPolicyOrder: jobset.InOrder}).Obj(),
+			restarts: 1,
+			want:     []string{"workers"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Record the replicated job of each created Job, in creation order.
+			var lock sync.Mutex
+			var got []string
+			fakeClient := newFakeClientBuilder().
+				WithInterceptorFuncs(withServerSideApply(interceptor.Funcs{
+					Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+						lock.Lock()
+						defer lock.Unlock()
+						rjobName := obj.GetLabels()[jobset.ReplicatedJobNameKey]
+						if len(got) == 0 || got[len(got)-1] != rjobName {
+							got = append(got, rjobName)
+						}
+						return c.Create(ctx, obj, opts...)
+					},
+				})).Build()
+			r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{})
+
+			tc.js.Status.Restarts = tc.restarts
+			if err := r.createJobs(context.TODO(), tc.js, &tc.ownedJobs, tc.statuses, &statusUpdateOpts{}); err != nil {
+				t.Fatalf("unexpected error creating jobs: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected creation order of replicated jobs (-want/+got): %s", diff)
+			}
+		})
+	}
+}
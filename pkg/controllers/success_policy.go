@@ -14,22 +14,158 @@ limitations under the License.
 package controllers
 
 import (
+	"context"
+	"fmt"
+	"time"
+
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	jobset "sigs.k8s.io/jobset/api/jobset/v1alpha2"
+	"sigs.k8s.io/jobset/pkg/constants"
 
 	"sigs.k8s.io/jobset/pkg/util/collections"
 )
 
 // jobMatchesSuccessPolicy returns a boolean value indicating if the Job is part of a
-// ReplicatedJob that matches the JobSet's success policy.
+// ReplicatedJob that matches the JobSet's success policy. Jobs of non-critical replicated
+// jobs never match it.
 func jobMatchesSuccessPolicy(js *jobset.JobSet, job *batchv1.Job) bool {
+	if jobNonCritical(js, job) {
+		return false
+	}
 	return len(js.Spec.SuccessPolicy.TargetReplicatedJobs) == 0 || collections.Contains(js.Spec.SuccessPolicy.TargetReplicatedJobs, job.ObjectMeta.Labels[jobset.ReplicatedJobNameKey])
 }
 
+// markJobsSucceededByPodAnnotation moves active jobs matching the JobSet's success policy into the
+// successful jobs bucket if all of their pods of the current restart attempt carry the success
+// policy's pod annotation.
+func (r *JobSetReconciler) markJobsSucceededByPodAnnotation(ctx context.Context, js *jobset.JobSet, jobs *childJobs) error {
+	if js.Spec.SuccessPolicy == nil || js.Spec.SuccessPolicy.PodAnnotation == nil || len(jobs.active) == 0 {
+		return nil
+	}
+
+	var podList corev1.PodList
+	if err := r.List(ctx, &podList, client.InNamespace(js.Namespace), client.MatchingLabels{jobset.JobSetNameKey: js.Name}); err != nil {
+		return err
+	}
+	podsByJobKey := map[string][]*corev1.Pod{}
+	for i, pod := range podList.Items {
+		jobKey := pod.Labels[jobset.JobKey]
+		podsByJobKey[jobKey] = append(podsByJobKey[jobKey], &podList.Items[i])
+	}
+
+	var stillActive []*batchv1.Job
+	for _, job := range jobs.active {
+		if jobMatchesSuccessPolicy(js, job) && podsCarryAnnotation(job, podsByJobKey[job.Labels[jobset.JobKey]], js.Spec.SuccessPolicy.PodAnnotation) {
+			ctrl.LoggerFrom(ctx).V(2).Info("job succeeded by pod annotation", "job", job.Name)
+			jobs.successful = append(jobs.successful, job)
+			continue
+		}
+		stillActive = append(stillActive, job)
+	}
+	jobs.active = stillActive
+	return nil
+}
+
+// countSuccessesAcrossRestarts returns true if the success policy of the JobSet counts the
+// child Jobs which succeeded in any run of the JobSet.
+func countSuccessesAcrossRestarts(js *jobset.JobSet) bool {
+	return js.Spec.SuccessPolicy != nil && ptr.Deref(js.Spec.SuccessPolicy.CountAcrossRestarts, false)
+}
+
+// recordSucceededJobs adds the names of the succeeded child Jobs matching the success policy to
+// the JobSet status, if the success policy counts them across restarts. Jobs of the previous run
+// which succeeded before they were marked for deletion are included. Jobs which are already
+// recorded are skipped, so each Job is counted once however often it succeeded. Recorded Jobs
+// beyond the current replicas of their replicated job, which was scaled down, are removed, as
+// they don't count towards the success policy anymore.
+func recordSucceededJobs(js *jobset.JobSet, ownedJobs *childJobs, updateStatusOpts *statusUpdateOpts) {
+	if !countSuccessesAcrossRestarts(js) {
+		return
+	}
+	succeeded := append([]*batchv1.Job{}, ownedJobs.successful...)
+	for _, job := range ownedJobs.delete {
+		if _, finishedType := JobFinished(job); finishedType == batchv1.JobComplete && !jobBeyondReplicas(js, job) && allIndexesSucceeded(js, job) {
+			succeeded = append(succeeded, job)
+		}
+	}
+
+	recorded := sets.New(js.Status.SucceededJobs...)
+	changed := false
+	if current, err := currentJobNames(js); err == nil {
+		for name := range recorded {
+			if !current.Has(name) {
+				recorded.Delete(name)
+				changed = true
+			}
+		}
+	}
+	for _, job := range succeeded {
+		if jobMatchesSuccessPolicy(js, job) && !recorded.Has(job.Name) {
+			recorded.Insert(job.Name)
+			changed = true
+		}
+	}
+	if !changed {
+		return
+	}
+	js.Status.SucceededJobs = sets.List(recorded)
+	updateStatusOpts.shouldUpdate = true
+}
+
+// numSucceededJobsMatchingSuccessPolicy returns the number of succeeded child Jobs counted
+// towards the success policy, which are the Jobs recorded in the JobSet status if the success
+// policy counts them across restarts, and the succeeded Jobs of the current run otherwise.
+func numSucceededJobsMatchingSuccessPolicy(js *jobset.JobSet, ownedJobs *childJobs) int {
+	if countSuccessesAcrossRestarts(js) {
+		return len(js.Status.SucceededJobs)
+	}
+	return numJobsMatchingSuccessPolicy(js, ownedJobs.successful)
+}
+
+// jobNonCritical returns true if the Job is part of a non-critical replicated job.
+func jobNonCritical(js *jobset.JobSet, job *batchv1.Job) bool {
+	rjobName := job.Labels[jobset.ReplicatedJobNameKey]
+	for _, rjob := range js.Spec.ReplicatedJobs {
+		if rjob.Name == rjobName {
+			return ptr.Deref(rjob.NonCritical, false)
+		}
+	}
+	return false
+}
+
+// podsCarryAnnotation returns true if the running pods of the given Job's restart attempt,
+// at least as many as the parallelism of the Job, all carry the annotation defined in the
+// success condition. The pods of earlier restart attempts share the job key of the Job, so
+// they are told apart by their restart attempt label, which is compared against the pod
+// template of the Job, as a Job kept across restarts only has its own label updated. Failed
+// pods are replaced by the Job controller and are not counted.
+func podsCarryAnnotation(job *batchv1.Job, pods []*corev1.Pod, cond *jobset.PodAnnotationSuccessCondition) bool {
+	running := 0
+	for _, pod := range pods {
+		if pod.Labels[constants.RestartsKey] != job.Spec.Template.Labels[constants.RestartsKey] || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if value, ok := pod.Annotations[cond.Key]; !ok || value != cond.Value {
+			return false
+		}
+		running++
+	}
+	return running > 0 && running >= int(ptr.Deref(job.Spec.Parallelism, 1))
+}
+
 // replicatedJobMatchesSuccessPolicy returns a boolean value indicating if the ReplicatedJob
-// matches the JobSet's success policy.
+// matches the JobSet's success policy. Non-critical replicated jobs never match it.
 func replicatedJobMatchesSuccessPolicy(js *jobset.JobSet, rjob *jobset.ReplicatedJob) bool {
+	if ptr.Deref(rjob.NonCritical, false) {
+		return false
+	}
 	return len(js.Spec.SuccessPolicy.TargetReplicatedJobs) == 0 || collections.Contains(js.Spec.SuccessPolicy.TargetReplicatedJobs, rjob.Name)
 }
 
@@ -47,8 +183,12 @@ func numJobsMatchingSuccessPolicy(js *jobset.JobSet, jobs []*batchv1.Job) int {
 
 // numJobsExpectedToSucceed the number of jobs that must complete successfully
 // in order to satisfy the JobSet's success policy and mark the JobSet complete.
-// This is determined based on the JobSet spec.
+// This is determined based on the JobSet spec. If the success policy sets a total
+// succeeded target, it takes precedence over the operator.
 func numJobsExpectedToSucceed(js *jobset.JobSet) int {
+	if js.Spec.SuccessPolicy.TotalSucceeded != nil {
+		return int(*js.Spec.SuccessPolicy.TotalSucceeded)
+	}
 	total := 0
 	switch js.Spec.SuccessPolicy.Operator {
 	case jobset.OperatorAny:
@@ -56,9 +196,195 @@ func numJobsExpectedToSucceed(js *jobset.JobSet) int {
 	case jobset.OperatorAll:
 		for _, rjob := range js.Spec.ReplicatedJobs {
 			if replicatedJobMatchesSuccessPolicy(js, &rjob) {
-				total += int(rjob.Replicas)
+				total += int(expectedJobs(js, &rjob))
 			}
 		}
 	}
 	return total
 }
+
+// jobsToTearDownBeforeCompletion returns the child Jobs which must be gone before the JobSet is
+// declared completed. With a TargetsLast completion policy, these are the active Jobs of the
+// replicated jobs not targeted by the success policy, e.g. the workers of a driver which
+// succeeded. Jobs already being deleted are included, so the JobSet waits until they are gone.
+func jobsToTearDownBeforeCompletion(js *jobset.JobSet, ownedJobs *childJobs) []*batchv1.Job {
+	if js.Spec.CompletionPolicy == nil || js.Spec.CompletionPolicy.Order != jobset.CompletionPolicyTargetsLast {
+		return nil
+	}
+	var jobs []*batchv1.Job
+	for _, job := range ownedJobs.active {
+		if !jobMatchesSuccessPolicy(js, job) {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs
+}
+
+// completionGracePeriodRemaining returns how long the JobSet controller should still wait for
+// the pods of the succeeded child Jobs to terminate before declaring the JobSet completed, or 0
+// if it should not wait. The completion grace period starts when the last succeeded child Job
+// completed.
+func (r *JobSetReconciler) completionGracePeriodRemaining(ctx context.Context, js *jobset.JobSet, successfulJobs []*batchv1.Job, now time.Time) (time.Duration, error) {
+	if js.Spec.SuccessPolicy.CompletionGracePeriodSeconds == nil {
+		return 0, nil
+	}
+	lastCompletionTime := findLastJobCompletionTime(successfulJobs)
+	if lastCompletionTime == nil {
+		return 0, nil
+	}
+	gracePeriod := time.Duration(*js.Spec.SuccessPolicy.CompletionGracePeriodSeconds) * time.Second
+	remaining := lastCompletionTime.Add(gracePeriod).Sub(now)
+	if remaining <= 0 {
+		return 0, nil
+	}
+	terminated, err := r.podsTerminated(ctx, js, successfulJobs)
+	if err != nil || terminated {
+		return 0, err
+	}
+	return remaining, nil
+}
+
+// podsTerminated returns true if all pods of the given child Jobs of the JobSet terminated.
+func (r *JobSetReconciler) podsTerminated(ctx context.Context, js *jobset.JobSet, jobs []*batchv1.Job) (bool, error) {
+	var podList corev1.PodList
+	if err := r.List(ctx, &podList, client.InNamespace(js.Namespace), client.MatchingLabels{jobset.JobSetNameKey: js.Name}); err != nil {
+		return false, err
+	}
+	jobKeys := make(map[string]bool, len(jobs))
+	for _, job := range jobs {
+		jobKeys[job.Labels[jobset.JobKey]] = true
+	}
+	for _, pod := range podList.Items {
+		if !jobKeys[pod.Labels[jobset.JobKey]] {
+			continue
+		}
+		if !podPhaseTerminal(pod.Status.Phase) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// findLastJobCompletionTime returns the latest completion time of the given Jobs, or nil if
+// none of them completed, e.g. if they succeeded by pod annotation.
+func findLastJobCompletionTime(jobs []*batchv1.Job) *metav1.Time {
+	var lastCompletionTime *metav1.Time
+	for _, job := range jobs {
+		completionTime := job.Status.CompletionTime
+		for _, c := range job.Status.Conditions {
+			if completionTime == nil && c.Type == batchv1.JobComplete && c.Status == corev1.ConditionTrue {
+				completionTime = &c.LastTransitionTime
+			}
+		}
+		if completionTime != nil && (lastCompletionTime == nil || lastCompletionTime.Before(completionTime)) {
+			lastCompletionTime = completionTime
+		}
+	}
+	return lastCompletionTime
+}
+
+// setInsufficientSuccessfulJobsCondition sets the InsufficientSuccessfulJobs condition of a
+// failed JobSet, reporting how many of the child Jobs required by its success policy succeeded
+// before it failed, based on the statuses of its replicated jobs. The reason of the condition
+// is the reason the JobSet failed, and the message is suffixed with the given message.
+func setInsufficientSuccessfulJobsCondition(js *jobset.JobSet, rjobStatuses []jobset.ReplicatedJobStatus, reason, msg string, updateStatusOpts *statusUpdateOpts) {
+	if js.Spec.SuccessPolicy == nil {
+		return
+	}
+	succeeded, required := numJobsSucceeded(js, rjobStatuses), numJobsExpectedToSucceed(js)
+	if succeeded >= required {
+		return
+	}
+	setCondition(js, &conditionOpts{
+		eventType: corev1.EventTypeWarning,
+		condition: &metav1.Condition{
+			Type:    string(jobset.JobSetInsufficientSuccessfulJobs),
+			Status:  metav1.ConditionTrue,
+			Reason:  reason,
+			Message: fmt.Sprintf("%d/%d %s", succeeded, required, msg),
+		},
+	}, updateStatusOpts)
+}
+
+// numJobsSucceeded returns the number of succeeded child Jobs of the replicated jobs
+// matching the success policy of the JobSet.
+func numJobsSucceeded(js *jobset.JobSet, rjobStatuses []jobset.ReplicatedJobStatus) int {
+	total := 0
+	for _, rjob := range js.Spec.ReplicatedJobs {
+		if replicatedJobMatchesSuccessPolicy(js, &rjob) {
+			total += int(findReplicatedJobStatus(rjobStatuses, rjob.Name).Succeeded)
+		}
+	}
+	return total
+}
+
+// executeCompletionTimeouts moves the active Jobs of the replicated jobs exceeding their
+// completion timeout to the failed Jobs, so they are handled by the failure policy. The
+// moved Jobs are given a JobFailed condition, in memory only, at the time the timeout was
+// exceeded. It returns how long until the next replicated job exceeds its completion
+// timeout, or 0 if none will.
+func executeCompletionTimeouts(js *jobset.JobSet, ownedJobs *childJobs, now time.Time) time.Duration {
+	var requeueAfter time.Duration
+	deadlines := map[string]time.Time{}
+	for _, rjob := range js.Spec.ReplicatedJobs {
+		if rjob.CompletionTimeoutSeconds == nil {
+			continue
+		}
+		startTime := findFirstJobStartTime(rjob.Name, ownedJobs)
+		if startTime == nil {
+			continue
+		}
+		deadline := startTime.Add(time.Duration(*rjob.CompletionTimeoutSeconds) * time.Second)
+		if remaining := deadline.Sub(now); remaining > 0 {
+			if requeueAfter == 0 || remaining < requeueAfter {
+				requeueAfter = remaining
+			}
+			continue
+		}
+		deadlines[rjob.Name] = deadline
+	}
+	if len(deadlines) == 0 {
+		return requeueAfter
+	}
+	var active []*batchv1.Job
+	for _, job := range ownedJobs.active {
+		if deadline, exceeded := deadlines[job.Labels[jobset.ReplicatedJobNameKey]]; exceeded {
+			ownedJobs.failed = append(ownedJobs.failed, completionTimeoutExceededJob(job, deadline))
+			continue
+		}
+		active = append(active, job)
+	}
+	ownedJobs.active = active
+	return requeueAfter
+}
+
+// findFirstJobStartTime returns the earliest start time of the Jobs of the given replicated job
+// in the current JobSet run, or nil if none of them started.
+func findFirstJobStartTime(rjobName string, ownedJobs *childJobs) *metav1.Time {
+	var first *metav1.Time
+	for _, jobs := range [][]*batchv1.Job{ownedJobs.active, ownedJobs.successful, ownedJobs.failed} {
+		for _, job := range jobs {
+			if job.Labels[jobset.ReplicatedJobNameKey] != rjobName || job.Status.StartTime == nil {
+				continue
+			}
+			if first == nil || job.Status.StartTime.Before(first) {
+				first = job.Status.StartTime
+			}
+		}
+	}
+	return first
+}
+
+// completionTimeoutExceededJob returns a copy of the Job with a JobFailed condition recording
+// that its replicated job exceeded its completion timeout at the given time.
+func completionTimeoutExceededJob(job *batchv1.Job, deadline time.Time) *batchv1.Job {
+	failed := job.DeepCopy()
+	failed.Status.Conditions = append(failed.Status.Conditions, batchv1.JobCondition{
+		Type:               batchv1.JobFailed,
+		Status:             corev1.ConditionTrue,
+		Reason:             constants.CompletionTimeoutExceededReason,
+		Message:            constants.CompletionTimeoutExceededMessage,
+		LastTransitionTime: metav1.NewTime(deadline),
+	})
+	return failed
+}
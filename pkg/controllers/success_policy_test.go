@@ -14,12 +14,26 @@ limitations under the License.
 package controllers
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	jobset "sigs.k8s.io/jobset/api/jobset/v1alpha2"
+	"sigs.k8s.io/jobset/pkg/constants"
+	"sigs.k8s.io/jobset/pkg/util/placement"
 	testutils "sigs.k8s.io/jobset/pkg/util/testing"
 )
 
@@ -69,6 +83,17 @@ func TestJobMatchesSuccessPolicy(t *testing.T) {
 				Obj(),
 			expected: false,
 		},
+		{
+			name: "job of a non-critical replicated job",
+			js: testutils.MakeJobSet(jobSetName, ns).
+				SuccessPolicy(&jobset.SuccessPolicy{}).
+				ReplicatedJob(testutils.MakeReplicatedJob("test-replicated-job-1").NonCritical(true).Obj()).
+				Obj(),
+			job: testutils.MakeJob(jobName, ns).
+				JobLabels(map[string]string{jobset.ReplicatedJobNameKey: "test-replicated-job-1"}).
+				Obj(),
+			expected: false,
+		},
 	}
 
 	for _, tc := range tests {
@@ -120,6 +145,13 @@ func TestReplicatedJobMatchesSuccessPolicy(t *testing.T) {
 			replicatedJob: testutils.MakeReplicatedJob("test-replicated-job-3").Obj(),
 			expected:      false,
 		},
+		{
+			name: "non-critical replicated job",
+			js: testutils.MakeJobSet(jobSetName, ns).
+				SuccessPolicy(&jobset.SuccessPolicy{}).Obj(),
+			replicatedJob: testutils.MakeReplicatedJob("test-replicated-job-1").NonCritical(true).Obj(),
+			expected:      false,
+		},
 	}
 
 	for _, tc := range tests {
@@ -268,6 +300,19 @@ func TestNumJobsExpectedToSucceed(t *testing.T) {
 					Replicas(3).Obj()).Obj(),
 			expected: 4,
 		},
+		{
+			name: "total succeeded target takes precedence over the operator",
+			js: testutils.MakeJobSet(jobSetName, ns).
+				SuccessPolicy(&jobset.SuccessPolicy{
+					Operator:       jobset.OperatorAll,
+					TotalSucceeded: ptr.To[int32](5),
+				}).
+				ReplicatedJob(testutils.MakeReplicatedJob("test-replicated-job-1").
+					Replicas(4).Obj()).
+				ReplicatedJob(testutils.MakeReplicatedJob("test-replicated-job-2").
+					Replicas(4).Obj()).Obj(),
+			expected: 5,
+		},
 	}
 
 	for _, tc := range tests {
@@ -279,3 +324,960 @@ func TestNumJobsExpectedToSucceed(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileDriverSuccessTearsDownWorkers(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	js := testutils.MakeJobSet(jobSetName, ns).
+		SuccessPolicy(&jobset.SuccessPolicy{Operator: jobset.OperatorAll, TargetReplicatedJobs: []string{"driver"}}).
+		ReplicatedJob(testutils.MakeReplicatedJob("driver").Replicas(1).Obj()).
+		ReplicatedJob(testutils.MakeReplicatedJob("workers").Replicas(2).Obj()).
+		Obj()
+	js.UID = "test-uid"
+	childJob := func(rjobName string, jobIdx int, replicas int, condition batchv1.JobConditionType) *batchv1.Job {
+		job := makeJob(&makeJobArgs{
+			jobSetName:        jobSetName,
+			replicatedJobName: rjobName,
+			jobName:           placement.GenJobName(jobSetName, rjobName, jobIdx),
+			ns:                ns,
+			replicas:          replicas,
+			jobIdx:            jobIdx,
+		}).Parallelism(1).Obj()
+		job.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(js, jobset.GroupVersion.WithKind("JobSet"))}
+		if condition != "" {
+			job.Status.Conditions = []batchv1.JobCondition{{Type: condition, Status: corev1.ConditionTrue}}
+		}
+		return job
+	}
+	driver := childJob("driver", 0, 1, batchv1.JobComplete)
+	failedWorker := childJob("workers", 0, 2, batchv1.JobFailed)
+	activeWorker := childJob("workers", 1, 2, "")
+
+	fakeClient := newFakeClientBuilder().
+		WithObjects(js, driver, failedWorker, activeWorker).
+		WithStatusSubresource(js).
+		Build()
+	r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{})
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: jobSetName, Namespace: ns}}
+
+	// The driver succeeded while a worker failed, so the JobSet completes instead of failing.
+	// The next reconcile of the completed JobSet tears down the remaining workers, and the
+	// failed worker does not flip the JobSet to failed.
+	reconcileJobSet(t, r, req, 2)
+
+	var got jobset.JobSet
+	if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+		t.Fatalf("unexpected error getting jobset: %v", err)
+	}
+	if !meta.IsStatusConditionTrue(got.Status.Conditions, string(jobset.JobSetCompleted)) {
+		t.Errorf("expected jobset to be completed, got conditions %v", got.Status.Conditions)
+	}
+	if meta.IsStatusConditionTrue(got.Status.Conditions, string(jobset.JobSetFailed)) {
+		t.Errorf("expected jobset not to be failed, got conditions %v", got.Status.Conditions)
+	}
+	var worker batchv1.Job
+	err := fakeClient.Get(context.TODO(), types.NamespacedName{Name: activeWorker.Name, Namespace: ns}, &worker)
+	if !k8serrors.IsNotFound(err) {
+		t.Errorf("expected active worker job to be deleted, got error: %v", err)
+	}
+}
+
+func TestReconcileNonCriticalReplicatedJob(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	tests := []struct {
+		name               string
+		workersCondition   batchv1.JobConditionType
+		dashboardCondition batchv1.JobConditionType
+		wantCompleted      bool
+	}{
+		{
+			name:             "active non-critical job does not block completion",
+			workersCondition: batchv1.JobComplete,
+			wantCompleted:    true,
+		},
+		{
+			name:               "failed non-critical job does not fail the jobset",
+			dashboardCondition: batchv1.JobFailed,
+		},
+		{
+			name:               "failed non-critical job does not prevent completion",
+			workersCondition:   batchv1.JobComplete,
+			dashboardCondition: batchv1.JobFailed,
+			wantCompleted:      true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet(jobSetName, ns).
+				SuccessPolicy(&jobset.SuccessPolicy{Operator: jobset.OperatorAll}).
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").Replicas(1).Obj()).
+				ReplicatedJob(testutils.MakeReplicatedJob("dashboard").Replicas(1).NonCritical(true).Obj()).
+				Obj()
+			js.UID = "test-uid"
+			childJob := func(rjobName string, condition batchv1.JobConditionType) *batchv1.Job {
+				job := makeJob(&makeJobArgs{
+					jobSetName:        jobSetName,
+					replicatedJobName: rjobName,
+					jobName:           placement.GenJobName(jobSetName, rjobName, 0),
+					ns:                ns,
+					replicas:          1,
+				}).Parallelism(1).Obj()
+				job.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(js, jobset.GroupVersion.WithKind("JobSet"))}
+				if condition != "" {
+					job.Status.Conditions = []batchv1.JobCondition{{Type: condition, Status: corev1.ConditionTrue}}
+				}
+				return job
+			}
+			workers := childJob("workers", tc.workersCondition)
+			dashboard := childJob("dashboard", tc.dashboardCondition)
+
+			fakeClient := newFakeClientBuilder().
+				WithObjects(js, workers, dashboard).
+				WithStatusSubresource(js).
+				Build()
+			r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{})
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: jobSetName, Namespace: ns}}
+
+			// The second reconcile tears down the active non-critical job of a completed JobSet.
+			reconcileJobSet(t, r, req, 2)
+
+			var got jobset.JobSet
+			if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+				t.Fatalf("unexpected error getting jobset: %v", err)
+			}
+			if completed := meta.IsStatusConditionTrue(got.Status.Conditions, string(jobset.JobSetCompleted)); completed != tc.wantCompleted {
+				t.Errorf("unexpected completed condition: got %t, want %t", completed, tc.wantCompleted)
+			}
+			if meta.IsStatusConditionTrue(got.Status.Conditions, string(jobset.JobSetFailed)) {
+				t.Errorf("expected jobset not to be failed, got conditions %v", got.Status.Conditions)
+			}
+			var job batchv1.Job
+			err := fakeClient.Get(context.TODO(), types.NamespacedName{Name: dashboard.Name, Namespace: ns}, &job)
+			if deleted := k8serrors.IsNotFound(err); deleted != (tc.wantCompleted && tc.dashboardCondition == "") {
+				t.Errorf("unexpected non-critical job deletion: got deleted %t, error %v", deleted, err)
+			}
+		})
+	}
+}
+
+func TestReconcileTotalSucceeded(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	tests := []struct {
+		name string
+		// succeeded is the number of succeeded jobs of each replicated job.
+		succeeded     map[string]int
+		wantCompleted bool
+		wantJobs      int
+	}{
+		{
+			name:      "target not reached",
+			succeeded: map[string]int{"queue-a": 1, "queue-b": 1},
+			wantJobs:  6,
+		},
+		{
+			name:          "target reached by summing successes across replicated jobs",
+			succeeded:     map[string]int{"queue-a": 2, "queue-b": 1},
+			wantCompleted: true,
+			wantJobs:      3,
+		},
+		{
+			name:          "target reached within a single replicated job",
+			succeeded:     map[string]int{"queue-a": 3},
+			wantCompleted: true,
+			wantJobs:      3,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet(jobSetName, ns).
+				SuccessPolicy(&jobset.SuccessPolicy{Operator: jobset.OperatorAll, TotalSucceeded: ptr.To[int32](3)}).
+				ReplicatedJob(testutils.MakeReplicatedJob("queue-a").Replicas(3).Obj()).
+				ReplicatedJob(testutils.MakeReplicatedJob("queue-b").Replicas(3).Obj()).
+				Obj()
+			js.UID = "test-uid"
+			objs := []client.Object{js}
+			for _, rjob := range js.Spec.ReplicatedJobs {
+				for jobIdx := 0; jobIdx < int(rjob.Replicas); jobIdx++ {
+					job := makeJob(&makeJobArgs{
+						jobSetName:        jobSetName,
+						replicatedJobName: rjob.Name,
+						jobName:           placement.GenJobName(jobSetName, rjob.Name, jobIdx),
+						ns:                ns,
+						replicas:          int(rjob.Replicas),
+						jobIdx:            jobIdx,
+					}).Parallelism(1).Obj()
+					job.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(js, jobset.GroupVersion.WithKind("JobSet"))}
+					if jobIdx < tc.succeeded[rjob.Name] {
+						job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+					}
+					objs = append(objs, job)
+				}
+			}
+			fakeClient := newFakeClientBuilder().
+				WithObjects(objs...).
+				WithStatusSubresource(js).
+				Build()
+			r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{})
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: jobSetName, Namespace: ns}}
+
+			// The first reconcile evaluates the success policy, and the next reconcile of
+			// a completed JobSet deletes the remaining active jobs.
+			reconcileJobSet(t, r, req, 2)
+
+			var got jobset.JobSet
+			if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+				t.Fatalf("unexpected error getting jobset: %v", err)
+			}
+			if gotCompleted := meta.IsStatusConditionTrue(got.Status.Conditions, string(jobset.JobSetCompleted)); gotCompleted != tc.wantCompleted {
+				t.Errorf("expected completed %v, got conditions %v", tc.wantCompleted, got.Status.Conditions)
+			}
+			var jobs batchv1.JobList
+			if err := fakeClient.List(context.TODO(), &jobs, client.InNamespace(ns)); err != nil {
+				t.Fatalf("unexpected error listing jobs: %v", err)
+			}
+			if len(jobs.Items) != tc.wantJobs {
+				t.Errorf("expected %d jobs, got %d", tc.wantJobs, len(jobs.Items))
+			}
+		})
+	}
+}
+
+func TestRecordSucceededJobs(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	completed := func(job *batchv1.Job) *batchv1.Job {
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+		return job
+	}
+	makeTestJob := func(rjobName string, jobIdx int) *batchv1.Job {
+		return makeJob(&makeJobArgs{
+			jobSetName:        jobSetName,
+			replicatedJobName: rjobName,
+			jobName:           placement.GenJobName(jobSetName, rjobName, jobIdx),
+			ns:                ns,
+			replicas:          2,
+			jobIdx:            jobIdx,
+		}).Obj()
+	}
+	makeJS := func(countAcrossRestarts bool) *testutils.JobSetWrapper {
+		return testutils.MakeJobSet(jobSetName, ns).
+			SuccessPolicy(&jobset.SuccessPolicy{
+				Operator:             jobset.OperatorAll,
+				TargetReplicatedJobs: []string{"workers"},
+				CountAcrossRestarts:  ptr.To(countAcrossRestarts),
+			}).
+			ReplicatedJob(testutils.MakeReplicatedJob("driver").Replicas(2).Obj()).
+			ReplicatedJob(testutils.MakeReplicatedJob("workers").Replicas(2).Obj())
+	}
+
+	tests := []struct {
+		name             string
+		js               *jobset.JobSet
+		recorded         []string
+		ownedJobs        *childJobs
+		want             []string
+		wantShouldUpdate bool
+	}{
+		{
+			name: "not counted across restarts",
+			js:   makeJS(false).Obj(),
+			ownedJobs: &childJobs{
+				successful: []*batchv1.Job{completed(makeTestJob("workers", 0))},
+			},
+		},
+		{
+			name: "succeeded jobs of the target replicated jobs are recorded",
+			js:   makeJS(true).Obj(),
+			ownedJobs: &childJobs{
+				successful: []*batchv1.Job{
+					completed(makeTestJob("workers", 1)),
+					completed(makeTestJob("workers", 0)),
+					completed(makeTestJob("driver", 0)),
+				},
+			},
+			want:             []string{"test-jobset-workers-0", "test-jobset-workers-1"},
+			wantShouldUpdate: true,
+		},
+		{
+			name:     "jobs already recorded are not counted twice",
+			js:       makeJS(true).Obj(),
+			recorded: []string{"test-jobset-workers-0"},
+			ownedJobs: &childJobs{
+				successful: []*batchv1.Job{completed(makeTestJob("workers", 0))},
+			},
+			want: []string{"test-jobset-workers-0"},
+		},
+		{
+			name: "succeeded jobs of the previous run are recorded",
+			js:   makeJS(true).Obj(),
+			ownedJobs: &childJobs{
+				delete: []*batchv1.Job{
+					completed(makeTestJob("workers", 1)),
+					makeTestJob("workers", 0),
+				},
+			},
+			want:             []string{"test-jobset-workers-1"},
+			wantShouldUpdate: true,
+		},
+		{
+			name: "succeeded jobs beyond the replicas are not recorded",
+			js:   makeJS(true).Obj(),
+			ownedJobs: &childJobs{
+				delete: []*batchv1.Job{completed(makeTestJob("workers", 2))},
+			},
+		},
+		{
+			name:             "recorded jobs beyond the scaled down replicas are removed",
+			js:               makeJS(true).SetAnnotations(map[string]string{jobset.ReplicasKey: "workers=1"}).Obj(),
+			recorded:         []string{"test-jobset-workers-0", "test-jobset-workers-1"},
+			ownedJobs:        &childJobs{},
+			want:             []string{"test-jobset-workers-0"},
+			wantShouldUpdate: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.js.Status.SucceededJobs = tc.recorded
+			updateStatusOpts := &statusUpdateOpts{}
+			recordSucceededJobs(tc.js, tc.ownedJobs, updateStatusOpts)
+			if diff := cmp.Diff(tc.want, tc.js.Status.SucceededJobs); diff != "" {
+				t.Errorf("unexpected succeeded jobs (-want/+got): %s", diff)
+			}
+			if updateStatusOpts.shouldUpdate != tc.wantShouldUpdate {
+				t.Errorf("unexpected shouldUpdate: got %v, want %v", updateStatusOpts.shouldUpdate, tc.wantShouldUpdate)
+			}
+		})
+	}
+}
+
+func TestReconcileSuccessesCountedAcrossRestarts(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	tests := []struct {
+		name string
+		// secondRunSucceeded is the index of the job which succeeds in the second run.
+		secondRunSucceeded int
+		wantCompleted      bool
+	}{
+		{
+			name:               "jobs succeeded in different runs complete the jobset",
+			secondRunSucceeded: 1,
+			wantCompleted:      true,
+		},
+		{
+			name:               "job succeeded in both runs is counted once",
+			secondRunSucceeded: 0,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet(jobSetName, ns).
+				SuccessPolicy(&jobset.SuccessPolicy{Operator: jobset.OperatorAll, CountAcrossRestarts: ptr.To(true)}).
+				FailurePolicy(&jobset.FailurePolicy{MaxRestarts: 1}).
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").
+					Job(testutils.MakeJobTemplate("job", ns).Obj()).
+					Replicas(2).
+					Obj()).
+				Obj()
+			js.Spec.ReplicatedJobs[0].Template.Spec.Parallelism = ptr.To[int32](1)
+			fakeClient := newFakeClientBuilder().
+				WithObjects(js).
+				WithStatusSubresource(js).
+				Build()
+			r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(100), JobSetReconcilerOptions{})
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: jobSetName, Namespace: ns}}
+			reconcile := func() {
+				t.Helper()
+				reconcileJobSet(t, r, req, 1)
+			}
+			finishJob := func(jobIdx int, conditionType batchv1.JobConditionType) {
+				t.Helper()
+				var job batchv1.Job
+				key := types.NamespacedName{Name: placement.GenJobName(jobSetName, "workers", jobIdx), Namespace: ns}
+				if err := fakeClient.Get(context.TODO(), key, &job); err != nil {
+					t.Fatalf("unexpected error getting job: %v", err)
+				}
+				job.Status.Conditions = []batchv1.JobCondition{{Type: conditionType, Status: corev1.ConditionTrue}}
+				if err := fakeClient.Status().Update(context.TODO(), &job); err != nil {
+					t.Fatalf("unexpected error updating job: %v", err)
+				}
+			}
+			getJobSet := func() *jobset.JobSet {
+				t.Helper()
+				var got jobset.JobSet
+				if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+					t.Fatalf("unexpected error getting jobset: %v", err)
+				}
+				return &got
+			}
+
+			// In the first run, the first job succeeds and the second job fails, which restarts the JobSet.
+			reconcile()
+			finishJob(0, batchv1.JobComplete)
+			finishJob(1, batchv1.JobFailed)
+			reconcile()
+			if got := getJobSet(); got.Status.Restarts != 1 {
+				t.Fatalf("expected the jobset to restart, got %d restarts", got.Status.Restarts)
+			}
+
+			// The jobs of the first run are deleted and recreated.
+			reconcile()
+			reconcile()
+			var jobs batchv1.JobList
+			if err := fakeClient.List(context.TODO(), &jobs, client.InNamespace(ns)); err != nil {
+				t.Fatalf("unexpected error listing jobs: %v", err)
+			}
+			for _, job := range jobs.Items {
+				if job.Labels[constants.RestartsKey] != "1" {
+					t.Fatalf("expected job %s of the second run, got restart attempt %s", job.Name, job.Labels[constants.RestartsKey])
+				}
+			}
+			if len(jobs.Items) != 2 {
+				t.Fatalf("expected 2 jobs, got %d", len(jobs.Items))
+			}
+
+			// In the second run, one job succeeds.
+			finishJob(tc.secondRunSucceeded, batchv1.JobComplete)
+			reconcile()
+
+			got := getJobSet()
+			if gotCompleted := meta.IsStatusConditionTrue(got.Status.Conditions, string(jobset.JobSetCompleted)); gotCompleted != tc.wantCompleted {
+				t.Errorf("expected completed %v, got conditions %v", tc.wantCompleted, got.Status.Conditions)
+			}
+			wantSucceededJobs := []string{placement.GenJobName(jobSetName, "workers", 0)}
+			if tc.wantCompleted {
+				wantSucceededJobs = append(wantSucceededJobs, placement.GenJobName(jobSetName, "workers", 1))
+			}
+			if diff := cmp.Diff(wantSucceededJobs, got.Status.SucceededJobs); diff != "" {
+				t.Errorf("unexpected succeeded jobs (-want/+got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestJobsToTearDownBeforeCompletion(t *testing.T) {
+	job := func(name, rjobName string) *batchv1.Job {
+		return testutils.MakeJob(name, "default").JobLabels(map[string]string{jobset.ReplicatedJobNameKey: rjobName}).Obj()
+	}
+	tests := []struct {
+		name             string
+		completionPolicy *jobset.CompletionPolicy
+		active           []*batchv1.Job
+		want             []string
+	}{
+		{
+			name:   "no completion policy",
+			active: []*batchv1.Job{job("driver-0", "driver"), job("workers-0", "workers")},
+		},
+		{
+			name:             "any order",
+			completionPolicy: &jobset.CompletionPolicy{Order: jobset.CompletionPolicyAnyOrder},
+			active:           []*batchv1.Job{job("driver-0", "driver"), job("workers-0", "workers")},
+		},
+		{
+			name:             "targets last tears down the jobs not targeted by the success policy",
+			completionPolicy: &jobset.CompletionPolicy{Order: jobset.CompletionPolicyTargetsLast},
+			active:           []*batchv1.Job{job("driver-0", "driver"), job("workers-0", "workers"), job("workers-1", "workers")},
+			want:             []string{"workers-0", "workers-1"},
+		},
+		{
+			name:             "targets last without active jobs not targeted by the success policy",
+			completionPolicy: &jobset.CompletionPolicy{Order: jobset.CompletionPolicyTargetsLast},
+			active:           []*batchv1.Job{job("driver-0", "driver")},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet("js", "default").
+				SuccessPolicy(&jobset.SuccessPolicy{Operator: jobset.OperatorAll, TargetReplicatedJobs: []string{"driver"}}).
+				Obj()
+			js.Spec.CompletionPolicy = tc.completionPolicy
+			var got []string
+			for _, job := range jobsToTearDownBeforeCompletion(js, &childJobs{active: tc.active}) {
+				got = append(got, job.Name)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected jobs to tear down (-want/+got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestReconcileCompletionPolicyTargetsLast(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	js := testutils.MakeJobSet(jobSetName, ns).
+		SuccessPolicy(&jobset.SuccessPolicy{Operator: jobset.OperatorAll, TargetReplicatedJobs: []string{"driver"}}).
+		ReplicatedJob(testutils.MakeReplicatedJob("driver").Replicas(1).Obj()).
+		ReplicatedJob(testutils.MakeReplicatedJob("workers").Replicas(1).Obj()).
+		Obj()
+	js.Spec.CompletionPolicy = &jobset.CompletionPolicy{Order: jobset.CompletionPolicyTargetsLast}
+	js.UID = "test-uid"
+	makeChildJob := func(rjobName string) *batchv1.Job {
+		job := makeJob(&makeJobArgs{
+			jobSetName:        jobSetName,
+			jobSetUID:         string(js.UID),
+			replicatedJobName: rjobName,
+			jobName:           placement.GenJobName(jobSetName, rjobName, 0),
+			ns:                ns,
+			replicas:          1,
+		}).Parallelism(1).Obj()
+		job.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(js, jobset.GroupVersion.WithKind("JobSet"))}
+		return job
+	}
+	driver := makeChildJob("driver")
+	driver.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	// The finalizer keeps the worker terminating once it is deleted, like its pods would in a
+	// foreground deletion.
+	worker := makeChildJob("workers")
+	worker.Finalizers = []string{"test.jobset.sigs.k8s.io/pods"}
+
+	fakeClient := newFakeClientBuilder().
+		WithObjects(js, driver, worker).
+		WithStatusSubresource(js).
+		Build()
+	r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{})
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: jobSetName, Namespace: ns}}
+	assertJobSet := func(wantCompleted bool, wantJobs []string) {
+		t.Helper()
+		reconcileJobSet(t, r, req, 1)
+		var got jobset.JobSet
+		if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+			t.Fatalf("unexpected error getting jobset: %v", err)
+		}
+		if gotCompleted := meta.IsStatusConditionTrue(got.Status.Conditions, string(jobset.JobSetCompleted)); gotCompleted != wantCompleted {
+			t.Errorf("unexpected %s condition: got %t, want %t", jobset.JobSetCompleted, gotCompleted, wantCompleted)
+		}
+		var jobs batchv1.JobList
+		if err := fakeClient.List(context.TODO(), &jobs, client.InNamespace(ns)); err != nil {
+			t.Fatalf("unexpected error listing jobs: %v", err)
+		}
+		var gotJobs []string
+		for _, job := range jobs.Items {
+			gotJobs = append(gotJobs, job.Name)
+		}
+		if diff := cmp.Diff(wantJobs, gotJobs); diff != "" {
+			t.Errorf("unexpected jobs (-want/+got): %s", diff)
+		}
+	}
+
+	// The workers are torn down first, while the JobSet is not completed yet.
+	assertJobSet(false, []string{driver.Name, worker.Name})
+	var terminating batchv1.Job
+	if err := fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(worker), &terminating); err != nil {
+		t.Fatalf("unexpected error getting worker job: %v", err)
+	}
+	if terminating.DeletionTimestamp == nil {
+		t.Fatalf("expected worker job %s to be deleted", worker.Name)
+	}
+	// The JobSet is not completed while the workers are terminating.
+	assertJobSet(false, []string{driver.Name, worker.Name})
+
+	// The JobSet is completed once the workers are gone.
+	terminating.Finalizers = nil
+	if err := fakeClient.Update(context.TODO(), &terminating); err != nil {
+		t.Fatalf("unexpected error removing the finalizer of the worker job: %v", err)
+	}
+	assertJobSet(true, []string{driver.Name})
+}
+
+func TestReconcileCompletionGracePeriod(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+		jobName    = placement.GenJobName(jobSetName, "workers", 0)
+		now        = time.Now().Truncate(time.Second)
+	)
+	tests := []struct {
+		name               string
+		gracePeriodSeconds *int32
+		completedAgo       time.Duration
+		podPhase           corev1.PodPhase
+		wantCompleted      bool
+		wantRequeueAfter   time.Duration
+	}{
+		{
+			name:          "no grace period",
+			completedAgo:  10 * time.Second,
+			podPhase:      corev1.PodRunning,
+			wantCompleted: true,
+		},
+		{
+			name:               "completion is delayed while pods are running",
+			gracePeriodSeconds: ptr.To[int32](60),
+			completedAgo:       10 * time.Second,
+			podPhase:           corev1.PodRunning,
+			wantRequeueAfter:   50 * time.Second,
+		},
+		{
+			name:               "completion is delayed until the end of the grace period",
+			gracePeriodSeconds: ptr.To[int32](60),
+			completedAgo:       58 * time.Second,
+			podPhase:           corev1.PodRunning,
+			wantRequeueAfter:   2 * time.Second,
+		},
+		{
+			name:               "completed once pods terminated",
+			gracePeriodSeconds: ptr.To[int32](60),
+			completedAgo:       10 * time.Second,
+			podPhase:           corev1.PodSucceeded,
+			wantCompleted:      true,
+		},
+		{
+			name:               "completed once the grace period passed",
+			gracePeriodSeconds: ptr.To[int32](60),
+			completedAgo:       2 * time.Minute,
+			podPhase:           corev1.PodRunning,
+			wantCompleted:      true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet(jobSetName, ns).
+				SuccessPolicy(&jobset.SuccessPolicy{Operator: jobset.OperatorAll, CompletionGracePeriodSeconds: tc.gracePeriodSeconds}).
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").Replicas(1).Obj()).
+				Obj()
+			js.UID = "test-uid"
+			completionTime := metav1.NewTime(now.Add(-tc.completedAgo))
+			job := makeJob(&makeJobArgs{
+				jobSetName:        jobSetName,
+				replicatedJobName: "workers",
+				jobName:           jobName,
+				ns:                ns,
+				replicas:          1,
+				jobIdx:            0,
+			}).Obj()
+			job.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(js, jobset.GroupVersion.WithKind("JobSet"))}
+			job.Status.CompletionTime = &completionTime
+			job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue, LastTransitionTime: completionTime}}
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod",
+					Namespace: ns,
+					Labels:    map[string]string{jobset.JobSetNameKey: jobSetName, jobset.JobKey: jobHashKey(ns, jobName)},
+				},
+				Status: corev1.PodStatus{Phase: tc.podPhase},
+			}
+
+			fakeClient := newFakeClientBuilder().
+				WithObjects([]client.Object{js, job, pod}...).
+				WithStatusSubresource(js).
+				Build()
+			r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{})
+			r.clock = clocktesting.NewFakeClock(now)
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: jobSetName, Namespace: ns}}
+			result := reconcileJobSet(t, r, req, 1)
+			if result.RequeueAfter != tc.wantRequeueAfter {
+				t.Errorf("unexpected requeue after: got %v, want %v", result.RequeueAfter, tc.wantRequeueAfter)
+			}
+
+			var got jobset.JobSet
+			if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+				t.Fatalf("unexpected error getting jobset: %v", err)
+			}
+			if gotCompleted := meta.IsStatusConditionTrue(got.Status.Conditions, string(jobset.JobSetCompleted)); gotCompleted != tc.wantCompleted {
+				t.Errorf("unexpected %s condition: got %t, want %t", jobset.JobSetCompleted, gotCompleted, tc.wantCompleted)
+			}
+		})
+	}
+}
+
+func TestReconcileInsufficientSuccessfulJobsCondition(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+		now        = time.Now()
+	)
+	tests := []struct {
+		name                  string
+		successPolicy         *jobset.SuccessPolicy
+		activeDeadlineSeconds int64
+		succeeded             int
+		failed                int
+		// wantCondition is the InsufficientSuccessfulJobs condition, or nil if none is expected.
+		wantCondition *metav1.Condition
+	}{
+		{
+			name:                  "deadline exceeded before all jobs succeeded",
+			successPolicy:         &jobset.SuccessPolicy{Operator: jobset.OperatorAll},
+			activeDeadlineSeconds: 60,
+			succeeded:             2,
+			wantCondition: &metav1.Condition{
+				Type:    string(jobset.JobSetInsufficientSuccessfulJobs),
+				Status:  metav1.ConditionTrue,
+				Reason:  constants.DeadlineExceededReason,
+				Message: "2/4 succeeded before deadline",
+			},
+		},
+		{
+			name:                  "deadline exceeded before the total succeeded quorum was met",
+			successPolicy:         &jobset.SuccessPolicy{Operator: jobset.OperatorAll, TotalSucceeded: ptr.To[int32](3)},
+			activeDeadlineSeconds: 60,
+			succeeded:             1,
+			wantCondition: &metav1.Condition{
+				Type:    string(jobset.JobSetInsufficientSuccessfulJobs),
+				Status:  metav1.ConditionTrue,
+				Reason:  constants.DeadlineExceededReason,
+				Message: "1/3 succeeded before deadline",
+			},
+		},
+		{
+			name:          "jobset failed before all jobs succeeded",
+			successPolicy: &jobset.SuccessPolicy{Operator: jobset.OperatorAll},
+			succeeded:     1,
+			failed:        1,
+			wantCondition: &metav1.Condition{
+				Type:    string(jobset.JobSetInsufficientSuccessfulJobs),
+				Status:  metav1.ConditionTrue,
+				Reason:  constants.FailedJobsReason,
+				Message: "1/4 succeeded before the jobset failed",
+			},
+		},
+		{
+			name:          "active jobset has no condition",
+			successPolicy: &jobset.SuccessPolicy{Operator: jobset.OperatorAll},
+			succeeded:     1,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			jsWrapper := testutils.MakeJobSet(jobSetName, ns).
+				SuccessPolicy(tc.successPolicy).
+				StartTime(&metav1.Time{Time: now.Add(-2 * time.Minute)}).
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").Replicas(4).Obj())
+			if tc.activeDeadlineSeconds > 0 {
+				jsWrapper = jsWrapper.ActiveDeadlineSeconds(tc.activeDeadlineSeconds)
+			}
+			js := jsWrapper.Obj()
+			js.UID = "test-uid"
+
+			objs := []client.Object{js}
+			for jobIdx := 0; jobIdx < tc.succeeded+tc.failed; jobIdx++ {
+				job := makeJob(&makeJobArgs{
+					jobSetName:        jobSetName,
+					replicatedJobName: "workers",
+					jobName:           placement.GenJobName(jobSetName, "workers", jobIdx),
+					ns:                ns,
+					replicas:          4,
+					jobIdx:            jobIdx,
+				}).Obj()
+				job.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(js, jobset.GroupVersion.WithKind("JobSet"))}
+				conditionType := batchv1.JobComplete
+				if jobIdx >= tc.succeeded {
+					conditionType = batchv1.JobFailed
+				}
+				job.Status.Conditions = []batchv1.JobCondition{{Type: conditionType, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(now)}}
+				objs = append(objs, job)
+			}
+
+			fakeClient := newFakeClientBuilder().
+				WithObjects(objs...).
+				WithStatusSubresource(js).
+				Build()
+			r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{})
+			r.clock = clocktesting.NewFakeClock(now)
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: jobSetName, Namespace: ns}}
+			reconcileJobSet(t, r, req, 1)
+
+			var got jobset.JobSet
+			if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+				t.Fatalf("unexpected error getting jobset: %v", err)
+			}
+			gotCondition := meta.FindStatusCondition(got.Status.Conditions, string(jobset.JobSetInsufficientSuccessfulJobs))
+			if tc.wantCondition == nil {
+				if gotCondition != nil {
+					t.Errorf("expected no %s condition, got %v", jobset.JobSetInsufficientSuccessfulJobs, gotCondition)
+				}
+				return
+			}
+			if gotCondition == nil {
+				t.Fatalf("expected %s condition, got %v", jobset.JobSetInsufficientSuccessfulJobs, got.Status.Conditions)
+			}
+			if gotCondition.Status != tc.wantCondition.Status || gotCondition.Reason != tc.wantCondition.Reason || gotCondition.Message != tc.wantCondition.Message {
+				t.Errorf("unexpected %s condition: got %v, want %v", jobset.JobSetInsufficientSuccessfulJobs, gotCondition, tc.wantCondition)
+			}
+		})
+	}
+}
+
+func TestExecuteCompletionTimeouts(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+		now        = time.Now().Truncate(time.Second)
+	)
+	makeStartedJob := func(rjobName string, startedAgo time.Duration) *batchv1.Job {
+		job := makeJob(&makeJobArgs{
+			jobSetName:        jobSetName,
+			replicatedJobName: rjobName,
+			jobName:           placement.GenJobName(jobSetName, rjobName, 0),
+			ns:                ns,
+			replicas:          1,
+			jobIdx:            0,
+		}).Obj()
+		startTime := metav1.NewTime(now.Add(-startedAgo))
+		job.Status.StartTime = &startTime
+		return job
+	}
+
+	tests := []struct {
+		name             string
+		rjobs            []jobset.ReplicatedJob
+		active           []*batchv1.Job
+		wantActive       int
+		wantFailed       int
+		wantRequeueAfter time.Duration
+	}{
+		{
+			name:       "no completion timeout",
+			rjobs:      []jobset.ReplicatedJob{testutils.MakeReplicatedJob("workers").Obj()},
+			active:     []*batchv1.Job{makeStartedJob("workers", time.Hour)},
+			wantActive: 1,
+		},
+		{
+			name:             "completion timeout not exceeded",
+			rjobs:            []jobset.ReplicatedJob{testutils.MakeReplicatedJob("workers").CompletionTimeoutSeconds(60).Obj()},
+			active:           []*batchv1.Job{makeStartedJob("workers", 20*time.Second)},
+			wantActive:       1,
+			wantRequeueAfter: 40 * time.Second,
+		},
+		{
+			name:       "completion timeout exceeded",
+			rjobs:      []jobset.ReplicatedJob{testutils.MakeReplicatedJob("workers").CompletionTimeoutSeconds(60).Obj()},
+			active:     []*batchv1.Job{makeStartedJob("workers", 2*time.Minute)},
+			wantFailed: 1,
+		},
+		{
+			name: "only the jobs of the overdue replicated job are failed",
+			rjobs: []jobset.ReplicatedJob{
+				testutils.MakeReplicatedJob("driver").CompletionTimeoutSeconds(300).Obj(),
+				testutils.MakeReplicatedJob("workers").CompletionTimeoutSeconds(60).Obj(),
+			},
+			active:           []*batchv1.Job{makeStartedJob("driver", 2*time.Minute), makeStartedJob("workers", 2*time.Minute)},
+			wantActive:       1,
+			wantFailed:       1,
+			wantRequeueAfter: 3 * time.Minute,
+		},
+		{
+			name:       "jobs not started yet",
+			rjobs:      []jobset.ReplicatedJob{testutils.MakeReplicatedJob("workers").CompletionTimeoutSeconds(60).Obj()},
+			active:     []*batchv1.Job{makeJob(&makeJobArgs{jobSetName: jobSetName, replicatedJobName: "workers", jobName: "job", ns: ns, replicas: 1}).Obj()},
+			wantActive: 1,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet(jobSetName, ns).Obj()
+			js.Spec.ReplicatedJobs = tc.rjobs
+			ownedJobs := &childJobs{active: tc.active}
+			gotRequeueAfter := executeCompletionTimeouts(js, ownedJobs, now)
+			if gotRequeueAfter != tc.wantRequeueAfter {
+				t.Errorf("unexpected requeue after: got %v, want %v", gotRequeueAfter, tc.wantRequeueAfter)
+			}
+			if len(ownedJobs.active) != tc.wantActive {
+				t.Errorf("unexpected number of active jobs: got %d, want %d", len(ownedJobs.active), tc.wantActive)
+			}
+			if len(ownedJobs.failed) != tc.wantFailed {
+				t.Errorf("unexpected number of failed jobs: got %d, want %d", len(ownedJobs.failed), tc.wantFailed)
+			}
+			for _, job := range ownedJobs.failed {
+				if findJobFailureTime(job) == nil {
+					t.Errorf("expected failed job %s to have a failure time", job.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestReconcileCompletionTimeout(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+		jobName    = placement.GenJobName(jobSetName, "workers", 0)
+		now        = time.Now().Truncate(time.Second)
+	)
+	tests := []struct {
+		name             string
+		failurePolicy    *jobset.FailurePolicy
+		startedAgo       time.Duration
+		wantRestarts     int32
+		wantFailed       bool
+		wantRequeueAfter time.Duration
+	}{
+		{
+			name:             "replicated job within its completion timeout",
+			failurePolicy:    &jobset.FailurePolicy{MaxRestarts: 1},
+			startedAgo:       30 * time.Second,
+			wantRequeueAfter: 30 * time.Second,
+		},
+		{
+			name:          "overdue replicated job restarts the jobset",
+			failurePolicy: &jobset.FailurePolicy{MaxRestarts: 1},
+			startedAgo:    2 * time.Minute,
+			wantRestarts:  1,
+		},
+		{
+			name:       "overdue replicated job fails the jobset without failure policy",
+			startedAgo: 2 * time.Minute,
+			wantFailed: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet(jobSetName, ns).
+				FailurePolicy(tc.failurePolicy).
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").Replicas(1).CompletionTimeoutSeconds(60).Obj()).
+				Obj()
+			js.UID = "test-uid"
+			job := makeJob(&makeJobArgs{
+				jobSetName:        jobSetName,
+				replicatedJobName: "workers",
+				jobName:           jobName,
+				ns:                ns,
+				replicas:          1,
+				jobIdx:            0,
+			}).Obj()
+			job.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(js, jobset.GroupVersion.WithKind("JobSet"))}
+			job.Spec.Parallelism = ptr.To[int32](1)
+			startTime := metav1.NewTime(now.Add(-tc.startedAgo))
+			job.Status.StartTime = &startTime
+
+			fakeClient := newFakeClientBuilder().
+				WithObjects([]client.Object{js, job}...).
+				WithStatusSubresource(js).
+				Build()
+			r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{})
+			r.clock = clocktesting.NewFakeClock(now)
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: jobSetName, Namespace: ns}}
+			result := reconcileJobSet(t, r, req, 1)
+			if result.RequeueAfter != tc.wantRequeueAfter {
+				t.Errorf("unexpected requeue after: got %v, want %v", result.RequeueAfter, tc.wantRequeueAfter)
+			}
+
+			var got jobset.JobSet
+			if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+				t.Fatalf("unexpected error getting jobset: %v", err)
+			}
+			if got.Status.Restarts != tc.wantRestarts {
+				t.Errorf("unexpected restarts: got %d, want %d", got.Status.Restarts, tc.wantRestarts)
+			}
+			if gotFailed := meta.IsStatusConditionTrue(got.Status.Conditions, string(jobset.JobSetFailed)); gotFailed != tc.wantFailed {
+				t.Errorf("unexpected %s condition: got %t, want %t", jobset.JobSetFailed, gotFailed, tc.wantFailed)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	jobset "sigs.k8s.io/jobset/api/jobset/v1alpha2"
+)
+
+const (
+	// tracerName is the name of the tracer of the JobSet controller.
+	tracerName = "sigs.k8s.io/jobset"
+
+	// Names of the spans of the JobSet lifecycle.
+	reconcileSpanName  = "Reconcile"
+	createJobsSpanName = "CreateJobs"
+	restartSpanName    = "Restart"
+	completeSpanName   = "Complete"
+)
+
+// newTracer returns the tracer of the JobSet controller from the given tracer provider,
+// defaulting to the global tracer provider if no tracer provider is set.
+func newTracer(provider trace.TracerProvider) trace.Tracer {
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+	return provider.Tracer(tracerName)
+}
+
+// startChildSpan starts a span of the JobSet lifecycle as a child of the span in the context,
+// using the tracer provider of that span. Without a span in the context, the span is a no-op.
+func startChildSpan(ctx context.Context, name string, js *jobset.JobSet) (context.Context, trace.Span) {
+	tracer := trace.SpanFromContext(ctx).TracerProvider().Tracer(tracerName)
+	return tracer.Start(ctx, name, trace.WithAttributes(jobSetSpanAttributes(js)...))
+}
+
+// traceLifecycleEvent records a step of the JobSet lifecycle, e.g. a restart, as a child span of
+// the reconciliation with the given additional attributes.
+func traceLifecycleEvent(ctx context.Context, name string, js *jobset.JobSet, attrs ...attribute.KeyValue) {
+	_, span := startChildSpan(ctx, name, js)
+	span.SetAttributes(attrs...)
+	span.End()
+}
+
+// jobSetSpanAttributes returns the attributes identifying the JobSet a span belongs to.
+func jobSetSpanAttributes(js *jobset.JobSet) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("jobset.namespace", js.Namespace),
+		attribute.String("jobset.name", js.Name),
+		attribute.String("jobset.uid", string(js.UID)),
+		attribute.Int64("jobset.generation", js.Generation),
+	}
+}
+
+// endSpan records the error, if any, on the span and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
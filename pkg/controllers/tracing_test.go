@@ -0,0 +1,140 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	jobset "sigs.k8s.io/jobset/api/jobset/v1alpha2"
+	"sigs.k8s.io/jobset/pkg/util/placement"
+	testutils "sigs.k8s.io/jobset/pkg/util/testing"
+)
+
+func TestReconcileTracing(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+		jobName    = placement.GenJobName(jobSetName, "workers", 0)
+	)
+	tests := []struct {
+		name          string
+		failurePolicy *jobset.FailurePolicy
+		jobCondition  *batchv1.JobConditionType
+		wantSpans     []string
+	}{
+		{
+			name:      "jobs are created",
+			wantSpans: []string{createJobsSpanName, reconcileSpanName},
+		},
+		{
+			name:          "jobset is restarted",
+			failurePolicy: &jobset.FailurePolicy{MaxRestarts: 1},
+			jobCondition:  ptr.To(batchv1.JobFailed),
+			wantSpans:     []string{restartSpanName, reconcileSpanName},
+		},
+		{
+			name:         "jobset completes",
+			jobCondition: ptr.To(batchv1.JobComplete),
+			wantSpans:    []string{completeSpanName, reconcileSpanName},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet(jobSetName, ns).
+				SuccessPolicy(&jobset.SuccessPolicy{Operator: jobset.OperatorAll}).
+				FailurePolicy(tc.failurePolicy).
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").Replicas(1).Obj()).
+				Obj()
+			js.UID = "test-uid"
+			js.Generation = 2
+			objs := []client.Object{js}
+			if tc.jobCondition != nil {
+				job := makeJob(&makeJobArgs{
+					jobSetName:        jobSetName,
+					replicatedJobName: "workers",
+					jobName:           jobName,
+					ns:                ns,
+					replicas:          1,
+					jobIdx:            0,
+				}).Obj()
+				job.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(js, jobset.GroupVersion.WithKind("JobSet"))}
+				job.Status.Conditions = []batchv1.JobCondition{{Type: *tc.jobCondition, Status: corev1.ConditionTrue, LastTransitionTime: metav1.Now()}}
+				objs = append(objs, job)
+			}
+
+			fakeClient := newFakeClientBuilder().
+				WithObjects(objs...).
+				WithStatusSubresource(js).
+				Build()
+			recorder := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+			r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{TracerProvider: provider})
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: jobSetName, Namespace: ns}}
+			reconcileJobSet(t, r, req, 1)
+
+			// Spans are recorded when they end, so child spans come before the reconcile span.
+			spans := recorder.Ended()
+			var gotSpans []string
+			for _, span := range spans {
+				gotSpans = append(gotSpans, span.Name())
+			}
+			if diff := cmp.Diff(tc.wantSpans, gotSpans); diff != "" {
+				t.Fatalf("unexpected spans (-want/+got): %s", diff)
+			}
+			root := spans[len(spans)-1]
+			for _, span := range spans {
+				attrs := attribute.NewSet(span.Attributes()...)
+				if uid, _ := attrs.Value("jobset.uid"); uid.AsString() != string(js.UID) {
+					t.Errorf("unexpected jobset.uid attribute of span %s: got %q, want %q", span.Name(), uid.AsString(), js.UID)
+				}
+				if generation, _ := attrs.Value("jobset.generation"); generation.AsInt64() != js.Generation {
+					t.Errorf("unexpected jobset.generation attribute of span %s: got %d, want %d", span.Name(), generation.AsInt64(), js.Generation)
+				}
+				if span != root && span.Parent().SpanID() != root.SpanContext().SpanID() {
+					t.Errorf("expected span %s to be a child of the %s span", span.Name(), root.Name())
+				}
+			}
+		})
+	}
+}
+
+func TestNewTracerDefaultsToGlobalProvider(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() {
+		otel.SetTracerProvider(trace.NewNoopTracerProvider())
+	})
+
+	_, span := newTracer(nil).Start(context.TODO(), reconcileSpanName)
+	span.End()
+	if got := len(recorder.Ended()); got != 1 {
+		t.Errorf("expected the span to be recorded by the global tracer provider, got %d spans", got)
+	}
+}
@@ -5,14 +5,20 @@ import (
 	"fmt"
 	"time"
 
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/clock"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	jobset "sigs.k8s.io/jobset/api/jobset/v1alpha2"
+	"sigs.k8s.io/jobset/pkg/constants"
 )
 
 // executeTTLAfterFinishedPolicy checks if the JobSet has a TTLSecondsAfterFinished set.
@@ -132,3 +138,199 @@ func deleteJobSet(ctx context.Context, c client.Client, js *jobset.JobSet) error
 
 	return c.Delete(ctx, js, options...)
 }
+
+// setJobTTLAfterFinished sets the JobSet level TTL of finished child Jobs on the Job, along
+// with the finalizer keeping the Job once it is deleted by the Job controller, until the JobSet
+// no longer needs its result.
+func setJobTTLAfterFinished(js *jobset.JobSet, job *batchv1.Job) {
+	if js.Spec.JobTTLSecondsAfterFinished == nil {
+		return
+	}
+	job.Spec.TTLSecondsAfterFinished = js.Spec.JobTTLSecondsAfterFinished
+	controllerutil.AddFinalizer(job, jobset.JobResultFinalizer)
+}
+
+// jobResultsToRelease returns the child Jobs whose result finalizer can be removed. The result
+// of a finished Job of the current run is kept until the JobSet finishes. Jobs of previous runs
+// and active Jobs being deleted are released right away, so they are never stuck.
+func jobResultsToRelease(js *jobset.JobSet, ownedJobs *childJobs) []*batchv1.Job {
+	var jobs []*batchv1.Job
+	release := func(job *batchv1.Job) {
+		if controllerutil.ContainsFinalizer(job, jobset.JobResultFinalizer) {
+			jobs = append(jobs, job)
+		}
+	}
+	for _, job := range ownedJobs.delete {
+		release(job)
+	}
+	for _, job := range ownedJobs.active {
+		if JobSetFinished(js) || job.DeletionTimestamp != nil {
+			release(job)
+		}
+	}
+	if JobSetFinished(js) {
+		for _, job := range ownedJobs.successful {
+			release(job)
+		}
+		for _, job := range ownedJobs.failed {
+			release(job)
+		}
+	}
+	return jobs
+}
+
+// releaseJobResults removes the result finalizer from the given child Jobs, allowing the
+// Jobs deleted by their TTL to be removed.
+func (r *JobSetReconciler) releaseJobResults(ctx context.Context, jobs []*batchv1.Job) error {
+	log := ctrl.LoggerFrom(ctx)
+	for _, job := range jobs {
+		patch := finalizersPatch(job)
+		if !controllerutil.RemoveFinalizer(job, jobset.JobResultFinalizer) {
+			continue
+		}
+		if err := r.Patch(ctx, job, patch, client.FieldOwner(r.fieldManager())); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		log.V(2).Info("released job result", "job", klog.KObj(job))
+	}
+	return nil
+}
+
+// jobsToCleanUp returns the finished child Jobs of a finished JobSet which are deleted by its
+// cleanup policy, depending on whether they succeeded or failed.
+func jobsToCleanUp(js *jobset.JobSet, ownedJobs *childJobs) []*batchv1.Job {
+	policy := js.Spec.CleanupPolicy
+	if policy == nil {
+		return nil
+	}
+	var jobs []*batchv1.Job
+	if policy.OnSuccess == jobset.CleanupPolicyDelete {
+		jobs = append(jobs, ownedJobs.successful...)
+	}
+	if policy.OnFailure == jobset.CleanupPolicyDelete {
+		jobs = append(jobs, ownedJobs.failed...)
+	}
+	return jobs
+}
+
+// ensureCleanupFinalizer adds the cleanup finalizer to the JobSet if it is not set yet.
+func (r *JobSetReconciler) ensureCleanupFinalizer(ctx context.Context, js *jobset.JobSet) error {
+	patch := finalizersPatch(js)
+	if !controllerutil.AddFinalizer(js, jobset.CleanupFinalizer) {
+		return nil
+	}
+	return r.Patch(ctx, js, patch, client.FieldOwner(r.fieldManager()))
+}
+
+// finalizeJobSet tears down the child resources of a deleted JobSet in order. The child Jobs
+// are deleted first, and the Services are only deleted once all the Jobs and their pods are
+// gone, so the pods can resolve each other until they terminate. The cleanup finalizer is
+// then removed, releasing the JobSet. If the child Jobs are not gone within the cleanup
+// timeout, the finalizer is removed anyway so the JobSet deletion is never stuck.
+func (r *JobSetReconciler) finalizeJobSet(ctx context.Context, js *jobset.JobSet) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx).WithValues("jobset", klog.KObj(js))
+	ctx = ctrl.LoggerInto(ctx, log)
+
+	// The child Jobs of a JobSet being deleted are no longer listed by the reconciliation.
+	r.jobListingRetries.reset(client.ObjectKeyFromObject(js))
+
+	if !controllerutil.ContainsFinalizer(js, jobset.CleanupFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	remaining := r.cleanupFinalizerTimeout() - r.clock.Since(js.DeletionTimestamp.Time)
+	if remaining <= 0 {
+		log.Info("timed out waiting for child jobs to be deleted, removing cleanup finalizer")
+		r.Record.Eventf(js, corev1.EventTypeWarning, constants.CleanupTimedOutReason, constants.CleanupTimedOutMessage)
+		return ctrl.Result{}, r.removeCleanupFinalizer(ctx, js)
+	}
+
+	var childJobList batchv1.JobList
+	if err := r.List(ctx, &childJobList, client.InNamespace(js.Namespace), client.MatchingFields{constants.JobOwnerKey: js.Name}); err != nil {
+		log.Error(err, "listing jobs owned by jobset")
+		return ctrl.Result{}, err
+	}
+
+	// Wait for the child Jobs to be deleted. Jobs are deleted in the foreground, so they are
+	// only gone once their pods are. Each Job deletion triggers another reconciliation, and
+	// the requeue ensures the timeout is honored.
+	if len(childJobList.Items) > 0 {
+		jobsToDelete := make([]*batchv1.Job, len(childJobList.Items))
+		for i := range childJobList.Items {
+			jobsToDelete[i] = &childJobList.Items[i]
+		}
+		// The results of the child Jobs are no longer needed once the JobSet is deleted.
+		if err := r.releaseJobResults(ctx, jobsToDelete); err != nil {
+			log.Error(err, "releasing job results")
+			return ctrl.Result{}, err
+		}
+		if err := r.deleteJobs(ctx, jobsToDelete, defaultDeleteOptions()); err != nil {
+			log.Error(err, "deleting jobs")
+			return ctrl.Result{}, err
+		}
+		log.V(2).Info("waiting for child jobs to be deleted", "jobs", len(jobsToDelete))
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	if err := r.deleteServices(ctx, js); err != nil {
+		log.Error(err, "deleting services")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, r.removeCleanupFinalizer(ctx, js)
+}
+
+// deleteServices deletes the headless and coordinator Services controlled by the JobSet, unless
+// network management is disabled.
+func (r *JobSetReconciler) deleteServices(ctx context.Context, js *jobset.JobSet) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	if r.opts.DisableNetworkManagement {
+		return nil
+	}
+
+	for _, name := range []string{GetSubdomain(js), coordinatorServiceName(js)} {
+		var svc corev1.Service
+		if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: js.Namespace}, &svc); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		// The headless service may be shared with other JobSets using the same subdomain.
+		if !metav1.IsControlledBy(&svc, js) || svc.DeletionTimestamp != nil {
+			continue
+		}
+		if err := r.Delete(ctx, &svc); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		log.V(2).Info("successfully deleted service", "service", klog.KObj(&svc))
+	}
+	return nil
+}
+
+// removeCleanupFinalizer removes the cleanup finalizer from the JobSet.
+func (r *JobSetReconciler) removeCleanupFinalizer(ctx context.Context, js *jobset.JobSet) error {
+	patch := finalizersPatch(js)
+	if !controllerutil.RemoveFinalizer(js, jobset.CleanupFinalizer) {
+		return nil
+	}
+	return client.IgnoreNotFound(r.Patch(ctx, js, patch, client.FieldOwner(r.fieldManager())))
+}
+
+// finalizersPatch returns a merge patch of the changes made to the finalizers of the given
+// object. A merge patch replaces the whole list of finalizers, so it fails with a conflict if
+// the object was changed since it was read, instead of dropping the finalizers added meanwhile.
+// Finalizers are not applied, as a server-side apply of the field manager which applied a child
+// Job on creation would remove all the other fields it applied then.
+func finalizersPatch(obj client.Object) client.Patch {
+	return client.MergeFromWithOptions(obj.DeepCopyObject().(client.Object), client.MergeFromWithOptimisticLock{})
+}
+
+// cleanupFinalizerTimeout returns the maximum time to wait for the child Jobs of a deleted
+// JobSet to be deleted.
+func (r *JobSetReconciler) cleanupFinalizerTimeout() time.Duration {
+	if r.opts.CleanupFinalizerTimeout > 0 {
+		return r.opts.CleanupFinalizerTimeout
+	}
+	return constants.DefaultCleanupFinalizerTimeout
+}
@@ -6,13 +6,20 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2/ktesting"
@@ -21,6 +28,8 @@ import (
 	"k8s.io/utils/ptr"
 
 	jobset "sigs.k8s.io/jobset/api/jobset/v1alpha2"
+	"sigs.k8s.io/jobset/pkg/constants"
+	"sigs.k8s.io/jobset/pkg/util/placement"
 	testutils "sigs.k8s.io/jobset/pkg/util/testing"
 )
 
@@ -356,3 +365,558 @@ func TestRequeueJobSetAfter(t *testing.T) {
 		})
 	}
 }
+
+func TestConstructJobWithJobTTLAfterFinished(t *testing.T) {
+	tests := []struct {
+		name           string
+		ttl            *int32
+		wantTTL        *int32
+		wantFinalizers []string
+	}{
+		{
+			name: "no job ttl",
+		},
+		{
+			name:           "job ttl is set on the job",
+			ttl:            ptr.To[int32](300),
+			wantTTL:        ptr.To[int32](300),
+			wantFinalizers: []string{jobset.JobResultFinalizer},
+		},
+		{
+			name:           "zero job ttl is set on the job",
+			ttl:            ptr.To[int32](0),
+			wantTTL:        ptr.To[int32](0),
+			wantFinalizers: []string{jobset.JobResultFinalizer},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet("test-jobset", "default").
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").
+					Job(testutils.MakeJobTemplate("job", "default").Obj()).
+					Replicas(2).
+					Obj()).
+				Obj()
+			js.Spec.JobTTLSecondsAfterFinished = tc.ttl
+			for jobIdx := 0; jobIdx < 2; jobIdx++ {
+				job, err := constructJob(js, &js.Spec.ReplicatedJobs[0], 0, jobIdx, "")
+				if err != nil {
+					t.Fatalf("constructJob() error = %v", err)
+				}
+				if diff := cmp.Diff(tc.wantTTL, job.Spec.TTLSecondsAfterFinished); diff != "" {
+					t.Errorf("unexpected ttlSecondsAfterFinished (-want/+got): %s", diff)
+				}
+				if diff := cmp.Diff(tc.wantFinalizers, job.Finalizers); diff != "" {
+					t.Errorf("unexpected finalizers (-want/+got): %s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestJobResultsToRelease(t *testing.T) {
+	job := func(name string, finalizer, deleted bool) *batchv1.Job {
+		j := testutils.MakeJob(name, "default").Obj()
+		if finalizer {
+			j.Finalizers = []string{jobset.JobResultFinalizer}
+		}
+		if deleted {
+			j.DeletionTimestamp = ptr.To(metav1.Now())
+		}
+		return j
+	}
+	active := testutils.MakeJobSet("js", "default").Obj()
+	completed := testutils.MakeJobSet("js", "default").CompletedCondition(metav1.Now()).Obj()
+
+	tests := []struct {
+		name      string
+		js        *jobset.JobSet
+		ownedJobs *childJobs
+		want      []string
+	}{
+		{
+			name: "results of finished jobs are kept while the jobset is active",
+			js:   active,
+			ownedJobs: &childJobs{
+				successful: []*batchv1.Job{job("succeeded", true, true)},
+				failed:     []*batchv1.Job{job("failed", true, false)},
+			},
+		},
+		{
+			name: "jobs of previous runs and deleted active jobs are released",
+			js:   active,
+			ownedJobs: &childJobs{
+				active: []*batchv1.Job{job("running", true, false), job("deleted", true, true)},
+				delete: []*batchv1.Job{job("previous-run", true, false)},
+			},
+			want: []string{"previous-run", "deleted"},
+		},
+		{
+			name: "all jobs are released once the jobset finished",
+			js:   completed,
+			ownedJobs: &childJobs{
+				active:     []*batchv1.Job{job("running", true, false)},
+				successful: []*batchv1.Job{job("succeeded", true, true)},
+				failed:     []*batchv1.Job{job("failed", true, false)},
+			},
+			want: []string{"running", "succeeded", "failed"},
+		},
+		{
+			name: "jobs without the finalizer are skipped",
+			js:   completed,
+			ownedJobs: &childJobs{
+				successful: []*batchv1.Job{job("succeeded", false, false)},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []string
+			for _, job := range jobResultsToRelease(tc.js, tc.ownedJobs) {
+				got = append(got, job.Name)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected released jobs (-want/+got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestReconcileKeepsJobResultsUntilJobSetFinishes(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	js := testutils.MakeJobSet(jobSetName, ns).
+		SuccessPolicy(&jobset.SuccessPolicy{Operator: jobset.OperatorAll}).
+		ReplicatedJob(testutils.MakeReplicatedJob("workers").Replicas(2).Obj()).
+		Obj()
+	js.Spec.JobTTLSecondsAfterFinished = ptr.To[int32](0)
+	js.UID = "test-uid"
+	childJob := func(jobIdx int) *batchv1.Job {
+		job := makeJob(&makeJobArgs{
+			jobSetName:        jobSetName,
+			replicatedJobName: "workers",
+			jobName:           placement.GenJobName(jobSetName, "workers", jobIdx),
+			ns:                ns,
+			replicas:          2,
+			jobIdx:            jobIdx,
+		}).Parallelism(1).Obj()
+		job.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(js, jobset.GroupVersion.WithKind("JobSet"))}
+		job.Finalizers = []string{jobset.JobResultFinalizer}
+		return job
+	}
+	// The first job finished and was deleted by the Job controller once its TTL expired.
+	deleted := childJob(0)
+	deleted.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	deleted.DeletionTimestamp = ptr.To(metav1.NewTime(time.Now()))
+	running := childJob(1)
+
+	fakeClient := newFakeClientBuilder().
+		WithObjects(js, deleted, running).
+		WithStatusSubresource(js, &batchv1.Job{}).
+		Build()
+	r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{})
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: jobSetName, Namespace: ns}}
+
+	reconcileAndCheck := func(wantJobs int, wantCompleted bool) {
+		t.Helper()
+		reconcileJobSet(t, r, req, 1)
+		var jobs batchv1.JobList
+		if err := fakeClient.List(context.TODO(), &jobs, client.InNamespace(ns)); err != nil {
+			t.Fatalf("unexpected error listing jobs: %v", err)
+		}
+		if len(jobs.Items) != wantJobs {
+			t.Errorf("expected %d jobs, got %d", wantJobs, len(jobs.Items))
+		}
+		var got jobset.JobSet
+		if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+			t.Fatalf("unexpected error getting jobset: %v", err)
+		}
+		if gotCompleted := meta.IsStatusConditionTrue(got.Status.Conditions, string(jobset.JobSetCompleted)); gotCompleted != wantCompleted {
+			t.Errorf("expected completed %v, got conditions %v", wantCompleted, got.Status.Conditions)
+		}
+	}
+
+	// The deleted job is kept for its result and not recreated while the JobSet is active.
+	reconcileAndCheck(2, false)
+	var got jobset.JobSet
+	if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+		t.Fatalf("unexpected error getting jobset: %v", err)
+	}
+	if succeeded := got.Status.ReplicatedJobsStatus[0].Succeeded; succeeded != 1 {
+		t.Errorf("expected 1 succeeded job, got %d", succeeded)
+	}
+
+	// Once the second job succeeds, the JobSet completes and the deleted job is released.
+	running.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	if err := fakeClient.Status().Update(context.TODO(), running); err != nil {
+		t.Fatalf("unexpected error updating job status: %v", err)
+	}
+	reconcileAndCheck(2, true)
+	reconcileAndCheck(1, true)
+}
+
+func TestJobsToCleanUp(t *testing.T) {
+	succeeded := testutils.MakeJob("succeeded", "default").Obj()
+	failed := testutils.MakeJob("failed", "default").Obj()
+	ownedJobs := &childJobs{
+		successful: []*batchv1.Job{succeeded},
+		failed:     []*batchv1.Job{failed},
+	}
+	tests := []struct {
+		name   string
+		policy *jobset.CleanupPolicy
+		want   []*batchv1.Job
+	}{
+		{
+			name: "no cleanup policy",
+		},
+		{
+			name:   "retain by default",
+			policy: &jobset.CleanupPolicy{},
+		},
+		{
+			name:   "delete successful jobs",
+			policy: &jobset.CleanupPolicy{OnSuccess: jobset.CleanupPolicyDelete, OnFailure: jobset.CleanupPolicyRetain},
+			want:   []*batchv1.Job{succeeded},
+		},
+		{
+			name:   "delete failed jobs",
+			policy: &jobset.CleanupPolicy{OnFailure: jobset.CleanupPolicyDelete},
+			want:   []*batchv1.Job{failed},
+		},
+		{
+			name:   "delete all finished jobs",
+			policy: &jobset.CleanupPolicy{OnSuccess: jobset.CleanupPolicyDelete, OnFailure: jobset.CleanupPolicyDelete},
+			want:   []*batchv1.Job{succeeded, failed},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet("test-jobset", "default").CleanupPolicy(tc.policy).Obj()
+			if diff := cmp.Diff(tc.want, jobsToCleanUp(js, ownedJobs)); diff != "" {
+				t.Errorf("unexpected jobs to clean up (-want/+got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestReconcileCleanupPolicy(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	succeededJobName := placement.GenJobName(jobSetName, "workers", 0)
+	failedJobName := placement.GenJobName(jobSetName, "workers", 1)
+	tests := []struct {
+		name     string
+		policy   *jobset.CleanupPolicy
+		wantJobs []string
+	}{
+		{
+			name:     "finished jobs are retained by default",
+			wantJobs: []string{succeededJobName, failedJobName},
+		},
+		{
+			name:     "successful jobs are deleted",
+			policy:   &jobset.CleanupPolicy{OnSuccess: jobset.CleanupPolicyDelete},
+			wantJobs: []string{failedJobName},
+		},
+		{
+			name:     "failed jobs are deleted",
+			policy:   &jobset.CleanupPolicy{OnFailure: jobset.CleanupPolicyDelete},
+			wantJobs: []string{succeededJobName},
+		},
+		{
+			name:   "all finished jobs are deleted",
+			policy: &jobset.CleanupPolicy{OnSuccess: jobset.CleanupPolicyDelete, OnFailure: jobset.CleanupPolicyDelete},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := testutils.MakeJobSet(jobSetName, ns).
+				SuccessPolicy(&jobset.SuccessPolicy{Operator: jobset.OperatorAll}).
+				ReplicatedJob(testutils.MakeReplicatedJob("workers").Replicas(2).Obj()).
+				CleanupPolicy(tc.policy).
+				Obj()
+			js.UID = "test-uid"
+			js.Status.Conditions = []metav1.Condition{{
+				Type:   string(jobset.JobSetFailed),
+				Status: metav1.ConditionTrue,
+				Reason: constants.ReachedMaxRestartsReason,
+			}}
+			var objs []client.Object
+			for idx, conditionType := range []batchv1.JobConditionType{batchv1.JobComplete, batchv1.JobFailed} {
+				job := makeJob(&makeJobArgs{
+					jobSetName:        jobSetName,
+					replicatedJobName: "workers",
+					jobName:           placement.GenJobName(jobSetName, "workers", idx),
+					ns:                ns,
+					replicas:          2,
+					jobIdx:            idx,
+				}).Parallelism(1).Obj()
+				job.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(js, jobset.GroupVersion.WithKind("JobSet"))}
+				job.Status.Conditions = []batchv1.JobCondition{{Type: conditionType, Status: corev1.ConditionTrue}}
+				objs = append(objs, job)
+			}
+
+			fakeClient := newFakeClientBuilder().
+				WithObjects(append(objs, js)...).
+				WithStatusSubresource(js).
+				Build()
+			r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{})
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: jobSetName, Namespace: ns}}
+			reconcileJobSet(t, r, req, 1)
+
+			var jobs batchv1.JobList
+			if err := fakeClient.List(context.TODO(), &jobs, client.InNamespace(ns)); err != nil {
+				t.Fatalf("unexpected error listing jobs: %v", err)
+			}
+			var got []string
+			for _, job := range jobs.Items {
+				got = append(got, job.Name)
+			}
+			if diff := cmp.Diff(tc.wantJobs, got); diff != "" {
+				t.Errorf("unexpected jobs (-want/+got): %s", diff)
+			}
+		})
+	}
+}
+
+// holdFinalizer keeps a deleted Job around in the fake client, simulating a Job whose
+// pods are still draining.
+const holdFinalizer = "test.jobset.sigs.k8s.io/hold"
+
+func TestReconcileAddsCleanupFinalizer(t *testing.T) {
+	tests := []struct {
+		name          string
+		js            *jobset.JobSet
+		wantFinalizer bool
+	}{
+		{
+			name:          "active jobset gets the cleanup finalizer",
+			js:            testutils.MakeJobSet("test-jobset", "default").Obj(),
+			wantFinalizer: true,
+		},
+		{
+			name: "paused jobset is left alone",
+			js: func() *jobset.JobSet {
+				js := testutils.MakeJobSet("test-jobset", "default").Obj()
+				js.Spec.Paused = ptr.To(true)
+				return js
+			}(),
+		},
+		{
+			name: "finished jobset is left alone",
+			js: testutils.MakeJobSet("test-jobset", "default").
+				Conditions([]metav1.Condition{{Type: string(jobset.JobSetCompleted), Status: metav1.ConditionTrue}}).
+				Obj(),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := newFakeClientBuilder().
+				WithObjects(tc.js).
+				WithStatusSubresource(tc.js).
+				Build()
+			r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{})
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: tc.js.Name, Namespace: tc.js.Namespace}}
+
+			reconcileJobSet(t, r, req, 1)
+			var got jobset.JobSet
+			if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+				t.Fatalf("unexpected error getting jobset: %v", err)
+			}
+			if gotFinalizer := controllerutil.ContainsFinalizer(&got, jobset.CleanupFinalizer); gotFinalizer != tc.wantFinalizer {
+				t.Errorf("unexpected cleanup finalizer, want %v, got finalizers %v", tc.wantFinalizer, got.Finalizers)
+			}
+		})
+	}
+}
+
+func TestFinalizersPatch(t *testing.T) {
+	js := testutils.MakeJobSet("test-jobset", "default").Finalizers([]string{"example.com/other"}).Obj()
+	job := makeJob(&makeJobArgs{jobSetName: js.Name, replicatedJobName: "workers", jobName: "test-jobset-workers-0", ns: js.Namespace, replicas: 1}).Obj()
+	job.Finalizers = []string{jobset.JobResultFinalizer, "example.com/other"}
+
+	// Record the field managers of the patches, and reject applies and updates.
+	var fieldManagers []string
+	fakeClient := newFakeClientBuilder().
+		WithObjects(js, job).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				if patch.Type() == types.ApplyPatchType {
+					t.Errorf("unexpected apply of %s", obj.GetName())
+				}
+				fieldManagers = append(fieldManagers, (&client.PatchOptions{}).ApplyOptions(opts).FieldManager)
+				return c.Patch(ctx, obj, patch, opts...)
+			},
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				t.Errorf("unexpected update of %s", obj.GetName())
+				return c.Update(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{FieldManager: "gitops-jobset"})
+	getJobSet := func() *jobset.JobSet {
+		t.Helper()
+		var got jobset.JobSet
+		if err := fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(js), &got); err != nil {
+			t.Fatalf("unexpected error getting jobset: %v", err)
+		}
+		return &got
+	}
+
+	// The finalizers of a JobSet changed since it was read are not overwritten.
+	stale := getJobSet()
+	stale.ResourceVersion = "1"
+	if err := r.ensureCleanupFinalizer(context.TODO(), stale); !apierrors.IsConflict(err) {
+		t.Errorf("expected a conflict adding the cleanup finalizer to a stale jobset, got %v", err)
+	}
+
+	if err := r.ensureCleanupFinalizer(context.TODO(), getJobSet()); err != nil {
+		t.Fatalf("unexpected error adding the cleanup finalizer: %v", err)
+	}
+	if diff := cmp.Diff([]string{"example.com/other", jobset.CleanupFinalizer}, getJobSet().Finalizers); diff != "" {
+		t.Errorf("unexpected finalizers once the cleanup finalizer is added (-want/+got): %s", diff)
+	}
+	if err := r.removeCleanupFinalizer(context.TODO(), getJobSet()); err != nil {
+		t.Fatalf("unexpected error removing the cleanup finalizer: %v", err)
+	}
+	if diff := cmp.Diff([]string{"example.com/other"}, getJobSet().Finalizers); diff != "" {
+		t.Errorf("unexpected finalizers once the cleanup finalizer is removed (-want/+got): %s", diff)
+	}
+
+	var gotJob batchv1.Job
+	if err := fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(job), &gotJob); err != nil {
+		t.Fatalf("unexpected error getting job: %v", err)
+	}
+	if err := r.releaseJobResults(context.TODO(), []*batchv1.Job{&gotJob}); err != nil {
+		t.Fatalf("unexpected error releasing job results: %v", err)
+	}
+	if err := fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(job), &gotJob); err != nil {
+		t.Fatalf("unexpected error getting job: %v", err)
+	}
+	if diff := cmp.Diff([]string{"example.com/other"}, gotJob.Finalizers); diff != "" {
+		t.Errorf("unexpected finalizers of the job once its result is released (-want/+got): %s", diff)
+	}
+	if diff := cmp.Diff([]string{"gitops-jobset", "gitops-jobset", "gitops-jobset", "gitops-jobset"}, fieldManagers); diff != "" {
+		t.Errorf("unexpected field managers of the patches (-want/+got): %s", diff)
+	}
+}
+
+func TestReconcileCleanupFinalizerGatesServiceDeletion(t *testing.T) {
+	var (
+		jobSetName = "test-jobset"
+		ns         = "default"
+	)
+	now := time.Now().Truncate(time.Second)
+	deletedAt := metav1.NewTime(now.Add(-time.Minute))
+
+	newObjects := func() (*jobset.JobSet, []client.Object) {
+		js := testutils.MakeJobSet(jobSetName, ns).
+			Finalizers([]string{jobset.CleanupFinalizer}).
+			ReplicatedJob(testutils.MakeReplicatedJob("workers").
+				Job(testutils.MakeJobTemplate("job", ns).Obj()).
+				Replicas(2).
+				Obj()).
+			Obj()
+		js.UID = "test-uid"
+		js.DeletionTimestamp = &deletedAt
+
+		objs := []client.Object{js}
+		for i := 0; i < 2; i++ {
+			job := makeJob(&makeJobArgs{
+				jobSetName:        jobSetName,
+				replicatedJobName: "workers",
+				jobName:           placement.GenJobName(jobSetName, "workers", i),
+				ns:                ns,
+				replicas:          2,
+				jobIdx:            i,
+			}).Parallelism(1).Obj()
+			job.Finalizers = []string{holdFinalizer}
+			utilruntime.Must(ctrl.SetControllerReference(js, job, testScheme))
+			objs = append(objs, job)
+		}
+		svc := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: GetSubdomain(js), Namespace: ns},
+			Spec:       corev1.ServiceSpec{ClusterIP: "None"},
+		}
+		utilruntime.Must(ctrl.SetControllerReference(js, svc, testScheme))
+		objs = append(objs, svc)
+		return js, objs
+	}
+
+	t.Run("service is deleted only after all jobs are gone", func(t *testing.T) {
+		js, objs := newObjects()
+		fakeClient := newFakeClientBuilder().
+			WithObjects(objs...).
+			WithStatusSubresource(js).
+			Build()
+		r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{})
+		r.clock = clocktesting.NewFakeClock(now)
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Name: jobSetName, Namespace: ns}}
+		svcKey := types.NamespacedName{Name: GetSubdomain(js), Namespace: ns}
+
+		result := reconcileJobSet(t, r, req, 1)
+		if want := constants.DefaultCleanupFinalizerTimeout - time.Minute; result.RequeueAfter != want {
+			t.Errorf("expected requeue after %v while jobs are draining, got %v", want, result.RequeueAfter)
+		}
+
+		// The jobs are terminating, but the service and the JobSet must remain.
+		var jobs batchv1.JobList
+		if err := fakeClient.List(context.TODO(), &jobs, client.InNamespace(ns)); err != nil {
+			t.Fatalf("unexpected error listing jobs: %v", err)
+		}
+		if len(jobs.Items) != 2 {
+			t.Fatalf("expected 2 draining jobs, got %d", len(jobs.Items))
+		}
+		for _, job := range jobs.Items {
+			if job.DeletionTimestamp == nil {
+				t.Errorf("expected job %q to be deleted", job.Name)
+			}
+		}
+		if err := fakeClient.Get(context.TODO(), svcKey, &corev1.Service{}); err != nil {
+			t.Errorf("expected service to remain while jobs are draining, got error: %v", err)
+		}
+		var got jobset.JobSet
+		if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); err != nil {
+			t.Fatalf("expected jobset to remain while jobs are draining, got error: %v", err)
+		}
+
+		// Let the jobs finish draining.
+		for i := range jobs.Items {
+			jobs.Items[i].Finalizers = nil
+			if err := fakeClient.Update(context.TODO(), &jobs.Items[i]); err != nil {
+				t.Fatalf("unexpected error updating job: %v", err)
+			}
+		}
+
+		reconcileJobSet(t, r, req, 1)
+		if err := fakeClient.Get(context.TODO(), svcKey, &corev1.Service{}); !apierrors.IsNotFound(err) {
+			t.Errorf("expected service to be deleted once jobs are gone, got error: %v", err)
+		}
+		if err := fakeClient.Get(context.TODO(), req.NamespacedName, &got); !apierrors.IsNotFound(err) {
+			t.Errorf("expected jobset to be released once its finalizer is removed, got error: %v", err)
+		}
+	})
+
+	t.Run("finalizer is removed after the timeout", func(t *testing.T) {
+		js, objs := newObjects()
+		fakeClient := newFakeClientBuilder().
+			WithObjects(objs...).
+			WithStatusSubresource(js).
+			Build()
+		r := NewJobSetReconciler(fakeClient, testScheme, record.NewFakeRecorder(10), JobSetReconcilerOptions{
+			CleanupFinalizerTimeout: 30 * time.Second,
+		})
+		r.clock = clocktesting.NewFakeClock(now)
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Name: jobSetName, Namespace: ns}}
+
+		reconcileJobSet(t, r, req, 1)
+		if err := fakeClient.Get(context.TODO(), req.NamespacedName, &jobset.JobSet{}); !apierrors.IsNotFound(err) {
+			t.Errorf("expected jobset to be released after the cleanup timeout, got error: %v", err)
+		}
+	})
+}
@@ -30,6 +30,18 @@ func CloneMap[K, V comparable](m map[K]V) map[K]V {
 	return copy
 }
 
+// MergeMaps returns a new map containing the entries of all given maps.
+// Entries of later maps take precedence over entries of earlier maps with the same key.
+func MergeMaps[K, V comparable](maps ...map[K]V) map[K]V {
+	merged := make(map[K]V)
+	for _, m := range maps {
+		for k, v := range m {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
 func Contains[T comparable](slice []T, element T) bool {
 	for _, item := range slice {
 		if item == element {
@@ -90,6 +90,45 @@ func TestCloneMap(t *testing.T) {
 	}
 }
 
+func TestMergeMaps(t *testing.T) {
+	type testCase struct {
+		name string
+		maps []map[string]string
+		want map[string]string
+	}
+
+	testCases := []testCase{
+		{
+			name: "No maps",
+			want: map[string]string{},
+		},
+		{
+			name: "Nil and empty maps",
+			maps: []map[string]string{nil, {}},
+			want: map[string]string{},
+		},
+		{
+			name: "Disjoint maps",
+			maps: []map[string]string{{"foo": "bar"}, {"baz": "qux"}},
+			want: map[string]string{"foo": "bar", "baz": "qux"},
+		},
+		{
+			name: "Later maps take precedence",
+			maps: []map[string]string{{"foo": "bar", "baz": "qux"}, {"foo": "override"}},
+			want: map[string]string{"foo": "override", "baz": "qux"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := MergeMaps(tc.maps...)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected diff (-want/+got): %s", diff)
+			}
+		})
+	}
+}
+
 func TestContains(t *testing.T) {
 	type testCase struct {
 		name    string
@@ -121,6 +121,144 @@ func (j *JobSetWrapper) Suspend(suspend bool) *JobSetWrapper {
 	return j
 }
 
+// OnSuspend sets the value of jobSet.spec.onSuspend
+func (j *JobSetWrapper) OnSuspend(policy jobset.OnSuspendPolicy) *JobSetWrapper {
+	j.JobSet.Spec.OnSuspend = policy
+	return j
+}
+
+// RuntimeClassName sets the value of jobSet.spec.runtimeClassName
+func (j *JobSetWrapper) RuntimeClassName(runtimeClassName string) *JobSetWrapper {
+	j.JobSet.Spec.RuntimeClassName = runtimeClassName
+	return j
+}
+
+// SpecLabels sets the value of jobSet.spec.labels
+func (j *JobSetWrapper) SpecLabels(labels map[string]string) *JobSetWrapper {
+	j.JobSet.Spec.Labels = labels
+	return j
+}
+
+// SpecAnnotations sets the value of jobSet.spec.annotations
+func (j *JobSetWrapper) SpecAnnotations(annotations map[string]string) *JobSetWrapper {
+	j.JobSet.Spec.Annotations = annotations
+	return j
+}
+
+// JobAnnotations sets the value of jobSet.spec.jobAnnotations
+func (j *JobSetWrapper) JobAnnotations(annotations map[string]string) *JobSetWrapper {
+	j.JobSet.Spec.JobAnnotations = annotations
+	return j
+}
+
+// PodAnnotations sets the value of jobSet.spec.podAnnotations
+func (j *JobSetWrapper) PodAnnotations(annotations map[string]string) *JobSetWrapper {
+	j.JobSet.Spec.PodAnnotations = annotations
+	return j
+}
+
+// ImagePullSecrets sets the value of jobSet.spec.imagePullSecrets
+func (j *JobSetWrapper) ImagePullSecrets(secrets ...corev1.LocalObjectReference) *JobSetWrapper {
+	j.JobSet.Spec.ImagePullSecrets = secrets
+	return j
+}
+
+// HostAliases sets the value of jobSet.spec.hostAliases
+func (j *JobSetWrapper) HostAliases(aliases ...corev1.HostAlias) *JobSetWrapper {
+	j.JobSet.Spec.HostAliases = aliases
+	return j
+}
+
+// PodReadinessGates sets the value of jobSet.spec.podReadinessGates
+func (j *JobSetWrapper) PodReadinessGates(gates ...corev1.PodReadinessGate) *JobSetWrapper {
+	j.JobSet.Spec.PodReadinessGates = gates
+	return j
+}
+
+// MinReadySeconds sets the value of jobSet.spec.minReadySeconds
+func (j *JobSetWrapper) MinReadySeconds(seconds int32) *JobSetWrapper {
+	j.JobSet.Spec.MinReadySeconds = ptr.To(seconds)
+	return j
+}
+
+// ActiveDeadlineSeconds sets the value of jobSet.spec.activeDeadlineSeconds
+func (j *JobSetWrapper) ActiveDeadlineSeconds(seconds int64) *JobSetWrapper {
+	j.JobSet.Spec.ActiveDeadlineSeconds = ptr.To(seconds)
+	return j
+}
+
+// StatusSyncPeriodSeconds sets the value of jobSet.spec.statusSyncPeriodSeconds
+func (j *JobSetWrapper) StatusSyncPeriodSeconds(seconds int32) *JobSetWrapper {
+	j.JobSet.Spec.StatusSyncPeriodSeconds = ptr.To(seconds)
+	return j
+}
+
+// CleanupPolicy sets the value of jobSet.spec.cleanupPolicy
+func (j *JobSetWrapper) CleanupPolicy(policy *jobset.CleanupPolicy) *JobSetWrapper {
+	j.JobSet.Spec.CleanupPolicy = policy
+	return j
+}
+
+// ReportJobStatuses sets the value of jobSet.spec.reportJobStatuses
+func (j *JobSetWrapper) ReportJobStatuses(report bool) *JobSetWrapper {
+	j.JobSet.Spec.ReportJobStatuses = ptr.To(report)
+	return j
+}
+
+// CreateJobsSuspended sets the value of jobSet.spec.createJobsSuspended
+func (j *JobSetWrapper) CreateJobsSuspended(val bool) *JobSetWrapper {
+	j.JobSet.Spec.CreateJobsSuspended = ptr.To(val)
+	return j
+}
+
+// StartTime sets the value of jobSet.status.startTime
+func (j *JobSetWrapper) StartTime(startTime *metav1.Time) *JobSetWrapper {
+	j.JobSet.Status.StartTime = startTime
+	return j
+}
+
+// FirstStartTime sets the value of jobSet.status.firstStartTime
+func (j *JobSetWrapper) FirstStartTime(firstStartTime *metav1.Time) *JobSetWrapper {
+	j.JobSet.Status.FirstStartTime = firstStartTime
+	return j
+}
+
+// ObservedRestartTrigger sets the value of jobSet.status.observedRestartTrigger
+func (j *JobSetWrapper) ObservedRestartTrigger(trigger string) *JobSetWrapper {
+	j.JobSet.Status.ObservedRestartTrigger = trigger
+	return j
+}
+
+// PodTemplates sets the value of jobSet.spec.podTemplates
+func (j *JobSetWrapper) PodTemplates(podTemplates map[string]corev1.PodTemplateSpec) *JobSetWrapper {
+	j.JobSet.Spec.PodTemplates = podTemplates
+	return j
+}
+
+// Coordinator sets the value of jobSet.spec.coordinator
+func (j *JobSetWrapper) Coordinator(coordinator *jobset.Coordinator) *JobSetWrapper {
+	j.JobSet.Spec.Coordinator = coordinator
+	return j
+}
+
+// JobNameTemplate sets the value of jobSet.spec.jobNameTemplate
+func (j *JobSetWrapper) JobNameTemplate(tmpl string) *JobSetWrapper {
+	j.JobSet.Spec.JobNameTemplate = tmpl
+	return j
+}
+
+// Instances sets the value of jobSet.spec.instances
+func (j *JobSetWrapper) Instances(instances int32) *JobSetWrapper {
+	j.JobSet.Spec.Instances = &instances
+	return j
+}
+
+// CoordinatorService sets the value of JobSet.Network.CoordinatorService
+func (j *JobSetWrapper) CoordinatorService(svc *jobset.CoordinatorService) *JobSetWrapper {
+	j.JobSet.Spec.Network.CoordinatorService = svc
+	return j
+}
+
 // NetworkSubdomain sets the value of JobSet.Network.Subdomain
 func (j *JobSetWrapper) NetworkSubdomain(val string) *JobSetWrapper {
 	j.JobSet.Spec.Network.Subdomain = val
@@ -196,6 +334,72 @@ func (r *ReplicatedJobWrapper) Replicas(val int32) *ReplicatedJobWrapper {
 	return r
 }
 
+// DependsOn sets the value of ReplicatedJob.DependsOn.
+func (r *ReplicatedJobWrapper) DependsOn(names ...string) *ReplicatedJobWrapper {
+	r.ReplicatedJob.DependsOn = names
+	return r
+}
+
+// PodTemplateName sets the value of the ReplicatedJob.PodTemplateName.
+func (r *ReplicatedJobWrapper) PodTemplateName(name string) *ReplicatedJobWrapper {
+	r.ReplicatedJob.PodTemplateName = name
+	return r
+}
+
+// IndexedOverrides sets the value of the ReplicatedJob.IndexedOverrides.
+func (r *ReplicatedJobWrapper) IndexedOverrides(overrides ...jobset.IndexedOverride) *ReplicatedJobWrapper {
+	r.ReplicatedJob.IndexedOverrides = overrides
+	return r
+}
+
+// FailurePolicy sets the value of the ReplicatedJob.FailurePolicy.
+func (r *ReplicatedJobWrapper) FailurePolicy(policy *jobset.FailurePolicy) *ReplicatedJobWrapper {
+	r.ReplicatedJob.FailurePolicy = policy
+	return r
+}
+
+// PodDisruptionBudget sets the value of ReplicatedJob.PodDisruptionBudget.
+func (r *ReplicatedJobWrapper) PodDisruptionBudget(pdb *jobset.PodDisruptionBudget) *ReplicatedJobWrapper {
+	r.ReplicatedJob.PodDisruptionBudget = pdb
+	return r
+}
+
+// RestartPriority sets the value of ReplicatedJob.RestartPriority.
+func (r *ReplicatedJobWrapper) RestartPriority(priority int32) *ReplicatedJobWrapper {
+	r.ReplicatedJob.RestartPriority = priority
+	return r
+}
+
+// CompletionTimeoutSeconds sets the value of ReplicatedJob.CompletionTimeoutSeconds.
+func (r *ReplicatedJobWrapper) CompletionTimeoutSeconds(seconds int32) *ReplicatedJobWrapper {
+	r.ReplicatedJob.CompletionTimeoutSeconds = &seconds
+	return r
+}
+
+// MaxFailedJobs sets the value of ReplicatedJob.MaxFailedJobs.
+func (r *ReplicatedJobWrapper) MaxFailedJobs(maxFailedJobs int32) *ReplicatedJobWrapper {
+	r.ReplicatedJob.MaxFailedJobs = &maxFailedJobs
+	return r
+}
+
+// NonCritical sets the value of ReplicatedJob.NonCritical.
+func (r *ReplicatedJobWrapper) NonCritical(nonCritical bool) *ReplicatedJobWrapper {
+	r.ReplicatedJob.NonCritical = &nonCritical
+	return r
+}
+
+// RestartIsolation sets the value of ReplicatedJob.RestartIsolation.
+func (r *ReplicatedJobWrapper) RestartIsolation(isolated bool) *ReplicatedJobWrapper {
+	r.ReplicatedJob.RestartIsolation = &isolated
+	return r
+}
+
+// SchedulerName sets the value of ReplicatedJob.SchedulerName.
+func (r *ReplicatedJobWrapper) SchedulerName(name string) *ReplicatedJobWrapper {
+	r.ReplicatedJob.SchedulerName = name
+	return r
+}
+
 // Subdomain sets the subdomain on the PodSpec
 // We artificially do this because the webhook does not work in testing
 func (r *ReplicatedJobWrapper) Subdomain(subdomain string) *ReplicatedJobWrapper {
@@ -353,6 +557,18 @@ func (j *JobWrapper) Ready(ready int32) *JobWrapper {
 	return j
 }
 
+// StartTime sets the job status start time.
+func (j *JobWrapper) StartTime(startTime *metav1.Time) *JobWrapper {
+	j.Status.StartTime = startTime
+	return j
+}
+
+// CompletionTime sets the job status completion time.
+func (j *JobWrapper) CompletionTime(completionTime *metav1.Time) *JobWrapper {
+	j.Status.CompletionTime = completionTime
+	return j
+}
+
 // Tolerations set the tolerations.
 func (j *JobWrapper) Tolerations(t []corev1.Toleration) *JobWrapper {
 	j.Spec.Template.Spec.Tolerations = t
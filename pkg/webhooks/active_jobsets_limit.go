@@ -0,0 +1,77 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	jobset "sigs.k8s.io/jobset/api/jobset/v1alpha2"
+	"sigs.k8s.io/jobset/pkg/controllers"
+)
+
+//+kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+
+// validateActiveJobSetsLimit validates that creating the JobSet does not exceed the maximum
+// number of active JobSets of its namespace. JobSets which are finished or being deleted are
+// not active. The limit is enforced on a best effort basis, since JobSets created concurrently
+// may not be counted yet.
+func (j *jobSetWebhook) validateActiveJobSetsLimit(ctx context.Context, js *jobset.JobSet) error {
+	limit, err := j.maxActiveJobSets(ctx, js.Namespace)
+	if err != nil || limit == 0 {
+		return err
+	}
+	var jobSets jobset.JobSetList
+	if err := j.client.List(ctx, &jobSets, client.InNamespace(js.Namespace)); err != nil {
+		return err
+	}
+	active := 0
+	for i := range jobSets.Items {
+		if jobSets.Items[i].DeletionTimestamp == nil && !controllers.JobSetFinished(&jobSets.Items[i]) {
+			active++
+		}
+	}
+	if active >= limit {
+		return field.Forbidden(field.NewPath("metadata", "namespace"), fmt.Sprintf("namespace %q already has %d active JobSets, the maximum is %d", js.Namespace, active, limit))
+	}
+	return nil
+}
+
+// maxActiveJobSets returns the maximum number of active JobSets of the namespace, defined by
+// its MaxActiveJobSetsKey annotation, or else by the webhook options. 0 means no limit.
+func (j *jobSetWebhook) maxActiveJobSets(ctx context.Context, namespace string) (int, error) {
+	var ns corev1.Namespace
+	if err := j.client.Get(ctx, types.NamespacedName{Name: namespace}, &ns); err != nil {
+		if apierrors.IsNotFound(err) {
+			return j.opts.MaxActiveJobSetsPerNamespace, nil
+		}
+		return 0, err
+	}
+	value, ok := ns.Annotations[jobset.MaxActiveJobSetsKey]
+	if !ok {
+		return j.opts.MaxActiveJobSetsPerNamespace, nil
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 0 {
+		return 0, fmt.Errorf("invalid value %q of the %s annotation of namespace %q, must be a non-negative integer", value, jobset.MaxActiveJobSetsKey, namespace)
+	}
+	return limit, nil
+}
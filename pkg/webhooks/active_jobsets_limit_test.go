@@ -0,0 +1,133 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	jobset "sigs.k8s.io/jobset/api/jobset/v1alpha2"
+)
+
+func TestValidateActiveJobSetsLimit(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(jobset.AddToScheme(scheme))
+	utilruntime.Must(corev1.AddToScheme(scheme))
+
+	const ns = "team"
+	namespace := func(annotations map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ns, Annotations: annotations}}
+	}
+	jobSet := func(name string, conditions ...metav1.Condition) *jobset.JobSet {
+		return &jobset.JobSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+			Spec: jobset.JobSetSpec{
+				ReplicatedJobs: []jobset.ReplicatedJob{
+					{
+						Name:     "rjob",
+						Replicas: 1,
+						Template: batchv1.JobTemplateSpec{
+							Spec: batchv1.JobSpec{Template: TestPodTemplate},
+						},
+					},
+				},
+				SuccessPolicy: &jobset.SuccessPolicy{},
+			},
+			Status: jobset.JobSetStatus{Conditions: conditions},
+		}
+	}
+	activeJobSets := func(n int) []client.Object {
+		var objs []client.Object
+		for i := 0; i < n; i++ {
+			objs = append(objs, jobSet(fmt.Sprintf("active-%d", i)))
+		}
+		return objs
+	}
+
+	tests := []struct {
+		name    string
+		opts    JobSetWebhookOptions
+		objs    []client.Object
+		wantErr bool
+	}{
+		{
+			name: "no limit",
+			objs: append(activeJobSets(3), namespace(nil)),
+		},
+		{
+			name: "below the limit of the webhook options",
+			opts: JobSetWebhookOptions{MaxActiveJobSetsPerNamespace: 3},
+			objs: append(activeJobSets(2), namespace(nil)),
+		},
+		{
+			name:    "at the limit of the webhook options",
+			opts:    JobSetWebhookOptions{MaxActiveJobSetsPerNamespace: 2},
+			objs:    append(activeJobSets(2), namespace(nil)),
+			wantErr: true,
+		},
+		{
+			name: "finished jobsets are not counted",
+			opts: JobSetWebhookOptions{MaxActiveJobSetsPerNamespace: 2},
+			objs: []client.Object{
+				namespace(nil),
+				jobSet("active"),
+				jobSet("completed", metav1.Condition{Type: string(jobset.JobSetCompleted), Status: metav1.ConditionTrue}),
+				jobSet("failed", metav1.Condition{Type: string(jobset.JobSetFailed), Status: metav1.ConditionTrue}),
+			},
+		},
+		{
+			name: "namespace annotation raises the limit",
+			opts: JobSetWebhookOptions{MaxActiveJobSetsPerNamespace: 2},
+			objs: append(activeJobSets(2), namespace(map[string]string{jobset.MaxActiveJobSetsKey: "3"})),
+		},
+		{
+			name:    "namespace annotation lowers the limit",
+			opts:    JobSetWebhookOptions{MaxActiveJobSetsPerNamespace: 3},
+			objs:    append(activeJobSets(1), namespace(map[string]string{jobset.MaxActiveJobSetsKey: "1"})),
+			wantErr: true,
+		},
+		{
+			name: "namespace annotation disables the limit",
+			opts: JobSetWebhookOptions{MaxActiveJobSetsPerNamespace: 1},
+			objs: append(activeJobSets(2), namespace(map[string]string{jobset.MaxActiveJobSetsKey: "0"})),
+		},
+		{
+			name:    "invalid namespace annotation",
+			objs:    []client.Object{namespace(map[string]string{jobset.MaxActiveJobSetsKey: "-1"})},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.objs...).Build()
+			webhook, err := NewJobSetWebhook(fakeClient, tc.opts)
+			if err != nil {
+				t.Fatalf("error creating jobset webhook: %v", err)
+			}
+			_, err = webhook.ValidateCreate(context.TODO(), jobSet("new"))
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("unexpected error: got %v, want error %t", err, tc.wantErr)
+			}
+		})
+	}
+}
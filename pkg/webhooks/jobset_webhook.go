@@ -18,14 +18,19 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"reflect"
 	"strconv"
 	"strings"
 
+	admissionv1 "k8s.io/api/admission/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
@@ -34,8 +39,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	"sigs.k8s.io/jobset/pkg/controllers"
 	"sigs.k8s.io/jobset/pkg/util/collections"
-	"sigs.k8s.io/jobset/pkg/util/placement"
 
 	jobset "sigs.k8s.io/jobset/api/jobset/v1alpha2"
 )
@@ -59,6 +64,14 @@ const (
 	// Error message returned by JobSet validation if the network subdomain
 	// will be longer than 63 characters.
 	subdomainTooLongErrMsg = ".spec.network.subdomain is too long, must be less than 63 characters"
+
+	// Error message returned by JobSet validation if a field which is immutable while the
+	// JobSet is active is updated.
+	immutableWhileActiveErrorMsg = "field is immutable while the JobSet is active, delete and recreate the JobSet to apply the change"
+
+	// Error message returned by JobSet validation if a replicatedJob failure policy sets a field
+	// which is only supported in the JobSet failure policy.
+	replicatedJobFailurePolicyErrorMsg = "only supported in spec.failurePolicy"
 )
 
 //+kubebuilder:webhook:path=/mutate-jobset-x-k8s-io-v1alpha2-jobset,mutating=true,failurePolicy=fail,sideEffects=None,groups=jobset.x-k8s.io,resources=jobsets,verbs=create;update,versions=v1alpha2,name=mjobset.kb.io,admissionReviewVersions=v1
@@ -67,10 +80,27 @@ const (
 type jobSetWebhook struct {
 	client  client.Client
 	decoder *admission.Decoder
+	opts    JobSetWebhookOptions
 }
 
-func NewJobSetWebhook(mgrClient client.Client) (*jobSetWebhook, error) {
-	return &jobSetWebhook{client: mgrClient}, nil
+// JobSetWebhookOptions configures the JobSet webhook.
+type JobSetWebhookOptions struct {
+	// MaxActiveJobSetsPerNamespace is the maximum number of active JobSets in a namespace
+	// without the MaxActiveJobSetsKey annotation. The limit is disabled if 0.
+	MaxActiveJobSetsPerNamespace int
+
+	// DefaultMaxRestarts is the maxRestarts of the failure policy set on JobSets created
+	// without a failure policy. JobSets without a failure policy are failed on the first
+	// child Job failure if 0.
+	DefaultMaxRestarts int32
+
+	// RejectUnknownTopologyKeys rejects JobSets whose exclusive placement topology key is not a
+	// label of any node, instead of only warning about it.
+	RejectUnknownTopologyKeys bool
+}
+
+func NewJobSetWebhook(mgrClient client.Client, opts JobSetWebhookOptions) (*jobSetWebhook, error) {
+	return &jobSetWebhook{client: mgrClient, opts: opts}, nil
 }
 
 // InjectDecoder injects the decoder into the jobSetWebhook.
@@ -99,14 +129,31 @@ func (j *jobSetWebhook) Default(ctx context.Context, obj runtime.Object) error {
 	if js.Spec.StartupPolicy == nil {
 		js.Spec.StartupPolicy = &jobset.StartupPolicy{StartupPolicyOrder: jobset.AnyOrder}
 	}
+	// Default the failure policy of new JobSets to the configured number of restarts, if any.
+	// Existing JobSets are not defaulted, since the failure policy is immutable.
+	if js.Spec.FailurePolicy == nil && j.opts.DefaultMaxRestarts > 0 && isCreate(ctx) {
+		js.Spec.FailurePolicy = &jobset.FailurePolicy{MaxRestarts: j.opts.DefaultMaxRestarts}
+	}
+	// Default the pod restart policy to the one enforced on the JobSet, if any, or OnFailure.
+	restartPolicy := corev1.RestartPolicyOnFailure
+	if js.Spec.PodRestartPolicy != "" {
+		restartPolicy = js.Spec.PodRestartPolicy
+	}
 	for i := range js.Spec.ReplicatedJobs {
 		// Default job completion mode to indexed.
 		if js.Spec.ReplicatedJobs[i].Template.Spec.CompletionMode == nil {
 			js.Spec.ReplicatedJobs[i].Template.Spec.CompletionMode = completionModePtr(batchv1.IndexedCompletion)
 		}
-		// Default pod restart policy to OnFailure.
-		if js.Spec.ReplicatedJobs[i].Template.Spec.Template.Spec.RestartPolicy == "" {
-			js.Spec.ReplicatedJobs[i].Template.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyOnFailure
+		// Default pod restart policy, unless a shared pod template is referenced.
+		if js.Spec.ReplicatedJobs[i].PodTemplateName == "" && js.Spec.ReplicatedJobs[i].Template.Spec.Template.Spec.RestartPolicy == "" {
+			js.Spec.ReplicatedJobs[i].Template.Spec.Template.Spec.RestartPolicy = restartPolicy
+		}
+	}
+	// Default pod restart policy of shared pod templates.
+	for name, podTemplate := range js.Spec.PodTemplates {
+		if podTemplate.Spec.RestartPolicy == "" {
+			podTemplate.Spec.RestartPolicy = restartPolicy
+			js.Spec.PodTemplates[name] = podTemplate
 		}
 	}
 
@@ -127,6 +174,12 @@ func (j *jobSetWebhook) Default(ctx context.Context, obj runtime.Object) error {
 	return nil
 }
 
+// isCreate returns true unless the admission request in the context is not a create request.
+func isCreate(ctx context.Context) bool {
+	req, err := admission.RequestFromContext(ctx)
+	return err != nil || req.Operation == admissionv1.Create
+}
+
 //+kubebuilder:webhook:path=/validate-jobset-x-k8s-io-v1alpha2-jobset,mutating=false,failurePolicy=fail,sideEffects=None,groups=jobset.x-k8s.io,resources=jobsets,verbs=create;update,versions=v1alpha2,name=vjobset.kb.io,admissionReviewVersions=v1
 
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
@@ -157,6 +210,35 @@ func (j *jobSetWebhook) ValidateCreate(ctx context.Context, obj runtime.Object)
 		}
 	}
 
+	// Validate the service account name set on the pods of the JobSet.
+	if js.Spec.ServiceAccountName != "" {
+		for _, errMessage := range validation.IsDNS1123Subdomain(js.Spec.ServiceAccountName) {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "serviceAccountName"), js.Spec.ServiceAccountName, errMessage))
+		}
+	}
+
+	// Validate the runtime class name set on the pods of the JobSet.
+	if js.Spec.RuntimeClassName != "" {
+		for _, errMessage := range validation.IsDNS1123Subdomain(js.Spec.RuntimeClassName) {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "runtimeClassName"), js.Spec.RuntimeClassName, errMessage))
+		}
+	}
+
+	// Validate the custom selector of the headless service.
+	for _, err := range validateServiceSelector(js) {
+		allErrs = append(allErrs, err)
+	}
+
+	// Validate the replicas set by the replicas annotation.
+	for _, err := range validateReplicasAnnotation(js) {
+		allErrs = append(allErrs, err)
+	}
+
+	// Validate the hash function selected for the job keys.
+	for _, err := range validateJobKeyHashAnnotation(js) {
+		allErrs = append(allErrs, err)
+	}
+
 	// Validate the managedBy field used for multi-kueue support.
 	if js.Spec.ManagedBy != nil {
 		manager := *js.Spec.ManagedBy
@@ -169,19 +251,114 @@ func (j *jobSetWebhook) ValidateCreate(ctx context.Context, obj runtime.Object)
 		}
 	}
 
+	// Validate the node selector strategy for exclusive placement is only used along with a topology key.
+	_, jsExclusive := js.Annotations[jobset.ExclusiveKey]
+	if _, ok := js.Annotations[jobset.NodeSelectorStrategyKey]; ok && !jsExclusive {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("metadata", "annotations").Key(jobset.NodeSelectorStrategyKey), js.Annotations[jobset.NodeSelectorStrategyKey], fmt.Sprintf("requires the %s annotation to also be set", jobset.ExclusiveKey)))
+	}
+	for _, err := range validateNodeAffinityStrategy(js.Annotations, jsExclusive, field.NewPath("metadata", "annotations")) {
+		allErrs = append(allErrs, err)
+	}
+
 	// Validate each replicatedJob.
-	for _, rjob := range js.Spec.ReplicatedJobs {
+	for i, rjob := range js.Spec.ReplicatedJobs {
+		// A replicatedJob referencing a shared pod template must not define an inline one,
+		// and the reference must resolve.
+		if rjob.PodTemplateName != "" {
+			rjobPath := field.NewPath("spec", "replicatedJobs").Index(i)
+			if _, ok := js.Spec.PodTemplates[rjob.PodTemplateName]; !ok {
+				allErrs = append(allErrs, field.NotFound(rjobPath.Child("podTemplateName"), rjob.PodTemplateName))
+			}
+			if !apiequality.Semantic.DeepEqual(rjob.Template.Spec.Template, corev1.PodTemplateSpec{}) {
+				allErrs = append(allErrs, field.Forbidden(rjobPath.Child("template", "spec", "template"), "must be empty when podTemplateName is set"))
+			}
+		}
+
+		// Indexed overrides must target existing Job indexes and contain a valid patch.
+		for _, err := range validateIndexedOverrides(&rjob, field.NewPath("spec", "replicatedJobs").Index(i).Child("indexedOverrides")) {
+			allErrs = append(allErrs, err)
+		}
+
+		// The restart overrides applied to the replicatedJob must contain a valid patch.
+		for _, err := range validateOnRestartOverrides(js, i) {
+			allErrs = append(allErrs, err)
+		}
+
+		// The failure aggregation, stabilization and deletion settings of a replicatedJob failure
+		// policy would conflict with the JobSet failure policy, which is used for all restarts.
+		if rjob.FailurePolicy != nil {
+			fieldPath := field.NewPath("spec", "replicatedJobs").Index(i).Child("failurePolicy")
+			if rjob.FailurePolicy.DeletePropagationPolicy != nil {
+				allErrs = append(allErrs, field.Forbidden(fieldPath.Child("deletePropagationPolicy"), replicatedJobFailurePolicyErrorMsg))
+			}
+			if rjob.FailurePolicy.TerminationGracePeriodOverride != nil {
+				allErrs = append(allErrs, field.Forbidden(fieldPath.Child("terminationGracePeriodOverride"), replicatedJobFailurePolicyErrorMsg))
+			}
+			if rjob.FailurePolicy.FailureAggregationSeconds != nil {
+				allErrs = append(allErrs, field.Forbidden(fieldPath.Child("failureAggregationSeconds"), replicatedJobFailurePolicyErrorMsg))
+			}
+			if rjob.FailurePolicy.StabilizationWindowSeconds != nil {
+				allErrs = append(allErrs, field.Forbidden(fieldPath.Child("stabilizationWindowSeconds"), replicatedJobFailurePolicyErrorMsg))
+			}
+		}
+
+		// A PodDisruptionBudget must set exactly one of minAvailable and maxUnavailable.
+		if pdb := rjob.PodDisruptionBudget; pdb != nil {
+			fieldPath := field.NewPath("spec", "replicatedJobs").Index(i).Child("podDisruptionBudget")
+			if pdb.MinAvailable == nil && pdb.MaxUnavailable == nil {
+				allErrs = append(allErrs, field.Required(fieldPath, "one of minAvailable and maxUnavailable must be set"))
+			}
+			if pdb.MinAvailable != nil && pdb.MaxUnavailable != nil {
+				allErrs = append(allErrs, field.Forbidden(fieldPath.Child("maxUnavailable"), "must not be set along with minAvailable"))
+			}
+		}
+
+		// A replicatedJob using the node selector strategy must have a topology key set at the
+		// replicatedJob or JobSet level.
+		if value, ok := rjob.Template.Annotations[jobset.NodeSelectorStrategyKey]; ok {
+			if _, rjobExclusive := rjob.Template.Annotations[jobset.ExclusiveKey]; !rjobExclusive && !jsExclusive {
+				fieldPath := field.NewPath("spec", "replicatedJobs").Index(i).Child("template", "metadata", "annotations").Key(jobset.NodeSelectorStrategyKey)
+				allErrs = append(allErrs, field.Invalid(fieldPath, value, fmt.Sprintf("replicatedJob '%s' requires the %s annotation to also be set", rjob.Name, jobset.ExclusiveKey)))
+			}
+		}
+		_, rjobExclusive := rjob.Template.Annotations[jobset.ExclusiveKey]
+		for _, err := range validateNodeAffinityStrategy(rjob.Template.Annotations, rjobExclusive || jsExclusive, field.NewPath("spec", "replicatedJobs").Index(i).Child("template", "metadata", "annotations")) {
+			allErrs = append(allErrs, err)
+		}
+
+		// A replicatedJob may have zero replicas, in which case it creates no jobs.
+		for _, err := range apivalidation.ValidateNonnegativeField(int64(rjob.Replicas), field.NewPath("spec", "replicatedJobs").Index(i).Child("replicas")) {
+			allErrs = append(allErrs, err)
+		}
+
 		var parallelism int32 = 1
 		if rjob.Template.Spec.Parallelism != nil {
 			parallelism = *rjob.Template.Spec.Parallelism
 		}
 		if int64(parallelism)*int64(rjob.Replicas) > math.MaxInt32 {
 			allErrs = append(allErrs, fmt.Errorf("the product of replicas and parallelism must not exceed %d for replicatedJob '%s'", math.MaxInt32, rjob.Name))
+		} else if int64(parallelism)*int64(rjob.Replicas)*int64(controllers.NumInstances(js)) > math.MaxInt32 {
+			allErrs = append(allErrs, fmt.Errorf("the product of instances, replicas and parallelism must not exceed %d for replicatedJob '%s'", math.MaxInt32, rjob.Name))
+		}
+
+		// A replicated job whose Jobs all failed has failed, however many failures it tolerates.
+		if rjob.MaxFailedJobs != nil {
+			numJobs := int64(rjob.Replicas) * int64(controllers.NumInstances(js))
+			if int64(*rjob.MaxFailedJobs) >= numJobs {
+				allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "replicatedJobs").Index(i).Child("maxFailedJobs"), *rjob.MaxFailedJobs, fmt.Sprintf("must be less than the %d jobs of replicatedJob '%s'", numJobs, rjob.Name)))
+			}
 		}
 
 		// Check that the generated job names for this replicated job will be DNS 1035 compliant.
-		// Use the largest job index as it will have the longest name.
-		longestJobName := placement.GenJobName(js.Name, rjob.Name, int(rjob.Replicas-1))
+		// Use the largest instance and job index as they will have the longest name. Errors
+		// rendering the job name template are reported by validateJobNameTemplate.
+		if rjob.Replicas <= 0 {
+			continue
+		}
+		longestJobName, err := controllers.GenJobName(js, rjob.Name, controllers.NumInstances(js)-1, int(rjob.Replicas-1))
+		if err != nil {
+			continue
+		}
 		for _, errMessage := range validation.IsDNS1035Label(longestJobName) {
 			if strings.Contains(errMessage, dns1035MaxLengthExceededErrorMsg) {
 				errMessage = jobNameTooLongErrorMsg
@@ -191,10 +368,9 @@ func (j *jobSetWebhook) ValidateCreate(ctx context.Context, obj runtime.Object)
 		// Check that the generated pod names for the replicated job is DNS 1035 compliant.
 		isIndexedJob := rjob.Template.Spec.CompletionMode != nil && *rjob.Template.Spec.CompletionMode == batchv1.IndexedCompletion
 		if isIndexedJob && rjob.Template.Spec.Completions != nil {
-			maxJobIndex := strconv.Itoa(int(rjob.Replicas - 1))
 			maxPodIndex := strconv.Itoa(int(*rjob.Template.Spec.Completions - 1))
 			// Add 5 char suffix to the deterministic part of the pod name to validate the full pod name is compliant.
-			longestPodName := placement.GenPodName(js.Name, rjob.Name, maxJobIndex, maxPodIndex) + "-abcde"
+			longestPodName := fmt.Sprintf("%s-%s-abcde", longestJobName, maxPodIndex)
 			for _, errMessage := range validation.IsDNS1035Label(longestPodName) {
 				if strings.Contains(errMessage, dns1035MaxLengthExceededErrorMsg) {
 					errMessage = podNameTooLongErrorMsg
@@ -204,13 +380,624 @@ func (j *jobSetWebhook) ValidateCreate(ctx context.Context, obj runtime.Object)
 		}
 	}
 
+	// Validate the pod templates use the pod restart policy enforced on the JobSet.
+	for _, err := range validatePodRestartPolicy(js) {
+		allErrs = append(allErrs, err)
+	}
+
+	// Validate the completions of the replicated jobs are allowed by the completions policy.
+	for _, err := range validateCompletionsPolicy(js) {
+		allErrs = append(allErrs, err)
+	}
+
+	// Validate the job name template renders distinct names for the child Jobs.
+	for _, err := range validateJobNameTemplate(js) {
+		allErrs = append(allErrs, err)
+	}
+
 	// Validate the success policy's target replicated jobs are valid.
 	for _, rjobName := range js.Spec.SuccessPolicy.TargetReplicatedJobs {
 		if !collections.Contains(validReplicatedJobs, rjobName) {
 			allErrs = append(allErrs, fmt.Errorf("invalid replicatedJob name '%s' does not appear in .spec.ReplicatedJobs", rjobName))
 		}
 	}
-	return nil, errors.Join(allErrs...)
+
+	// Validate the non-critical replicated jobs leave a critical replicated job for the success policy.
+	for _, err := range validateNonCriticalReplicatedJobs(js) {
+		allErrs = append(allErrs, err)
+	}
+
+	// Validate the startup gates target replicated jobs of an in-order startup policy.
+	for _, err := range validateStartupGates(js, validReplicatedJobs) {
+		allErrs = append(allErrs, err)
+	}
+
+	// Validate the dependencies of the replicated jobs form an acyclic graph.
+	for _, err := range validateDependsOn(js, validReplicatedJobs) {
+		allErrs = append(allErrs, err)
+	}
+
+	// Validate the success policy's total succeeded target can be reached by the target replicated jobs.
+	if totalSucceeded := js.Spec.SuccessPolicy.TotalSucceeded; totalSucceeded != nil {
+		var targetJobs int64
+		for _, rjob := range js.Spec.ReplicatedJobs {
+			if ptr.Deref(rjob.NonCritical, false) {
+				continue
+			}
+			if len(js.Spec.SuccessPolicy.TargetReplicatedJobs) == 0 || collections.Contains(js.Spec.SuccessPolicy.TargetReplicatedJobs, rjob.Name) {
+				targetJobs += int64(rjob.Replicas) * int64(controllers.NumInstances(js))
+			}
+		}
+		if int64(*totalSucceeded) > targetJobs {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "successPolicy", "totalSucceeded"), *totalSucceeded, fmt.Sprintf("must not exceed the number of jobs of the target replicated jobs (%d)", targetJobs)))
+		}
+	}
+
+	// Validate the coordinator, which is required by the coordinator Service.
+	for _, err := range validateCoordinator(js) {
+		allErrs = append(allErrs, err)
+	}
+
+	// Validate the sidecar containers can be added to the pods of the JobSet.
+	for _, err := range validateSidecarContainers(js) {
+		allErrs = append(allErrs, err)
+	}
+
+	// Validate the volumes and volume mounts can be added to the pods of the JobSet.
+	for _, err := range validateVolumes(js) {
+		allErrs = append(allErrs, err)
+	}
+
+	// Validate the scheduling gates can be added to the pods of the JobSet.
+	for _, err := range validateSchedulingGates(js.Spec.SchedulingGates, field.NewPath("spec", "schedulingGates")) {
+		allErrs = append(allErrs, err)
+	}
+	for i, rjob := range js.Spec.ReplicatedJobs {
+		for _, err := range validateSchedulingGates(rjob.SchedulingGates, field.NewPath("spec", "replicatedJobs").Index(i).Child("schedulingGates")) {
+			allErrs = append(allErrs, err)
+		}
+	}
+
+	// Validate the readiness gates can be added to the pods of the JobSet.
+	var readinessConditionTypes []corev1.PodConditionType
+	for i, gate := range js.Spec.PodReadinessGates {
+		conditionTypePath := field.NewPath("spec", "podReadinessGates").Index(i).Child("conditionType")
+		for _, errMessage := range validation.IsQualifiedName(string(gate.ConditionType)) {
+			allErrs = append(allErrs, field.Invalid(conditionTypePath, string(gate.ConditionType), errMessage))
+		}
+		if collections.Contains(readinessConditionTypes, gate.ConditionType) {
+			allErrs = append(allErrs, field.Duplicate(conditionTypePath, string(gate.ConditionType)))
+		}
+		readinessConditionTypes = append(readinessConditionTypes, gate.ConditionType)
+	}
+
+	// Validate the priority class names set on the pods of the replicated jobs.
+	for i, rjob := range js.Spec.ReplicatedJobs {
+		if rjob.PodPriorityClassName == "" {
+			continue
+		}
+		for _, errMessage := range validation.IsDNS1123Subdomain(rjob.PodPriorityClassName) {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "replicatedJobs").Index(i).Child("podPriorityClassName"), rjob.PodPriorityClassName, errMessage))
+		}
+	}
+
+	// Validate the scheduler names set on the pods of the replicated jobs.
+	for i, rjob := range js.Spec.ReplicatedJobs {
+		if rjob.SchedulerName == "" {
+			continue
+		}
+		for _, errMessage := range validation.IsDNS1123Subdomain(rjob.SchedulerName) {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "replicatedJobs").Index(i).Child("schedulerName"), rjob.SchedulerName, errMessage))
+		}
+	}
+
+	// Validate the IPs of the host aliases added to the pods of the JobSet.
+	for i, alias := range js.Spec.HostAliases {
+		for _, errMessage := range validation.IsValidIP(alias.IP) {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "hostAliases").Index(i).Child("ip"), alias.IP, errMessage))
+		}
+	}
+
+	// Validate the namespace does not exceed its maximum number of active JobSets.
+	if err := j.validateActiveJobSetsLimit(ctx, js); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	// Validate the exclusive placement topology keys are labels of the nodes.
+	warnings, topologyErrs := j.validateExclusiveTopologyKeys(ctx, js)
+	for _, err := range topologyErrs {
+		allErrs = append(allErrs, err)
+	}
+	return warnings, errors.Join(allErrs...)
+}
+
+// validateNonCriticalReplicatedJobs validates that at least one replicated job is critical, and
+// that the success policy does not target non-critical replicated jobs, which never complete it.
+func validateNonCriticalReplicatedJobs(js *jobset.JobSet) field.ErrorList {
+	var allErrs field.ErrorList
+	nonCritical := sets.New[string]()
+	for _, rjob := range js.Spec.ReplicatedJobs {
+		if ptr.Deref(rjob.NonCritical, false) {
+			nonCritical.Insert(rjob.Name)
+		}
+	}
+	if nonCritical.Len() == 0 {
+		return nil
+	}
+	if nonCritical.Len() == len(js.Spec.ReplicatedJobs) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "replicatedJobs"), "all replicated jobs are non-critical, at least one replicated job must be critical"))
+	}
+	targetsPath := field.NewPath("spec", "successPolicy", "targetReplicatedJobs")
+	for i, rjobName := range js.Spec.SuccessPolicy.TargetReplicatedJobs {
+		if nonCritical.Has(rjobName) {
+			allErrs = append(allErrs, field.Invalid(targetsPath.Index(i), rjobName, "must not be a non-critical replicated job"))
+		}
+	}
+	return allErrs
+}
+
+// validateStartupGates validates that the startup gates are only set with the InOrder startup
+// order, and that each of them targets a distinct replicated job of the JobSet.
+func validateStartupGates(js *jobset.JobSet, validReplicatedJobs []string) field.ErrorList {
+	if js.Spec.StartupPolicy == nil || len(js.Spec.StartupPolicy.Gates) == 0 {
+		return nil
+	}
+	var errs field.ErrorList
+	gatesPath := field.NewPath("spec", "startupPolicy", "gates")
+	if js.Spec.StartupPolicy.StartupPolicyOrder != jobset.InOrder {
+		errs = append(errs, field.Forbidden(gatesPath, fmt.Sprintf("requires the %s startup policy order", jobset.InOrder)))
+	}
+	gated := sets.New[string]()
+	for i, gate := range js.Spec.StartupPolicy.Gates {
+		rjobPath := gatesPath.Index(i).Child("replicatedJob")
+		if !collections.Contains(validReplicatedJobs, gate.ReplicatedJob) {
+			errs = append(errs, field.NotFound(rjobPath, gate.ReplicatedJob))
+		} else if gated.Has(gate.ReplicatedJob) {
+			errs = append(errs, field.Duplicate(rjobPath, gate.ReplicatedJob))
+		}
+		gated.Insert(gate.ReplicatedJob)
+	}
+	return errs
+}
+
+// validatePodRestartPolicy validates that the pod templates of the replicated jobs and the shared
+// pod templates use the pod restart policy enforced on the JobSet, if any.
+func validatePodRestartPolicy(js *jobset.JobSet) field.ErrorList {
+	policy := js.Spec.PodRestartPolicy
+	if policy == "" {
+		return nil
+	}
+	var errs field.ErrorList
+	msg := fmt.Sprintf("must match spec.podRestartPolicy (%s)", policy)
+	for i, rjob := range js.Spec.ReplicatedJobs {
+		if rjob.PodTemplateName != "" {
+			continue
+		}
+		if restartPolicy := rjob.Template.Spec.Template.Spec.RestartPolicy; restartPolicy != "" && restartPolicy != policy {
+			fieldPath := field.NewPath("spec", "replicatedJobs").Index(i).Child("template", "spec", "template", "spec", "restartPolicy")
+			errs = append(errs, field.Invalid(fieldPath, restartPolicy, msg))
+		}
+	}
+	for _, name := range sets.List(sets.KeySet(js.Spec.PodTemplates)) {
+		if restartPolicy := js.Spec.PodTemplates[name].Spec.RestartPolicy; restartPolicy != "" && restartPolicy != policy {
+			fieldPath := field.NewPath("spec", "podTemplates").Key(name).Child("spec", "restartPolicy")
+			errs = append(errs, field.Invalid(fieldPath, restartPolicy, msg))
+		}
+	}
+	return errs
+}
+
+// validateCompletionsPolicy validates that the completions of the Indexed Job templates of the
+// replicated jobs are allowed by the completions policy of the JobSet, relative to their parallelism.
+func validateCompletionsPolicy(js *jobset.JobSet) field.ErrorList {
+	policy := js.Spec.CompletionsPolicy
+	if policy == "" || policy == jobset.CompletionsPolicyAny {
+		return nil
+	}
+	var errs field.ErrorList
+	for i, rjob := range js.Spec.ReplicatedJobs {
+		jobSpec := rjob.Template.Spec
+		if ptr.Deref(jobSpec.CompletionMode, batchv1.NonIndexedCompletion) != batchv1.IndexedCompletion || jobSpec.Completions == nil {
+			continue
+		}
+		completions, parallelism := *jobSpec.Completions, ptr.Deref(jobSpec.Parallelism, 1)
+		fieldPath := field.NewPath("spec", "replicatedJobs").Index(i).Child("template", "spec", "completions")
+		switch {
+		case policy == jobset.CompletionsPolicyEqualParallelism && completions != parallelism:
+			errs = append(errs, field.Invalid(fieldPath, completions, fmt.Sprintf("must equal parallelism (%d) as required by the %s completions policy", parallelism, policy)))
+		case policy == jobset.CompletionsPolicyAtMostParallelism && completions > parallelism:
+			errs = append(errs, field.Invalid(fieldPath, completions, fmt.Sprintf("must not exceed parallelism (%d) as required by the %s completions policy", parallelism, policy)))
+		}
+	}
+	return errs
+}
+
+// validateServiceSelector validates that spec.network.serviceSelector holds valid labels, and
+// does not override the JobSet name label which is always part of the selector.
+func validateServiceSelector(js *jobset.JobSet) field.ErrorList {
+	if js.Spec.Network == nil || len(js.Spec.Network.ServiceSelector) == 0 {
+		return nil
+	}
+	fieldPath := field.NewPath("spec", "network", "serviceSelector")
+	errs := metav1validation.ValidateLabels(js.Spec.Network.ServiceSelector, fieldPath)
+	if _, ok := js.Spec.Network.ServiceSelector[jobset.JobSetNameKey]; ok {
+		errs = append(errs, field.Forbidden(fieldPath.Key(jobset.JobSetNameKey), "the jobset name label is always part of the selector and can't be overridden"))
+	}
+	return errs
+}
+
+// validateReplicasAnnotation validates that the replicas annotation of the JobSet lists existing
+// replicatedJobs, and that the child Jobs created for the given replicas have valid names.
+func validateReplicasAnnotation(js *jobset.JobSet) field.ErrorList {
+	value, ok := js.Annotations[jobset.ReplicasKey]
+	if !ok {
+		return nil
+	}
+	fieldPath := field.NewPath("metadata", "annotations").Key(jobset.ReplicasKey)
+	replicas, err := controllers.ParseReplicasAnnotation(value)
+	if err != nil {
+		return field.ErrorList{field.Invalid(fieldPath, value, err.Error())}
+	}
+	var errs field.ErrorList
+	for _, rjob := range js.Spec.ReplicatedJobs {
+		n, ok := replicas[rjob.Name]
+		if !ok {
+			continue
+		}
+		delete(replicas, rjob.Name)
+		parallelism := int64(ptr.Deref(rjob.Template.Spec.Parallelism, 1))
+		if parallelism*int64(n)*int64(controllers.NumInstances(js)) > math.MaxInt32 {
+			errs = append(errs, field.Invalid(fieldPath, value, fmt.Sprintf("the product of instances, replicas and parallelism must not exceed %d for replicatedJob '%s'", math.MaxInt32, rjob.Name)))
+			continue
+		}
+		if n == 0 {
+			continue
+		}
+		longestJobName, err := controllers.GenJobName(js, rjob.Name, controllers.NumInstances(js)-1, int(n-1))
+		if err != nil {
+			continue
+		}
+		if len(validation.IsDNS1035Label(longestJobName)) > 0 {
+			errs = append(errs, field.Invalid(fieldPath, value, fmt.Sprintf("the name of Job '%s' of replicatedJob '%s' must be a valid DNS label", longestJobName, rjob.Name)))
+		}
+	}
+	for _, name := range sets.List(sets.KeySet(replicas)) {
+		errs = append(errs, field.Invalid(fieldPath, value, fmt.Sprintf("replicatedJob '%s' does not exist", name)))
+	}
+	return errs
+}
+
+// validateJobKeyHashAnnotation validates that the job key hash annotation of the JobSet, if set,
+// selects a supported hash function.
+func validateJobKeyHashAnnotation(js *jobset.JobSet) field.ErrorList {
+	value, ok := js.Annotations[jobset.JobKeyHashKey]
+	if !ok {
+		return nil
+	}
+	supported := []string{jobset.JobKeyHashSHA1, jobset.JobKeyHashSHA256}
+	if !collections.Contains(supported, value) {
+		return field.ErrorList{field.NotSupported(field.NewPath("metadata", "annotations").Key(jobset.JobKeyHashKey), value, supported)}
+	}
+	return nil
+}
+
+// validateJobNameTemplate validates that spec.jobNameTemplate renders distinct names for the
+// child Jobs. The DNS compliance of the rendered names is validated along with each replicatedJob.
+func validateJobNameTemplate(js *jobset.JobSet) field.ErrorList {
+	if js.Spec.JobNameTemplate == "" {
+		return nil
+	}
+	fieldPath := field.NewPath("spec", "jobNameTemplate")
+	// Rendering the first and last instance index catches templates which do not reference the
+	// instance index.
+	instanceIndexes := []int{0}
+	if instances := controllers.NumInstances(js); instances > 1 {
+		instanceIndexes = append(instanceIndexes, instances-1)
+	}
+	jobNames := map[string]bool{}
+	for _, rjob := range js.Spec.ReplicatedJobs {
+		// Rendering the first and last Job index of each replicatedJob catches templates which do
+		// not reference the Job index or the replicatedJob name.
+		jobIndexes := []int{0}
+		if rjob.Replicas > 1 {
+			jobIndexes = append(jobIndexes, int(rjob.Replicas-1))
+		}
+		for _, instanceIdx := range instanceIndexes {
+			for _, jobIdx := range jobIndexes {
+				jobName, err := controllers.GenJobName(js, rjob.Name, instanceIdx, jobIdx)
+				if err != nil {
+					return field.ErrorList{field.Invalid(fieldPath, js.Spec.JobNameTemplate, err.Error())}
+				}
+				if jobNames[jobName] {
+					return field.ErrorList{field.Invalid(fieldPath, js.Spec.JobNameTemplate, fmt.Sprintf("renders the same name '%s' for different jobs", jobName))}
+				}
+				jobNames[jobName] = true
+			}
+		}
+	}
+	return nil
+}
+
+// validateIndexedOverrides validates that the indexed overrides of the replicatedJob target
+// existing Job indexes, and that their patches can be applied to the Job template.
+func validateIndexedOverrides(rjob *jobset.ReplicatedJob, fieldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for i, override := range rjob.IndexedOverrides {
+		overridePath := fieldPath.Index(i)
+		endIndex := ptr.Deref(override.EndIndex, override.StartIndex)
+		if endIndex < override.StartIndex {
+			errs = append(errs, field.Invalid(overridePath.Child("endIndex"), endIndex, "must be greater than or equal to startIndex"))
+		}
+		if endIndex >= rjob.Replicas {
+			errs = append(errs, field.Invalid(overridePath.Child("endIndex"), endIndex, fmt.Sprintf("must be less than the %d replicas of replicatedJob '%s'", rjob.Replicas, rjob.Name)))
+		}
+		if len(override.Patch.Raw) == 0 {
+			errs = append(errs, field.Required(overridePath.Child("patch"), ""))
+			continue
+		}
+		if _, err := controllers.ApplyIndexedOverride(&rjob.Template, override.Patch.Raw); err != nil {
+			errs = append(errs, field.Invalid(overridePath.Child("patch"), string(override.Patch.Raw), fmt.Sprintf("invalid strategic merge patch for the job template: %v", err)))
+		}
+	}
+	return errs
+}
+
+// validateOnRestartOverrides validates that the restart overrides applied to the replicatedJob
+// at the given index can be applied to its Job template. The overrides of the replicatedJob
+// failure policy take precedence over the overrides of the JobSet failure policy.
+func validateOnRestartOverrides(js *jobset.JobSet, rjobIdx int) field.ErrorList {
+	rjob := &js.Spec.ReplicatedJobs[rjobIdx]
+	var patch *runtime.RawExtension
+	var fieldPath *field.Path
+	switch {
+	case rjob.FailurePolicy != nil && rjob.FailurePolicy.OnRestartOverrides != nil:
+		patch = rjob.FailurePolicy.OnRestartOverrides
+		fieldPath = field.NewPath("spec", "replicatedJobs").Index(rjobIdx).Child("failurePolicy", "onRestartOverrides")
+	case js.Spec.FailurePolicy != nil && js.Spec.FailurePolicy.OnRestartOverrides != nil:
+		patch = js.Spec.FailurePolicy.OnRestartOverrides
+		fieldPath = field.NewPath("spec", "failurePolicy", "onRestartOverrides")
+	default:
+		return nil
+	}
+	if len(patch.Raw) == 0 {
+		return nil
+	}
+	if _, err := controllers.ApplyIndexedOverride(&rjob.Template, patch.Raw); err != nil {
+		return field.ErrorList{field.Invalid(fieldPath, string(patch.Raw), fmt.Sprintf("invalid strategic merge patch for the job template of replicatedJob '%s': %v", rjob.Name, err))}
+	}
+	return nil
+}
+
+// validateCoordinator validates that the coordinator references a pod of the JobSet, and
+// that it is defined when the coordinator Service is enabled.
+func validateCoordinator(js *jobset.JobSet) field.ErrorList {
+	var errs field.ErrorList
+	if js.Spec.Network != nil && js.Spec.Network.CoordinatorService != nil {
+		if js.Spec.Coordinator == nil {
+			errs = append(errs, field.Required(field.NewPath("spec", "coordinator"), "must be set when spec.network.coordinatorService is set"))
+		}
+		if len(js.Spec.Network.CoordinatorService.Ports) == 0 {
+			errs = append(errs, field.Required(field.NewPath("spec", "network", "coordinatorService", "ports"), "must expose at least one port"))
+		}
+	}
+	if js.Spec.Coordinator == nil {
+		return errs
+	}
+
+	coordinator := js.Spec.Coordinator
+	fieldPath := field.NewPath("spec", "coordinator")
+	var rjob *jobset.ReplicatedJob
+	for i := range js.Spec.ReplicatedJobs {
+		if js.Spec.ReplicatedJobs[i].Name == coordinator.ReplicatedJob {
+			rjob = &js.Spec.ReplicatedJobs[i]
+			break
+		}
+	}
+	if rjob == nil {
+		return append(errs, field.NotFound(fieldPath.Child("replicatedJob"), coordinator.ReplicatedJob))
+	}
+	if coordinator.JobIndex < 0 || coordinator.JobIndex >= rjob.Replicas {
+		errs = append(errs, field.Invalid(fieldPath.Child("jobIndex"), coordinator.JobIndex, fmt.Sprintf("must be less than the %d replicas of replicatedJob '%s'", rjob.Replicas, rjob.Name)))
+	}
+	if completions := rjob.Template.Spec.Completions; coordinator.PodIndex < 0 || (completions != nil && coordinator.PodIndex >= *completions) {
+		errs = append(errs, field.Invalid(fieldPath.Child("podIndex"), coordinator.PodIndex, fmt.Sprintf("must be less than the completions of replicatedJob '%s'", rjob.Name)))
+	}
+	return errs
+}
+
+// validateNodeAffinityStrategy validates that the node affinity strategy for exclusive placement
+// is only used along with a topology key, and not along with the node selector strategy.
+func validateNodeAffinityStrategy(annotations map[string]string, exclusive bool, fieldPath *field.Path) field.ErrorList {
+	value, ok := annotations[jobset.NodeAffinityStrategyKey]
+	if !ok {
+		return nil
+	}
+	var errs field.ErrorList
+	if !exclusive {
+		errs = append(errs, field.Invalid(fieldPath.Key(jobset.NodeAffinityStrategyKey), value, fmt.Sprintf("requires the %s annotation to also be set", jobset.ExclusiveKey)))
+	}
+	if _, ok := annotations[jobset.NodeSelectorStrategyKey]; ok {
+		errs = append(errs, field.Invalid(fieldPath.Key(jobset.NodeAffinityStrategyKey), value, fmt.Sprintf("must not be set along with the %s annotation", jobset.NodeSelectorStrategyKey)))
+	}
+	return errs
+}
+
+// validateSidecarContainers validates that the names of the sidecar containers are unique and
+// not used by the containers of the pod templates, and that they only set the restart policy
+// of native sidecars.
+func validateSidecarContainers(js *jobset.JobSet) field.ErrorList {
+	if len(js.Spec.SidecarContainers) == 0 {
+		return nil
+	}
+	var errs field.ErrorList
+	podTemplates := jobSetPodTemplates(js)
+	var names []string
+	for i, container := range js.Spec.SidecarContainers {
+		fieldPath := field.NewPath("spec", "sidecarContainers").Index(i)
+		if collections.Contains(names, container.Name) || podTemplatesUseContainerName(podTemplates, container.Name) {
+			errs = append(errs, field.Duplicate(fieldPath.Child("name"), container.Name))
+		}
+		names = append(names, container.Name)
+		if container.RestartPolicy != nil && *container.RestartPolicy != corev1.ContainerRestartPolicyAlways {
+			errs = append(errs, field.NotSupported(fieldPath.Child("restartPolicy"), *container.RestartPolicy, []string{string(corev1.ContainerRestartPolicyAlways)}))
+		}
+	}
+	return errs
+}
+
+// validateDependsOn validates that the replicated jobs only depend on other replicated jobs of the
+// JobSet, and that their dependencies don't form a cycle.
+func validateDependsOn(js *jobset.JobSet, validReplicatedJobs []string) field.ErrorList {
+	var errs field.ErrorList
+	dependencies := map[string][]string{}
+	for i, rjob := range js.Spec.ReplicatedJobs {
+		dependsOnPath := field.NewPath("spec", "replicatedJobs").Index(i).Child("dependsOn")
+		seen := sets.New[string]()
+		for j, name := range rjob.DependsOn {
+			switch {
+			case name == rjob.Name:
+				errs = append(errs, field.Invalid(dependsOnPath.Index(j), name, "a replicated job must not depend on itself"))
+			case !collections.Contains(validReplicatedJobs, name):
+				errs = append(errs, field.NotFound(dependsOnPath.Index(j), name))
+			case seen.Has(name):
+				errs = append(errs, field.Duplicate(dependsOnPath.Index(j), name))
+			default:
+				dependencies[rjob.Name] = append(dependencies[rjob.Name], name)
+			}
+			seen.Insert(name)
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	// Detect a cycle with a depth-first search over the dependencies.
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := map[string]int{}
+	var visit func(name string) bool
+	visit = func(name string) bool {
+		state[name] = visiting
+		for _, dependency := range dependencies[name] {
+			if state[dependency] == visiting || (state[dependency] == 0 && visit(dependency)) {
+				return true
+			}
+		}
+		state[name] = visited
+		return false
+	}
+	for i, rjob := range js.Spec.ReplicatedJobs {
+		if state[rjob.Name] == 0 && visit(rjob.Name) {
+			errs = append(errs, field.Invalid(field.NewPath("spec", "replicatedJobs").Index(i).Child("dependsOn"), rjob.DependsOn, "the dependencies of the replicated jobs must not form a cycle"))
+			break
+		}
+	}
+	return errs
+}
+
+// validateSchedulingGates validates that the names of the scheduling gates are unique qualified
+// names, as required for the scheduling gates of a pod.
+func validateSchedulingGates(gates []corev1.PodSchedulingGate, fieldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	var names []string
+	for i, gate := range gates {
+		namePath := fieldPath.Index(i).Child("name")
+		for _, errMessage := range validation.IsQualifiedName(gate.Name) {
+			errs = append(errs, field.Invalid(namePath, gate.Name, errMessage))
+		}
+		if collections.Contains(names, gate.Name) {
+			errs = append(errs, field.Duplicate(namePath, gate.Name))
+		}
+		names = append(names, gate.Name)
+	}
+	return errs
+}
+
+// jobSetPodTemplates returns the inline pod templates of the replicatedJobs and the shared pod
+// templates of the JobSet.
+func jobSetPodTemplates(js *jobset.JobSet) []*corev1.PodTemplateSpec {
+	podTemplates := make([]*corev1.PodTemplateSpec, 0, len(js.Spec.ReplicatedJobs)+len(js.Spec.PodTemplates))
+	for i := range js.Spec.ReplicatedJobs {
+		podTemplates = append(podTemplates, &js.Spec.ReplicatedJobs[i].Template.Spec.Template)
+	}
+	for _, podTemplate := range js.Spec.PodTemplates {
+		podTemplates = append(podTemplates, &podTemplate)
+	}
+	return podTemplates
+}
+
+// validateVolumes validates that the JobSet level volumes and volume mounts can be added to the
+// pods of the JobSet, i.e. that their volume names and mount paths are unique and not already
+// used by the pod templates, or by the containers of the pod templates and the sidecar containers.
+func validateVolumes(js *jobset.JobSet) field.ErrorList {
+	if len(js.Spec.Volumes) == 0 && len(js.Spec.VolumeMounts) == 0 {
+		return nil
+	}
+	var errs field.ErrorList
+	podTemplates := jobSetPodTemplates(js)
+	var names []string
+	for i, volume := range js.Spec.Volumes {
+		if collections.Contains(names, volume.Name) || podTemplatesUseVolumeName(podTemplates, volume.Name) {
+			errs = append(errs, field.Duplicate(field.NewPath("spec", "volumes").Index(i).Child("name"), volume.Name))
+		}
+		names = append(names, volume.Name)
+	}
+	var mountPaths []string
+	for i, volumeMount := range js.Spec.VolumeMounts {
+		if collections.Contains(mountPaths, volumeMount.MountPath) || podTemplatesUseMountPath(podTemplates, js.Spec.SidecarContainers, volumeMount.MountPath) {
+			errs = append(errs, field.Duplicate(field.NewPath("spec", "volumeMounts").Index(i).Child("mountPath"), volumeMount.MountPath))
+		}
+		mountPaths = append(mountPaths, volumeMount.MountPath)
+	}
+	return errs
+}
+
+// podTemplatesUseVolumeName returns true if a volume of any of the pod templates has the given name.
+func podTemplatesUseVolumeName(podTemplates []*corev1.PodTemplateSpec, name string) bool {
+	for _, podTemplate := range podTemplates {
+		for _, volume := range podTemplate.Spec.Volumes {
+			if volume.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// podTemplatesUseMountPath returns true if a container or init container of any of the pod
+// templates, or any of the sidecar containers, mounts a volume at the given path.
+func podTemplatesUseMountPath(podTemplates []*corev1.PodTemplateSpec, sidecars []corev1.Container, mountPath string) bool {
+	containerLists := [][]corev1.Container{sidecars}
+	for _, podTemplate := range podTemplates {
+		containerLists = append(containerLists, podTemplate.Spec.InitContainers, podTemplate.Spec.Containers)
+	}
+	for _, containers := range containerLists {
+		for _, container := range containers {
+			for _, volumeMount := range container.VolumeMounts {
+				if volumeMount.MountPath == mountPath {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// podTemplatesUseContainerName returns true if a container or init container of any of the
+// pod templates has the given name.
+func podTemplatesUseContainerName(podTemplates []*corev1.PodTemplateSpec, name string) bool {
+	for _, podTemplate := range podTemplates {
+		for _, containers := range [][]corev1.Container{podTemplate.Spec.InitContainers, podTemplate.Spec.Containers} {
+			for _, container := range containers {
+				if container.Name == name {
+					return true
+				}
+			}
+		}
+	}
+	return false
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -226,12 +1013,33 @@ func (j *jobSetWebhook) ValidateUpdate(ctx context.Context, old, newObj runtime.
 	mungedSpec := js.Spec.DeepCopy()
 	if ptr.Deref(oldJS.Spec.Suspend, false) {
 		for index := range js.Spec.ReplicatedJobs {
+			if index >= len(oldJS.Spec.ReplicatedJobs) {
+				break
+			}
 			mungedSpec.ReplicatedJobs[index].Template.Spec.Template.Spec.NodeSelector = oldJS.Spec.ReplicatedJobs[index].Template.Spec.Template.Spec.NodeSelector
 		}
+		for name, podTemplate := range mungedSpec.PodTemplates {
+			if oldPodTemplate, ok := oldJS.Spec.PodTemplates[name]; ok {
+				podTemplate.Spec.NodeSelector = oldPodTemplate.Spec.NodeSelector
+				mungedSpec.PodTemplates[name] = podTemplate
+			}
+		}
 	}
 	// Note that SucccessPolicy and failurePolicy are made immutable via CEL.
 	errs := apivalidation.ValidateImmutableField(mungedSpec.ReplicatedJobs, oldJS.Spec.ReplicatedJobs, field.NewPath("spec").Child("replicatedJobs"))
+	errs = append(errs, apivalidation.ValidateImmutableField(mungedSpec.PodTemplates, oldJS.Spec.PodTemplates, field.NewPath("spec").Child("podTemplates"))...)
 	errs = append(errs, apivalidation.ValidateImmutableField(mungedSpec.ManagedBy, oldJS.Spec.ManagedBy, field.NewPath("spec").Child("labels").Key("managedBy"))...)
+	// The replicas annotation is updated by autoscalers while the JobSet is running.
+	if js.Annotations[jobset.ReplicasKey] != oldJS.Annotations[jobset.ReplicasKey] {
+		errs = append(errs, validateReplicasAnnotation(js)...)
+	}
+	// The remaining fields are only applied by the controller to newly created Jobs,
+	// so reject changes to them while the JobSet is active to avoid confusing drift.
+	if !controllers.JobSetFinished(oldJS) {
+		errs = append(errs, validateImmutableWhileActive(mungedSpec, &oldJS.Spec)...)
+		// Changing the job key hash would change the keys of recreated Jobs only.
+		errs = append(errs, apivalidation.ValidateImmutableField(js.Annotations[jobset.JobKeyHashKey], oldJS.Annotations[jobset.JobKeyHashKey], field.NewPath("metadata", "annotations").Key(jobset.JobKeyHashKey))...)
+	}
 	return nil, errs.ToAggregate()
 }
 
@@ -240,6 +1048,39 @@ func (j *jobSetWebhook) ValidateDelete(ctx context.Context, obj runtime.Object)
 	return nil, nil
 }
 
+// validateImmutableWhileActive returns an error for each field of the JobSet spec which was
+// changed, except for fields which are mutable while the JobSet is active, and fields which
+// are validated separately.
+func validateImmutableWhileActive(newSpec, oldSpec *jobset.JobSetSpec) field.ErrorList {
+	spec := newSpec.DeepCopy()
+	// Fields which can be updated while the JobSet is active.
+	spec.Suspend = oldSpec.Suspend
+	spec.TTLSecondsAfterFinished = oldSpec.TTLSecondsAfterFinished
+	spec.ActiveDeadlineSeconds = oldSpec.ActiveDeadlineSeconds
+	spec.OnSuspend = oldSpec.OnSuspend
+	spec.ImagePullSecrets = oldSpec.ImagePullSecrets
+	spec.Paused = oldSpec.Paused
+	spec.StatusSyncPeriodSeconds = oldSpec.StatusSyncPeriodSeconds
+	spec.Lifecycle = oldSpec.Lifecycle
+	spec.ReportJobStatuses = oldSpec.ReportJobStatuses
+	spec.CleanupPolicy = oldSpec.CleanupPolicy
+	// Fields which are validated separately.
+	spec.ReplicatedJobs = oldSpec.ReplicatedJobs
+	spec.PodTemplates = oldSpec.PodTemplates
+	spec.ManagedBy = oldSpec.ManagedBy
+
+	var errs field.ErrorList
+	newValue, oldValue := reflect.ValueOf(*spec), reflect.ValueOf(*oldSpec)
+	for i := 0; i < newValue.NumField(); i++ {
+		if apiequality.Semantic.DeepEqual(newValue.Field(i).Interface(), oldValue.Field(i).Interface()) {
+			continue
+		}
+		name, _, _ := strings.Cut(newValue.Type().Field(i).Tag.Get("json"), ",")
+		errs = append(errs, field.Forbidden(field.NewPath("spec").Child(name), immutableWhileActiveErrorMsg))
+	}
+	return errs
+}
+
 func completionModePtr(mode batchv1.CompletionMode) *batchv1.CompletionMode {
 	return &mode
 }
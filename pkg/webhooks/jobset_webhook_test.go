@@ -10,14 +10,18 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
 
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	jobset "sigs.k8s.io/jobset/api/jobset/v1alpha2"
 )
@@ -367,6 +371,65 @@ func TestJobSetDefaulting(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "pod restart policy unset with a pod restart policy enforced on the jobset",
+			js: &jobset.JobSet{
+				Spec: jobset.JobSetSpec{
+					SuccessPolicy:    defaultSuccessPolicy,
+					StartupPolicy:    defaultStartupPolicy,
+					Network:          defaultNetwork,
+					PodRestartPolicy: corev1.RestartPolicyNever,
+					PodTemplates: map[string]corev1.PodTemplateSpec{
+						"shared": {
+							Spec: corev1.PodSpec{},
+						},
+					},
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: corev1.PodTemplateSpec{
+										Spec: corev1.PodSpec{},
+									},
+									CompletionMode: completionModePtr(batchv1.IndexedCompletion),
+								},
+							},
+						},
+					},
+					ManagedBy: ptr.To(jobset.JobSetControllerName),
+				},
+			},
+			want: &jobset.JobSet{
+				Spec: jobset.JobSetSpec{
+					SuccessPolicy:    defaultSuccessPolicy,
+					StartupPolicy:    defaultStartupPolicy,
+					Network:          defaultNetwork,
+					PodRestartPolicy: corev1.RestartPolicyNever,
+					PodTemplates: map[string]corev1.PodTemplateSpec{
+						"shared": {
+							Spec: corev1.PodSpec{
+								RestartPolicy: corev1.RestartPolicyNever,
+							},
+						},
+					},
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: corev1.PodTemplateSpec{
+										Spec: corev1.PodSpec{
+											RestartPolicy: corev1.RestartPolicyNever,
+										},
+									},
+									CompletionMode: completionModePtr(batchv1.IndexedCompletion),
+								},
+							},
+						},
+					},
+					ManagedBy: ptr.To(jobset.JobSetControllerName),
+				},
+			},
+		},
 		{
 			name: "success policy unset",
 			js: &jobset.JobSet{
@@ -593,9 +656,63 @@ func TestJobSetDefaulting(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "pod restart policy of referenced pod templates unset",
+			js: &jobset.JobSet{
+				Spec: jobset.JobSetSpec{
+					SuccessPolicy: defaultSuccessPolicy,
+					StartupPolicy: defaultStartupPolicy,
+					Network:       defaultNetwork,
+					ManagedBy:     ptr.To(jobset.JobSetControllerName),
+					PodTemplates: map[string]corev1.PodTemplateSpec{
+						"shared": {
+							Spec: corev1.PodSpec{
+								Containers: TestPodTemplate.Spec.Containers,
+							},
+						},
+					},
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							PodTemplateName: "shared",
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									CompletionMode: completionModePtr(batchv1.IndexedCompletion),
+								},
+							},
+						},
+					},
+				},
+			},
+			want: &jobset.JobSet{
+				Spec: jobset.JobSetSpec{
+					SuccessPolicy: defaultSuccessPolicy,
+					StartupPolicy: defaultStartupPolicy,
+					Network:       defaultNetwork,
+					ManagedBy:     ptr.To(jobset.JobSetControllerName),
+					PodTemplates: map[string]corev1.PodTemplateSpec{
+						"shared": {
+							Spec: corev1.PodSpec{
+								RestartPolicy: corev1.RestartPolicyOnFailure,
+								Containers:    TestPodTemplate.Spec.Containers,
+							},
+						},
+					},
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							PodTemplateName: "shared",
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									CompletionMode: completionModePtr(batchv1.IndexedCompletion),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 	fakeClient := fake.NewFakeClient()
-	webhook, err := NewJobSetWebhook(fakeClient)
+	webhook, err := NewJobSetWebhook(fakeClient, JobSetWebhookOptions{})
 	if err != nil {
 		t.Fatalf("error creating jobset webhook: %v", err)
 	}
@@ -612,6 +729,63 @@ func TestJobSetDefaulting(t *testing.T) {
 	}
 }
 
+func TestJobSetDefaultMaxRestarts(t *testing.T) {
+	createCtx := admission.NewContextWithRequest(context.TODO(), admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Operation: admissionv1.Create}})
+	updateCtx := admission.NewContextWithRequest(context.TODO(), admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Operation: admissionv1.Update}})
+	testCases := []struct {
+		name              string
+		ctx               context.Context
+		opts              JobSetWebhookOptions
+		failurePolicy     *jobset.FailurePolicy
+		wantFailurePolicy *jobset.FailurePolicy
+	}{
+		{
+			name: "no default max restarts",
+			ctx:  createCtx,
+		},
+		{
+			name:              "default max restarts is applied when the failure policy is unset",
+			ctx:               createCtx,
+			opts:              JobSetWebhookOptions{DefaultMaxRestarts: 3},
+			wantFailurePolicy: &jobset.FailurePolicy{MaxRestarts: 3},
+		},
+		{
+			name:              "failure policy of the jobset takes precedence",
+			ctx:               createCtx,
+			opts:              JobSetWebhookOptions{DefaultMaxRestarts: 3},
+			failurePolicy:     &jobset.FailurePolicy{MaxRestarts: 1},
+			wantFailurePolicy: &jobset.FailurePolicy{MaxRestarts: 1},
+		},
+		{
+			name:              "failure policy of the jobset with zero max restarts takes precedence",
+			ctx:               createCtx,
+			opts:              JobSetWebhookOptions{DefaultMaxRestarts: 3},
+			failurePolicy:     &jobset.FailurePolicy{},
+			wantFailurePolicy: &jobset.FailurePolicy{},
+		},
+		{
+			name: "existing jobsets are not defaulted",
+			ctx:  updateCtx,
+			opts: JobSetWebhookOptions{DefaultMaxRestarts: 3},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			webhook, err := NewJobSetWebhook(fake.NewFakeClient(), tc.opts)
+			if err != nil {
+				t.Fatalf("error creating jobset webhook: %v", err)
+			}
+			js := &jobset.JobSet{Spec: jobset.JobSetSpec{FailurePolicy: tc.failurePolicy}}
+			if err := webhook.Default(tc.ctx, js); err != nil {
+				t.Errorf("unexpected error defaulting jobset: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantFailurePolicy, js.Spec.FailurePolicy); diff != "" {
+				t.Errorf("unexpected failure policy (-want/+got): %s", diff)
+			}
+		})
+	}
+}
+
 func TestValidateCreate(t *testing.T) {
 	managedByFieldPath := field.NewPath("spec", "managedBy")
 
@@ -683,6 +857,7 @@ func TestValidateCreate(t *testing.T) {
 			},
 			want: errors.Join(
 				fmt.Errorf("the product of replicas and parallelism must not exceed 2147483647 for replicatedJob 'test-jobset-replicated-job-0'"),
+				field.Invalid(field.NewPath("spec", "replicatedJobs").Index(1).Child("replicas"), int64(math.MinInt32), "must be greater than or equal to 0"),
 				fmt.Errorf("the product of replicas and parallelism must not exceed 2147483647 for replicatedJob 'test-jobset-replicated-job-1'"),
 			),
 		},
@@ -744,7 +919,7 @@ func TestValidateCreate(t *testing.T) {
 			),
 		},
 		{
-			name: "network has invalid dns name",
+			name: "success policy total succeeded within the number of target jobs",
 			js: &jobset.JobSet{
 				TypeMeta: metav1.TypeMeta{
 					Kind:       "JobSet",
@@ -752,13 +927,9 @@ func TestValidateCreate(t *testing.T) {
 				},
 				ObjectMeta: validObjectMeta,
 				Spec: jobset.JobSetSpec{
-					Network: &jobset.Network{
-						EnableDNSHostnames: ptr.To(true),
-						Subdomain:          strings.Repeat("a", 64),
-					},
 					ReplicatedJobs: []jobset.ReplicatedJob{
 						{
-							Name:     "test-jobset-replicated-job-0",
+							Name:     "driver",
 							Replicas: 1,
 							Template: batchv1.JobTemplateSpec{
 								Spec: batchv1.JobSpec{
@@ -766,18 +937,27 @@ func TestValidateCreate(t *testing.T) {
 								},
 							},
 						},
+						{
+							Name:     "workers",
+							Replicas: 4,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
 					},
 					SuccessPolicy: &jobset.SuccessPolicy{
-						Operator: jobset.OperatorAll,
+						Operator:             jobset.OperatorAll,
+						TargetReplicatedJobs: []string{"workers"},
+						TotalSucceeded:       ptr.To[int32](4),
 					},
 				},
 			},
-			want: errors.Join(
-				fmt.Errorf(subdomainTooLongErrMsg),
-			),
+			want: errors.Join(),
 		},
 		{
-			name: "jobset name with invalid character",
+			name: "success policy total succeeded exceeds the number of target jobs",
 			js: &jobset.JobSet{
 				TypeMeta: metav1.TypeMeta{
 					Kind:       "JobSet",
@@ -787,7 +967,7 @@ func TestValidateCreate(t *testing.T) {
 				Spec: jobset.JobSetSpec{
 					ReplicatedJobs: []jobset.ReplicatedJob{
 						{
-							Name:     "username.llama65b",
+							Name:     "driver",
 							Replicas: 1,
 							Template: batchv1.JobTemplateSpec{
 								Spec: batchv1.JobSpec{
@@ -795,31 +975,44 @@ func TestValidateCreate(t *testing.T) {
 								},
 							},
 						},
+						{
+							Name:     "workers",
+							Replicas: 4,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
 					},
 					SuccessPolicy: &jobset.SuccessPolicy{
-						Operator: jobset.OperatorAll,
+						Operator:             jobset.OperatorAll,
+						TargetReplicatedJobs: []string{"workers"},
+						TotalSucceeded:       ptr.To[int32](5),
 					},
 				},
 			},
 			want: errors.Join(
-				fmt.Errorf("a DNS-1035 label must consist of lower case alphanumeric characters or '-', start with an alphabetic character, and end with an alphanumeric character (e.g. 'my-name',  or 'abc-123', regex used for validation is '[a-z]([-a-z0-9]*[a-z0-9])?')"),
+				field.Invalid(field.NewPath("spec", "successPolicy", "totalSucceeded"), int32(5), "must not exceed the number of jobs of the target replicated jobs (4)"),
 			),
 		},
 		{
-			name: "jobset name will result in job name being too long",
+			name: "network has invalid dns name",
 			js: &jobset.JobSet{
 				TypeMeta: metav1.TypeMeta{
 					Kind:       "JobSet",
 					APIVersion: "jobset.x-k8s.io/v1alpha2",
 				},
-				ObjectMeta: metav1.ObjectMeta{
-					Name: strings.Repeat("a", 62),
-				},
+				ObjectMeta: validObjectMeta,
 				Spec: jobset.JobSetSpec{
+					Network: &jobset.Network{
+						EnableDNSHostnames: ptr.To(true),
+						Subdomain:          strings.Repeat("a", 64),
+					},
 					ReplicatedJobs: []jobset.ReplicatedJob{
 						{
-							Name:     "rj",
-							Replicas: 101,
+							Name:     "test-jobset-replicated-job-0",
+							Replicas: 1,
 							Template: batchv1.JobTemplateSpec{
 								Spec: batchv1.JobSpec{
 									Template: validPodTemplateSpec,
@@ -833,30 +1026,25 @@ func TestValidateCreate(t *testing.T) {
 				},
 			},
 			want: errors.Join(
-				fmt.Errorf(jobNameTooLongErrorMsg),
+				fmt.Errorf(subdomainTooLongErrMsg),
 			),
 		},
 		{
-			name: "jobset name will result in a pod name being too long",
+			name: "valid service selector",
 			js: &jobset.JobSet{
-				TypeMeta: metav1.TypeMeta{
-					Kind:       "JobSet",
-					APIVersion: "jobset.x-k8s.io/v1alpha2",
-				},
-				ObjectMeta: metav1.ObjectMeta{
-					Name: strings.Repeat("a", 56),
-				},
+				ObjectMeta: validObjectMeta,
 				Spec: jobset.JobSetSpec{
+					Network: &jobset.Network{
+						EnableDNSHostnames: ptr.To(true),
+						ServiceSelector:    map[string]string{"role": "worker"},
+					},
 					ReplicatedJobs: []jobset.ReplicatedJob{
 						{
-							Name:     "rj",
+							Name:     "test-jobset-replicated-job-0",
 							Replicas: 1,
 							Template: batchv1.JobTemplateSpec{
 								Spec: batchv1.JobSpec{
-									CompletionMode: ptr.To(batchv1.IndexedCompletion),
-									Completions:    ptr.To(int32(1)),
-									Parallelism:    ptr.To(int32(1)),
-									Template:       validPodTemplateSpec,
+									Template: validPodTemplateSpec,
 								},
 							},
 						},
@@ -866,127 +1054,2622 @@ func TestValidateCreate(t *testing.T) {
 					},
 				},
 			},
-			want: errors.Join(
-				fmt.Errorf(podNameTooLongErrorMsg),
-			),
+			want: errors.Join(),
 		},
 		{
-			name: "jobset controller name is not a domain-prefixed path",
+			name: "service selector with invalid label value",
 			js: &jobset.JobSet{
 				ObjectMeta: validObjectMeta,
 				Spec: jobset.JobSetSpec{
-					ManagedBy: ptr.To(notDomainPrefixedPathControllerName),
+					Network: &jobset.Network{
+						EnableDNSHostnames: ptr.To(true),
+						ServiceSelector:    map[string]string{"role": "-worker"},
+					},
 					ReplicatedJobs: []jobset.ReplicatedJob{
 						{
-							Name:     "rj",
+							Name:     "test-jobset-replicated-job-0",
 							Replicas: 1,
 							Template: batchv1.JobTemplateSpec{
 								Spec: batchv1.JobSpec{
-									CompletionMode: ptr.To(batchv1.IndexedCompletion),
-									Completions:    ptr.To(int32(1)),
-									Parallelism:    ptr.To(int32(1)),
+									Template: validPodTemplateSpec,
 								},
 							},
 						},
 					},
-					SuccessPolicy: &jobset.SuccessPolicy{},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
 				},
 			},
 			want: errors.Join(
-				notDomainPrefixedPathControllerErrors...,
+				field.Invalid(field.NewPath("spec", "network", "serviceSelector"), "-worker", "a valid label must be an empty string or consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyValue',  or 'my_value',  or '12345', regex used for validation is '(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?')"),
 			),
 		},
 		{
-			name: "jobset controller name is too long",
+			name: "service selector overriding the jobset name label",
 			js: &jobset.JobSet{
 				ObjectMeta: validObjectMeta,
 				Spec: jobset.JobSetSpec{
-					ManagedBy: ptr.To(tooLongControllerName),
+					Network: &jobset.Network{
+						EnableDNSHostnames: ptr.To(true),
+						ServiceSelector:    map[string]string{jobset.JobSetNameKey: "other"},
+					},
 					ReplicatedJobs: []jobset.ReplicatedJob{
 						{
-							Name:     "rj",
+							Name:     "test-jobset-replicated-job-0",
 							Replicas: 1,
 							Template: batchv1.JobTemplateSpec{
 								Spec: batchv1.JobSpec{
-									CompletionMode: ptr.To(batchv1.IndexedCompletion),
-									Completions:    ptr.To(int32(1)),
-									Parallelism:    ptr.To(int32(1)),
+									Template: validPodTemplateSpec,
 								},
 							},
 						},
 					},
-					SuccessPolicy: &jobset.SuccessPolicy{},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
 				},
 			},
 			want: errors.Join(
-				tooLongControllerNameError,
+				field.Forbidden(field.NewPath("spec", "network", "serviceSelector").Key(jobset.JobSetNameKey), "the jobset name label is always part of the selector and can't be overridden"),
 			),
 		},
 		{
-			name: "jobset controller name is set and valid",
+			name: "valid replicas annotation",
 			js: &jobset.JobSet{
-				ObjectMeta: validObjectMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "js",
+					Annotations: map[string]string{jobset.ReplicasKey: "test-jobset-replicated-job-0=5"},
+				},
 				Spec: jobset.JobSetSpec{
-					ManagedBy: ptr.To(jobset.JobSetControllerName),
 					ReplicatedJobs: []jobset.ReplicatedJob{
 						{
-							Name:     "rj",
+							Name:     "test-jobset-replicated-job-0",
 							Replicas: 1,
 							Template: batchv1.JobTemplateSpec{
 								Spec: batchv1.JobSpec{
-									CompletionMode: ptr.To(batchv1.IndexedCompletion),
-									Completions:    ptr.To(int32(1)),
-									Parallelism:    ptr.To(int32(1)),
+									Template: validPodTemplateSpec,
 								},
 							},
 						},
 					},
-					SuccessPolicy: &jobset.SuccessPolicy{},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
 				},
 			},
 			want: errors.Join(),
 		},
 		{
-			name: "jobset controller name is unset",
+			name: "replicas annotation with invalid replicas",
 			js: &jobset.JobSet{
-				ObjectMeta: validObjectMeta,
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "js",
+					Annotations: map[string]string{jobset.ReplicasKey: "test-jobset-replicated-job-0=-1"},
+				},
 				Spec: jobset.JobSetSpec{
 					ReplicatedJobs: []jobset.ReplicatedJob{
 						{
-							Name:     "rj",
+							Name:     "test-jobset-replicated-job-0",
 							Replicas: 1,
 							Template: batchv1.JobTemplateSpec{
 								Spec: batchv1.JobSpec{
-									CompletionMode: ptr.To(batchv1.IndexedCompletion),
-									Completions:    ptr.To(int32(1)),
-									Parallelism:    ptr.To(int32(1)),
+									Template: validPodTemplateSpec,
 								},
 							},
 						},
 					},
-					SuccessPolicy: &jobset.SuccessPolicy{},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
 				},
 			},
-			want: errors.Join(),
+			want: errors.Join(
+				field.Invalid(field.NewPath("metadata", "annotations").Key(jobset.ReplicasKey), "test-jobset-replicated-job-0=-1", `invalid replicas "-1" of replicated job "test-jobset-replicated-job-0", must be a non-negative integer`),
+			),
 		},
-	}
-	fakeClient := fake.NewFakeClient()
-	webhook, err := NewJobSetWebhook(fakeClient)
-	if err != nil {
-		t.Fatalf("error creating jobset webhook: %v", err)
-	}
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			_, err := webhook.ValidateCreate(context.TODO(), tc.js.DeepCopyObject())
-			if err != nil && tc.want != nil {
-				assert.Contains(t, err.Error(), tc.want.Error())
-			} else if err != nil && tc.want == nil {
-				t.Errorf("unexpected error: %v", err)
-			} else if err == nil && tc.want != nil {
-				t.Errorf("missing expected error: %v", tc.want)
-			}
-		})
-	}
+		{
+			name: "replicas annotation with unknown replicated job",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "js",
+					Annotations: map[string]string{jobset.ReplicasKey: "test-jobset-replicated-job-0=2,other=1"},
+				},
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "test-jobset-replicated-job-0",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+			want: errors.Join(
+				field.Invalid(field.NewPath("metadata", "annotations").Key(jobset.ReplicasKey), "test-jobset-replicated-job-0=2,other=1", "replicatedJob 'other' does not exist"),
+			),
+		},
+		{
+			name: "job key hash annotation with sha256",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "js",
+					Annotations: map[string]string{jobset.JobKeyHashKey: "sha256"},
+				},
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "test-jobset-replicated-job-0",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+			want: errors.Join(),
+		},
+		{
+			name: "job key hash annotation with unsupported hash",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "js",
+					Annotations: map[string]string{jobset.JobKeyHashKey: "md5"},
+				},
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "test-jobset-replicated-job-0",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+			want: errors.Join(
+				field.NotSupported(field.NewPath("metadata", "annotations").Key(jobset.JobKeyHashKey), "md5", []string{jobset.JobKeyHashSHA1, jobset.JobKeyHashSHA256}),
+			),
+		},
+		{
+			name: "valid service account name",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "js"},
+				Spec: jobset.JobSetSpec{
+					ServiceAccountName: "team-a",
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "rjob",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+		},
+		{
+			name: "invalid service account name",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "js"},
+				Spec: jobset.JobSetSpec{
+					ServiceAccountName: "Team_A",
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "rjob",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+			want: errors.Join(
+				field.Invalid(field.NewPath("spec", "serviceAccountName"), "Team_A", "a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters"),
+			),
+		},
+		{
+			name: "valid runtime class name",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "js"},
+				Spec: jobset.JobSetSpec{
+					RuntimeClassName: "nvidia",
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "rjob",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+		},
+		{
+			name: "invalid runtime class name",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "js"},
+				Spec: jobset.JobSetSpec{
+					RuntimeClassName: "NVIDIA_GPU",
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "rjob",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+			want: errors.Join(
+				field.Invalid(field.NewPath("spec", "runtimeClassName"), "NVIDIA_GPU", "a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters"),
+			),
+		},
+		{
+			name: "valid pod priority class name",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "js"},
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "rjob",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+							PodPriorityClassName: "high-priority",
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+			want: errors.Join(),
+		},
+		{
+			name: "invalid pod priority class name",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "js"},
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "rjob",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+							PodPriorityClassName: "High_Priority",
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+			want: errors.Join(
+				field.Invalid(field.NewPath("spec", "replicatedJobs").Index(0).Child("podPriorityClassName"), "High_Priority", "a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters"),
+			),
+		},
+		{
+			name: "valid scheduler name",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "js"},
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "rjob",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+							SchedulerName: "volcano",
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+			want: errors.Join(),
+		},
+		{
+			name: "invalid scheduler name",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "js"},
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "rjob",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+							SchedulerName: "Volcano_Scheduler",
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+			want: errors.Join(
+				field.Invalid(field.NewPath("spec", "replicatedJobs").Index(0).Child("schedulerName"), "Volcano_Scheduler", "a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters"),
+			),
+		},
+		{
+			name: "valid host aliases",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "js"},
+				Spec: jobset.JobSetSpec{
+					HostAliases: []corev1.HostAlias{{IP: "10.0.0.1", Hostnames: []string{"registry.local"}}},
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "rjob",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+			want: errors.Join(),
+		},
+		{
+			name: "invalid host alias ip",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "js"},
+				Spec: jobset.JobSetSpec{
+					HostAliases: []corev1.HostAlias{{IP: "registry", Hostnames: []string{"registry.local"}}},
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "rjob",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+			want: errors.Join(
+				field.Invalid(field.NewPath("spec", "hostAliases").Index(0).Child("ip"), "registry", "must be a valid IP address"),
+			),
+		},
+		{
+			name: "valid scheduling gates",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "js"},
+				Spec: jobset.JobSetSpec{
+					SchedulingGates: []corev1.PodSchedulingGate{{Name: "example.com/admission"}},
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "rjob",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+							SchedulingGates: []corev1.PodSchedulingGate{{Name: "example.com/topology"}},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+			want: errors.Join(),
+		},
+		{
+			name: "invalid scheduling gate name",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "js"},
+				Spec: jobset.JobSetSpec{
+					SchedulingGates: []corev1.PodSchedulingGate{{Name: "example.com/admission gate"}},
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "rjob",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+			want: errors.Join(
+				field.Invalid(field.NewPath("spec", "schedulingGates").Index(0).Child("name"), "example.com/admission gate", "name part must consist of alphanumeric characters"),
+			),
+		},
+		{
+			name: "duplicate replicated job scheduling gate",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "js"},
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "rjob",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+							SchedulingGates: []corev1.PodSchedulingGate{{Name: "example.com/topology"}, {Name: "example.com/topology"}},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+			want: errors.Join(
+				field.Duplicate(field.NewPath("spec", "replicatedJobs").Index(0).Child("schedulingGates").Index(1).Child("name"), "example.com/topology"),
+			),
+		},
+		{
+			name: "valid pod readiness gates",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "js"},
+				Spec: jobset.JobSetSpec{
+					PodReadinessGates: []corev1.PodReadinessGate{{ConditionType: "example.com/mesh-ready"}, {ConditionType: "example.com/lb-ready"}},
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "rjob",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+			want: errors.Join(),
+		},
+		{
+			name: "invalid pod readiness gate condition type",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "js"},
+				Spec: jobset.JobSetSpec{
+					PodReadinessGates: []corev1.PodReadinessGate{{ConditionType: "example.com/mesh ready"}},
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "rjob",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+			want: errors.Join(
+				field.Invalid(field.NewPath("spec", "podReadinessGates").Index(0).Child("conditionType"), "example.com/mesh ready", "name part must consist of alphanumeric characters"),
+			),
+		},
+		{
+			name: "duplicate pod readiness gate",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "js"},
+				Spec: jobset.JobSetSpec{
+					PodReadinessGates: []corev1.PodReadinessGate{{ConditionType: "example.com/mesh-ready"}, {ConditionType: "example.com/mesh-ready"}},
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "rjob",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+			want: errors.Join(
+				field.Duplicate(field.NewPath("spec", "podReadinessGates").Index(1).Child("conditionType"), "example.com/mesh-ready"),
+			),
+		},
+		{
+			name: "startup gate on a replicated job of an in-order startup policy",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "js"},
+				Spec: jobset.JobSetSpec{
+					StartupPolicy: &jobset.StartupPolicy{
+						StartupPolicyOrder: jobset.InOrder,
+						Gates:              []jobset.StartupGate{{ReplicatedJob: "warmup", Condition: jobset.StartupGateCompletedSuccessfully}},
+					},
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "warmup",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+						{
+							Name:     "trainer",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+			want: errors.Join(),
+		},
+		{
+			name: "startup gate requires the in-order startup policy",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "js"},
+				Spec: jobset.JobSetSpec{
+					StartupPolicy: &jobset.StartupPolicy{
+						StartupPolicyOrder: jobset.AnyOrder,
+						Gates:              []jobset.StartupGate{{ReplicatedJob: "warmup", Condition: jobset.StartupGateCompletedSuccessfully}},
+					},
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "warmup",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+						{
+							Name:     "trainer",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+			want: errors.Join(
+				field.Forbidden(field.NewPath("spec", "startupPolicy", "gates"), "requires the InOrder startup policy order"),
+			),
+		},
+		{
+			name: "startup gate on an unknown or duplicate replicated job",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "js"},
+				Spec: jobset.JobSetSpec{
+					StartupPolicy: &jobset.StartupPolicy{
+						StartupPolicyOrder: jobset.InOrder,
+						Gates:              []jobset.StartupGate{{ReplicatedJob: "warmup", Condition: jobset.StartupGateCompletedSuccessfully}, {ReplicatedJob: "warmup", Condition: jobset.StartupGateCompletedSuccessfully}, {ReplicatedJob: "missing", Condition: jobset.StartupGateCompletedSuccessfully}},
+					},
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "warmup",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+						{
+							Name:     "trainer",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+			want: errors.Join(
+				field.Duplicate(field.NewPath("spec", "startupPolicy", "gates").Index(1).Child("replicatedJob"), "warmup"),
+				field.NotFound(field.NewPath("spec", "startupPolicy", "gates").Index(2).Child("replicatedJob"), "missing"),
+			),
+		},
+		{
+			name: "pod templates match the enforced pod restart policy",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "js"},
+				Spec: jobset.JobSetSpec{
+					PodRestartPolicy: corev1.RestartPolicyNever,
+					PodTemplates: map[string]corev1.PodTemplateSpec{
+						"shared": {
+							Spec: corev1.PodSpec{
+								RestartPolicy: corev1.RestartPolicyNever,
+								Containers:    validPodTemplateSpec.Spec.Containers,
+							},
+						},
+					},
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "rjob",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: corev1.PodTemplateSpec{
+										Spec: corev1.PodSpec{
+											RestartPolicy: corev1.RestartPolicyNever,
+											Containers:    validPodTemplateSpec.Spec.Containers,
+										},
+									},
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+			want: errors.Join(),
+		},
+		{
+			name: "pod templates do not match the enforced pod restart policy",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "js"},
+				Spec: jobset.JobSetSpec{
+					PodRestartPolicy: corev1.RestartPolicyNever,
+					PodTemplates: map[string]corev1.PodTemplateSpec{
+						"shared": {
+							Spec: corev1.PodSpec{
+								RestartPolicy: corev1.RestartPolicyOnFailure,
+								Containers:    validPodTemplateSpec.Spec.Containers,
+							},
+						},
+					},
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "rjob",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: corev1.PodTemplateSpec{
+										Spec: corev1.PodSpec{
+											RestartPolicy: corev1.RestartPolicyOnFailure,
+											Containers:    validPodTemplateSpec.Spec.Containers,
+										},
+									},
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+			want: errors.Join(
+				field.Invalid(field.NewPath("spec", "replicatedJobs").Index(0).Child("template", "spec", "template", "spec", "restartPolicy"), corev1.RestartPolicyOnFailure, "must match spec.podRestartPolicy (Never)"),
+				field.Invalid(field.NewPath("spec", "podTemplates").Key("shared").Child("spec", "restartPolicy"), corev1.RestartPolicyOnFailure, "must match spec.podRestartPolicy (Never)"),
+			),
+		},
+		{
+			name: "completions equal to parallelism",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "js"},
+				Spec: jobset.JobSetSpec{
+					CompletionsPolicy: jobset.CompletionsPolicyEqualParallelism,
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "rjob",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									CompletionMode: completionModePtr(batchv1.IndexedCompletion),
+									Completions:    ptr.To[int32](4),
+									Parallelism:    ptr.To[int32](4),
+									Template:       validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+			want: errors.Join(),
+		},
+		{
+			name: "completions not equal to parallelism",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "js"},
+				Spec: jobset.JobSetSpec{
+					CompletionsPolicy: jobset.CompletionsPolicyEqualParallelism,
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "rjob",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									CompletionMode: completionModePtr(batchv1.IndexedCompletion),
+									Completions:    ptr.To[int32](4),
+									Parallelism:    ptr.To[int32](2),
+									Template:       validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+			want: errors.Join(
+				field.Invalid(field.NewPath("spec", "replicatedJobs").Index(0).Child("template", "spec", "completions"), 4, "must equal parallelism (2) as required by the EqualParallelism completions policy"),
+			),
+		},
+		{
+			name: "completions below parallelism",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "js"},
+				Spec: jobset.JobSetSpec{
+					CompletionsPolicy: jobset.CompletionsPolicyAtMostParallelism,
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "rjob",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									CompletionMode: completionModePtr(batchv1.IndexedCompletion),
+									Completions:    ptr.To[int32](2),
+									Parallelism:    ptr.To[int32](4),
+									Template:       validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+			want: errors.Join(),
+		},
+		{
+			name: "completions exceeding the default parallelism",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "js"},
+				Spec: jobset.JobSetSpec{
+					CompletionsPolicy: jobset.CompletionsPolicyAtMostParallelism,
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "rjob",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									CompletionMode: completionModePtr(batchv1.IndexedCompletion),
+									Completions:    ptr.To[int32](2),
+									Template:       validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+			want: errors.Join(
+				field.Invalid(field.NewPath("spec", "replicatedJobs").Index(0).Child("template", "spec", "completions"), 2, "must not exceed parallelism (1) as required by the AtMostParallelism completions policy"),
+			),
+		},
+		{
+			name: "completions of non-indexed jobs are not restricted",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "js"},
+				Spec: jobset.JobSetSpec{
+					CompletionsPolicy: jobset.CompletionsPolicyEqualParallelism,
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "rjob",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									CompletionMode: completionModePtr(batchv1.NonIndexedCompletion),
+									Completions:    ptr.To[int32](4),
+									Parallelism:    ptr.To[int32](2),
+									Template:       validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+			want: errors.Join(),
+		},
+		{
+			name: "any completions are allowed",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "js"},
+				Spec: jobset.JobSetSpec{
+					CompletionsPolicy: jobset.CompletionsPolicyAny,
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "rjob",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									CompletionMode: completionModePtr(batchv1.IndexedCompletion),
+									Completions:    ptr.To[int32](4),
+									Parallelism:    ptr.To[int32](2),
+									Template:       validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+			want: errors.Join(),
+		},
+		{
+			name: "non-critical replicated job",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "js"},
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "workers",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+						{
+							Name:        "tensorboard",
+							Replicas:    1,
+							NonCritical: ptr.To(true),
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+		},
+		{
+			name: "all replicated jobs are non-critical",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "js"},
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:        "tensorboard",
+							Replicas:    1,
+							NonCritical: ptr.To(true),
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+			want: errors.Join(
+				field.Forbidden(field.NewPath("spec", "replicatedJobs"), "all replicated jobs are non-critical, at least one replicated job must be critical"),
+			),
+		},
+		{
+			name: "success policy targets a non-critical replicated job",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "js"},
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "workers",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+						{
+							Name:        "tensorboard",
+							Replicas:    1,
+							NonCritical: ptr.To(true),
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator:             jobset.OperatorAll,
+						TargetReplicatedJobs: []string{"tensorboard"},
+					},
+				},
+			},
+			want: errors.Join(
+				field.Invalid(field.NewPath("spec", "successPolicy", "targetReplicatedJobs").Index(0), "tensorboard", "must not be a non-critical replicated job"),
+			),
+		},
+		{
+			name: "jobset name with invalid character",
+			js: &jobset.JobSet{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "JobSet",
+					APIVersion: "jobset.x-k8s.io/v1alpha2",
+				},
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "username.llama65b",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+			want: errors.Join(
+				fmt.Errorf("a DNS-1035 label must consist of lower case alphanumeric characters or '-', start with an alphabetic character, and end with an alphanumeric character (e.g. 'my-name',  or 'abc-123', regex used for validation is '[a-z]([-a-z0-9]*[a-z0-9])?')"),
+			),
+		},
+		{
+			name: "jobset name will result in job name being too long",
+			js: &jobset.JobSet{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "JobSet",
+					APIVersion: "jobset.x-k8s.io/v1alpha2",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: strings.Repeat("a", 62),
+				},
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "rj",
+							Replicas: 101,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+			want: errors.Join(
+				fmt.Errorf(jobNameTooLongErrorMsg),
+			),
+		},
+		{
+			name: "jobset name will result in a pod name being too long",
+			js: &jobset.JobSet{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "JobSet",
+					APIVersion: "jobset.x-k8s.io/v1alpha2",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: strings.Repeat("a", 56),
+				},
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "rj",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									CompletionMode: ptr.To(batchv1.IndexedCompletion),
+									Completions:    ptr.To(int32(1)),
+									Parallelism:    ptr.To(int32(1)),
+									Template:       validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+			want: errors.Join(
+				fmt.Errorf(podNameTooLongErrorMsg),
+			),
+		},
+		{
+			name: "jobset controller name is not a domain-prefixed path",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ManagedBy: ptr.To(notDomainPrefixedPathControllerName),
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "rj",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									CompletionMode: ptr.To(batchv1.IndexedCompletion),
+									Completions:    ptr.To(int32(1)),
+									Parallelism:    ptr.To(int32(1)),
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(
+				notDomainPrefixedPathControllerErrors...,
+			),
+		},
+		{
+			name: "jobset controller name is too long",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ManagedBy: ptr.To(tooLongControllerName),
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "rj",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									CompletionMode: ptr.To(batchv1.IndexedCompletion),
+									Completions:    ptr.To(int32(1)),
+									Parallelism:    ptr.To(int32(1)),
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(
+				tooLongControllerNameError,
+			),
+		},
+		{
+			name: "jobset controller name is set and valid",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ManagedBy: ptr.To(jobset.JobSetControllerName),
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "rj",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									CompletionMode: ptr.To(batchv1.IndexedCompletion),
+									Completions:    ptr.To(int32(1)),
+									Parallelism:    ptr.To(int32(1)),
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(),
+		},
+		{
+			name: "jobset controller name is unset",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "rj",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									CompletionMode: ptr.To(batchv1.IndexedCompletion),
+									Completions:    ptr.To(int32(1)),
+									Parallelism:    ptr.To(int32(1)),
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(),
+		},
+		{
+			name: "jobset node selector strategy set without exclusive topology",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "js",
+					Annotations: map[string]string{
+						jobset.NodeSelectorStrategyKey: "true",
+					},
+				},
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "rj",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(
+				field.Invalid(field.NewPath("metadata", "annotations").Key(jobset.NodeSelectorStrategyKey), "true", fmt.Sprintf("requires the %s annotation to also be set", jobset.ExclusiveKey)),
+			),
+		},
+		{
+			name: "replicated job node selector strategy set without exclusive topology",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "rj-valid",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+						{
+							Name:     "rj",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								ObjectMeta: metav1.ObjectMeta{
+									Annotations: map[string]string{
+										jobset.NodeSelectorStrategyKey: "true",
+									},
+								},
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(
+				field.Invalid(field.NewPath("spec", "replicatedJobs").Index(1).Child("template", "metadata", "annotations").Key(jobset.NodeSelectorStrategyKey), "true", fmt.Sprintf("replicatedJob 'rj' requires the %s annotation to also be set", jobset.ExclusiveKey)),
+			),
+		},
+		{
+			name: "replicated job node selector strategy set with jobset exclusive topology",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "js",
+					Annotations: map[string]string{
+						jobset.ExclusiveKey: "topology.kubernetes.io/zone",
+					},
+				},
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "rj",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								ObjectMeta: metav1.ObjectMeta{
+									Annotations: map[string]string{
+										jobset.NodeSelectorStrategyKey: "true",
+									},
+								},
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(),
+		},
+		{
+			name: "node selector strategy set with exclusive topology on replicated job",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "rj",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								ObjectMeta: metav1.ObjectMeta{
+									Annotations: map[string]string{
+										jobset.ExclusiveKey:            "topology.kubernetes.io/zone",
+										jobset.NodeSelectorStrategyKey: "true",
+									},
+								},
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(),
+		},
+		{
+			name: "jobset node affinity strategy set with exclusive topology",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "js",
+					Annotations: map[string]string{
+						jobset.ExclusiveKey:            "topology.kubernetes.io/zone",
+						jobset.NodeAffinityStrategyKey: "true",
+					},
+				},
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "rj",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(),
+		},
+		{
+			name: "jobset node affinity strategy set without exclusive topology",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "js",
+					Annotations: map[string]string{
+						jobset.NodeAffinityStrategyKey: "true",
+					},
+				},
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "rj",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(
+				field.Invalid(field.NewPath("metadata", "annotations").Key(jobset.NodeAffinityStrategyKey), "true", fmt.Sprintf("requires the %s annotation to also be set", jobset.ExclusiveKey)),
+			),
+		},
+		{
+			name: "replicated job node affinity strategy set along with node selector strategy",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "rj",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								ObjectMeta: metav1.ObjectMeta{
+									Annotations: map[string]string{
+										jobset.ExclusiveKey:            "topology.kubernetes.io/zone",
+										jobset.NodeSelectorStrategyKey: "true",
+										jobset.NodeAffinityStrategyKey: "true",
+									},
+								},
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(
+				field.Invalid(field.NewPath("spec", "replicatedJobs").Index(0).Child("template", "metadata", "annotations").Key(jobset.NodeAffinityStrategyKey), "true", fmt.Sprintf("must not be set along with the %s annotation", jobset.NodeSelectorStrategyKey)),
+			),
+		},
+		{
+			name: "replicated job references a pod template",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					PodTemplates: map[string]corev1.PodTemplateSpec{
+						"shared": validPodTemplateSpec,
+					},
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:            "rj",
+							Replicas:        1,
+							PodTemplateName: "shared",
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(),
+		},
+		{
+			name: "replicated job references a missing pod template",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					PodTemplates: map[string]corev1.PodTemplateSpec{
+						"shared": validPodTemplateSpec,
+					},
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:            "rj",
+							Replicas:        1,
+							PodTemplateName: "missing",
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(
+				field.NotFound(field.NewPath("spec", "replicatedJobs").Index(0).Child("podTemplateName"), "missing"),
+			),
+		},
+		{
+			name: "replicated job references a pod template and defines an inline one",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					PodTemplates: map[string]corev1.PodTemplateSpec{
+						"shared": validPodTemplateSpec,
+					},
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:            "rj",
+							Replicas:        1,
+							PodTemplateName: "shared",
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(
+				field.Forbidden(field.NewPath("spec", "replicatedJobs").Index(0).Child("template", "spec", "template"), "must be empty when podTemplateName is set"),
+			),
+		},
+		{
+			name: "valid coordinator service",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "leader",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Completions: ptr.To[int32](2),
+									Template:    validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					Coordinator: &jobset.Coordinator{
+						ReplicatedJob: "leader",
+						PodIndex:      1,
+					},
+					Network: &jobset.Network{
+						CoordinatorService: &jobset.CoordinatorService{
+							Ports: []corev1.ServicePort{{Port: 8471}},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(),
+		},
+		{
+			name: "coordinator service without coordinator",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "leader",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Completions: ptr.To[int32](2),
+									Template:    validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					Network: &jobset.Network{
+						CoordinatorService: &jobset.CoordinatorService{
+							Ports: []corev1.ServicePort{{Port: 8471}},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(
+				field.Required(field.NewPath("spec", "coordinator"), "must be set when spec.network.coordinatorService is set"),
+			),
+		},
+		{
+			name: "coordinator references unknown replicated job",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "leader",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Completions: ptr.To[int32](2),
+									Template:    validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					Coordinator: &jobset.Coordinator{
+						ReplicatedJob: "unknown",
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(
+				field.NotFound(field.NewPath("spec", "coordinator", "replicatedJob"), "unknown"),
+			),
+		},
+		{
+			name: "coordinator job and pod index out of range",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "leader",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Completions: ptr.To[int32](2),
+									Template:    validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					Coordinator: &jobset.Coordinator{
+						ReplicatedJob: "leader",
+						JobIndex:      1,
+						PodIndex:      2,
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(
+				field.Invalid(field.NewPath("spec", "coordinator", "jobIndex"), 1, "must be less than the 1 replicas of replicatedJob 'leader'"),
+				field.Invalid(field.NewPath("spec", "coordinator", "podIndex"), 2, "must be less than the completions of replicatedJob 'leader'"),
+			),
+		},
+		{
+			name: "valid max failed jobs",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:          "workers",
+							Replicas:      2,
+							MaxFailedJobs: ptr.To[int32](1),
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+		},
+		{
+			name: "max failed jobs not less than the jobs of the replicated job",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:          "workers",
+							Replicas:      2,
+							MaxFailedJobs: ptr.To[int32](2),
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(
+				field.Invalid(field.NewPath("spec", "replicatedJobs").Index(0).Child("maxFailedJobs"), int32(2), "must be less than the 2 jobs of replicatedJob 'workers'"),
+			),
+		},
+		{
+			name: "valid indexed overrides",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "workers",
+							Replicas: 4,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+							IndexedOverrides: []jobset.IndexedOverride{
+								{
+									StartIndex: 0,
+									Patch:      runtime.RawExtension{Raw: []byte(`{"metadata":{"labels":{"role":"primary"}}}`)},
+								},
+								{
+									StartIndex: 1,
+									EndIndex:   ptr.To[int32](3),
+									Patch:      runtime.RawExtension{Raw: []byte(`{"spec":{"template":{"spec":{"containers":[{"name":"test","image":"bash:5"}]}}}}`)},
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(),
+		},
+		{
+			name: "indexed override with out of range indexes",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "workers",
+							Replicas: 2,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+							IndexedOverrides: []jobset.IndexedOverride{
+								{
+									StartIndex: 1,
+									EndIndex:   ptr.To[int32](0),
+									Patch:      runtime.RawExtension{Raw: []byte(`{}`)},
+								},
+								{
+									StartIndex: 2,
+									Patch:      runtime.RawExtension{Raw: []byte(`{}`)},
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(
+				field.Invalid(field.NewPath("spec", "replicatedJobs").Index(0).Child("indexedOverrides").Index(0).Child("endIndex"), 0, "must be greater than or equal to startIndex"),
+				field.Invalid(field.NewPath("spec", "replicatedJobs").Index(0).Child("indexedOverrides").Index(1).Child("endIndex"), 2, "must be less than the 2 replicas of replicatedJob 'workers'"),
+			),
+		},
+		{
+			name: "indexed override with invalid patch",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "workers",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+							IndexedOverrides: []jobset.IndexedOverride{
+								{
+									StartIndex: 0,
+									Patch:      runtime.RawExtension{Raw: []byte(`{"spec":{"parallelism":"two"}}`)},
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(
+				field.Invalid(field.NewPath("spec", "replicatedJobs").Index(0).Child("indexedOverrides").Index(0).Child("patch"), `{"spec":{"parallelism":"two"}}`, "invalid strategic merge patch for the job template: json: cannot unmarshal string into Go struct field JobTemplateSpec.spec.parallelism of type int32"),
+			),
+		},
+		{
+			name: "valid restart overrides",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "workers",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					FailurePolicy: &jobset.FailurePolicy{
+						MaxRestarts:        1,
+						OnRestartOverrides: &runtime.RawExtension{Raw: []byte(`{"spec":{"parallelism":2,"backoffLimit":3}}`)},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(),
+		},
+		{
+			name: "restart overrides with invalid patch",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "workers",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+							FailurePolicy: &jobset.FailurePolicy{
+								OnRestartOverrides: &runtime.RawExtension{Raw: []byte(`{"spec":{"backoffLimit":"three"}}`)},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(
+				field.Invalid(field.NewPath("spec", "replicatedJobs").Index(0).Child("failurePolicy", "onRestartOverrides"), `{"spec":{"backoffLimit":"three"}}`, "invalid strategic merge patch for the job template of replicatedJob 'workers': json: cannot unmarshal string into Go struct field JobTemplateSpec.spec.backoffLimit of type int32"),
+			),
+		},
+		{
+			name: "valid job name template",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					JobNameTemplate: "train-{{.ReplicatedJob}}-{{.Index}}",
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "workers",
+							Replicas: 3,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+						{
+							Name:     "driver",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(),
+		},
+		{
+			name: "job name template which fails to parse",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					JobNameTemplate: "{{.JobSet",
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "workers",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(
+				field.Invalid(field.NewPath("spec", "jobNameTemplate"), "{{.JobSet", "parsing job name template: template: jobName:1: unclosed action"),
+			),
+		},
+		{
+			name: "job name template referencing an unknown field",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					JobNameTemplate: "{{.Namespace}}-{{.Index}}",
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "workers",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(
+				field.Invalid(field.NewPath("spec", "jobNameTemplate"), "{{.Namespace}}-{{.Index}}", `rendering job name template: template: jobName:1:2: executing "jobName" at <.Namespace>: can't evaluate field Namespace in type controllers.jobNameTemplateData`),
+			),
+		},
+		{
+			name: "job name template without job index",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					JobNameTemplate: "{{.JobSet}}-{{.ReplicatedJob}}",
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "workers",
+							Replicas: 2,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(
+				field.Invalid(field.NewPath("spec", "jobNameTemplate"), "{{.JobSet}}-{{.ReplicatedJob}}", "renders the same name 'js-workers' for different jobs"),
+			),
+		},
+		{
+			name: "job name template without replicated job name",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					JobNameTemplate: "{{.JobSet}}-{{.Index}}",
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "workers",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+						{
+							Name:     "driver",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(
+				field.Invalid(field.NewPath("spec", "jobNameTemplate"), "{{.JobSet}}-{{.Index}}", "renders the same name 'js-0' for different jobs"),
+			),
+		},
+		{
+			name: "job name template rendering invalid names",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					JobNameTemplate: "Job_{{.Index}}",
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "workers",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(
+				fmt.Errorf("a DNS-1035 label must consist of lower case alphanumeric characters or '-', start with an alphabetic character, and end with an alphanumeric character (e.g. 'my-name',  or 'abc-123', regex used for validation is '[a-z]([-a-z0-9]*[a-z0-9])?')"),
+			),
+		},
+		{
+			name: "replicated job failure policy with jobset level settings",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "checkpoint-saver",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+							FailurePolicy: &jobset.FailurePolicy{
+								Action:                         jobset.FailurePolicyActionIgnore,
+								DeletePropagationPolicy:        ptr.To(metav1.DeletePropagationBackground),
+								TerminationGracePeriodOverride: ptr.To[int64](0),
+								FailureAggregationSeconds:      ptr.To[int32](10),
+								StabilizationWindowSeconds:     ptr.To[int32](10),
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(
+				field.Forbidden(field.NewPath("spec", "replicatedJobs").Index(0).Child("failurePolicy", "deletePropagationPolicy"), "only supported in spec.failurePolicy"),
+				field.Forbidden(field.NewPath("spec", "replicatedJobs").Index(0).Child("failurePolicy", "terminationGracePeriodOverride"), "only supported in spec.failurePolicy"),
+				field.Forbidden(field.NewPath("spec", "replicatedJobs").Index(0).Child("failurePolicy", "failureAggregationSeconds"), "only supported in spec.failurePolicy"),
+				field.Forbidden(field.NewPath("spec", "replicatedJobs").Index(0).Child("failurePolicy", "stabilizationWindowSeconds"), "only supported in spec.failurePolicy"),
+			),
+		},
+		{
+			name: "valid replicated job failure policy",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "checkpoint-saver",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+							FailurePolicy: &jobset.FailurePolicy{
+								Action: jobset.FailurePolicyActionIgnore,
+							},
+						},
+					},
+					FailurePolicy: &jobset.FailurePolicy{
+						MaxRestarts: 3,
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(),
+		},
+		{
+			name: "valid instances",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					Instances: ptr.To[int32](3),
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "workers",
+							Replicas: 2,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(),
+		},
+		{
+			name: "instances make job names too long",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: strings.Repeat("a", 52),
+				},
+				Spec: jobset.JobSetSpec{
+					Instances: ptr.To[int32](100),
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "workers",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(
+				fmt.Errorf(jobNameTooLongErrorMsg),
+			),
+		},
+		{
+			name: "product of instances, replicas and parallelism exceeds max int32",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					Instances: ptr.To[int32](4),
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "workers",
+							Replicas: 65536,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Parallelism: ptr.To[int32](16384),
+									Template:    validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(
+				fmt.Errorf("the product of instances, replicas and parallelism must not exceed 2147483647 for replicatedJob 'workers'"),
+			),
+		},
+		{
+			name: "job name template without instance index",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					Instances:       ptr.To[int32](2),
+					JobNameTemplate: "{{.JobSet}}-{{.ReplicatedJob}}-{{.Index}}",
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "workers",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(
+				field.Invalid(field.NewPath("spec", "jobNameTemplate"), "{{.JobSet}}-{{.ReplicatedJob}}-{{.Index}}", "renders the same name 'js-workers-0' for different jobs"),
+			),
+		},
+		{
+			name: "pod disruption budget with min available",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:                "workers",
+							Replicas:            2,
+							PodDisruptionBudget: &jobset.PodDisruptionBudget{MinAvailable: ptr.To(intstr.FromString("50%"))},
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(),
+		},
+		{
+			name: "pod disruption budget without min available or max unavailable",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:                "workers",
+							Replicas:            2,
+							PodDisruptionBudget: &jobset.PodDisruptionBudget{},
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(
+				field.Required(field.NewPath("spec", "replicatedJobs").Index(0).Child("podDisruptionBudget"), "one of minAvailable and maxUnavailable must be set"),
+			),
+		},
+		{
+			name: "pod disruption budget with both min available and max unavailable",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "workers",
+							Replicas: 2,
+							PodDisruptionBudget: &jobset.PodDisruptionBudget{
+								MinAvailable:   ptr.To(intstr.FromInt32(1)),
+								MaxUnavailable: ptr.To(intstr.FromInt32(1)),
+							},
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(
+				field.Forbidden(field.NewPath("spec", "replicatedJobs").Index(0).Child("podDisruptionBudget", "maxUnavailable"), "must not be set along with minAvailable"),
+			),
+		},
+		{
+			name: "valid volumes and volume mounts",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "workers",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{Name: "shm", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}}},
+					},
+					VolumeMounts: []corev1.VolumeMount{
+						{Name: "shm", MountPath: "/dev/shm"},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+		},
+		{
+			name: "volume name and mount path used by the pod template",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "workers",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: corev1.PodTemplateSpec{
+										Spec: corev1.PodSpec{
+											Containers: []corev1.Container{
+												{
+													Name:         "test",
+													Image:        "bash:latest",
+													VolumeMounts: []corev1.VolumeMount{{Name: "data", MountPath: "/data"}},
+												},
+											},
+											Volumes: []corev1.Volume{{Name: "data"}},
+										},
+									},
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{Name: "data"},
+					},
+					VolumeMounts: []corev1.VolumeMount{
+						{Name: "data", MountPath: "/data"},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(
+				field.Duplicate(field.NewPath("spec", "volumes").Index(0).Child("name"), "data"),
+				field.Duplicate(field.NewPath("spec", "volumeMounts").Index(0).Child("mountPath"), "/data"),
+			),
+		},
+		{
+			name: "duplicate volume names and mount paths",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "workers",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{Name: "shm"},
+						{Name: "shm"},
+					},
+					VolumeMounts: []corev1.VolumeMount{
+						{Name: "shm", MountPath: "/dev/shm"},
+						{Name: "shm", MountPath: "/dev/shm"},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(
+				field.Duplicate(field.NewPath("spec", "volumes").Index(1).Child("name"), "shm"),
+				field.Duplicate(field.NewPath("spec", "volumeMounts").Index(1).Child("mountPath"), "/dev/shm"),
+			),
+		},
+		{
+			name: "valid sidecar containers",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "workers",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SidecarContainers: []corev1.Container{
+						{Name: "proxy", Image: "proxy:latest"},
+						{Name: "logs", Image: "logs:latest", RestartPolicy: ptr.To(corev1.ContainerRestartPolicyAlways)},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+		},
+		{
+			name: "sidecar container name used by the pod template",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "workers",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SidecarContainers: []corev1.Container{
+						{Name: "test", Image: "proxy:latest"},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(
+				field.Duplicate(field.NewPath("spec", "sidecarContainers").Index(0).Child("name"), "test"),
+			),
+		},
+		{
+			name: "duplicate sidecar container names",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "workers",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SidecarContainers: []corev1.Container{
+						{Name: "proxy", Image: "proxy:latest"},
+						{Name: "proxy", Image: "proxy:latest"},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(
+				field.Duplicate(field.NewPath("spec", "sidecarContainers").Index(1).Child("name"), "proxy"),
+			),
+		},
+		{
+			name: "sidecar container with unsupported restart policy",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "workers",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SidecarContainers: []corev1.Container{
+						{Name: "proxy", Image: "proxy:latest", RestartPolicy: ptr.To(corev1.ContainerRestartPolicy("OnFailure"))},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(
+				field.NotSupported(field.NewPath("spec", "sidecarContainers").Index(0).Child("restartPolicy"), corev1.ContainerRestartPolicy("OnFailure"), []string{string(corev1.ContainerRestartPolicyAlways)}),
+			),
+		},
+		{
+			name: "replicated job with zero replicas",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "workers",
+							Replicas: 0,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									CompletionMode: ptr.To(batchv1.IndexedCompletion),
+									Completions:    ptr.To[int32](4),
+									Template:       validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+		},
+		{
+			name: "replicated job with negative replicas",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "workers",
+							Replicas: -1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{},
+				},
+			},
+			want: errors.Join(
+				field.Invalid(field.NewPath("spec", "replicatedJobs").Index(0).Child("replicas"), int64(-1), "must be greater than or equal to 0"),
+			),
+		},
+		{
+			name: "replicated jobs depending on each other in a diamond",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "js"},
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "loader",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+						{
+							Name:      "trainer",
+							Replicas:  1,
+							DependsOn: []string{"loader"},
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+						{
+							Name:      "evaluator",
+							Replicas:  1,
+							DependsOn: []string{"loader"},
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+						{
+							Name:      "exporter",
+							Replicas:  1,
+							DependsOn: []string{"trainer", "evaluator"},
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+		},
+		{
+			name: "replicated job depending on itself, an unknown or a duplicate replicated job",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "js"},
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:     "loader",
+							Replicas: 1,
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+						{
+							Name:      "trainer",
+							Replicas:  1,
+							DependsOn: []string{"trainer", "missing", "loader", "loader"},
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+			want: errors.Join(
+				field.Invalid(field.NewPath("spec", "replicatedJobs").Index(1).Child("dependsOn").Index(0), "trainer", "a replicated job must not depend on itself"),
+				field.NotFound(field.NewPath("spec", "replicatedJobs").Index(1).Child("dependsOn").Index(1), "missing"),
+				field.Duplicate(field.NewPath("spec", "replicatedJobs").Index(1).Child("dependsOn").Index(3), "loader"),
+			),
+		},
+		{
+			name: "replicated jobs with cyclic dependencies",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "js"},
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: []jobset.ReplicatedJob{
+						{
+							Name:      "loader",
+							Replicas:  1,
+							DependsOn: []string{"exporter"},
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+						{
+							Name:      "trainer",
+							Replicas:  1,
+							DependsOn: []string{"loader"},
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+						{
+							Name:      "exporter",
+							Replicas:  1,
+							DependsOn: []string{"trainer"},
+							Template: batchv1.JobTemplateSpec{
+								Spec: batchv1.JobSpec{
+									Template: validPodTemplateSpec,
+								},
+							},
+						},
+					},
+					SuccessPolicy: &jobset.SuccessPolicy{
+						Operator: jobset.OperatorAll,
+					},
+				},
+			},
+			want: errors.Join(
+				field.Invalid(field.NewPath("spec", "replicatedJobs").Index(0).Child("dependsOn"), []string{"exporter"}, "the dependencies of the replicated jobs must not form a cycle"),
+			),
+		},
+	}
+	fakeClient := fake.NewFakeClient()
+	webhook, err := NewJobSetWebhook(fakeClient, JobSetWebhookOptions{})
+	if err != nil {
+		t.Fatalf("error creating jobset webhook: %v", err)
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := webhook.ValidateCreate(context.TODO(), tc.js.DeepCopyObject())
+			if err != nil && tc.want != nil {
+				assert.Contains(t, err.Error(), tc.want.Error())
+			} else if err != nil && tc.want == nil {
+				t.Errorf("unexpected error: %v", err)
+			} else if err == nil && tc.want != nil {
+				t.Errorf("missing expected error: %v", tc.want)
+			}
+		})
+	}
 }
 
 func TestValidateUpdate(t *testing.T) {
@@ -1051,6 +3734,53 @@ func TestValidateUpdate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "update replicas annotation",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "js", Annotations: map[string]string{jobset.ReplicasKey: "test-jobset-replicated-job-0=3"}},
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: validReplicatedJobs,
+				},
+			},
+			oldJs: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: validReplicatedJobs,
+				},
+			},
+		},
+		{
+			name: "update replicas annotation with unknown replicated job",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "js", Annotations: map[string]string{jobset.ReplicasKey: "other=3"}},
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: validReplicatedJobs,
+				},
+			},
+			oldJs: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: validReplicatedJobs,
+				},
+			},
+			want: fmt.Errorf("replicatedJob 'other' does not exist"),
+		},
+		{
+			name: "job key hash annotation is immutable while the jobset is active",
+			js: &jobset.JobSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "js", Annotations: map[string]string{jobset.JobKeyHashKey: jobset.JobKeyHashSHA256}},
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: validReplicatedJobs,
+				},
+			},
+			oldJs: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: validReplicatedJobs,
+				},
+			},
+			want: fmt.Errorf("metadata.annotations[alpha.jobset.sigs.k8s.io/job-key-hash]: Invalid value: \"sha256\": field is immutable"),
+		},
 		{
 			name: "replicated jobs are immutable",
 			js: &jobset.JobSet{
@@ -1087,12 +3817,179 @@ func TestValidateUpdate(t *testing.T) {
 			},
 			want: fmt.Errorf("field is immutable"),
 		},
+		{
+			name: "pod templates are immutable",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: validReplicatedJobs,
+					PodTemplates: map[string]corev1.PodTemplateSpec{
+						"shared": {Spec: corev1.PodSpec{ServiceAccountName: "new"}},
+					},
+				},
+			},
+			oldJs: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: validReplicatedJobs,
+					PodTemplates: map[string]corev1.PodTemplateSpec{
+						"shared": {Spec: corev1.PodSpec{ServiceAccountName: "old"}},
+					},
+				},
+			},
+			want: fmt.Errorf("field is immutable"),
+		},
+		{
+			name: "pod template node selector can be updated while suspended",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: validReplicatedJobs,
+					PodTemplates: map[string]corev1.PodTemplateSpec{
+						"shared": {Spec: corev1.PodSpec{NodeSelector: map[string]string{"pool": "new"}}},
+					},
+				},
+			},
+			oldJs: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					Suspend:        ptr.To(true),
+					ReplicatedJobs: validReplicatedJobs,
+					PodTemplates: map[string]corev1.PodTemplateSpec{
+						"shared": {Spec: corev1.PodSpec{NodeSelector: map[string]string{"pool": "old"}}},
+					},
+				},
+			},
+		},
+		{
+			name: "ttlSecondsAfterFinished can be updated while active",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs:          validReplicatedJobs,
+					TTLSecondsAfterFinished: ptr.To[int32](60),
+				},
+			},
+			oldJs: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: validReplicatedJobs,
+				},
+			},
+		},
+		{
+			name: "image pull secrets can be updated while active",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs:   validReplicatedJobs,
+					ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry-rotated"}},
+				},
+			},
+			oldJs: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs:   validReplicatedJobs,
+					ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry"}},
+				},
+			},
+		},
+		{
+			name: "jobset can be paused while active",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: validReplicatedJobs,
+					Paused:         ptr.To(true),
+				},
+			},
+			oldJs: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: validReplicatedJobs,
+				},
+			},
+		},
+		{
+			name: "jobset can be drained while active",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: validReplicatedJobs,
+					Lifecycle:      jobset.JobSetLifecycleDrain,
+				},
+			},
+			oldJs: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: validReplicatedJobs,
+				},
+			},
+		},
+		{
+			name: "spec labels are immutable while active",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: validReplicatedJobs,
+					Labels:         map[string]string{"team": "new"},
+				},
+			},
+			oldJs: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: validReplicatedJobs,
+					Labels:         map[string]string{"team": "old"},
+				},
+			},
+			want: fmt.Errorf("spec.labels: Forbidden: %s", immutableWhileActiveErrorMsg),
+		},
+		{
+			name: "success policy is immutable while active",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: validReplicatedJobs,
+					SuccessPolicy:  &jobset.SuccessPolicy{Operator: jobset.OperatorAny},
+				},
+			},
+			oldJs: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: validReplicatedJobs,
+					SuccessPolicy:  &jobset.SuccessPolicy{Operator: jobset.OperatorAll},
+				},
+			},
+			want: fmt.Errorf("spec.successPolicy: Forbidden: %s", immutableWhileActiveErrorMsg),
+		},
+		{
+			name: "spec labels can be updated once the jobset has finished",
+			js: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: validReplicatedJobs,
+					Labels:         map[string]string{"team": "new"},
+				},
+			},
+			oldJs: &jobset.JobSet{
+				ObjectMeta: validObjectMeta,
+				Spec: jobset.JobSetSpec{
+					ReplicatedJobs: validReplicatedJobs,
+					Labels:         map[string]string{"team": "old"},
+				},
+				Status: jobset.JobSetStatus{
+					Conditions: []metav1.Condition{
+						{Type: string(jobset.JobSetCompleted), Status: metav1.ConditionTrue},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			fakeClient := fake.NewFakeClient()
-			webhook, err := NewJobSetWebhook(fakeClient)
+			webhook, err := NewJobSetWebhook(fakeClient, JobSetWebhookOptions{})
 			assert.Nil(t, err)
 			newObj := tc.js.DeepCopyObject()
 			oldObj := tc.oldJs.DeepCopyObject()
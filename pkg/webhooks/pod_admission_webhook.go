@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -39,6 +40,12 @@ func (p *podWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (ad
 		return nil, nil
 	}
 
+	// Likewise for the node affinity exclusive placement strategy, where all pods of a job
+	// require the topology domain assigned by the JobSet controller.
+	if _, usingNodeAffinityStrategy := pod.Annotations[jobset.NodeAffinityStrategyKey]; usingNodeAffinityStrategy {
+		return nil, nil
+	}
+
 	// If pod is not part of a JobSet using exclusive placement, we don't need to validate anything.
 	topologyKey, usingExclusivePlacement := pod.Annotations[jobset.ExclusiveKey]
 	if !usingExclusivePlacement {
@@ -126,7 +133,12 @@ func (p *podWebhook) leaderPodForFollower(ctx context.Context, pod *corev1.Pod)
 // genLeaderPodName accepts the name of a pod that is part of a jobset as input, and
 // returns the name of the pod with completion index 0 in the same child job.
 func genLeaderPodName(pod *corev1.Pod) (string, error) {
-	// Pod name format: <jobset>-<replicatedJob>-<jobIndex>-<podIndex>-<randomSuffix>
+	// Pod name format: <jobName>-<podIndex>-<randomSuffix>. The job name label is set on pods
+	// by the Job controller, and reflects the job name template of the JobSet, if any.
+	if jobName, ok := pod.Labels[batchv1.JobNameLabel]; ok {
+		return fmt.Sprintf("%s-0", jobName), nil
+	}
+	// Otherwise, fall back to the default job name format: <jobset>-<replicatedJob>-<jobIndex>.
 	jobSet, ok := pod.Labels[jobset.JobSetNameKey]
 	if !ok {
 		return "", fmt.Errorf("pod missing label: %s", jobset.JobSetNameKey)
@@ -139,6 +151,10 @@ func genLeaderPodName(pod *corev1.Pod) (string, error) {
 	if !ok {
 		return "", fmt.Errorf("pod missing label: %s", jobset.JobIndexKey)
 	}
+	// Jobs of a JobSet with multiple instances are prefixed with the instance index.
+	if instanceIndex, ok := pod.Labels[jobset.InstanceIndexKey]; ok {
+		jobSet = fmt.Sprintf("%s-%s", jobSet, instanceIndex)
+	}
 	leaderPodName := placement.GenPodName(jobSet, replicatedJob, jobIndex, "0")
 	return leaderPodName, nil
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -34,6 +35,36 @@ func TestLeaderPodName(t *testing.T) {
 			},
 			want: "js-rjob-0-0",
 		},
+		{
+			desc: "pod with job name label",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pod",
+					Labels: map[string]string{
+						jobset.JobSetNameKey:        "js",
+						jobset.ReplicatedJobNameKey: "rjob",
+						jobset.JobIndexKey:          "1",
+						batchv1.JobNameLabel:        "custom-rjob-1",
+					},
+				},
+			},
+			want: "custom-rjob-1-0",
+		},
+		{
+			desc: "pod of a jobset with instances",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pod",
+					Labels: map[string]string{
+						jobset.JobSetNameKey:        "js",
+						jobset.ReplicatedJobNameKey: "rjob",
+						jobset.JobIndexKey:          "1",
+						jobset.InstanceIndexKey:     "2",
+					},
+				},
+			},
+			want: "js-2-rjob-1-0",
+		},
 		{
 			desc: "pod missing labels",
 			pod: &corev1.Pod{
@@ -68,10 +68,12 @@ func (p *podWebhook) Default(ctx context.Context, obj runtime.Object) error {
 	}
 	// If this pod is part of a JobSet that is NOT using the exclusive placement feature,
 	// or if this jobset is using the node selector exclusive placement strategy (running
-	// the hack/label_nodes.py script beforehand), we don't need to mutate the pod here.
+	// the hack/label_nodes.py script beforehand) or the node affinity exclusive placement
+	// strategy (injected by the JobSet controller), we don't need to mutate the pod here.
 	_, usingExclusivePlacement := pod.Annotations[jobset.ExclusiveKey]
 	_, usingNodeSelectorStrategy := pod.Annotations[jobset.NodeSelectorStrategyKey]
-	if !usingExclusivePlacement || usingNodeSelectorStrategy {
+	_, usingNodeAffinityStrategy := pod.Annotations[jobset.NodeAffinityStrategyKey]
+	if !usingExclusivePlacement || usingNodeSelectorStrategy || usingNodeAffinityStrategy {
 		return nil
 	}
 	return p.patchPod(ctx, pod)
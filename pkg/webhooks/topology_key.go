@@ -0,0 +1,99 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	jobset "sigs.k8s.io/jobset/api/jobset/v1alpha2"
+)
+
+//+kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+
+// exclusiveTopologyKey is a topology key used for exclusive placement, along with the path of
+// the annotation setting it.
+type exclusiveTopologyKey struct {
+	key  string
+	path *field.Path
+}
+
+// validateExclusiveTopologyKeys checks that the topology keys used for exclusive placement are
+// labels of at least one node, since the pods of a Job can never be placed with a mistyped key.
+// Nodes can be labeled after the JobSet is created, so unknown keys are only warned about, unless
+// the webhook is configured to reject them. The check is best effort, and is skipped if the nodes
+// cannot be listed or there are no nodes yet.
+func (j *jobSetWebhook) validateExclusiveTopologyKeys(ctx context.Context, js *jobset.JobSet) (admission.Warnings, field.ErrorList) {
+	keys := exclusiveTopologyKeys(js)
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	var nodes corev1.NodeList
+	if err := j.client.List(ctx, &nodes); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "listing nodes to validate the exclusive placement topology keys")
+		return nil, nil
+	}
+	if len(nodes.Items) == 0 {
+		return nil, nil
+	}
+	var warnings admission.Warnings
+	var allErrs field.ErrorList
+	for _, k := range keys {
+		if nodesHaveLabel(nodes.Items, k.key) {
+			continue
+		}
+		msg := fmt.Sprintf("topology key %q is not a label of any node, the pods of the JobSet cannot be placed until nodes are labeled with it", k.key)
+		if j.opts.RejectUnknownTopologyKeys {
+			allErrs = append(allErrs, field.Invalid(k.path, k.key, msg))
+		} else {
+			warnings = append(warnings, fmt.Sprintf("%s: %s", k.path, msg))
+		}
+	}
+	return warnings, allErrs
+}
+
+// exclusiveTopologyKeys returns the distinct topology keys used for exclusive placement by the
+// JobSet and its replicated jobs, in the order in which they are set.
+func exclusiveTopologyKeys(js *jobset.JobSet) []exclusiveTopologyKey {
+	var keys []exclusiveTopologyKey
+	seen := map[string]bool{}
+	add := func(annotations map[string]string, path *field.Path) {
+		key, ok := annotations[jobset.ExclusiveKey]
+		if !ok || seen[key] {
+			return
+		}
+		seen[key] = true
+		keys = append(keys, exclusiveTopologyKey{key: key, path: path.Child("annotations").Key(jobset.ExclusiveKey)})
+	}
+	add(js.Annotations, field.NewPath("metadata"))
+	for i, rjob := range js.Spec.ReplicatedJobs {
+		add(rjob.Template.Annotations, field.NewPath("spec", "replicatedJobs").Index(i).Child("template", "metadata"))
+	}
+	return keys
+}
+
+// nodesHaveLabel returns true if any of the nodes has the label key.
+func nodesHaveLabel(nodes []corev1.Node, key string) bool {
+	for _, node := range nodes {
+		if _, ok := node.Labels[key]; ok {
+			return true
+		}
+	}
+	return false
+}
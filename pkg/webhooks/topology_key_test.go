@@ -0,0 +1,126 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	jobset "sigs.k8s.io/jobset/api/jobset/v1alpha2"
+)
+
+func TestValidateExclusiveTopologyKeys(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(jobset.AddToScheme(scheme))
+	utilruntime.Must(corev1.AddToScheme(scheme))
+
+	node := func(name string, labels map[string]string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	jobSet := func(jobSetAnnotations, rjobAnnotations map[string]string) *jobset.JobSet {
+		return &jobset.JobSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "js", Namespace: "default", Annotations: jobSetAnnotations},
+			Spec: jobset.JobSetSpec{
+				ReplicatedJobs: []jobset.ReplicatedJob{
+					{
+						Name:     "rjob",
+						Replicas: 1,
+						Template: batchv1.JobTemplateSpec{
+							ObjectMeta: metav1.ObjectMeta{Annotations: rjobAnnotations},
+							Spec:       batchv1.JobSpec{Template: TestPodTemplate},
+						},
+					},
+				},
+				SuccessPolicy: &jobset.SuccessPolicy{},
+			},
+		}
+	}
+	nodes := []client.Object{
+		node("node-1", map[string]string{"kubernetes.io/hostname": "node-1", "rack": "rack-1"}),
+		node("node-2", map[string]string{"kubernetes.io/hostname": "node-2"}),
+	}
+
+	tests := []struct {
+		name         string
+		opts         JobSetWebhookOptions
+		nodes        []client.Object
+		js           *jobset.JobSet
+		wantWarnings int
+		wantErr      bool
+	}{
+		{
+			name:  "no exclusive placement",
+			nodes: nodes,
+			js:    jobSet(nil, nil),
+		},
+		{
+			name:  "jobset topology key is a node label",
+			nodes: nodes,
+			js:    jobSet(map[string]string{jobset.ExclusiveKey: "kubernetes.io/hostname"}, nil),
+		},
+		{
+			name:  "replicated job topology key is a node label of some nodes",
+			nodes: nodes,
+			js:    jobSet(nil, map[string]string{jobset.ExclusiveKey: "rack"}),
+		},
+		{
+			name:         "unknown jobset topology key is warned about",
+			nodes:        nodes,
+			js:           jobSet(map[string]string{jobset.ExclusiveKey: "kubernetes.io/hostnam"}, nil),
+			wantWarnings: 1,
+		},
+		{
+			name:         "unknown replicated job topology key is warned about",
+			nodes:        nodes,
+			js:           jobSet(map[string]string{jobset.ExclusiveKey: "rack"}, map[string]string{jobset.ExclusiveKey: "zone"}),
+			wantWarnings: 1,
+		},
+		{
+			name:    "unknown topology key is rejected",
+			opts:    JobSetWebhookOptions{RejectUnknownTopologyKeys: true},
+			nodes:   nodes,
+			js:      jobSet(map[string]string{jobset.ExclusiveKey: "kubernetes.io/hostnam"}, nil),
+			wantErr: true,
+		},
+		{
+			name: "topology key is not checked without nodes",
+			opts: JobSetWebhookOptions{RejectUnknownTopologyKeys: true},
+			js:   jobSet(map[string]string{jobset.ExclusiveKey: "kubernetes.io/hostnam"}, nil),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.nodes...).Build()
+			webhook, err := NewJobSetWebhook(fakeClient, tc.opts)
+			if err != nil {
+				t.Fatalf("error creating jobset webhook: %v", err)
+			}
+			warnings, err := webhook.ValidateCreate(context.TODO(), tc.js)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("unexpected error: got %v, want error %t", err, tc.wantErr)
+			}
+			if len(warnings) != tc.wantWarnings {
+				t.Errorf("unexpected warnings: got %v, want %d warnings", warnings, tc.wantWarnings)
+			}
+		})
+	}
+}
@@ -1296,6 +1296,45 @@ var _ = ginkgo.Describe("JobSet controller", func() {
 			}, timeout, interval).Should(gomega.BeTrue())
 		})
 	})
+
+	ginkgo.When("A JobSet is created", func() {
+		ginkgo.It("Should apply its jobs and headless service with the controller field manager", func() {
+			ns := &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					GenerateName: "jobset-ns-",
+				},
+			}
+			gomega.Expect(k8sClient.Create(ctx, ns)).To(gomega.Succeed())
+			defer func() {
+				gomega.Expect(testutil.DeleteNamespace(ctx, k8sClient, ns)).To(gomega.Succeed())
+			}()
+
+			js := testJobSet(ns).Obj()
+			ginkgo.By(fmt.Sprintf("creating jobSet %s/%s", js.Name, js.Namespace))
+			gomega.Expect(k8sClient.Create(ctx, js)).Should(gomega.Succeed())
+			gomega.Eventually(testutil.NumJobs, timeout, interval).WithArguments(ctx, k8sClient, js).Should(gomega.Equal(testutil.NumExpectedJobs(js)))
+
+			appliedBy := func(obj client.Object) bool {
+				for _, entry := range obj.GetManagedFields() {
+					if entry.Manager == constants.DefaultFieldManager && entry.Operation == metav1.ManagedFieldsOperationApply {
+						return true
+					}
+				}
+				return false
+			}
+			ginkgo.By("checking the jobs and headless service were applied by the controller")
+			var jobList batchv1.JobList
+			gomega.Expect(k8sClient.List(ctx, &jobList, client.InNamespace(js.Namespace))).Should(gomega.Succeed())
+			for i := range jobList.Items {
+				gomega.Expect(appliedBy(&jobList.Items[i])).To(gomega.BeTrue(), "job %s", jobList.Items[i].Name)
+			}
+			var svc corev1.Service
+			gomega.Eventually(func() error {
+				return k8sClient.Get(ctx, types.NamespacedName{Name: controllers.GetSubdomain(js), Namespace: js.Namespace}, &svc)
+			}, timeout, interval).Should(gomega.Succeed())
+			gomega.Expect(appliedBy(&svc)).To(gomega.BeTrue())
+		})
+	})
 }) // end of Describe
 
 func makeAllJobsReady(jl *batchv1.JobList) {
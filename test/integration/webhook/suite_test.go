@@ -112,7 +112,7 @@ var _ = BeforeSuite(func() {
 	err = controllers.SetupJobSetIndexes(ctx, mgr.GetFieldIndexer())
 	Expect(err).NotTo(HaveOccurred())
 
-	jobSetWebhook, err := webhooks.NewJobSetWebhook(mgr.GetClient())
+	jobSetWebhook, err := webhooks.NewJobSetWebhook(mgr.GetClient(), webhooks.JobSetWebhookOptions{})
 	Expect(err).NotTo(HaveOccurred())
 
 	err = jobSetWebhook.SetupWebhookWithManager(mgr)